@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	infraRepo "github.com/your-org/go-backend-starter/internal/infrastructure/repository"
+)
+
+func main() {
+	var startInput, endInput, dormitoryInput string
+	flag.StringVar(&startInput, "start", "", "First date (YYYY-MM-DD) to open attendance sessions for. Defaults to today in server timezone.")
+	flag.StringVar(&endInput, "end", "", "Last date (YYYY-MM-DD), inclusive. Defaults to six days after -start (one week).")
+	flag.StringVar(&dormitoryInput, "dormitory", "", "Optional dormitory ID to limit generation to.")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	if err := database.Connect(); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	if startInput == "" {
+		startInput = time.Now().Format("2006-01-02")
+	}
+	if endInput == "" {
+		start, err := time.Parse("2006-01-02", startInput)
+		if err != nil {
+			log.Fatalf("Invalid -start date %q: %v", startInput, err)
+		}
+		endInput = start.AddDate(0, 0, 6).Format("2006-01-02")
+	}
+
+	ctx := context.Background()
+
+	attendanceSessionRepo := infraRepo.NewAttendanceSessionRepository()
+	classScheduleRepo := infraRepo.NewClassScheduleRepository()
+	holidayRepo := infraRepo.NewHolidayRepository()
+	auditLogRepo := infraRepo.NewAuditLogRepository()
+
+	auditLogger := service.NewAuditLogger(auditLogRepo)
+	generatorUseCase := usecase.NewAttendanceGeneratorUseCase(attendanceSessionRepo, classScheduleRepo, holidayRepo, auditLogger)
+
+	req := dto.GenerateAttendanceSessionsRequest{StartDate: startInput, EndDate: endInput}
+	if dormitoryInput != "" {
+		req.DormitoryID = &dormitoryInput
+	}
+
+	result, err := generatorUseCase.GenerateSessions(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to generate attendance sessions: %v", err)
+	}
+
+	log.Printf("Attendance sessions generated for %s..%s: created=%d skipped_existing=%d skipped_holidays=%d",
+		startInput, endInput, result.Created, result.SkippedExisting, result.SkippedHolidays)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	infraRepo "github.com/your-org/go-backend-starter/internal/infrastructure/repository"
+	infraService "github.com/your-org/go-backend-starter/internal/infrastructure/service"
+)
+
+func main() {
+	var checkpoint bool
+	var exportPath string
+	flag.BoolVar(&checkpoint, "checkpoint", false, "Sign a checkpoint of the chain head after a successful verification.")
+	flag.StringVar(&exportPath, "export", "", "Write all signed checkpoints with their public keys to this JSON file.")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	if err := database.Connect(); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	ctx := context.Background()
+
+	auditSigner, err := infraService.NewAuditSigner()
+	if err != nil {
+		log.Fatalf("Failed to initialize audit signer: %v", err)
+	}
+	integrityUseCase := usecase.NewAuditIntegrityUseCase(infraRepo.NewAuditLogRepository(), infraRepo.NewAuditCheckpointRepository(), infraRepo.NewAuditArchiveRepository(), auditSigner)
+
+	result, err := integrityUseCase.VerifyChain(ctx)
+	if err != nil {
+		log.Fatalf("Failed to verify audit chain: %v", err)
+	}
+	if !result.Valid {
+		log.Printf("Audit chain BROKEN at sequence %d (entry %s): %s", result.FirstBroken.Sequence, result.FirstBroken.ID, result.FirstBroken.Reason)
+		os.Exit(1)
+	}
+	log.Printf("Audit chain intact: %d entries, head sequence %d, hash %s, %d checkpoint(s) verified",
+		result.Entries, result.HeadSequence, result.HeadHash, result.Checkpoints)
+
+	if checkpoint && result.Entries > 0 {
+		created, err := integrityUseCase.CreateCheckpoint(ctx)
+		if err != nil {
+			log.Fatalf("Failed to create checkpoint: %v", err)
+		}
+		log.Printf("Checkpoint %s signed at sequence %d with key %s", created.ID, created.Sequence, created.KeyID)
+	}
+
+	if exportPath != "" {
+		export, err := integrityUseCase.ExportCheckpoints(ctx)
+		if err != nil {
+			log.Fatalf("Failed to export checkpoints: %v", err)
+		}
+		body, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to encode checkpoints: %v", err)
+		}
+		if err := os.WriteFile(exportPath, body, 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", exportPath, err)
+		}
+		log.Printf("Exported %d checkpoint(s) to %s", len(export.Checkpoints), exportPath)
+	}
+}
@@ -1,35 +1,50 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	infraCache "github.com/your-org/go-backend-starter/internal/infrastructure/cache"
 	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/logger"
 	infraRepo "github.com/your-org/go-backend-starter/internal/infrastructure/repository"
 	infraService "github.com/your-org/go-backend-starter/internal/infrastructure/service"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/handler"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/middleware"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/router"
+	"github.com/your-org/go-backend-starter/internal/interfaces/scheduler"
 )
 
+// shutdownTimeout bounds how long in-flight requests and the audit flush
+// may delay a shutdown.
+const shutdownTimeout = 15 * time.Second
+
 func main() {
-	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
+	// Load environment variables, then configure logging from them
+	envErr := godotenv.Load()
+	logger.Setup()
+	if envErr != nil {
+		slog.Info("No .env file found, using environment variables")
 	}
 
 	// Connect to database
 	if err := database.Connect(); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("Failed to connect to database", err)
 	}
 
 	// Run migrations (using versioned migrations)
 	// For production, use: go run cmd/migrate/main.go -command up
 	if err := database.MigrateUpVersioned(); err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+		fatal("Failed to migrate database", err)
 	}
 
 	// Initialize repositories
@@ -44,6 +59,7 @@ func main() {
 	classStaffRepo := infraRepo.NewClassStaffRepository()
 	teacherRepo := infraRepo.NewTeacherRepository()
 	subjectRepo := infraRepo.NewSubjectRepository()
+	holidayRepo := infraRepo.NewHolidayRepository()
 	classScheduleRepo := infraRepo.NewClassScheduleRepository()
 	leavePermitRepo := infraRepo.NewLeavePermitRepository()
 	healthStatusRepo := infraRepo.NewHealthStatusRepository()
@@ -56,61 +72,119 @@ func main() {
 	teacherAttendanceRepo := infraRepo.NewTeacherAttendanceRepository()
 	scheduleSlotRepo := infraRepo.NewScheduleSlotRepository()
 	auditLogRepo := infraRepo.NewAuditLogRepository()
+	auditCheckpointRepo := infraRepo.NewAuditCheckpointRepository()
+	auditArchiveRepo := infraRepo.NewAuditArchiveRepository()
 	provinceRepo := infraRepo.NewProvinceRepository()
 	regencyRepo := infraRepo.NewRegencyRepository()
 	districtRepo := infraRepo.NewDistrictRepository()
 	villageRepo := infraRepo.NewVillageRepository()
 	reportRepo := infraRepo.NewReportRepository()
+	refreshSessionRepo := infraRepo.NewRefreshSessionRepository()
+	jobRunRepo := infraRepo.NewJobRunRepository()
+	jobLeaseRepo := infraRepo.NewJobLeaseRepository()
+	loginThrottleRepo := infraRepo.NewLoginThrottleRepository()
+	recoveryCodeRepo := infraRepo.NewRecoveryCodeRepository()
+	apiKeyRepo := infraRepo.NewAPIKeyRepository()
+	invitationRepo := infraRepo.NewInvitationRepository()
 
 	// Initialize services
-	tokenService := infraService.NewJWTService()
-	auditLogger := service.NewAuditLogger(auditLogRepo)
+	tokenService, err := infraService.NewJWTService()
+	if err != nil {
+		fatal("Failed to initialize token service", err)
+	}
+	totpService := infraService.NewTOTPService()
+	auditSigner, err := infraService.NewAuditSigner()
+	if err != nil {
+		fatal("Failed to initialize audit signer", err)
+	}
+	auditLogger, err := service.NewAuditSink(auditLogRepo, service.LoadAuditWriterConfig())
+	if err != nil {
+		fatal("Failed to initialize audit writer", err)
+	}
+	principalCache, err := infraCache.NewPrincipalCache()
+	if err != nil {
+		fatal("Failed to initialize principal cache", err)
+	}
 
 	// Initialize use cases
-	authUseCase := usecase.NewAuthUseCase(userRepo, tokenService)
-	userUseCase := usecase.NewUserUseCase(userRepo, roleRepo, auditLogger)
-	roleUseCase := usecase.NewRoleUseCase(roleRepo, permissionRepo, auditLogger)
-	dormitoryUseCase := usecase.NewDormitoryUseCase(dormitoryRepo, userRepo, auditLogger)
+	registrationPolicy := usecase.LoadRegistrationPolicy()
+	twoFactorUseCase := usecase.NewTwoFactorUseCase(userRepo, recoveryCodeRepo, totpService, auditLogger, principalCache)
+	authUseCase := usecase.NewAuthUseCase(userRepo, refreshSessionRepo, tokenService, loginThrottleRepo, auditLogger, usecase.LoadLoginLockoutPolicy(), registrationPolicy, twoFactorUseCase, principalCache)
+	userUseCase := usecase.NewUserUseCase(userRepo, roleRepo, refreshSessionRepo, auditLogger, principalCache)
+	serviceAccountUseCase := usecase.NewServiceAccountUseCase(userRepo, roleRepo, apiKeyRepo, auditLogger)
+	roleUseCase := usecase.NewRoleUseCase(roleRepo, permissionRepo, auditLogger, principalCache)
+	dormitoryUseCase := usecase.NewDormitoryUseCase(dormitoryRepo, userRepo, auditLogger, principalCache)
 	studentUseCase := usecase.NewStudentUseCase(studentRepo, dormitoryRepo, auditLogger)
+	studentImportUseCase := usecase.NewStudentImportUseCase(studentRepo, dormitoryRepo, classRepo, auditLogger)
 	studentSKSResultUseCase := usecase.NewStudentSKSResultUseCase(studentSKSResultRepo, fanCompletionRepo, studentRepo, sksDefinitionRepo, teacherRepo, auditLogger)
 	fanUseCase := usecase.NewFanUseCase(fanRepo, dormitoryRepo, auditLogger)
 	classUseCase := usecase.NewClassUseCase(classRepo, fanRepo, studentRepo, enrollmentRepo, classStaffRepo, auditLogger)
-	teacherUseCase := usecase.NewTeacherUseCase(teacherRepo, userRepo, roleRepo, auditLogger)
+	teacherUseCase := usecase.NewTeacherUseCase(teacherRepo, userRepo, roleRepo, auditLogger, principalCache)
 	scheduleSlotUseCase := usecase.NewScheduleSlotUseCase(scheduleSlotRepo, dormitoryRepo, auditLogger)
+	subjectUseCase := usecase.NewSubjectUseCase(subjectRepo, auditLogger)
 	classScheduleUseCase := usecase.NewClassScheduleUseCase(classScheduleRepo, classRepo, teacherRepo, subjectRepo, scheduleSlotRepo, dormitoryRepo, auditLogger)
 	sksDefinitionUseCase := usecase.NewSKSDefinitionUseCase(sksDefinitionRepo, fanRepo, subjectRepo, auditLogger)
 	sksExamUseCase := usecase.NewSKSExamScheduleUseCase(sksExamRepo, sksDefinitionRepo, teacherRepo, auditLogger)
 	leavePermitUseCase := usecase.NewLeavePermitUseCase(leavePermitRepo, studentRepo, auditLogger)
 	healthStatusUseCase := usecase.NewHealthStatusUseCase(healthStatusRepo, studentRepo, auditLogger)
 	attendanceUseCase := usecase.NewAttendanceUseCase(attendanceSessionRepo, studentAttendanceRepo, teacherAttendanceRepo, classScheduleRepo, leavePermitUseCase, healthStatusUseCase, auditLogger)
+	attendanceGeneratorUseCase := usecase.NewAttendanceGeneratorUseCase(attendanceSessionRepo, classScheduleRepo, holidayRepo, auditLogger)
+	holidayUseCase := usecase.NewHolidayUseCase(holidayRepo, dormitoryRepo, auditLogger)
 	locationUseCase := usecase.NewLocationUseCase(provinceRepo, regencyRepo, districtRepo, villageRepo)
-	auditLogUseCase := usecase.NewAuditLogUseCase(auditLogRepo)
+	auditLogUseCase := usecase.NewAuditLogUseCase(auditLogRepo, auditLogger)
+	auditIntegrityUseCase := usecase.NewAuditIntegrityUseCase(auditLogRepo, auditCheckpointRepo, auditArchiveRepo, auditSigner)
+	auditRetentionUseCase := usecase.NewAuditRetentionUseCase(auditLogRepo, auditArchiveRepo, infraService.NewAuditArchiveStore(), auditIntegrityUseCase, auditLogger, usecase.LoadAuditRetentionPolicy())
 	permissionUseCase := usecase.NewPermissionUseCase(permissionRepo)
 	reportUseCase := usecase.NewReportUseCase(reportRepo)
+	jobRunUseCase := usecase.NewJobRunUseCase(jobRunRepo)
+	loginLockoutUseCase := usecase.NewLoginLockoutUseCase(loginThrottleRepo, auditLogger)
+	impersonationUseCase := usecase.NewImpersonationUseCase(userRepo, tokenService, auditLogger)
+	invitationUseCase := usecase.NewInvitationUseCase(invitationRepo, userRepo, roleRepo, dormitoryRepo, auditLogger, registrationPolicy)
+
+	// Make sure every permission the routes check exists
+	synced, err := permissionUseCase.SyncPermissions(context.Background())
+	if err != nil {
+		fatal("Failed to sync permissions", err)
+	}
+	if len(synced.Created) > 0 {
+		slog.Info("Created permissions", "permissions", synced.Created)
+	}
+	if len(synced.Orphaned) > 0 {
+		slog.Warn("Permissions missing from the catalog, review and remove them", "permissions", synced.Orphaned)
+	}
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authUseCase)
 	userHandler := handler.NewUserHandler(userUseCase)
 	roleHandler := handler.NewRoleHandler(roleUseCase)
 	dormitoryHandler := handler.NewDormitoryHandler(dormitoryUseCase)
-	studentHandler := handler.NewStudentHandler(studentUseCase, studentSKSResultUseCase)
+	studentHandler := handler.NewStudentHandler(studentUseCase, studentSKSResultUseCase, studentImportUseCase)
 	fanHandler := handler.NewFanHandler(fanUseCase)
 	classHandler := handler.NewClassHandler(classUseCase)
 	teacherHandler := handler.NewTeacherHandler(teacherUseCase)
 	scheduleSlotHandler := handler.NewScheduleSlotHandler(scheduleSlotUseCase)
 	classScheduleHandler := handler.NewClassScheduleHandler(classScheduleUseCase)
+	subjectHandler := handler.NewSubjectHandler(subjectUseCase)
 	sksDefinitionHandler := handler.NewSKSDefinitionHandler(sksDefinitionUseCase)
 	sksExamHandler := handler.NewSKSExamScheduleHandler(sksExamUseCase)
-	attendanceHandler := handler.NewAttendanceHandler(attendanceUseCase)
+	attendanceHandler := handler.NewAttendanceHandler(attendanceUseCase, attendanceGeneratorUseCase)
+	holidayHandler := handler.NewHolidayHandler(holidayUseCase)
 	leavePermitHandler := handler.NewLeavePermitHandler(leavePermitUseCase)
 	healthStatusHandler := handler.NewHealthStatusHandler(healthStatusUseCase)
 	locationHandler := handler.NewLocationHandler(locationUseCase)
 	permissionHandler := handler.NewPermissionHandler(permissionUseCase)
-	auditLogHandler := handler.NewAuditLogHandler(auditLogUseCase)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogUseCase, auditIntegrityUseCase, auditRetentionUseCase)
 	reportHandler := handler.NewReportHandler(reportUseCase)
+	jobRunHandler := handler.NewJobRunHandler(jobRunUseCase)
+	loginLockoutHandler := handler.NewLoginLockoutHandler(loginLockoutUseCase)
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorUseCase)
+	jwksHandler := handler.NewJWKSHandler(tokenService)
+	serviceAccountHandler := handler.NewServiceAccountHandler(serviceAccountUseCase)
+	impersonationHandler := handler.NewImpersonationHandler(impersonationUseCase)
+	invitationHandler := handler.NewInvitationHandler(invitationUseCase)
 
 	// Initialize middleware
-	authMiddleware := middleware.NewAuthMiddleware(tokenService, userRepo)
+	authMiddleware := middleware.NewAuthMiddleware(tokenService, userRepo, serviceAccountUseCase, principalCache)
 
 	// Setup router (includes global CORS & audit context middleware inside SetupRouter)
 	r := router.SetupRouter(
@@ -126,16 +200,41 @@ func main() {
 		classHandler,
 		teacherHandler,
 		classScheduleHandler,
+		subjectHandler,
 		sksDefinitionHandler,
 		sksExamHandler,
 		attendanceHandler,
+		holidayHandler,
 		scheduleSlotHandler,
 		leavePermitHandler,
 		healthStatusHandler,
 		reportHandler,
+		jobRunHandler,
+		loginLockoutHandler,
+		twoFactorHandler,
+		jwksHandler,
+		serviceAccountHandler,
+		impersonationHandler,
+		invitationHandler,
 		authMiddleware,
 	)
 
+	// Start in-process scheduler (replaces external cron for attendance/permit jobs)
+	jobScheduler := scheduler.New(jobRunRepo, jobLeaseRepo, scheduler.LoadConfig())
+	jobs := map[string]scheduler.JobFunc{
+		scheduler.JobLockAttendanceSessions: scheduler.LockAttendanceSessionsJob(attendanceUseCase),
+		scheduler.JobOpenAttendanceSessions: scheduler.OpenAttendanceSessionsJob(attendanceGeneratorUseCase),
+		scheduler.JobExpireLeavePermits:     scheduler.ExpireLeavePermitsJob(leavePermitUseCase),
+		scheduler.JobAuditCheckpoint:        scheduler.AuditCheckpointJob(auditIntegrityUseCase),
+		scheduler.JobAuditRetention:         scheduler.AuditRetentionJob(auditRetentionUseCase),
+	}
+	for name, run := range jobs {
+		if err := jobScheduler.Register(name, run); err != nil {
+			fatal("Failed to register scheduled job", err)
+		}
+	}
+	jobScheduler.Start(context.Background())
+
 	// Get server port
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
@@ -143,8 +242,36 @@ func main() {
 	}
 
 	// Start server
-	log.Printf("Server starting on port %s", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	srv := &http.Server{Addr: ":" + port, Handler: r}
+	go func() {
+		slog.Info("Server starting", "port", port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("Failed to start server", err)
+		}
+	}()
+
+	// Graceful shutdown: finish in-flight requests and jobs, then flush
+	// buffered audit entries so none are lost on deploy
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	slog.Info("Shutting down server...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Server shutdown", "error", err)
+	}
+	jobScheduler.Stop()
+	if err := auditLogger.Close(shutdownCtx); err != nil {
+		slog.Error("Audit writer shutdown", "error", err)
 	}
+	stats := auditLogger.Stats()
+	slog.Info("Audit writer stopped", "written", stats.Written, "failed", stats.Failed, "spilled", stats.Spilled, "dropped", stats.Dropped)
+}
+
+// fatal logs err and exits, for failures during startup.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -7,7 +7,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/permission"
 	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
 	infraRepo "github.com/your-org/go-backend-starter/internal/infrastructure/repository"
 	"golang.org/x/crypto/bcrypt"
@@ -57,99 +59,26 @@ func main() {
 	userRepo := infraRepo.NewUserRepository()
 	dormitoryRepo := infraRepo.NewDormitoryRepository()
 
-	// Create permissions
-	permissions := []*entity.Permission{
-		// User permissions
-		{ID: uuid.New(), Name: "user:read", Slug: "user-read", Resource: "user", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "user:create", Slug: "user-create", Resource: "user", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "user:update", Slug: "user-update", Resource: "user", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "user:delete", Slug: "user-delete", Resource: "user", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Dormitory permissions
-		{ID: uuid.New(), Name: "dorm:read", Slug: "dorm-read", Resource: "dorm", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "dorm:create", Slug: "dorm-create", Resource: "dorm", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "dorm:update", Slug: "dorm-update", Resource: "dorm", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "dorm:delete", Slug: "dorm-delete", Resource: "dorm", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Role permissions
-		{ID: uuid.New(), Name: "role:read", Slug: "role-read", Resource: "role", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "role:create", Slug: "role-create", Resource: "role", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "role:update", Slug: "role-update", Resource: "role", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "role:delete", Slug: "role-delete", Resource: "role", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Audit permissions
-		{ID: uuid.New(), Name: "audit:read", Slug: "audit-read", Resource: "audit_log", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Student permissions
-		{ID: uuid.New(), Name: "student:read", Slug: "student-read", Resource: "student", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "student:create", Slug: "student-create", Resource: "student", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "student:update", Slug: "student-update", Resource: "student", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Fan permissions
-		{ID: uuid.New(), Name: "fans:read", Slug: "fans-read", Resource: "fans", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "fans:create", Slug: "fans-create", Resource: "fans", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "fans:update", Slug: "fans-update", Resource: "fans", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "fans:delete", Slug: "fans-delete", Resource: "fans", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Class permissions
-		{ID: uuid.New(), Name: "classes:read", Slug: "classes-read", Resource: "classes", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "classes:create", Slug: "classes-create", Resource: "classes", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "classes:update", Slug: "classes-update", Resource: "classes", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "classes:delete", Slug: "classes-delete", Resource: "classes", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Teacher permissions
-		{ID: uuid.New(), Name: "teachers:read", Slug: "teachers-read", Resource: "teachers", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "teachers:create", Slug: "teachers-create", Resource: "teachers", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "teachers:update", Slug: "teachers-update", Resource: "teachers", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "teachers:delete", Slug: "teachers-delete", Resource: "teachers", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Schedule slot permissions
-		{ID: uuid.New(), Name: "schedule_slots:read", Slug: "schedule-slots-read", Resource: "schedule_slots", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "schedule_slots:create", Slug: "schedule-slots-create", Resource: "schedule_slots", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "schedule_slots:update", Slug: "schedule-slots-update", Resource: "schedule_slots", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "schedule_slots:delete", Slug: "schedule-slots-delete", Resource: "schedule_slots", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Class schedule permissions
-		{ID: uuid.New(), Name: "class_schedules:read", Slug: "class-schedules-read", Resource: "class_schedules", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "class_schedules:create", Slug: "class-schedules-create", Resource: "class_schedules", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "class_schedules:update", Slug: "class-schedules-update", Resource: "class_schedules", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "class_schedules:delete", Slug: "class-schedules-delete", Resource: "class_schedules", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// SKS definition permissions
-		{ID: uuid.New(), Name: "sks_definitions:read", Slug: "sks-definitions-read", Resource: "sks_definitions", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "sks_definitions:create", Slug: "sks-definitions-create", Resource: "sks_definitions", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "sks_definitions:update", Slug: "sks-definitions-update", Resource: "sks_definitions", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "sks_definitions:delete", Slug: "sks-definitions-delete", Resource: "sks_definitions", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// SKS exam schedule permissions
-		{ID: uuid.New(), Name: "sks_exams:read", Slug: "sks-exams-read", Resource: "sks_exams", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "sks_exams:create", Slug: "sks-exams-create", Resource: "sks_exams", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "sks_exams:update", Slug: "sks-exams-update", Resource: "sks_exams", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "sks_exams:delete", Slug: "sks-exams-delete", Resource: "sks_exams", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Student SKS results & FAN completion permissions
-		{ID: uuid.New(), Name: "student_sks_results:read", Slug: "student-sks-results-read", Resource: "student_sks_results", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "student_sks_results:create", Slug: "student-sks-results-create", Resource: "student_sks_results", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "student_sks_results:update", Slug: "student-sks-results-update", Resource: "student_sks_results", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Attendance permissions
-		{ID: uuid.New(), Name: "attendance_sessions:read", Slug: "attendance-sessions-read", Resource: "attendance_sessions", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "attendance_sessions:create", Slug: "attendance-sessions-create", Resource: "attendance_sessions", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "attendance_sessions:update", Slug: "attendance-sessions-update", Resource: "attendance_sessions", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "attendance_sessions:lock", Slug: "attendance-sessions-lock", Resource: "attendance_sessions", Action: "lock", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Leave permit permissions
-		{ID: uuid.New(), Name: "leave_permits:read", Slug: "leave-permits-read", Resource: "leave_permits", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "leave_permits:create", Slug: "leave-permits-create", Resource: "leave_permits", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "leave_permits:approve", Slug: "leave-permits-approve", Resource: "leave_permits", Action: "approve", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "leave_permits:complete", Slug: "leave-permits-complete", Resource: "leave_permits", Action: "complete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		// Health status permissions
-		{ID: uuid.New(), Name: "health_statuses:read", Slug: "health-statuses-read", Resource: "health_statuses", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "health_statuses:create", Slug: "health-statuses-create", Resource: "health_statuses", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: uuid.New(), Name: "health_statuses:revoke", Slug: "health-statuses-revoke", Resource: "health_statuses", Action: "revoke", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	// Create the catalog permissions missing from the database
+	log.Println("Syncing permissions...")
+	synced, err := usecase.NewPermissionUseCase(permissionRepo).SyncPermissions(ctx)
+	if err != nil {
+		log.Fatalf("Failed to sync permissions: %v", err)
+	}
+	for _, name := range synced.Created {
+		log.Printf("Created permission: %s", name)
+	}
+	for _, name := range synced.Orphaned {
+		log.Printf("Permission %s is not in the catalog", name)
 	}
 
-	log.Println("Creating permissions...")
-	for _, perm := range permissions {
-		// Check if permission already exists
-		existingPerm, _ := permissionRepo.GetBySlug(ctx, perm.Slug)
-		if existingPerm != nil {
-			log.Printf("Permission %s already exists, skipping...", perm.Name)
-			// Update the permission ID in our array for later use
-			perm.ID = existingPerm.ID
-		} else {
-			if err := permissionRepo.Create(ctx, perm); err != nil {
-				log.Printf("Failed to create permission %s: %v", perm.Name, err)
-			} else {
-				log.Printf("Created permission: %s", perm.Name)
-			}
+	permissions := make([]*entity.Permission, 0, len(permission.Names()))
+	for _, name := range permission.Names() {
+		perm, err := permissionRepo.GetByName(ctx, name)
+		if err != nil {
+			log.Fatalf("permission %s not found: %v", name, err)
 		}
+		permissions = append(permissions, perm)
 	}
 
 	// Check if roles already exist (from migration)
@@ -186,7 +115,7 @@ func main() {
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 			Permissions: []entity.Permission{
-				*permissions[4], // dorm:read
+				*mustPermission(permissions, permission.DormRead),
 			},
 		}
 		if err := roleRepo.Create(ctx, userRole); err != nil {
@@ -197,13 +126,18 @@ func main() {
 	} else {
 		// Assign permissions to existing user role
 		if existingUserRole != nil {
-			roleRepo.AssignPermission(ctx, existingUserRole.ID, permissions[4].ID) // dorm:read
+			roleRepo.AssignPermission(ctx, existingUserRole.ID, mustPermission(permissions, permission.DormRead).ID)
 			log.Println("Updated user role permissions")
 		}
 	}
 
 	// Teacher Role (assign teacher permissions, not protected)
-	teacherPerms := []*entity.Permission{permissions[23], permissions[24], permissions[25], permissions[26]}
+	teacherPerms := []*entity.Permission{
+		mustPermission(permissions, permission.ClassesDelete),
+		mustPermission(permissions, permission.TeachersRead),
+		mustPermission(permissions, permission.TeachersCreate),
+		mustPermission(permissions, permission.TeachersUpdate),
+	}
 	teacherRole, _ := roleRepo.GetBySlug(ctx, "teacher")
 	if teacherRole == nil {
 		teacherRoleEntity := &entity.Role{
@@ -230,22 +164,23 @@ func main() {
 		log.Println("Updated teacher role permissions")
 	}
 
-	attendanceReadPerm := mustPermission(permissions, "attendance_sessions:read")
-	attendanceCreatePerm := mustPermission(permissions, "attendance_sessions:create")
-	attendanceUpdatePerm := mustPermission(permissions, "attendance_sessions:update")
-	attendanceLockPerm := mustPermission(permissions, "attendance_sessions:lock")
+	attendanceReadPerm := mustPermission(permissions, permission.AttendanceSessionsRead)
+	attendanceCreatePerm := mustPermission(permissions, permission.AttendanceSessionsCreate)
+	attendanceUpdatePerm := mustPermission(permissions, permission.AttendanceSessionsUpdate)
+	attendanceLockPerm := mustPermission(permissions, permission.AttendanceSessionsLock)
 
 	// Admin Role (protected)
 	if !adminRoleExists {
 		adminRole := &entity.Role{
-			ID:          uuid.New(),
-			Name:        "Admin",
-			Slug:        "admin",
-			IsActive:    true,
-			IsProtected: true,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-			Permissions: copyPermissions(permissions),
+			ID:                uuid.New(),
+			Name:              "Admin",
+			Slug:              "admin",
+			IsActive:          true,
+			IsProtected:       true,
+			TwoFactorRequired: true,
+			CreatedAt:         time.Now(),
+			UpdatedAt:         time.Now(),
+			Permissions:       copyPermissions(permissions),
 		}
 		if err := roleRepo.Create(ctx, adminRole); err != nil {
 			log.Printf("Failed to create admin role: %v", err)
@@ -265,14 +200,15 @@ func main() {
 	// Super Admin Role (protected, has all permissions)
 	if !superAdminRoleExists {
 		superAdminRole := &entity.Role{
-			ID:          uuid.New(),
-			Name:        "Super Admin",
-			Slug:        "super_admin",
-			IsActive:    true,
-			IsProtected: true,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-			Permissions: copyPermissions(permissions),
+			ID:                uuid.New(),
+			Name:              "Super Admin",
+			Slug:              "super_admin",
+			IsActive:          true,
+			IsProtected:       true,
+			TwoFactorRequired: true,
+			CreatedAt:         time.Now(),
+			UpdatedAt:         time.Now(),
+			Permissions:       copyPermissions(permissions),
 		}
 		if err := roleRepo.Create(ctx, superAdminRole); err != nil {
 			log.Printf("Failed to create super admin role: %v", err)
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	infraRepo "github.com/your-org/go-backend-starter/internal/infrastructure/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/spreadsheet"
+)
+
+func main() {
+	var fileInput string
+	var dryRun bool
+	flag.StringVar(&fileInput, "file", "", "CSV or XLSX file with student_number, full_name, birth_date, gender, parent_name, dormitory_code and optional class columns.")
+	flag.BoolVar(&dryRun, "dry-run", false, "Validate the file and report row errors without importing anything.")
+	flag.Parse()
+
+	if fileInput == "" {
+		log.Fatal("Missing -file")
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	if err := database.Connect(); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	file, err := os.Open(fileInput)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", fileInput, err)
+	}
+	rows, err := spreadsheet.ReadRows(fileInput, file)
+	file.Close()
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", fileInput, err)
+	}
+
+	ctx := context.Background()
+
+	studentRepo := infraRepo.NewStudentRepository()
+	dormitoryRepo := infraRepo.NewDormitoryRepository()
+	classRepo := infraRepo.NewClassRepository()
+	auditLogRepo := infraRepo.NewAuditLogRepository()
+
+	auditLogger := service.NewAuditLogger(auditLogRepo)
+	importUseCase := usecase.NewStudentImportUseCase(studentRepo, dormitoryRepo, classRepo, auditLogger)
+
+	result, err := importUseCase.ImportStudents(ctx, dto.ImportStudentsRequest{Rows: rows, DryRun: dryRun})
+	if err != nil && err != domainErrors.ErrStudentImportInvalid {
+		log.Fatalf("Failed to import students: %v", err)
+	}
+
+	for _, rowErr := range result.Errors {
+		log.Printf("Row %d %s: %s", rowErr.Row, rowErr.StudentNumber, strings.Join(rowErr.Errors, "; "))
+	}
+	log.Printf("Student import (dry_run=%t): total=%d valid=%d imported=%d invalid=%d",
+		result.DryRun, result.TotalRows, result.ValidRows, result.Imported, len(result.Errors))
+
+	if len(result.Errors) > 0 {
+		os.Exit(1)
+	}
+}
@@ -6,6 +6,24 @@ type OpenAttendanceSessionRequest struct {
 	Date             string   `json:"date" binding:"required"`
 }
 
+// GenerateAttendanceSessionsRequest opens sessions for every active schedule in a date range.
+type GenerateAttendanceSessionsRequest struct {
+	StartDate   string  `json:"start_date" binding:"required"`
+	EndDate     string  `json:"end_date" binding:"required"`
+	DormitoryID *string `json:"dormitory_id" binding:"omitempty,uuid4"`
+}
+
+// GenerateAttendanceSessionsResponse summarizes a generation run. Skipped counts are
+// schedule/date pairs; HolidayDates lists dates covered by a global or dormitory holiday.
+type GenerateAttendanceSessionsResponse struct {
+	StartDate       string   `json:"start_date"`
+	EndDate         string   `json:"end_date"`
+	Created         int      `json:"created"`
+	SkippedExisting int      `json:"skipped_existing"`
+	SkippedHolidays int      `json:"skipped_holidays"`
+	HolidayDates    []string `json:"holiday_dates"`
+}
+
 // SubmitStudentAttendanceRequest bulk submits student attendance for a session.
 type SubmitStudentAttendanceRequest struct {
 	Records []StudentAttendanceRecord `json:"records" binding:"required,dive"`
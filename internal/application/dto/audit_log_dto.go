@@ -1,21 +1,71 @@
 package dto
 
+// AuditLogFilterRequest filters audit entries. From and To accept RFC3339
+// timestamps or YYYY-MM-DD dates in the server timezone; From is inclusive,
+// To exclusive, and a To date includes that whole day.
+type AuditLogFilterRequest struct {
+	Resource      string `form:"resource"`
+	Action        string `form:"action"`
+	ActorUsername string `form:"actor_username"`
+	ActorID       string `form:"actor_id" binding:"omitempty,uuid"`
+	TargetID      string `form:"target_id"`
+	IPAddress     string `form:"ip_address"`
+	RequestID     string `form:"request_id"`
+	StatusCode    int    `form:"status_code" binding:"omitempty,min=100,max=599"`
+	From          string `form:"from"`
+	To            string `form:"to"`
+}
+
+// ListAuditLogsRequest is one page of filtered audit entries, newest first
+type ListAuditLogsRequest struct {
+	AuditLogFilterRequest
+	Page     int `form:"page"`
+	PageSize int `form:"page_size"`
+}
+
+// ExportAuditLogsRequest streams every filtered audit entry, oldest first
+type ExportAuditLogsRequest struct {
+	AuditLogFilterRequest
+	Format string `form:"format" binding:"omitempty,oneof=ndjson csv"`
+}
+
+// ArchiveAuditLogsRequest archives entries older than OlderThanMonths,
+// falling back to AUDIT_RETENTION_MONTHS when zero
+type ArchiveAuditLogsRequest struct {
+	OlderThanMonths int `json:"older_than_months" binding:"omitempty,min=1"`
+}
+
 // AuditLogResponse represents audit log data in responses
 type AuditLogResponse struct {
 	ID            string   `json:"id"`
+	Sequence      int64    `json:"sequence"`
+	Hash          string   `json:"hash,omitempty"`
 	ActorID       string   `json:"actor_id,omitempty"`
 	ActorUsername string   `json:"username,omitempty"`
 	ActorRoles    []string `json:"actor_roles,omitempty"`
-	Action        string   `json:"action"`
-	Resource      string   `json:"resource"`
-	TargetID      string   `json:"target_id,omitempty"`
-	RequestPath   string   `json:"request_path"`
-	RequestMethod string   `json:"request_method"`
-	StatusCode    int      `json:"status_code"`
-	IPAddress     string   `json:"ip_address,omitempty"`
-	UserAgent     string   `json:"user_agent,omitempty"`
-	Metadata      string   `json:"metadata,omitempty"`
-	CreatedAt     string   `json:"created_at"`
+	APIKeyID      string   `json:"api_key_id,omitempty"`
+	// ImpersonatorID and ImpersonatorUsername are set when an admin acted as the actor.
+	ImpersonatorID       string `json:"impersonator_id,omitempty"`
+	ImpersonatorUsername string `json:"impersonator_username,omitempty"`
+	Action               string `json:"action"`
+	Resource             string `json:"resource"`
+	TargetID             string `json:"target_id,omitempty"`
+	RequestPath          string `json:"request_path"`
+	RequestMethod        string `json:"request_method"`
+	RequestID            string `json:"request_id,omitempty"`
+	StatusCode           int    `json:"status_code"`
+	IPAddress            string `json:"ip_address,omitempty"`
+	UserAgent            string `json:"user_agent,omitempty"`
+	Metadata             string `json:"metadata,omitempty"`
+	// Changes maps field names to their old and new values for updates and deletes.
+	Changes   map[string]AuditFieldChange `json:"changes,omitempty"`
+	CreatedAt string                      `json:"created_at"`
+}
+
+// AuditFieldChange is the old and new value of one changed field
+type AuditFieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
 }
 
 // ListAuditLogsResponse represents paginated audit log list response
@@ -26,3 +76,89 @@ type ListAuditLogsResponse struct {
 	PageSize   int                `json:"page_size"`
 	TotalPages int                `json:"total_pages"`
 }
+
+// EntityHistoryResponse is the audit timeline of a single record, oldest first
+type EntityHistoryResponse struct {
+	Resource string             `json:"resource"`
+	TargetID string             `json:"target_id"`
+	Entries  []AuditLogResponse `json:"entries"`
+}
+
+// AuditWriterStatsResponse counts what happened to the audit entries logged
+// since the process started. Failed entries are spilled to disk and written
+// on the next start; only dropped entries are lost.
+type AuditWriterStatsResponse struct {
+	Mode     string `json:"mode"`
+	Queued   int    `json:"queued"`
+	Written  int64  `json:"written"`
+	Failed   int64  `json:"failed"`
+	Spilled  int64  `json:"spilled"`
+	Replayed int64  `json:"replayed"`
+	Dropped  int64  `json:"dropped"`
+}
+
+// AuditChainBreak describes the first entry at which the hash chain does not hold
+type AuditChainBreak struct {
+	Sequence int64  `json:"sequence"`
+	ID       string `json:"id,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// AuditChainVerificationResponse is the result of walking the audit hash chain
+type AuditChainVerificationResponse struct {
+	Valid   bool  `json:"valid"`
+	Entries int64 `json:"entries"`
+	// ArchivedThrough is the last sequence moved to an archive; the walk
+	// starts after it.
+	ArchivedThrough int64            `json:"archived_through,omitempty"`
+	HeadSequence    int64            `json:"head_sequence"`
+	HeadHash        string           `json:"head_hash,omitempty"`
+	Checkpoints     int              `json:"checkpoints"`
+	FirstBroken     *AuditChainBreak `json:"first_broken,omitempty"`
+	VerifiedAt      string           `json:"verified_at"`
+}
+
+// AuditCheckpointResponse is a signed statement of the chain head. The
+// signature covers "audit-checkpoint:v1:<sequence>:<hash>:<created_at>".
+type AuditCheckpointResponse struct {
+	ID        string `json:"id"`
+	Sequence  int64  `json:"sequence"`
+	Hash      string `json:"hash"`
+	KeyID     string `json:"key_id"`
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ListAuditCheckpointsResponse lists checkpoints, oldest first
+type ListAuditCheckpointsResponse struct {
+	Checkpoints []AuditCheckpointResponse `json:"checkpoints"`
+}
+
+// AuditCheckpointExport is the file handed to auditors
+type AuditCheckpointExport struct {
+	Format      string                    `json:"format"`
+	ExportedAt  string                    `json:"exported_at"`
+	Checkpoints []AuditCheckpointResponse `json:"checkpoints"`
+}
+
+// AuditArchiveResponse describes a chain range moved out of the audit_logs
+// table into a compressed NDJSON file
+type AuditArchiveResponse struct {
+	ID            string `json:"id"`
+	FromSequence  int64  `json:"from_sequence"`
+	ToSequence    int64  `json:"to_sequence"`
+	FirstPrevHash string `json:"first_prev_hash"`
+	LastHash      string `json:"last_hash"`
+	Entries       int64  `json:"entries"`
+	File          string `json:"file"`
+	FileSHA256    string `json:"file_sha256"`
+	OlderThan     string `json:"older_than"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// ListAuditArchivesResponse lists archives, oldest range first
+type ListAuditArchivesResponse struct {
+	Archives []AuditArchiveResponse `json:"archives"`
+}
@@ -18,18 +18,91 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
-// AuthResponse represents the authentication response
+// LogoutRequest represents the request for revoking a refresh token session
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// ChangePasswordRequest represents the request to change the current user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+// AuthResponse represents the authentication response. When the user has
+// two-factor authentication enabled, login returns TwoFactorRequired and a
+// TwoFactorToken instead of the token pair.
 type AuthResponse struct {
-	AccessToken  string  `json:"access_token"`
-	RefreshToken string  `json:"refresh_token"`
-	ExpiresAt    string  `json:"expires_at"`
-	User         UserDTO `json:"user"`
+	AccessToken       string  `json:"access_token"`
+	RefreshToken      string  `json:"refresh_token"`
+	ExpiresAt         string  `json:"expires_at"`
+	TwoFactorRequired bool    `json:"two_factor_required,omitempty"`
+	TwoFactorToken    string  `json:"two_factor_token,omitempty"`
+	User              UserDTO `json:"user"`
 }
 
 // UserDTO represents user data in responses
 type UserDTO struct {
-	ID       string   `json:"id"`
-	Username string   `json:"username"`
-	Name     string   `json:"name"`
-	Roles    []string `json:"roles,omitempty"`
+	ID                     string   `json:"id"`
+	Username               string   `json:"username"`
+	Name                   string   `json:"name"`
+	Roles                  []string `json:"roles,omitempty"`
+	MustChangePassword     bool     `json:"must_change_password"`
+	TwoFactorEnabled       bool     `json:"two_factor_enabled"`
+	TwoFactorSetupRequired bool     `json:"two_factor_setup_required"`
+}
+
+// LoginLockoutResponse represents a locked-out username or client IP
+type LoginLockoutResponse struct {
+	ID           string `json:"id"`
+	Kind         string `json:"kind"`
+	Value        string `json:"value"`
+	Lockouts     int    `json:"lockouts"`
+	LastFailedAt string `json:"last_failed_at"`
+	LockedUntil  string `json:"locked_until"`
+}
+
+// ListLoginLockoutsResponse lists the active login lockouts
+type ListLoginLockoutsResponse struct {
+	Lockouts []LoginLockoutResponse `json:"lockouts"`
+}
+
+// LoginTwoFactorRequest completes a login with a TOTP or recovery code
+type LoginTwoFactorRequest struct {
+	TwoFactorToken string `json:"two_factor_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// TwoFactorSetupResponse carries the pending TOTP secret. ProvisioningURI is
+// the otpauth:// URI to render as a QR code.
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TwoFactorCodeRequest carries a TOTP code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// DisableTwoFactorRequest requires both factors to turn 2FA off
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// RecoveryCodesResponse lists freshly generated recovery codes; they are shown only once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ImpersonationResponse carries a short-lived access token that acts as the
+// impersonated user. No refresh token is issued; request a new one when it expires.
+type ImpersonationResponse struct {
+	AccessToken          string `json:"access_token"`
+	ExpiresAt            string `json:"expires_at"`
+	UserID               string `json:"user_id"`
+	Username             string `json:"username"`
+	ImpersonatorID       string `json:"impersonator_id"`
+	ImpersonatorUsername string `json:"impersonator_username"`
 }
@@ -53,3 +53,28 @@ type ListClassSchedulesResponse struct {
 	PageSize   int                     `json:"page_size"`
 	TotalPages int                     `json:"total_pages"`
 }
+
+// BulkCreateClassSchedulesRequest represents payload to create several schedule entries at once.
+type BulkCreateClassSchedulesRequest struct {
+	Schedules []CreateClassScheduleRequest `json:"schedules" binding:"required,min=1,max=100,dive"`
+}
+
+// BulkCreateClassSchedulesResponse wraps schedules created in a bulk request.
+type BulkCreateClassSchedulesResponse struct {
+	Schedules []ClassScheduleResponse `json:"schedules"`
+}
+
+// ClassScheduleConflict describes a clash between a requested schedule and another one.
+// Index points at the requested entry in a bulk request; Schedule is set for clashes with
+// stored schedules and ConflictingIndex for clashes with another entry of the same request.
+type ClassScheduleConflict struct {
+	Index            *int                   `json:"index,omitempty"`
+	Reasons          []string               `json:"reasons"`
+	Schedule         *ClassScheduleResponse `json:"schedule,omitempty"`
+	ConflictingIndex *int                   `json:"conflicting_index,omitempty"`
+}
+
+// ClassScheduleConflictResponse lists every conflict found for a request.
+type ClassScheduleConflictResponse struct {
+	Conflicts []ClassScheduleConflict `json:"conflicts"`
+}
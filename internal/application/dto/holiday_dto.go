@@ -0,0 +1,23 @@
+package dto
+
+// CreateHolidayRequest captures payload for registering a holiday.
+type CreateHolidayRequest struct {
+	Date        string  `json:"date" binding:"required"`
+	Name        string  `json:"name" binding:"required,max=150"`
+	DormitoryID *string `json:"dormitory_id" binding:"omitempty,uuid4"`
+}
+
+// HolidayResponse represents holiday data returned to clients.
+type HolidayResponse struct {
+	ID          string  `json:"id"`
+	DormitoryID *string `json:"dormitory_id"`
+	Date        string  `json:"date"`
+	Name        string  `json:"name"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// ListHolidaysResponse wraps holidays within a date range.
+type ListHolidaysResponse struct {
+	Holidays []HolidayResponse `json:"holidays"`
+}
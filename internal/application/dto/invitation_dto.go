@@ -0,0 +1,39 @@
+package dto
+
+// CreateInvitationRequest represents the request to invite a user. The
+// invitee receives the role and dormitories once they accept.
+type CreateInvitationRequest struct {
+	Username     string   `json:"username" binding:"required,alphanumunicode,min=3,max=32"`
+	RoleID       string   `json:"role_id" binding:"required,uuid"`
+	DormitoryIDs []string `json:"dormitory_ids,omitempty" binding:"omitempty,dive,uuid"`
+}
+
+// AcceptInvitationRequest represents the request to redeem an invitation
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// InvitationResponse represents an invitation without its token
+type InvitationResponse struct {
+	ID           string   `json:"id"`
+	Username     string   `json:"username"`
+	RoleID       string   `json:"role_id"`
+	RoleName     string   `json:"role_name"`
+	DormitoryIDs []string `json:"dormitory_ids"`
+	ExpiresAt    string   `json:"expires_at"`
+	CreatedBy    *string  `json:"created_by"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+// CreateInvitationResponse contains the invitation token, which is only ever returned once
+type CreateInvitationResponse struct {
+	InvitationResponse
+	Token string `json:"token"`
+}
+
+// ListInvitationsResponse lists pending invitations
+type ListInvitationsResponse struct {
+	Invitations []InvitationResponse `json:"invitations"`
+}
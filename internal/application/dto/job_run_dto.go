@@ -0,0 +1,32 @@
+package dto
+
+// ListJobRunsRequest captures filters for GET /api/job-runs.
+type ListJobRunsRequest struct {
+	JobName  *string `form:"job_name"`
+	Status   *string `form:"status"`
+	Page     int     `form:"page"`
+	PageSize int     `form:"page_size"`
+}
+
+// JobRunResponse describes a single scheduled job execution.
+type JobRunResponse struct {
+	ID          string  `json:"id"`
+	JobName     string  `json:"job_name"`
+	Status      string  `json:"status"`
+	Holder      string  `json:"holder"`
+	ScheduledAt string  `json:"scheduled_at"`
+	StartedAt   string  `json:"started_at"`
+	FinishedAt  *string `json:"finished_at,omitempty"`
+	DurationMs  int64   `json:"duration_ms"`
+	Message     string  `json:"message,omitempty"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// ListJobRunsResponse wraps paginated job runs.
+type ListJobRunsResponse struct {
+	Runs       []JobRunResponse `json:"runs"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalPages int              `json:"total_pages"`
+}
@@ -17,3 +17,19 @@ type ListPermissionsResponse struct {
 	PageSize    int                  `json:"page_size"`
 	TotalPages  int                  `json:"total_pages"`
 }
+
+// EffectivePermissionsResponse is the current user's permission set. Grants
+// are the names assigned through roles, possibly wildcards such as
+// "reports:*"; Permissions expands them into every concrete permission held.
+type EffectivePermissionsResponse struct {
+	Permissions []string `json:"permissions"`
+	Grants      []string `json:"grants"`
+}
+
+// SyncPermissionsResponse reports how the permissions table compares to the
+// code-defined catalog. Orphaned permissions exist only in the database; they
+// are left in place so existing role assignments keep working.
+type SyncPermissionsResponse struct {
+	Created  []string `json:"created"`
+	Orphaned []string `json:"orphaned"`
+}
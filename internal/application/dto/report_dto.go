@@ -16,14 +16,17 @@ type StudentAttendanceReportRequest struct {
 
 // StudentAttendanceReportRow represents aggregated counts for a cohort.
 type StudentAttendanceReportRow struct {
-	DormitoryID *string `json:"dormitory_id,omitempty"`
-	ClassID     *string `json:"class_id,omitempty"`
-	FanID       *string `json:"fan_id,omitempty"`
-	Total       int     `json:"total"`
-	Present     int     `json:"present"`
-	Absent      int     `json:"absent"`
-	Permit      int     `json:"permit"`
-	Sick        int     `json:"sick"`
+	DormitoryID   *string `json:"dormitory_id,omitempty"`
+	DormitoryName string  `json:"dormitory_name,omitempty"`
+	ClassID       *string `json:"class_id,omitempty"`
+	ClassName     string  `json:"class_name,omitempty"`
+	FanID         *string `json:"fan_id,omitempty"`
+	FanName       string  `json:"fan_name,omitempty"`
+	Total         int     `json:"total"`
+	Present       int     `json:"present"`
+	Absent        int     `json:"absent"`
+	Permit        int     `json:"permit"`
+	Sick          int     `json:"sick"`
 }
 
 // StudentAttendanceReportResponse wraps rows plus metadata for the student attendance report.
@@ -42,10 +45,11 @@ type TeacherAttendanceReportRequest struct {
 
 // TeacherAttendanceReportRow summarizes teacher attendance metrics.
 type TeacherAttendanceReportRow struct {
-	TeacherID string `json:"teacher_id"`
-	Total     int    `json:"total"`
-	Present   int    `json:"present"`
-	Absent    int    `json:"absent"`
+	TeacherID   string `json:"teacher_id"`
+	TeacherName string `json:"teacher_name,omitempty"`
+	Total       int    `json:"total"`
+	Present     int    `json:"present"`
+	Absent      int    `json:"absent"`
 }
 
 // TeacherAttendanceReportResponse represents teacher attendance aggregation output.
@@ -65,10 +69,11 @@ type LeavePermitReportRequest struct {
 
 // LeavePermitReportRow summarises leave permit counts per grouping.
 type LeavePermitReportRow struct {
-	DormitoryID *string `json:"dormitory_id,omitempty"`
-	Type        string  `json:"type"`
-	Status      string  `json:"status"`
-	Total       int     `json:"total"`
+	DormitoryID   *string `json:"dormitory_id,omitempty"`
+	DormitoryName string  `json:"dormitory_name,omitempty"`
+	Type          string  `json:"type"`
+	Status        string  `json:"status"`
+	Total         int     `json:"total"`
 }
 
 // LeavePermitReportResponse contains aggregated leave permit data.
@@ -87,10 +92,11 @@ type HealthStatusReportRequest struct {
 
 // HealthStatusReportRow summarises health status counts.
 type HealthStatusReportRow struct {
-	DormitoryID *string `json:"dormitory_id,omitempty"`
-	Status      string  `json:"status"`
-	Total       int     `json:"total"`
-	Consecutive int     `json:"consecutive"`
+	DormitoryID   *string `json:"dormitory_id,omitempty"`
+	DormitoryName string  `json:"dormitory_name,omitempty"`
+	Status        string  `json:"status"`
+	Total         int     `json:"total"`
+	Consecutive   int     `json:"consecutive"`
 }
 
 // HealthStatusReportResponse contains aggregated health status data.
@@ -111,7 +117,9 @@ type SKSReportRequest struct {
 // SKSReportRow summarises SKS pass/fail counts.
 type SKSReportRow struct {
 	FanID   *string `json:"fan_id,omitempty"`
+	FanName string  `json:"fan_name,omitempty"`
 	SKSID   *string `json:"sks_id,omitempty"`
+	SKSName string  `json:"sks_name,omitempty"`
 	Total   int     `json:"total"`
 	Passed  int     `json:"passed"`
 	Failed  int     `json:"failed"`
@@ -135,13 +143,18 @@ type MutationReportRequest struct {
 
 // MutationReportRow represents a change event between dorm/class assignments.
 type MutationReportRow struct {
-	StudentID   string  `json:"student_id"`
-	FromDormID  *string `json:"from_dormitory_id"`
-	ToDormID    *string `json:"to_dormitory_id"`
-	FromClassID *string `json:"from_class_id"`
-	ToClassID   *string `json:"to_class_id"`
-	StartDate   string  `json:"start_date"`
-	EndDate     *string `json:"end_date"`
+	StudentID     string  `json:"student_id"`
+	StudentName   string  `json:"student_name,omitempty"`
+	FromDormID    *string `json:"from_dormitory_id"`
+	FromDormName  string  `json:"from_dormitory_name,omitempty"`
+	ToDormID      *string `json:"to_dormitory_id"`
+	ToDormName    string  `json:"to_dormitory_name,omitempty"`
+	FromClassID   *string `json:"from_class_id"`
+	FromClassName string  `json:"from_class_name,omitempty"`
+	ToClassID     *string `json:"to_class_id"`
+	ToClassName   string  `json:"to_class_name,omitempty"`
+	StartDate     string  `json:"start_date"`
+	EndDate       *string `json:"end_date"`
 }
 
 // MutationReportResponse contains mutation history data.
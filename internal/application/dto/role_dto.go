@@ -7,25 +7,29 @@ type CreateRoleRequest struct {
 	IsActive    bool     `json:"is_active"`
 	IsProtected bool     `json:"is_protected"`
 	PermissionIDs []string `json:"permission_ids,omitempty"`
+	// TwoFactorRequired forces holders of the role to enrol in 2FA
+	TwoFactorRequired bool `json:"two_factor_required"`
 }
 
 // UpdateRoleRequest represents the request to update a role
 type UpdateRoleRequest struct {
-	Name     string `json:"name,omitempty"`
-	Slug     string `json:"slug,omitempty"`
-	IsActive *bool  `json:"is_active,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Slug              string `json:"slug,omitempty"`
+	IsActive          *bool  `json:"is_active,omitempty"`
+	TwoFactorRequired *bool  `json:"two_factor_required,omitempty"`
 }
 
 // RoleResponse represents role data in responses
 type RoleResponse struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	Slug        string   `json:"slug"`
-	IsActive    bool     `json:"is_active"`
-	IsProtected bool     `json:"is_protected"`
-	Permissions []string `json:"permissions,omitempty"`
-	CreatedAt   string   `json:"created_at"`
-	UpdatedAt   string   `json:"updated_at"`
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	Slug              string   `json:"slug"`
+	IsActive          bool     `json:"is_active"`
+	IsProtected       bool     `json:"is_protected"`
+	TwoFactorRequired bool     `json:"two_factor_required"`
+	Permissions       []string `json:"permissions,omitempty"`
+	CreatedAt         string   `json:"created_at"`
+	UpdatedAt         string   `json:"updated_at"`
 }
 
 // ListRolesResponse represents paginated role list response
@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+// CreateServiceAccountRequest represents the request to create a service account
+type CreateServiceAccountRequest struct {
+	Username string   `json:"username" binding:"required,alphanumunicode,min=3,max=32"`
+	Name     string   `json:"name" binding:"required"`
+	RoleIDs  []string `json:"role_ids,omitempty"`
+}
+
+// ServiceAccountResponse represents a service account in responses
+type ServiceAccountResponse struct {
+	ID          string   `json:"id"`
+	Username    string   `json:"username"`
+	Name        string   `json:"name"`
+	IsActive    bool     `json:"is_active"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// ListServiceAccountsResponse lists all service accounts
+type ListServiceAccountsResponse struct {
+	ServiceAccounts []ServiceAccountResponse `json:"service_accounts"`
+}
+
+// CreateAPIKeyRequest represents the request to issue an API key. Permissions
+// must be a subset of the service account's permissions.
+type CreateAPIKeyRequest struct {
+	Name        string     `json:"name" binding:"required,max=100"`
+	Permissions []string   `json:"permissions" binding:"required,min=1"`
+	ExpiresAt   *time.Time `json:"expires_at" binding:"omitempty"`
+}
+
+// APIKeyResponse represents an API key without its secret
+type APIKeyResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Prefix      string   `json:"prefix"`
+	Permissions []string `json:"permissions"`
+	ExpiresAt   *string  `json:"expires_at"`
+	LastUsedAt  *string  `json:"last_used_at"`
+	RevokedAt   *string  `json:"revoked_at"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// CreateAPIKeyResponse contains the full key, which is only ever returned once
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// ListAPIKeysResponse lists the API keys of a service account
+type ListAPIKeysResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
@@ -52,6 +52,22 @@ type StudentDormitoryEvent struct {
 	EndDate     string `json:"end_date,omitempty"`
 }
 
+// ListStudentsRequest captures search, filter and sort options for GET /api/students.
+type ListStudentsRequest struct {
+	Search        string  `form:"q" binding:"omitempty,max=150"`
+	DormitoryID   *string `form:"dormitory_id" binding:"omitempty,uuid4"`
+	ClassID       *string `form:"class_id" binding:"omitempty,uuid4"`
+	FanID         *string `form:"fan_id" binding:"omitempty,uuid4"`
+	Status        *string `form:"status" binding:"omitempty,oneof=active inactive leave graduated"`
+	Gender        *string `form:"gender" binding:"omitempty,oneof=male female"`
+	BirthYearFrom *int    `form:"birth_year_from" binding:"omitempty,min=1900,max=2100"`
+	BirthYearTo   *int    `form:"birth_year_to" binding:"omitempty,min=1900,max=2100"`
+	SortBy        string  `form:"sort_by" binding:"omitempty,oneof=full_name student_number birth_date created_at"`
+	SortOrder     string  `form:"sort_order" binding:"omitempty,oneof=asc desc"`
+	Page          int     `form:"page"`
+	PageSize      int     `form:"page_size"`
+}
+
 // ListStudentsResponse paginated response.
 type ListStudentsResponse struct {
 	Students   []StudentResponse `json:"students"`
@@ -60,3 +76,25 @@ type ListStudentsResponse struct {
 	PageSize   int               `json:"page_size"`
 	TotalPages int               `json:"total_pages"`
 }
+
+// ImportStudentsRequest carries the rows of an import file; the first row is the header.
+type ImportStudentsRequest struct {
+	Rows   [][]string
+	DryRun bool
+}
+
+// StudentImportRowError lists the problems found in one import row.
+type StudentImportRowError struct {
+	Row           int      `json:"row"`
+	StudentNumber string   `json:"student_number,omitempty"`
+	Errors        []string `json:"errors"`
+}
+
+// ImportStudentsResponse reports the outcome of a bulk student import.
+type ImportStudentsResponse struct {
+	DryRun    bool                    `json:"dry_run"`
+	TotalRows int                     `json:"total_rows"`
+	ValidRows int                     `json:"valid_rows"`
+	Imported  int                     `json:"imported"`
+	Errors    []StudentImportRowError `json:"errors"`
+}
@@ -43,6 +43,8 @@ type TeacherResponse struct {
 	Username         string `json:"username,omitempty"`
 	CreatedAt        string `json:"created_at"`
 	UpdatedAt        string `json:"updated_at"`
+	// TemporaryPassword is returned once, when a login account is created
+	TemporaryPassword string `json:"temporary_password,omitempty"`
 }
 
 // ListTeachersResponse paginated list of teachers.
@@ -8,6 +8,8 @@ type CreateUserRequest struct {
 	Password string   `json:"password" binding:"required,min=6"`
 	Name     string   `json:"name" binding:"required"`
 	RoleIDs  []string `json:"role_ids,omitempty"`
+	// MustChangePassword makes the user set a new password at first login
+	MustChangePassword bool `json:"must_change_password,omitempty"`
 }
 
 // UpdateUserRequest represents the request to update a user
@@ -16,6 +18,8 @@ type UpdateUserRequest struct {
 	Username string   `json:"username,omitempty"`
 	IsActive *bool    `json:"is_active,omitempty"`
 	RoleIDs  []string `json:"role_ids,omitempty"`
+	// MustChangePassword makes the user set a new password at next login
+	MustChangePassword *bool `json:"must_change_password,omitempty"`
 }
 
 // UserDormitorySummary represents a simple dormitory view for user responses
@@ -35,6 +39,10 @@ type UserResponse struct {
 	Dormitories []UserDormitorySummary `json:"dormitories"`
 	CreatedAt   string                 `json:"created_at"`
 	UpdatedAt   string                 `json:"updated_at"`
+	// Password and two-factor state
+	MustChangePassword     bool `json:"must_change_password"`
+	TwoFactorEnabled       bool `json:"two_factor_enabled"`
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required,omitempty"`
 }
 
 // ListUsersResponse represents paginated user list response
@@ -51,3 +59,15 @@ type AssignRoleRequest struct {
 	UserID uuid.UUID `json:"user_id" binding:"required"`
 	RoleID uuid.UUID `json:"role_id" binding:"required"`
 }
+
+// ResetPasswordResponse carries the one-time temporary password issued by an admin reset
+type ResetPasswordResponse struct {
+	TemporaryPassword  string `json:"temporary_password"`
+	MustChangePassword bool   `json:"must_change_password"`
+	RevokedSessions    int64  `json:"revoked_sessions"`
+}
+
+// RevokeSessionsResponse represents the result of revoking a user's sessions
+type RevokeSessionsResponse struct {
+	RevokedSessions int64 `json:"revoked_sessions"`
+}
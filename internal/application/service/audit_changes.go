@@ -0,0 +1,209 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// RedactedValue replaces old and new values of sensitive fields in audit changes.
+const RedactedValue = "[REDACTED]"
+
+// FieldChange holds the old and new value of one field in an audit entry.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// sensitiveFields are recorded as changed without their values. Fields hidden
+// from JSON (`json:"-"`, e.g. password hashes and secrets) and fields tagged
+// `audit:"redact"` (e.g. health diagnoses) are redacted as well.
+var sensitiveFields = map[string]bool{
+	"password":          true,
+	"two_factor_secret": true,
+}
+
+// ignoredFields change on every write and carry no information.
+var ignoredFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// DiffFields compares two values of the same struct type (or pointers to it)
+// and returns the fields whose values differ, keyed by their JSON name. Either
+// side may be nil: a nil before records a creation, a nil after a deletion.
+// Relations (nested structs and slices of structs) are not compared.
+func DiffFields(before, after interface{}) map[string]FieldChange {
+	oldValue := structValue(before)
+	newValue := structValue(after)
+	if !oldValue.IsValid() && !newValue.IsValid() {
+		return nil
+	}
+	var structType reflect.Type
+	switch {
+	case !oldValue.IsValid():
+		structType = newValue.Type()
+	case newValue.IsValid() && newValue.Type() != oldValue.Type():
+		return nil
+	default:
+		structType = oldValue.Type()
+	}
+
+	changes := map[string]FieldChange{}
+	diffStruct(structType, oldValue, newValue, changes)
+	return changes
+}
+
+// diffStruct adds the differing fields of structType to changes. Fields of
+// embedded structs are compared as if declared on the outer struct, so a
+// view such as struct{ entity.User; RoleIDs []uuid.UUID } records both.
+func diffStruct(structType reflect.Type, oldValue, newValue reflect.Value, changes map[string]FieldChange) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			var oldField, newField reflect.Value
+			if oldValue.IsValid() {
+				oldField = oldValue.Field(i)
+			}
+			if newValue.IsValid() {
+				newField = newValue.Field(i)
+			}
+			diffStruct(field.Type, oldField, newField, changes)
+			continue
+		}
+		if !field.IsExported() || !comparableField(field.Type) {
+			continue
+		}
+		name, hidden := fieldName(field)
+		if ignoredFields[name] {
+			continue
+		}
+
+		var oldField, newField interface{}
+		if oldValue.IsValid() {
+			oldField = plainValue(oldValue.Field(i))
+		}
+		if newValue.IsValid() {
+			newField = plainValue(newValue.Field(i))
+		}
+		if reflect.DeepEqual(oldField, newField) {
+			continue
+		}
+		if hidden || sensitiveFields[name] || field.Tag.Get("audit") == "redact" {
+			changes[name] = FieldChange{Old: redact(oldField), New: redact(newField)}
+			continue
+		}
+		changes[name] = FieldChange{Old: oldField, New: newField}
+	}
+}
+
+func structValue(v interface{}) reflect.Value {
+	if v == nil {
+		return reflect.Value{}
+	}
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return value
+}
+
+// comparableField reports whether a field holds a value rather than a relation.
+func comparableField(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t == timeType
+	case reflect.Slice, reflect.Array:
+		if t == uuidType {
+			return true
+		}
+		return comparableField(t.Elem())
+	case reflect.Map, reflect.Interface, reflect.Func, reflect.Chan:
+		return false
+	default:
+		return true
+	}
+}
+
+// fieldName returns the JSON name of a field and whether it is hidden from JSON.
+func fieldName(field reflect.StructField) (string, bool) {
+	tag := strings.Split(field.Tag.Get("json"), ",")[0]
+	if tag == "-" {
+		return snakeCase(field.Name), true
+	}
+	if tag == "" {
+		return snakeCase(field.Name), false
+	}
+	return tag, false
+}
+
+// plainValue converts a field to a JSON-friendly value; nil pointers become nil.
+func plainValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch value := v.Interface().(type) {
+	case time.Time:
+		if value.IsZero() {
+			return nil
+		}
+		return value.UTC().Format(time.RFC3339)
+	case uuid.UUID:
+		if value == uuid.Nil {
+			return nil
+		}
+		return value.String()
+	case []uuid.UUID:
+		ids := make([]string, 0, len(value))
+		for _, id := range value {
+			ids = append(ids, id.String())
+		}
+		return ids
+	default:
+		return value
+	}
+}
+
+func redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return RedactedValue
+}
+
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word unless inside an acronym such as "ID"
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+func TestDiffFields(t *testing.T) {
+	t.Run("records changed fields only", func(t *testing.T) {
+		before := entity.User{ID: uuid.New(), Username: "budi", Name: "Budi", IsActive: true, UpdatedAt: time.Now()}
+		after := before
+		after.Name = "Budi Santoso"
+		after.IsActive = false
+		after.UpdatedAt = before.UpdatedAt.Add(time.Minute)
+		after.Roles = []entity.Role{{Name: "admin"}}
+
+		changes := DiffFields(&before, &after)
+		assert.Equal(t, map[string]FieldChange{
+			"name":      {Old: "Budi", New: "Budi Santoso"},
+			"is_active": {Old: true, New: false},
+		}, changes)
+	})
+
+	t.Run("redacts secrets and tagged fields", func(t *testing.T) {
+		before := entity.User{Password: "old-hash", TwoFactorSecret: ""}
+		after := entity.User{Password: "new-hash", TwoFactorSecret: "SECRET"}
+
+		changes := DiffFields(before, after)
+		assert.Equal(t, FieldChange{Old: RedactedValue, New: RedactedValue}, changes["password"])
+		assert.Equal(t, FieldChange{Old: RedactedValue, New: RedactedValue}, changes["two_factor_secret"])
+
+		status := entity.HealthStatus{Diagnosis: "Flu", Status: entity.HealthStatusStateActive}
+		revoked := status
+		revoked.Status = entity.HealthStatusStateRevoked
+		revoked.Diagnosis = "Demam"
+		changes = DiffFields(&status, &revoked)
+		assert.Equal(t, FieldChange{Old: RedactedValue, New: RedactedValue}, changes["diagnosis"])
+		assert.Equal(t, FieldChange{Old: entity.HealthStatusStateActive, New: entity.HealthStatusStateRevoked}, changes["status"])
+	})
+
+	t.Run("records every value on delete", func(t *testing.T) {
+		id := uuid.New()
+		joined := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+		deleted := &entity.Teacher{ID: id, FullName: "Siti", JoinedAt: &joined}
+
+		changes := DiffFields(deleted, nil)
+		assert.Equal(t, FieldChange{Old: id.String(), New: nil}, changes["id"])
+		assert.Equal(t, FieldChange{Old: "Siti", New: nil}, changes["full_name"])
+		assert.Equal(t, FieldChange{Old: "2024-07-01T00:00:00Z", New: nil}, changes["joined_at"])
+		assert.NotContains(t, changes, "user_id")
+	})
+
+	t.Run("records every value on create", func(t *testing.T) {
+		var missing *entity.Teacher
+		changes := DiffFields(missing, &entity.Teacher{FullName: "Siti"})
+		assert.Equal(t, FieldChange{Old: nil, New: "Siti"}, changes["full_name"])
+	})
+
+	t.Run("flattens embedded structs", func(t *testing.T) {
+		type userView struct {
+			entity.User
+			RoleIDs []uuid.UUID `json:"role_ids"`
+		}
+		oldRole, newRole := uuid.New(), uuid.New()
+		before := userView{User: entity.User{Name: "Budi"}, RoleIDs: []uuid.UUID{oldRole}}
+		after := userView{User: entity.User{Name: "Budi Santoso"}, RoleIDs: []uuid.UUID{newRole}}
+
+		assert.Equal(t, map[string]FieldChange{
+			"name":     {Old: "Budi", New: "Budi Santoso"},
+			"role_ids": {Old: []string{oldRole.String()}, New: []string{newRole.String()}},
+		}, DiffFields(&before, &after))
+	})
+
+	t.Run("ignores mismatched types", func(t *testing.T) {
+		assert.Nil(t, DiffFields(&entity.User{}, &entity.Role{}))
+		assert.Nil(t, DiffFields(nil, nil))
+	})
+}
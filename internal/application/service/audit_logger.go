@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,33 +20,145 @@ const (
 	CtxKeyStatusCode    = "status_code"
 	CtxKeyIPAddress     = "ip_address"
 	CtxKeyUserAgent     = "user_agent"
+	CtxKeyRequestID     = "request_id"
 	CtxKeyActorID       = "user_id"
 	CtxKeyActorUsername = "user_username"
 	CtxKeyActorRoles    = "user_roles"
+	// CtxKeyAPIKeyID holds the uuid.UUID of the API key a service account authenticated with.
+	CtxKeyAPIKeyID = "api_key_id"
+	// CtxKeyImpersonatorID and CtxKeyImpersonatorUsername identify the admin
+	// behind an impersonation token; the actor keys then hold the impersonated user.
+	CtxKeyImpersonatorID       = "impersonator_id"
+	CtxKeyImpersonatorUsername = "impersonator_username"
+	// CtxKeyAuditScope holds the *AuditScope of the HTTP request being handled.
+	CtxKeyAuditScope = "audit_scope"
 )
 
 // AuditLogger defines interface for writing audit logs
 type AuditLogger interface {
 	Log(ctx context.Context, resource, action, targetID string, metadata map[string]string) error
+	// LogChange records an update or delete together with the fields that
+	// differ between before and after (see DiffFields); after is nil for deletes.
+	LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error
 }
 
+// auditLogger writes every entry synchronously inside the caller's request.
+// It is the AUDIT_WRITER_MODE=sync writer and the one tests use.
 type auditLogger struct {
-	repo domainRepo.AuditLogRepository
+	repo    domainRepo.AuditLogRepository
+	written atomic.Int64
+	failed  atomic.Int64
 }
 
-// NewAuditLogger creates a new AuditLogger
+// NewAuditLogger creates a new synchronous AuditLogger
 func NewAuditLogger(repo domainRepo.AuditLogRepository) AuditLogger {
 	return &auditLogger{repo: repo}
 }
 
 func (l *auditLogger) Log(ctx context.Context, resource, action, targetID string, metadata map[string]string) error {
-	// Marshal metadata to JSON (best-effort)
+	submitAuditEntry(ctx, newAuditEntry(ctx, resource, action, targetID, metadata, nil), func(entry *entity.AuditLog) {
+		l.write(ctx, entry)
+	})
+	return nil
+}
+
+func (l *auditLogger) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	submitAuditEntry(ctx, newAuditEntry(ctx, resource, action, targetID, metadata, DiffFields(before, after)), func(entry *entity.AuditLog) {
+		l.write(ctx, entry)
+	})
+	return nil
+}
+
+// Stats reports how many entries were written and how many inserts failed.
+func (l *auditLogger) Stats() AuditWriterStats {
+	return AuditWriterStats{
+		Mode:    AuditWriterModeSync,
+		Written: l.written.Load(),
+		Failed:  l.failed.Load(),
+	}
+}
+
+// Close is a no-op: nothing is buffered.
+func (l *auditLogger) Close(ctx context.Context) error {
+	return nil
+}
+
+func (l *auditLogger) write(ctx context.Context, entry *entity.AuditLog) {
+	// Best-effort logging: if audit log fails, jangan block main flow
+	if err := l.repo.Create(ctx, entry); err != nil {
+		l.failed.Add(1)
+		slog.ErrorContext(ctx, "audit: failed to write entry", "action", entry.Action, "error", err)
+		return
+	}
+	l.written.Add(1)
+}
+
+// AuditScope holds back the entries logged while an HTTP request is handled
+// until its response status is known, so every entry records the status code.
+type AuditScope struct {
+	mu      sync.Mutex
+	done    bool
+	status  int
+	pending []pendingAuditEntry
+}
+
+type pendingAuditEntry struct {
+	entry *entity.AuditLog
+	write func(*entity.AuditLog)
+}
+
+// NewAuditScope starts the scope of one request.
+func NewAuditScope() *AuditScope {
+	return &AuditScope{}
+}
+
+// Complete records the response status and writes the held entries in the
+// order they were logged. Entries logged afterwards are written immediately.
+func (s *AuditScope) Complete(status int) {
+	s.mu.Lock()
+	pending := s.pending
+	s.done, s.status, s.pending = true, status, nil
+	s.mu.Unlock()
+
+	for _, p := range pending {
+		p.entry.StatusCode = status
+		p.write(p.entry)
+	}
+}
+
+// submitAuditEntry hands entry to write, or holds it in the request's scope
+// until the response status is known.
+func submitAuditEntry(ctx context.Context, entry *entity.AuditLog, write func(*entity.AuditLog)) {
+	if scope, ok := ctx.Value(CtxKeyAuditScope).(*AuditScope); ok {
+		scope.mu.Lock()
+		if !scope.done {
+			scope.pending = append(scope.pending, pendingAuditEntry{entry: entry, write: write})
+			scope.mu.Unlock()
+			return
+		}
+		if entry.StatusCode == 0 {
+			entry.StatusCode = scope.status
+		}
+		scope.mu.Unlock()
+	}
+	write(entry)
+}
+
+// newAuditEntry builds an entry from the request details and actor stored in ctx.
+func newAuditEntry(ctx context.Context, resource, action, targetID string, metadata map[string]string, changes map[string]FieldChange) *entity.AuditLog {
+	// Marshal metadata and changes to JSON (best-effort)
 	var metadataStr string
 	if len(metadata) > 0 {
 		if b, err := json.Marshal(metadata); err == nil {
 			metadataStr = string(b)
 		}
 	}
+	var changesStr string
+	if len(changes) > 0 {
+		if b, err := json.Marshal(changes); err == nil {
+			changesStr = string(b)
+		}
+	}
 
 	// Extract actor info from context
 	var actorIDPtr *uuid.UUID
@@ -52,6 +167,15 @@ func (l *auditLogger) Log(ctx context.Context, resource, action, targetID string
 			actorIDPtr = &id
 		}
 	}
+	var apiKeyIDPtr *uuid.UUID
+	if id, ok := ctx.Value(CtxKeyAPIKeyID).(uuid.UUID); ok {
+		apiKeyIDPtr = &id
+	}
+	var impersonatorIDPtr *uuid.UUID
+	if id, ok := ctx.Value(CtxKeyImpersonatorID).(uuid.UUID); ok {
+		impersonatorIDPtr = &id
+	}
+	impersonatorUsername, _ := ctx.Value(CtxKeyImpersonatorUsername).(string)
 	actorUsername, _ := ctx.Value(CtxKeyActorUsername).(string)
 	actorRolesStr := ""
 	if roles, ok := ctx.Value(CtxKeyActorRoles).([]string); ok {
@@ -64,29 +188,31 @@ func (l *auditLogger) Log(ctx context.Context, resource, action, targetID string
 	requestMethod, _ := ctx.Value(CtxKeyRequestMethod).(string)
 	ipAddress, _ := ctx.Value(CtxKeyIPAddress).(string)
 	userAgent, _ := ctx.Value(CtxKeyUserAgent).(string)
+	requestID, _ := ctx.Value(CtxKeyRequestID).(string)
 	statusCode := 0
 	if sc, ok := ctx.Value(CtxKeyStatusCode).(int); ok {
 		statusCode = sc
 	}
 
-	logEntry := &entity.AuditLog{
-		ID:            uuid.New(),
-		ActorID:       actorIDPtr,
-		ActorUsername: actorUsername,
-		ActorRoles:    actorRolesStr,
-		Action:        action,
-		Resource:      resource,
-		TargetID:      targetID,
-		RequestPath:   requestPath,
-		RequestMethod: requestMethod,
-		StatusCode:    statusCode,
-		IPAddress:     ipAddress,
-		UserAgent:     userAgent,
-		Metadata:      metadataStr,
-		CreatedAt:     time.Now(),
+	return &entity.AuditLog{
+		ID:                   uuid.New(),
+		ActorID:              actorIDPtr,
+		ActorUsername:        actorUsername,
+		ActorRoles:           actorRolesStr,
+		APIKeyID:             apiKeyIDPtr,
+		ImpersonatorID:       impersonatorIDPtr,
+		ImpersonatorUsername: impersonatorUsername,
+		Action:               action,
+		Resource:             resource,
+		TargetID:             targetID,
+		RequestPath:          requestPath,
+		RequestMethod:        requestMethod,
+		RequestID:            requestID,
+		StatusCode:           statusCode,
+		IPAddress:            ipAddress,
+		UserAgent:            userAgent,
+		Metadata:             metadataStr,
+		Changes:              changesStr,
+		CreatedAt:            time.Now(),
 	}
-
-	// Best-effort logging: if audit log fails, jangan block main flow
-	_ = l.repo.Create(ctx, logEntry)
-	return nil
 }
@@ -0,0 +1,439 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// Audit writer modes selectable with AUDIT_WRITER_MODE.
+const (
+	AuditWriterModeSync  = "sync"
+	AuditWriterModeAsync = "async"
+)
+
+const (
+	defaultAuditBufferSize    = 1000
+	defaultAuditBatchSize     = 100
+	defaultAuditFlushInterval = time.Second
+	defaultAuditSpillFile     = "var/audit-spill.ndjson"
+)
+
+// AuditWriterConfig controls how audit entries reach the database.
+type AuditWriterConfig struct {
+	// Mode is "async" (AUDIT_WRITER_MODE, default) or "sync".
+	Mode string
+	// BufferSize bounds the entries waiting to be written (AUDIT_BUFFER_SIZE, default 1000).
+	BufferSize int
+	// BatchSize bounds the entries inserted together (AUDIT_BATCH_SIZE, default 100).
+	BatchSize int
+	// FlushInterval is the longest an entry waits for its batch to fill (AUDIT_FLUSH_INTERVAL, default 1s).
+	FlushInterval time.Duration
+	// SpillFile receives entries as NDJSON when the buffer is full or an
+	// insert fails (AUDIT_SPILL_FILE, default var/audit-spill.ndjson). They
+	// are written to the database the next time an async writer starts.
+	SpillFile string
+}
+
+// LoadAuditWriterConfig reads audit writer settings from the environment.
+func LoadAuditWriterConfig() AuditWriterConfig {
+	cfg := AuditWriterConfig{
+		Mode:          AuditWriterModeAsync,
+		BufferSize:    defaultAuditBufferSize,
+		BatchSize:     defaultAuditBatchSize,
+		FlushInterval: defaultAuditFlushInterval,
+		SpillFile:     defaultAuditSpillFile,
+	}
+
+	if mode := os.Getenv("AUDIT_WRITER_MODE"); mode != "" {
+		cfg.Mode = mode
+	}
+	if v, err := strconv.Atoi(os.Getenv("AUDIT_BUFFER_SIZE")); err == nil && v > 0 {
+		cfg.BufferSize = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("AUDIT_BATCH_SIZE")); err == nil && v > 0 {
+		cfg.BatchSize = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("AUDIT_FLUSH_INTERVAL")); err == nil && v > 0 {
+		cfg.FlushInterval = v
+	}
+	if path := os.Getenv("AUDIT_SPILL_FILE"); path != "" {
+		cfg.SpillFile = path
+	}
+
+	return cfg
+}
+
+// AuditWriterStats counts what happened to the entries handed to a writer.
+type AuditWriterStats struct {
+	Mode string `json:"mode"`
+	// Queued is the number of entries currently waiting in the buffer.
+	Queued int `json:"queued"`
+	// Written entries reached the database, including replayed ones.
+	Written int64 `json:"written"`
+	// Failed entries were part of an insert that returned an error; the
+	// async writer spills them.
+	Failed int64 `json:"failed"`
+	// Spilled entries were appended to the spill file.
+	Spilled int64 `json:"spilled"`
+	// Replayed entries were read back from the spill file and written.
+	Replayed int64 `json:"replayed"`
+	// Dropped entries could be neither written nor spilled and are lost.
+	Dropped int64 `json:"dropped"`
+}
+
+// AuditSink is an AuditLogger that reports its write counters and flushes
+// buffered entries on Close.
+type AuditSink interface {
+	AuditLogger
+	Stats() AuditWriterStats
+	// Close writes every buffered entry, waiting at most until ctx is done.
+	// Entries logged after Close are spilled.
+	Close(ctx context.Context) error
+}
+
+// NewAuditSink builds the writer selected by cfg.Mode.
+func NewAuditSink(repo domainRepo.AuditLogRepository, cfg AuditWriterConfig) (AuditSink, error) {
+	switch cfg.Mode {
+	case AuditWriterModeSync:
+		return &auditLogger{repo: repo}, nil
+	case "", AuditWriterModeAsync:
+		return NewAsyncAuditWriter(repo, cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown AUDIT_WRITER_MODE %q, use %s or %s", cfg.Mode, AuditWriterModeAsync, AuditWriterModeSync)
+	}
+}
+
+var _ AuditSink = (*auditLogger)(nil)
+var _ AuditSink = (*AsyncAuditWriter)(nil)
+
+// AsyncAuditWriter hands entries to a background worker through a bounded
+// buffer so requests never wait on the audit insert. The worker inserts
+// them in batches, in the order they were logged.
+type AsyncAuditWriter struct {
+	repo domainRepo.AuditLogRepository
+	cfg  AuditWriterConfig
+
+	// mu guards closed; Log holds it for reading while sending so Close
+	// never closes entries under a sender.
+	mu      sync.RWMutex
+	closed  bool
+	entries chan *entity.AuditLog
+	done    chan struct{}
+
+	spillMu sync.Mutex
+
+	written  atomic.Int64
+	failed   atomic.Int64
+	spilled  atomic.Int64
+	replayed atomic.Int64
+	dropped  atomic.Int64
+}
+
+// NewAsyncAuditWriter starts the background worker. It first replays
+// entries left in the spill file by an earlier process.
+func NewAsyncAuditWriter(repo domainRepo.AuditLogRepository, cfg AuditWriterConfig) *AsyncAuditWriter {
+	if cfg.BufferSize < 1 {
+		cfg.BufferSize = defaultAuditBufferSize
+	}
+	if cfg.BatchSize < 1 {
+		cfg.BatchSize = defaultAuditBatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultAuditFlushInterval
+	}
+
+	w := &AsyncAuditWriter{
+		repo:    repo,
+		cfg:     cfg,
+		entries: make(chan *entity.AuditLog, cfg.BufferSize),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *AsyncAuditWriter) Log(ctx context.Context, resource, action, targetID string, metadata map[string]string) error {
+	submitAuditEntry(ctx, newAuditEntry(ctx, resource, action, targetID, metadata, nil), w.enqueue)
+	return nil
+}
+
+func (w *AsyncAuditWriter) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	submitAuditEntry(ctx, newAuditEntry(ctx, resource, action, targetID, metadata, DiffFields(before, after)), w.enqueue)
+	return nil
+}
+
+// Stats returns a snapshot of the writer's counters.
+func (w *AsyncAuditWriter) Stats() AuditWriterStats {
+	return AuditWriterStats{
+		Mode:     AuditWriterModeAsync,
+		Queued:   len(w.entries),
+		Written:  w.written.Load(),
+		Failed:   w.failed.Load(),
+		Spilled:  w.spilled.Load(),
+		Replayed: w.replayed.Load(),
+		Dropped:  w.dropped.Load(),
+	}
+}
+
+// Close stops accepting entries and waits for the worker to write the
+// buffered ones.
+func (w *AsyncAuditWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.entries)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("audit writer: %d entries not flushed: %w", len(w.entries), ctx.Err())
+	}
+}
+
+// enqueue never blocks: a full buffer or a closed writer sends the entry to
+// the spill file instead.
+func (w *AsyncAuditWriter) enqueue(entry *entity.AuditLog) {
+	w.mu.RLock()
+	if !w.closed {
+		select {
+		case w.entries <- entry:
+			w.mu.RUnlock()
+			return
+		default:
+		}
+	}
+	w.mu.RUnlock()
+
+	w.spill([]*entity.AuditLog{entry})
+}
+
+func (w *AsyncAuditWriter) run() {
+	defer close(w.done)
+
+	w.replaySpill()
+
+	ticker := time.NewTicker(w.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*entity.AuditLog, 0, w.cfg.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		w.insert(batch)
+		batch = make([]*entity.AuditLog, 0, w.cfg.BatchSize)
+	}
+
+	for {
+		select {
+		case entry, ok := <-w.entries:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, entry)
+			if len(batch) >= w.cfg.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// insert writes one batch; a failed batch is spilled so a later start can
+// retry it.
+func (w *AsyncAuditWriter) insert(batch []*entity.AuditLog) bool {
+	if err := w.repo.CreateBatch(context.Background(), batch); err != nil {
+		w.failed.Add(int64(len(batch)))
+		slog.Error("audit: failed to write entries", "entries", len(batch), "error", err)
+		w.spill(batch)
+		return false
+	}
+	w.written.Add(int64(len(batch)))
+	return true
+}
+
+// spill appends entries to the spill file, counting them as dropped when
+// that is not possible either.
+func (w *AsyncAuditWriter) spill(entries []*entity.AuditLog) {
+	if err := w.appendSpill(entries); err != nil {
+		w.dropped.Add(int64(len(entries)))
+		slog.Error("audit: dropped entries", "entries", len(entries), "error", err)
+		return
+	}
+	w.spilled.Add(int64(len(entries)))
+}
+
+func (w *AsyncAuditWriter) appendSpill(entries []*entity.AuditLog) error {
+	if w.cfg.SpillFile == "" {
+		return errors.New("no spill file configured")
+	}
+
+	w.spillMu.Lock()
+	defer w.spillMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(w.cfg.SpillFile), 0o750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(w.cfg.SpillFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	buf := bufio.NewWriter(f)
+	enc := json.NewEncoder(buf)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// replaySpill moves the spill file aside and writes its entries. Entries
+// that still cannot be written go back to a fresh spill file. A tail that
+// does not decode, such as a line truncated by a crash while spilling, is
+// moved to a .corrupt file so it never blocks later replays.
+func (w *AsyncAuditWriter) replaySpill() {
+	if w.cfg.SpillFile == "" {
+		return
+	}
+	replayPath := w.cfg.SpillFile + ".replay"
+
+	// A replay file left by a crash is retried before the current spill file.
+	w.spillMu.Lock()
+	if _, err := os.Stat(replayPath); errors.Is(err, os.ErrNotExist) {
+		if err := os.Rename(w.cfg.SpillFile, replayPath); err != nil {
+			w.spillMu.Unlock()
+			if !errors.Is(err, os.ErrNotExist) {
+				slog.Error("audit: cannot replay spill file", "file", w.cfg.SpillFile, "error", err)
+			}
+			return
+		}
+	}
+	w.spillMu.Unlock()
+
+	entries, corruptAt, err := readSpill(replayPath)
+	if err != nil {
+		slog.Error("audit: cannot read spill file", "file", replayPath, "error", err)
+		if corruptAt < 0 {
+			corruptAt = 0
+		}
+	}
+
+	for start := 0; start < len(entries); start += w.cfg.BatchSize {
+		end := min(start+w.cfg.BatchSize, len(entries))
+		if !w.insert(entries[start:end]) {
+			// insert spilled this batch; keep the rest with it.
+			if end < len(entries) {
+				w.spill(entries[end:])
+			}
+			break
+		}
+		w.replayed.Add(int64(end - start))
+	}
+
+	if corruptAt >= 0 {
+		w.quarantineSpill(replayPath, corruptAt, len(entries))
+		return
+	}
+	if err := os.Remove(replayPath); err != nil {
+		slog.Warn("audit: cannot remove replayed spill file", "file", replayPath, "error", err)
+	}
+}
+
+// quarantineSpill moves the bytes of replayPath from offset on to a new
+// .corrupt file next to the spill file and removes replayPath. When the tail
+// cannot be copied the whole replay file is renamed instead.
+func (w *AsyncAuditWriter) quarantineSpill(replayPath string, offset int64, decoded int) {
+	corruptPath := fmt.Sprintf("%s.%s.corrupt", w.cfg.SpillFile, time.Now().UTC().Format("20060102T150405.000000000"))
+	if err := copyFileTail(replayPath, corruptPath, offset); err != nil {
+		if err := os.Rename(replayPath, corruptPath); err != nil {
+			slog.Error("audit: cannot quarantine corrupt spill file", "file", replayPath, "error", err)
+			return
+		}
+		slog.Error("audit: spill file has an undecodable tail, moved the whole file aside",
+			"file", corruptPath, "offset", offset, "replayed_entries", decoded, "error", err)
+		return
+	}
+	if err := os.Remove(replayPath); err != nil {
+		slog.Warn("audit: cannot remove replayed spill file", "file", replayPath, "error", err)
+	}
+	slog.Error("audit: spill file has an undecodable tail, moved it aside",
+		"file", corruptPath, "offset", offset, "replayed_entries", decoded)
+}
+
+func copyFileTail(src, dst string, offset int64) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if _, err := in.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return errors.Join(err, os.Remove(dst))
+	}
+	return out.Close()
+}
+
+// readSpill decodes the spill file one line per entry. It stops at the first
+// line that does not decode and returns its byte offset as corruptAt, which
+// is -1 when the whole file decoded.
+func readSpill(path string) (entries []*entity.AuditLog, corruptAt int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, -1, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var offset int64
+	for {
+		line, readErr := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var entry entity.AuditLog
+			if err := json.Unmarshal(line, &entry); err != nil {
+				return entries, offset, nil
+			}
+			entries = append(entries, &entry)
+		}
+		offset += int64(len(line))
+		if errors.Is(readErr, io.EOF) {
+			return entries, -1, nil
+		}
+		if readErr != nil {
+			return entries, offset, readErr
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// batchAuditRepo records inserted batches; unblock gates inserts and fail
+// makes them return an error.
+type batchAuditRepo struct {
+	domainRepo.AuditLogRepository
+
+	mu      sync.Mutex
+	batches [][]*entity.AuditLog
+	fail    bool
+	unblock chan struct{}
+}
+
+func (r *batchAuditRepo) Create(ctx context.Context, log *entity.AuditLog) error {
+	return r.CreateBatch(ctx, []*entity.AuditLog{log})
+}
+
+func (r *batchAuditRepo) CreateBatch(ctx context.Context, logs []*entity.AuditLog) error {
+	if r.unblock != nil {
+		<-r.unblock
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		return errors.New("database unavailable")
+	}
+	r.batches = append(r.batches, append([]*entity.AuditLog(nil), logs...))
+	return nil
+}
+
+func (r *batchAuditRepo) actions() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var actions []string
+	for _, batch := range r.batches {
+		for _, log := range batch {
+			actions = append(actions, log.Action)
+		}
+	}
+	return actions
+}
+
+func testWriterConfig(t *testing.T) AuditWriterConfig {
+	return AuditWriterConfig{
+		Mode:          AuditWriterModeAsync,
+		BufferSize:    10,
+		BatchSize:     3,
+		FlushInterval: time.Hour,
+		SpillFile:     filepath.Join(t.TempDir(), "audit-spill.ndjson"),
+	}
+}
+
+func TestAsyncAuditWriter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("batches entries in order and flushes on close", func(t *testing.T) {
+		repo := &batchAuditRepo{}
+		w := NewAsyncAuditWriter(repo, testWriterConfig(t))
+
+		for _, action := range []string{"a", "b", "c", "d"} {
+			require.NoError(t, w.Log(ctx, "student", action, "", nil))
+		}
+		require.NoError(t, w.Close(ctx))
+
+		assert.Equal(t, []string{"a", "b", "c", "d"}, repo.actions())
+		assert.Len(t, repo.batches, 2)
+		assert.Equal(t, AuditWriterStats{Mode: AuditWriterModeAsync, Written: 4}, w.Stats())
+	})
+
+	t.Run("spills when the buffer is full and replays on the next start", func(t *testing.T) {
+		cfg := testWriterConfig(t)
+		cfg.BufferSize = 1
+		cfg.BatchSize = 1
+		repo := &batchAuditRepo{unblock: make(chan struct{})}
+		w := NewAsyncAuditWriter(repo, cfg)
+
+		// The worker holds the first entry in a blocked insert, the second
+		// fills the buffer and the rest overflow to the spill file.
+		require.NoError(t, w.Log(ctx, "attendance", "first", "", nil))
+		require.Eventually(t, func() bool { return len(w.entries) == 0 }, time.Second, time.Millisecond)
+		for _, action := range []string{"second", "third", "fourth"} {
+			require.NoError(t, w.Log(ctx, "attendance", action, "", nil))
+		}
+		assert.Equal(t, int64(2), w.Stats().Spilled)
+
+		close(repo.unblock)
+		require.NoError(t, w.Close(ctx))
+		assert.Equal(t, []string{"first", "second"}, repo.actions())
+
+		next := NewAsyncAuditWriter(repo, cfg)
+		require.NoError(t, next.Close(ctx))
+		assert.Equal(t, []string{"first", "second", "third", "fourth"}, repo.actions())
+		assert.Equal(t, int64(2), next.Stats().Replayed)
+		assert.NoFileExists(t, cfg.SpillFile)
+	})
+
+	t.Run("replays a truncated spill file and quarantines its tail", func(t *testing.T) {
+		cfg := testWriterConfig(t)
+		var spill bytes.Buffer
+		enc := json.NewEncoder(&spill)
+		for _, action := range []string{"first", "second"} {
+			require.NoError(t, enc.Encode(&entity.AuditLog{Action: action}))
+		}
+		tail := `{"action":"third","resou`
+		spill.WriteString(tail)
+		// A crash left the truncated file as the replay file, and new entries
+		// were spilled afterwards
+		require.NoError(t, os.WriteFile(cfg.SpillFile+".replay", spill.Bytes(), 0o600))
+		var next bytes.Buffer
+		require.NoError(t, json.NewEncoder(&next).Encode(&entity.AuditLog{Action: "fourth"}))
+		require.NoError(t, os.WriteFile(cfg.SpillFile, next.Bytes(), 0o600))
+
+		repo := &batchAuditRepo{}
+		w := NewAsyncAuditWriter(repo, cfg)
+		require.NoError(t, w.Close(ctx))
+		assert.Equal(t, []string{"first", "second"}, repo.actions())
+		assert.NoFileExists(t, cfg.SpillFile+".replay")
+
+		corrupt, err := filepath.Glob(cfg.SpillFile + ".*.corrupt")
+		require.NoError(t, err)
+		require.Len(t, corrupt, 1)
+		quarantined, err := os.ReadFile(corrupt[0])
+		require.NoError(t, err)
+		assert.Equal(t, tail, string(quarantined))
+
+		// The next start is no longer blocked by the replay file
+		w = NewAsyncAuditWriter(repo, cfg)
+		require.NoError(t, w.Close(ctx))
+		assert.Equal(t, []string{"first", "second", "fourth"}, repo.actions())
+		assert.NoFileExists(t, cfg.SpillFile)
+	})
+
+	t.Run("spills failed batches", func(t *testing.T) {
+		cfg := testWriterConfig(t)
+		repo := &batchAuditRepo{fail: true}
+		w := NewAsyncAuditWriter(repo, cfg)
+
+		require.NoError(t, w.Log(ctx, "student", "create", "", nil))
+		require.NoError(t, w.Close(ctx))
+
+		stats := w.Stats()
+		assert.Equal(t, int64(1), stats.Failed)
+		assert.Equal(t, int64(1), stats.Spilled)
+		assert.Zero(t, stats.Dropped)
+		assert.FileExists(t, cfg.SpillFile)
+	})
+
+	t.Run("drops entries that cannot be spilled", func(t *testing.T) {
+		cfg := testWriterConfig(t)
+		cfg.SpillFile = ""
+		w := NewAsyncAuditWriter(&batchAuditRepo{fail: true}, cfg)
+
+		require.NoError(t, w.Log(ctx, "student", "create", "", nil))
+		require.NoError(t, w.Close(ctx))
+		require.NoError(t, w.Log(ctx, "student", "update", "", nil))
+
+		stats := w.Stats()
+		assert.Equal(t, int64(1), stats.Failed)
+		assert.Equal(t, int64(2), stats.Dropped)
+	})
+}
+
+func TestAuditScope(t *testing.T) {
+	repo := &batchAuditRepo{}
+	sink, err := NewAuditSink(repo, AuditWriterConfig{Mode: AuditWriterModeSync})
+	require.NoError(t, err)
+
+	scope := NewAuditScope()
+	ctx := context.WithValue(context.Background(), CtxKeyAuditScope, scope)
+	require.NoError(t, sink.Log(ctx, "student", "create", "", nil))
+	require.NoError(t, sink.Log(ctx, "student", "update", "", nil))
+	assert.Empty(t, repo.actions(), "entries wait for the response status")
+
+	scope.Complete(201)
+	require.NoError(t, sink.Log(ctx, "student", "delete", "", nil))
+
+	assert.Equal(t, []string{"create", "update", "delete"}, repo.actions())
+	for _, batch := range repo.batches {
+		for _, log := range batch {
+			assert.Equal(t, 201, log.StatusCode)
+		}
+	}
+}
+
+func TestNewAuditSink(t *testing.T) {
+	repo := &batchAuditRepo{}
+
+	sink, err := NewAuditSink(repo, AuditWriterConfig{Mode: AuditWriterModeSync})
+	require.NoError(t, err)
+	require.NoError(t, sink.Log(context.Background(), "student", "create", "", nil))
+	assert.Equal(t, []string{"create"}, repo.actions())
+	assert.Equal(t, int64(1), sink.Stats().Written)
+
+	_, err = NewAuditSink(repo, AuditWriterConfig{Mode: "kafka"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// CtxKeyDormitoryScope stores the authenticated actor's DormitoryScope.
+const CtxKeyDormitoryScope = "dormitory_scope"
+
+// DormitoryScope describes which dormitories the current actor may access.
+type DormitoryScope struct {
+	// All is true for roles that bypass dormitory scoping (admin, super_admin).
+	All          bool
+	DormitoryIDs []uuid.UUID
+}
+
+// Allows reports whether the scope covers the given dormitory.
+func (s DormitoryScope) Allows(dormitoryID uuid.UUID) bool {
+	if s.All {
+		return true
+	}
+	for _, id := range s.DormitoryIDs {
+		if id == dormitoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// WithDormitoryScope attaches a dormitory scope to ctx.
+func WithDormitoryScope(ctx context.Context, scope DormitoryScope) context.Context {
+	return context.WithValue(ctx, CtxKeyDormitoryScope, scope)
+}
+
+// DormitoryScopeFromContext returns the scope attached to ctx. Calls without an
+// authenticated actor (CLI tools, scheduled jobs) are unrestricted.
+func DormitoryScopeFromContext(ctx context.Context) DormitoryScope {
+	if scope, ok := ctx.Value(CtxKeyDormitoryScope).(DormitoryScope); ok {
+		return scope
+	}
+	return DormitoryScope{All: true}
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// maxGenerateDays bounds a single generation run.
+const maxGenerateDays = 31
+
+// AttendanceGeneratorUseCase opens attendance sessions from weekly class schedules.
+type AttendanceGeneratorUseCase struct {
+	sessionRepo       repository.AttendanceSessionRepository
+	classScheduleRepo repository.ClassScheduleRepository
+	holidayRepo       repository.HolidayRepository
+	auditLogger       appService.AuditLogger
+}
+
+// NewAttendanceGeneratorUseCase wires dependencies for session generation.
+func NewAttendanceGeneratorUseCase(
+	sessionRepo repository.AttendanceSessionRepository,
+	classScheduleRepo repository.ClassScheduleRepository,
+	holidayRepo repository.HolidayRepository,
+	auditLogger appService.AuditLogger,
+) *AttendanceGeneratorUseCase {
+	return &AttendanceGeneratorUseCase{
+		sessionRepo:       sessionRepo,
+		classScheduleRepo: classScheduleRepo,
+		holidayRepo:       holidayRepo,
+		auditLogger:       auditLogger,
+	}
+}
+
+// GenerateSessions opens a session for every active schedule whose day matches each date
+// in the range. Holidays and schedule/date pairs that already have a session are skipped,
+// so the run is safe to repeat.
+func (uc *AttendanceGeneratorUseCase) GenerateSessions(ctx context.Context, req dto.GenerateAttendanceSessionsRequest) (*dto.GenerateAttendanceSessionsResponse, error) {
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, domainErrors.ErrBadRequest
+	}
+	end, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil || end.Before(start) || end.Sub(start) >= maxGenerateDays*24*time.Hour {
+		return nil, domainErrors.ErrBadRequest
+	}
+
+	dormID := uuid.Nil
+	var dormFilter *uuid.UUID
+	if req.DormitoryID != nil {
+		parsed, err := uuid.Parse(*req.DormitoryID)
+		if err != nil {
+			return nil, domainErrors.ErrBadRequest
+		}
+		dormID = parsed
+		dormFilter = &parsed
+		if err := ensureDormitoryInScope(ctx, parsed); err != nil {
+			return nil, err
+		}
+	}
+	scope := appService.DormitoryScopeFromContext(ctx)
+
+	holidays, err := uc.holidayRepo.List(ctx, repository.HolidayFilter{StartDate: start, EndDate: end, DormitoryID: dormFilter})
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	calendar := newHolidayCalendar(holidays)
+
+	result := &dto.GenerateAttendanceSessionsResponse{
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		HolidayDates: []string{},
+	}
+
+	schedulesByDay := make(map[string][]*entity.ClassSchedule)
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		dateKey := date.Format("2006-01-02")
+		day := dayOfWeekCode(date)
+		schedules, ok := schedulesByDay[day]
+		if !ok {
+			schedules, err = uc.classScheduleRepo.ListActiveByDay(ctx, day, dormID)
+			if err != nil {
+				return nil, domainErrors.ErrInternalServer
+			}
+			if !scope.All {
+				schedules = filterSchedulesInScope(schedules, scope)
+			}
+			schedulesByDay[day] = schedules
+		}
+
+		if calendar.global[dateKey] {
+			result.HolidayDates = append(result.HolidayDates, dateKey)
+			result.SkippedHolidays += len(schedules)
+			continue
+		}
+		if len(schedules) == 0 {
+			continue
+		}
+
+		existingIDs, err := uc.sessionRepo.ListScheduleIDsByDate(ctx, date)
+		if err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
+		existing := make(map[uuid.UUID]bool, len(existingIDs))
+		for _, id := range existingIDs {
+			existing[id] = true
+		}
+
+		if len(calendar.dormitory[dateKey]) > 0 {
+			result.HolidayDates = append(result.HolidayDates, dateKey)
+		}
+		for _, schedule := range schedules {
+			if calendar.dormitory[dateKey][schedule.DormitoryID] {
+				result.SkippedHolidays++
+				continue
+			}
+			if existing[schedule.ID] {
+				result.SkippedExisting++
+				continue
+			}
+
+			now := time.Now()
+			session := &entity.AttendanceSession{
+				ID:              uuid.New(),
+				ClassScheduleID: schedule.ID,
+				Date:            date,
+				StartTime:       schedule.StartTime,
+				EndTime:         schedule.EndTime,
+				TeacherID:       schedule.TeacherID,
+				Status:          entity.AttendanceSessionStatusOpen,
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			}
+			if err := uc.sessionRepo.Create(ctx, session); err != nil {
+				return nil, domainErrors.ErrInternalServer
+			}
+			result.Created++
+
+			_ = uc.auditLogger.Log(ctx, "attendance_session", "attendance_session:open", session.ID.String(), map[string]string{
+				"class_schedule_id": schedule.ID.String(),
+				"date":              dateKey,
+				"source":            "generator",
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// holidayCalendar indexes holidays by date, separating global ones from dormitory-specific ones.
+type holidayCalendar struct {
+	global    map[string]bool
+	dormitory map[string]map[uuid.UUID]bool
+}
+
+func newHolidayCalendar(holidays []*entity.Holiday) holidayCalendar {
+	calendar := holidayCalendar{
+		global:    make(map[string]bool),
+		dormitory: make(map[string]map[uuid.UUID]bool),
+	}
+	for _, holiday := range holidays {
+		key := holiday.Date.Format("2006-01-02")
+		if holiday.DormitoryID == nil {
+			calendar.global[key] = true
+			continue
+		}
+		if calendar.dormitory[key] == nil {
+			calendar.dormitory[key] = make(map[uuid.UUID]bool)
+		}
+		calendar.dormitory[key][*holiday.DormitoryID] = true
+	}
+	return calendar
+}
+
+// dayOfWeekCode maps a date to the day codes used by class schedules (mon, tue, ...).
+func dayOfWeekCode(date time.Time) string {
+	return strings.ToLower(date.Weekday().String()[:3])
+}
+
+// filterSchedulesInScope keeps the schedules held in dormitories the actor may access.
+func filterSchedulesInScope(schedules []*entity.ClassSchedule, scope appService.DormitoryScope) []*entity.ClassSchedule {
+	allowed := make([]*entity.ClassSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if scope.Allows(schedule.DormitoryID) {
+			allowed = append(allowed, schedule)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+func generatorDate(value string) time.Time {
+	date, _ := time.Parse("2006-01-02", value)
+	return date
+}
+
+func TestAttendanceGeneratorUseCase_GenerateSessions(t *testing.T) {
+	sessionRepo := new(mocks.AttendanceSessionRepositoryMock)
+	classScheduleRepo := new(mocks.ClassScheduleRepositoryMock)
+	holidayRepo := new(mocks.HolidayRepositoryMock)
+	uc := usecase.NewAttendanceGeneratorUseCase(sessionRepo, classScheduleRepo, holidayRepo, auditLoggerStub{})
+
+	dormA, dormB := uuid.New(), uuid.New()
+	mondayA := &entity.ClassSchedule{ID: uuid.New(), DormitoryID: dormA, TeacherID: uuid.New(), DayOfWeek: "mon", IsActive: true}
+	mondayB := &entity.ClassSchedule{ID: uuid.New(), DormitoryID: dormB, TeacherID: uuid.New(), DayOfWeek: "mon", IsActive: true}
+	tuesdayA := &entity.ClassSchedule{ID: uuid.New(), DormitoryID: dormA, TeacherID: uuid.New(), DayOfWeek: "tue", IsActive: true}
+
+	// 2025-01-06 is a Monday; 2025-01-13 is the following Monday.
+	holidayRepo.On("List", mock.Anything, mock.Anything).Return([]*entity.Holiday{
+		{ID: uuid.New(), Date: generatorDate("2025-01-07"), Name: "Libur Nasional"},
+		{ID: uuid.New(), DormitoryID: &dormB, Date: generatorDate("2025-01-13"), Name: "Haflah"},
+	}, nil)
+
+	for _, day := range []string{"wed", "thu", "fri", "sat", "sun"} {
+		classScheduleRepo.On("ListActiveByDay", mock.Anything, day, uuid.Nil).Return([]*entity.ClassSchedule{}, nil)
+	}
+	classScheduleRepo.On("ListActiveByDay", mock.Anything, "mon", uuid.Nil).Return([]*entity.ClassSchedule{mondayA, mondayB}, nil).Once()
+	classScheduleRepo.On("ListActiveByDay", mock.Anything, "tue", uuid.Nil).Return([]*entity.ClassSchedule{tuesdayA}, nil).Once()
+
+	sessionRepo.On("ListScheduleIDsByDate", mock.Anything, generatorDate("2025-01-06")).Return([]uuid.UUID{mondayA.ID}, nil)
+	sessionRepo.On("ListScheduleIDsByDate", mock.Anything, generatorDate("2025-01-13")).Return([]uuid.UUID{}, nil)
+	sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.AttendanceSession")).Return(nil).Times(2)
+
+	result, err := uc.GenerateSessions(context.Background(), dto.GenerateAttendanceSessionsRequest{
+		StartDate: "2025-01-06",
+		EndDate:   "2025-01-13",
+	})
+
+	require.NoError(t, err)
+	// 01-06: mondayA exists, mondayB created. 01-07: global holiday. 01-13: mondayA created, mondayB on dorm holiday.
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 1, result.SkippedExisting)
+	assert.Equal(t, 2, result.SkippedHolidays)
+	assert.Equal(t, []string{"2025-01-07", "2025-01-13"}, result.HolidayDates)
+	classScheduleRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+	sessionRepo.AssertNotCalled(t, "ListScheduleIDsByDate", mock.Anything, generatorDate("2025-01-07"))
+}
+
+func TestAttendanceGeneratorUseCase_GenerateSessions_InvalidRange(t *testing.T) {
+	uc := usecase.NewAttendanceGeneratorUseCase(
+		new(mocks.AttendanceSessionRepositoryMock),
+		new(mocks.ClassScheduleRepositoryMock),
+		new(mocks.HolidayRepositoryMock),
+		auditLoggerStub{},
+	)
+
+	tests := []struct {
+		name string
+		req  dto.GenerateAttendanceSessionsRequest
+	}{
+		{"bad start", dto.GenerateAttendanceSessionsRequest{StartDate: "06-01-2025", EndDate: "2025-01-07"}},
+		{"end before start", dto.GenerateAttendanceSessionsRequest{StartDate: "2025-01-07", EndDate: "2025-01-06"}},
+		{"range too long", dto.GenerateAttendanceSessionsRequest{StartDate: "2025-01-01", EndDate: "2025-02-01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.GenerateSessions(context.Background(), tt.req)
+			assert.ErrorIs(t, err, domainErrors.ErrBadRequest)
+		})
+	}
+}
@@ -123,6 +123,9 @@ func (uc *AttendanceUseCase) OpenSessions(ctx context.Context, req dto.OpenAtten
 			}
 			return domainErrors.ErrInternalServer
 		}
+		if err := ensureDormitoryInScope(ctx, schedule.DormitoryID); err != nil {
+			return err
+		}
 
 		existing, err := uc.sessionRepo.GetOpenByScheduleAndDate(ctx, classScheduleID, date)
 		if err == nil && existing != nil {
@@ -171,6 +174,9 @@ func (uc *AttendanceUseCase) SubmitStudentAttendance(ctx context.Context, sessio
 	if session.Status == entity.AttendanceSessionStatusLocked {
 		return domainErrors.ErrAttendanceAlreadyLocked
 	}
+	if err := uc.ensureSessionInScope(ctx, session); err != nil {
+		return err
+	}
 	if len(req.Records) == 0 {
 		return domainErrors.ErrBadRequest
 	}
@@ -208,6 +214,16 @@ func (uc *AttendanceUseCase) SubmitStudentAttendance(ctx context.Context, sessio
 		})
 	}
 
+	// Load the current records so overwritten statuses are audited
+	existing, err := uc.studentAttendanceRepo.ListBySession(ctx, sessionID)
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	previous := make(map[uuid.UUID]*entity.StudentAttendance, len(existing))
+	for _, record := range existing {
+		previous[record.StudentID] = record
+	}
+
 	if err := uc.studentAttendanceRepo.BulkUpsert(ctx, attendances); err != nil {
 		return domainErrors.ErrInternalServer
 	}
@@ -215,6 +231,18 @@ func (uc *AttendanceUseCase) SubmitStudentAttendance(ctx context.Context, sessio
 	_ = uc.auditLogger.Log(ctx, "attendance", "attendance:students:update", sessionID.String(), map[string]string{
 		"count": fmt.Sprintf("%d", len(attendances)),
 	})
+	for _, record := range attendances {
+		before, ok := previous[record.StudentID]
+		if !ok || (before.Status == record.Status && before.Note == record.Note) {
+			continue
+		}
+		// The upsert keeps the stored row, so compare against its ID
+		after := *record
+		after.ID = before.ID
+		_ = uc.auditLogger.LogChange(ctx, "attendance", "attendance:student:update", sessionID.String(), before, &after, map[string]string{
+			"student_id": record.StudentID.String(),
+		})
+	}
 	return nil
 }
 
@@ -231,6 +259,9 @@ func (uc *AttendanceUseCase) SubmitTeacherAttendance(ctx context.Context, sessio
 	if session.Status == entity.AttendanceSessionStatusLocked {
 		return domainErrors.ErrAttendanceAlreadyLocked
 	}
+	if err := uc.ensureSessionInScope(ctx, session); err != nil {
+		return err
+	}
 
 	teacherID, err := uuid.Parse(req.TeacherID)
 	if err != nil {
@@ -251,24 +282,39 @@ func (uc *AttendanceUseCase) SubmitTeacherAttendance(ctx context.Context, sessio
 		UpdatedAt:           time.Now(),
 	}
 
+	// Load the current record so an overwritten status is audited
+	before, err := uc.teacherAttendanceRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return domainErrors.ErrInternalServer
+		}
+		before = nil
+	}
+
 	if err := uc.teacherAttendanceRepo.Upsert(ctx, record); err != nil {
 		return domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "attendance", "attendance:teacher:update", sessionID.String(), map[string]string{
+	// The upsert keeps the stored row, so compare against its ID
+	after := *record
+	if before != nil {
+		after.ID = before.ID
+	}
+	_ = uc.auditLogger.LogChange(ctx, "attendance", "attendance:teacher:update", sessionID.String(), before, &after, map[string]string{
 		"teacher_id": req.TeacherID,
 	})
 	return nil
 }
 
 // LockSessions locks all sessions for a particular date.
+// Dormitory-bound actors only lock sessions of their own dormitories.
 func (uc *AttendanceUseCase) LockSessions(ctx context.Context, req dto.LockAttendanceRequest) error {
 	date, err := time.Parse("2006-01-02", req.Date)
 	if err != nil {
 		return domainErrors.ErrBadRequest
 	}
 
-	if err := uc.sessionRepo.LockSessionsByDate(ctx, date); err != nil {
+	if err := uc.sessionRepo.LockSessionsByDate(ctx, date, scopedDormitoryIDs(ctx)); err != nil {
 		return domainErrors.ErrInternalServer
 	}
 
@@ -278,7 +324,7 @@ func (uc *AttendanceUseCase) LockSessions(ctx context.Context, req dto.LockAtten
 
 // ListAttendanceSessions lists sessions by filters.
 func (uc *AttendanceUseCase) ListAttendanceSessions(ctx context.Context, req dto.ListAttendanceSessionsRequest) (*dto.ListAttendanceSessionsResponse, error) {
-	filter := repository.AttendanceSessionFilter{}
+	filter := repository.AttendanceSessionFilter{DormitoryIDs: scopedDormitoryIDs(ctx)}
 	if req.ClassScheduleID != nil && *req.ClassScheduleID != "" {
 		parsed, err := uuid.Parse(*req.ClassScheduleID)
 		if err != nil {
@@ -357,6 +403,22 @@ func (uc *AttendanceUseCase) ListAttendanceSessions(ctx context.Context, req dto
 	}, nil
 }
 
+// ensureSessionInScope rejects sessions whose class schedule belongs to a dormitory outside the actor's scope.
+func (uc *AttendanceUseCase) ensureSessionInScope(ctx context.Context, session *entity.AttendanceSession) error {
+	if appService.DormitoryScopeFromContext(ctx).All {
+		return nil
+	}
+
+	schedule, err := uc.classScheduleRepo.GetByID(ctx, session.ClassScheduleID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return domainErrors.ErrClassScheduleNotFound
+		}
+		return domainErrors.ErrInternalServer
+	}
+	return ensureDormitoryInScope(ctx, schedule.DormitoryID)
+}
+
 func mapStudentStatus(status string) (entity.StudentAttendanceStatus, error) {
 	switch status {
 	case string(entity.StudentAttendancePresent):
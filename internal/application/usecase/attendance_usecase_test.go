@@ -8,9 +8,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/application/usecase"
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
@@ -23,6 +25,23 @@ func (auditLoggerStub) Log(ctx context.Context, resource, action, targetID strin
 	return nil
 }
 
+func (auditLoggerStub) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	return nil
+}
+
+// auditChangeRecorder captures the field changes written with LogChange.
+type auditChangeRecorder struct {
+	auditLoggerStub
+	actions []string
+	changes []map[string]appService.FieldChange
+}
+
+func (r *auditChangeRecorder) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	r.actions = append(r.actions, action)
+	r.changes = append(r.changes, appService.DiffFields(before, after))
+	return nil
+}
+
 type fakeLeavePermitProvider struct {
 	permit *entity.LeavePermit
 	err    error
@@ -78,22 +97,93 @@ func TestAttendanceUseCase_SubmitStudentAttendance(t *testing.T) {
 	studentRepo := new(mocks.StudentAttendanceRepositoryMock)
 	teacherRepo := new(mocks.TeacherAttendanceRepositoryMock)
 	classScheduleRepo := new(mocks.ClassScheduleRepositoryMock)
-	uc := usecase.NewAttendanceUseCase(sessionRepo, studentRepo, teacherRepo, classScheduleRepo, nil, nil, auditLoggerStub{})
+	recorder := &auditChangeRecorder{}
+	uc := usecase.NewAttendanceUseCase(sessionRepo, studentRepo, teacherRepo, classScheduleRepo, nil, nil, recorder)
 
 	sessionID := uuidFromString("cccccccc-cccc-cccc-cccc-cccccccccccc")
 	studentID := uuidFromString("dddddddd-dddd-dddd-dddd-dddddddddddd")
+	unchangedID := uuidFromString("eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee")
 
 	sessionRepo.On("GetByID", mock.Anything, sessionID).
 		Return(&entity.AttendanceSession{ID: sessionID, Status: entity.AttendanceSessionStatusOpen}, nil)
+	studentRepo.On("ListBySession", mock.Anything, sessionID).
+		Return([]*entity.StudentAttendance{
+			{ID: uuid.New(), AttendanceSessionID: sessionID, StudentID: studentID, Status: entity.StudentAttendanceAbsent},
+			{ID: uuid.New(), AttendanceSessionID: sessionID, StudentID: unchangedID, Status: entity.StudentAttendancePresent},
+		}, nil)
 	studentRepo.On("BulkUpsert", mock.Anything, mock.Anything).
 		Return(nil)
 
 	err := uc.SubmitStudentAttendance(context.Background(), sessionID, dto.SubmitStudentAttendanceRequest{
-		Records: []dto.StudentAttendanceRecord{{StudentID: studentID.String(), Status: "present"}},
+		Records: []dto.StudentAttendanceRecord{
+			{StudentID: studentID.String(), Status: "present", Note: "late bus"},
+			{StudentID: unchangedID.String(), Status: "present"},
+		},
 	})
 
 	assert.NoError(t, err)
 	studentRepo.AssertExpectations(t)
+	// Only the overwritten record is audited with its old and new values
+	assert.Equal(t, []string{"attendance:student:update"}, recorder.actions)
+	assert.Equal(t, []map[string]appService.FieldChange{{
+		"status": {Old: entity.StudentAttendanceAbsent, New: entity.StudentAttendancePresent},
+		"note":   {Old: "", New: "late bus"},
+	}}, recorder.changes)
+}
+
+func TestAttendanceUseCase_SubmitTeacherAttendance(t *testing.T) {
+	sessionID := uuidFromString("cccccccc-cccc-cccc-cccc-cccccccccccc")
+	teacherID := uuid.New()
+	existing := &entity.TeacherAttendance{ID: uuid.New(), AttendanceSessionID: sessionID, TeacherID: teacherID, Status: entity.TeacherAttendancePresent}
+
+	tests := []struct {
+		name     string
+		existing *entity.TeacherAttendance
+		err      error
+		expected map[string]appService.FieldChange
+	}{
+		{
+			name:     "records the overwritten status",
+			existing: existing,
+			expected: map[string]appService.FieldChange{
+				"status": {Old: entity.TeacherAttendancePresent, New: entity.TeacherAttendanceAbsent},
+			},
+		},
+		{
+			name: "records a first submission as a creation",
+			err:  gorm.ErrRecordNotFound,
+			expected: map[string]appService.FieldChange{
+				"attendance_session_id": {Old: nil, New: sessionID.String()},
+				"teacher_id":            {Old: nil, New: teacherID.String()},
+				"status":                {Old: nil, New: entity.TeacherAttendanceAbsent},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionRepo := new(mocks.AttendanceSessionRepositoryMock)
+			teacherRepo := new(mocks.TeacherAttendanceRepositoryMock)
+			recorder := &auditChangeRecorder{}
+			uc := usecase.NewAttendanceUseCase(sessionRepo, new(mocks.StudentAttendanceRepositoryMock), teacherRepo, new(mocks.ClassScheduleRepositoryMock), nil, nil, recorder)
+
+			sessionRepo.On("GetByID", mock.Anything, sessionID).
+				Return(&entity.AttendanceSession{ID: sessionID, Status: entity.AttendanceSessionStatusOpen}, nil)
+			teacherRepo.On("GetBySession", mock.Anything, sessionID).Return(tt.existing, tt.err)
+			teacherRepo.On("Upsert", mock.Anything, mock.Anything).Return(nil)
+
+			err := uc.SubmitTeacherAttendance(context.Background(), sessionID, dto.SubmitTeacherAttendanceRequest{
+				TeacherID: teacherID.String(),
+				Status:    "absent",
+			})
+
+			assert.NoError(t, err)
+			teacherRepo.AssertExpectations(t)
+			require.Len(t, recorder.changes, 1)
+			delete(recorder.changes[0], "id")
+			assert.Equal(t, tt.expected, recorder.changes[0])
+		})
+	}
 }
 
 func TestAttendanceUseCase_SubmitStudentAttendance_Locked(t *testing.T) {
@@ -115,7 +205,7 @@ func TestAttendanceUseCase_LockSessions(t *testing.T) {
 	sessionRepo := new(mocks.AttendanceSessionRepositoryMock)
 	uc := usecase.NewAttendanceUseCase(sessionRepo, new(mocks.StudentAttendanceRepositoryMock), new(mocks.TeacherAttendanceRepositoryMock), new(mocks.ClassScheduleRepositoryMock), nil, nil, auditLoggerStub{})
 
-	sessionRepo.On("LockSessionsByDate", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil)
+	sessionRepo.On("LockSessionsByDate", mock.Anything, mock.AnythingOfType("time.Time"), []uuid.UUID(nil)).Return(nil)
 
 	err := uc.LockSessions(context.Background(), dto.LockAttendanceRequest{Date: "2025-11-20"})
 
@@ -148,6 +238,7 @@ func TestAttendanceUseCase_SubmitStudentAttendance_HealthOverride(t *testing.T)
 	studentID := uuidFromString("22222222-2222-2222-2222-222222222222")
 	sessionRepo.On("GetByID", mock.Anything, sessionID).
 		Return(&entity.AttendanceSession{ID: sessionID, Status: entity.AttendanceSessionStatusOpen, Date: time.Now()}, nil)
+	studentRepo.On("ListBySession", mock.Anything, sessionID).Return(nil, nil)
 	studentRepo.
 		On("BulkUpsert", mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
@@ -181,6 +272,7 @@ func TestAttendanceUseCase_SubmitStudentAttendance_LeaveOverride(t *testing.T) {
 	studentID := uuidFromString("44444444-4444-4444-4444-444444444444")
 	sessionRepo.On("GetByID", mock.Anything, sessionID).
 		Return(&entity.AttendanceSession{ID: sessionID, Status: entity.AttendanceSessionStatusOpen, Date: time.Now()}, nil)
+	studentRepo.On("ListBySession", mock.Anything, sessionID).Return(nil, nil)
 	studentRepo.
 		On("BulkUpsert", mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
@@ -214,6 +306,7 @@ func TestAttendanceUseCase_SubmitStudentAttendance_HealthPriorityOverLeave(t *te
 	studentID := uuidFromString("66666666-6666-6666-6666-666666666666")
 	sessionRepo.On("GetByID", mock.Anything, sessionID).
 		Return(&entity.AttendanceSession{ID: sessionID, Status: entity.AttendanceSessionStatusOpen, Date: time.Now()}, nil)
+	studentRepo.On("ListBySession", mock.Anything, sessionID).Return(nil, nil)
 	studentRepo.
 		On("BulkUpsert", mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// AuditCheckpointFormat names the layout of signed checkpoint payloads.
+const AuditCheckpointFormat = "audit-checkpoint:v1"
+
+// auditChainBatchSize is how many entries are loaded at a time while walking the chain.
+const auditChainBatchSize = 500
+
+// AuditIntegrityUseCase verifies the audit hash chain and issues signed checkpoints
+type AuditIntegrityUseCase struct {
+	auditRepo      repository.AuditLogRepository
+	checkpointRepo repository.AuditCheckpointRepository
+	archiveRepo    repository.AuditArchiveRepository
+	signer         service.AuditSigner
+}
+
+// NewAuditIntegrityUseCase creates a new audit integrity use case
+func NewAuditIntegrityUseCase(auditRepo repository.AuditLogRepository, checkpointRepo repository.AuditCheckpointRepository, archiveRepo repository.AuditArchiveRepository, signer service.AuditSigner) *AuditIntegrityUseCase {
+	return &AuditIntegrityUseCase{auditRepo: auditRepo, checkpointRepo: checkpointRepo, archiveRepo: archiveRepo, signer: signer}
+}
+
+// VerifyChain walks the chain left in the table and reports the first entry
+// whose hash, link or checkpoint does not match. The walk starts after the
+// latest archive, whose last hash the first remaining entry must link to.
+// Checkpoints beyond the chain head reveal entries deleted from its end.
+func (uc *AuditIntegrityUseCase) VerifyChain(ctx context.Context) (*dto.AuditChainVerificationResponse, error) {
+	archive, err := uc.archiveRepo.GetLatest(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	afterSequence, prevHash := archivedChainEnd(archive)
+
+	checkpoints, err := uc.checkpointRepo.List(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	bySequence := make(map[int64][]*entity.AuditCheckpoint, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		bySequence[checkpoint.Sequence] = append(bySequence[checkpoint.Sequence], checkpoint)
+	}
+
+	walk, err := uc.walkChain(ctx, afterSequence, prevHash, bySequence)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	result := &dto.AuditChainVerificationResponse{
+		Entries:         walk.checked,
+		ArchivedThrough: afterSequence,
+		HeadSequence:    afterSequence,
+		HeadHash:        prevHash,
+		Checkpoints:     walk.checkpoints,
+		FirstBroken:     walk.broken,
+		VerifiedAt:      time.Now().Format(time.RFC3339),
+	}
+	if walk.head != nil {
+		result.HeadSequence = walk.head.Sequence
+		result.HeadHash = walk.head.Hash
+	}
+	if result.FirstBroken == nil {
+		for _, checkpoint := range checkpoints {
+			if checkpoint.Sequence > result.HeadSequence {
+				result.FirstBroken = &dto.AuditChainBreak{
+					Sequence: result.HeadSequence + 1,
+					Reason:   fmt.Sprintf("entries up to checkpoint %s at sequence %d are missing", checkpoint.ID, checkpoint.Sequence),
+				}
+				break
+			}
+		}
+	}
+	result.Valid = result.FirstBroken == nil
+	return result, nil
+}
+
+// CreateCheckpoint signs the current chain head. The entries written since the
+// previous checkpoint are verified first, so a tampered chain is never signed.
+// When nothing was logged since the previous checkpoint, that one is returned.
+func (uc *AuditIntegrityUseCase) CreateCheckpoint(ctx context.Context) (*dto.AuditCheckpointResponse, error) {
+	head, err := uc.auditRepo.GetLatest(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if head == nil {
+		return nil, domainErrors.ErrAuditLogEmpty
+	}
+
+	archive, err := uc.archiveRepo.GetLatest(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	afterSequence, prevHash := archivedChainEnd(archive)
+
+	previous, err := uc.checkpointRepo.GetLatest(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if previous != nil {
+		if err := uc.signer.Verify(previous.SigningPayload(), previous.Signature, previous.PublicKey); err != nil {
+			return nil, domainErrors.ErrAuditChainBroken
+		}
+		if previous.Sequence > head.Sequence {
+			return nil, domainErrors.ErrAuditChainBroken
+		}
+		if previous.Sequence == head.Sequence {
+			if previous.Hash != head.Hash {
+				return nil, domainErrors.ErrAuditChainBroken
+			}
+			resp := toAuditCheckpointResponse(previous)
+			return &resp, nil
+		}
+		// Entries up to an archived checkpoint are no longer in the table
+		if previous.Sequence > afterSequence {
+			afterSequence, prevHash = previous.Sequence, previous.Hash
+		}
+	}
+
+	walk, err := uc.walkChain(ctx, afterSequence, prevHash, nil)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if walk.broken != nil || walk.head == nil {
+		return nil, domainErrors.ErrAuditChainBroken
+	}
+
+	checkpoint := &entity.AuditCheckpoint{
+		ID:        uuid.New(),
+		Sequence:  walk.head.Sequence,
+		Hash:      walk.head.Hash,
+		KeyID:     uc.signer.KeyID(),
+		Algorithm: uc.signer.Algorithm(),
+		PublicKey: uc.signer.PublicKeyPEM(),
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	checkpoint.Signature, err = uc.signer.Sign(checkpoint.SigningPayload())
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if err := uc.checkpointRepo.Create(ctx, checkpoint); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	resp := toAuditCheckpointResponse(checkpoint)
+	return &resp, nil
+}
+
+// ListCheckpoints returns every checkpoint, oldest first
+func (uc *AuditIntegrityUseCase) ListCheckpoints(ctx context.Context) (*dto.ListAuditCheckpointsResponse, error) {
+	checkpoints, err := uc.checkpointRepo.List(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	items := make([]dto.AuditCheckpointResponse, 0, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		items = append(items, toAuditCheckpointResponse(checkpoint))
+	}
+	return &dto.ListAuditCheckpointsResponse{Checkpoints: items}, nil
+}
+
+// ExportCheckpoints bundles every checkpoint with its public key for auditors
+func (uc *AuditIntegrityUseCase) ExportCheckpoints(ctx context.Context) (*dto.AuditCheckpointExport, error) {
+	list, err := uc.ListCheckpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AuditCheckpointExport{
+		Format:      AuditCheckpointFormat,
+		ExportedAt:  time.Now().Format(time.RFC3339),
+		Checkpoints: list.Checkpoints,
+	}, nil
+}
+
+// archivedChainEnd returns the last sequence and hash moved out of the table,
+// or 0 and "" when nothing was archived.
+func archivedChainEnd(archive *entity.AuditArchive) (int64, string) {
+	if archive == nil {
+		return 0, ""
+	}
+	return archive.ToSequence, archive.LastHash
+}
+
+// chainWalk is the outcome of walking part of the chain.
+type chainWalk struct {
+	checked     int64
+	checkpoints int
+	head        *entity.AuditLog
+	broken      *dto.AuditChainBreak
+}
+
+// walkChain checks the entries after afterSequence, expecting the first one to
+// link to prevHash, and stops at the first break. Entries with a checkpoint
+// must match its hash and the checkpoint signature must be valid.
+func (uc *AuditIntegrityUseCase) walkChain(ctx context.Context, afterSequence int64, prevHash string, checkpoints map[int64][]*entity.AuditCheckpoint) (*chainWalk, error) {
+	walk := &chainWalk{}
+	expected := afterSequence + 1
+	for {
+		entries, err := uc.auditRepo.ListChain(ctx, expected-1, auditChainBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if reason := uc.checkEntry(entry, expected, prevHash, checkpoints[entry.Sequence], walk); reason != "" {
+				walk.broken = &dto.AuditChainBreak{Sequence: expected, ID: entry.ID.String(), Reason: reason}
+				return walk, nil
+			}
+			walk.checked++
+			walk.head = entry
+			prevHash = entry.Hash
+			expected++
+		}
+		if len(entries) < auditChainBatchSize {
+			return walk, nil
+		}
+	}
+}
+
+// checkEntry returns why entry breaks the chain, or "" when it holds.
+func (uc *AuditIntegrityUseCase) checkEntry(entry *entity.AuditLog, expected int64, prevHash string, checkpoints []*entity.AuditCheckpoint, walk *chainWalk) string {
+	if entry.Sequence != expected {
+		return fmt.Sprintf("entries %d to %d are missing", expected, entry.Sequence-1)
+	}
+	if entry.PrevHash != prevHash {
+		return "previous hash does not match the entry before it"
+	}
+	if entry.ComputeHash() != entry.Hash {
+		return "content does not match its hash"
+	}
+	for _, checkpoint := range checkpoints {
+		if err := uc.signer.Verify(checkpoint.SigningPayload(), checkpoint.Signature, checkpoint.PublicKey); err != nil {
+			return fmt.Sprintf("checkpoint %s has an invalid signature", checkpoint.ID)
+		}
+		if checkpoint.Hash != entry.Hash {
+			return fmt.Sprintf("hash differs from signed checkpoint %s", checkpoint.ID)
+		}
+		walk.checkpoints++
+	}
+	return ""
+}
+
+func toAuditCheckpointResponse(checkpoint *entity.AuditCheckpoint) dto.AuditCheckpointResponse {
+	return dto.AuditCheckpointResponse{
+		ID:        checkpoint.ID.String(),
+		Sequence:  checkpoint.Sequence,
+		Hash:      checkpoint.Hash,
+		KeyID:     checkpoint.KeyID,
+		Algorithm: checkpoint.Algorithm,
+		PublicKey: checkpoint.PublicKey,
+		Signature: checkpoint.Signature,
+		// Same representation as in the signed payload
+		CreatedAt: checkpoint.CreatedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+	}
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+// inMemoryCheckpointRepo keeps audit checkpoints in insertion order
+type inMemoryCheckpointRepo struct {
+	checkpoints []*entity.AuditCheckpoint
+}
+
+func (r *inMemoryCheckpointRepo) Create(ctx context.Context, checkpoint *entity.AuditCheckpoint) error {
+	r.checkpoints = append(r.checkpoints, checkpoint)
+	return nil
+}
+
+func (r *inMemoryCheckpointRepo) List(ctx context.Context) ([]*entity.AuditCheckpoint, error) {
+	return r.checkpoints, nil
+}
+
+func (r *inMemoryCheckpointRepo) GetLatest(ctx context.Context) (*entity.AuditCheckpoint, error) {
+	if len(r.checkpoints) == 0 {
+		return nil, nil
+	}
+	return r.checkpoints[len(r.checkpoints)-1], nil
+}
+
+// inMemoryArchiveRepo keeps audit archives in insertion order
+type inMemoryArchiveRepo struct {
+	archives []*entity.AuditArchive
+}
+
+func (r *inMemoryArchiveRepo) Create(ctx context.Context, archive *entity.AuditArchive) error {
+	r.archives = append(r.archives, archive)
+	return nil
+}
+
+func (r *inMemoryArchiveRepo) List(ctx context.Context) ([]*entity.AuditArchive, error) {
+	return r.archives, nil
+}
+
+func (r *inMemoryArchiveRepo) GetLatest(ctx context.Context) (*entity.AuditArchive, error) {
+	if len(r.archives) == 0 {
+		return nil, nil
+	}
+	return r.archives[len(r.archives)-1], nil
+}
+
+// stubAuditSigner "signs" by prefixing the payload, which is enough to detect altered checkpoints
+type stubAuditSigner struct{}
+
+func (stubAuditSigner) KeyID() string        { return "test-key" }
+func (stubAuditSigner) Algorithm() string    { return "stub" }
+func (stubAuditSigner) PublicKeyPEM() string { return "test-public-key" }
+
+func (stubAuditSigner) Sign(payload []byte) (string, error) {
+	return "signed:" + string(payload), nil
+}
+
+func (stubAuditSigner) Verify(payload []byte, signature, publicKeyPEM string) error {
+	if signature != "signed:"+string(payload) || publicKeyPEM != "test-public-key" {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func newTestAuditIntegrityUseCase(t *testing.T, entries int) (*AuditIntegrityUseCase, *inMemoryAuditLogRepo, *inMemoryCheckpointRepo) {
+	uc, repo, checkpoints, _ := newTestAuditIntegrityUseCaseWithArchives(t, entries)
+	return uc, repo, checkpoints
+}
+
+func newTestAuditIntegrityUseCaseWithArchives(t *testing.T, entries int) (*AuditIntegrityUseCase, *inMemoryAuditLogRepo, *inMemoryCheckpointRepo, *inMemoryArchiveRepo) {
+	repo := &inMemoryAuditLogRepo{}
+	for i := 0; i < entries; i++ {
+		require.NoError(t, repo.Create(context.Background(), &entity.AuditLog{
+			ID:        uuid.New(),
+			Action:    "student:update",
+			Resource:  "student",
+			Metadata:  `{"score":"80"}`,
+			CreatedAt: time.Now(),
+		}))
+	}
+	checkpoints := &inMemoryCheckpointRepo{}
+	archives := &inMemoryArchiveRepo{}
+	return NewAuditIntegrityUseCase(repo, checkpoints, archives, stubAuditSigner{}), repo, checkpoints, archives
+}
+
+func TestAuditIntegrityUseCase_VerifyChain(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("intact chain", func(t *testing.T) {
+		uc, repo, _ := newTestAuditIntegrityUseCase(t, 3)
+		_, err := uc.CreateCheckpoint(ctx)
+		require.NoError(t, err)
+
+		result, err := uc.VerifyChain(ctx)
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.Equal(t, int64(3), result.Entries)
+		assert.Equal(t, repo.logs[2].Hash, result.HeadHash)
+		assert.Equal(t, 1, result.Checkpoints)
+	})
+
+	t.Run("edited entry", func(t *testing.T) {
+		uc, repo, _ := newTestAuditIntegrityUseCase(t, 3)
+		repo.logs[1].Metadata = `{"score":"95"}`
+
+		result, err := uc.VerifyChain(ctx)
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		require.NotNil(t, result.FirstBroken)
+		assert.Equal(t, int64(2), result.FirstBroken.Sequence)
+		assert.Equal(t, repo.logs[1].ID.String(), result.FirstBroken.ID)
+		assert.Equal(t, "content does not match its hash", result.FirstBroken.Reason)
+	})
+
+	t.Run("edited entry with recomputed hash", func(t *testing.T) {
+		uc, repo, _ := newTestAuditIntegrityUseCase(t, 3)
+		repo.logs[1].Metadata = `{"score":"95"}`
+		repo.logs[1].Hash = repo.logs[1].ComputeHash()
+
+		result, err := uc.VerifyChain(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, result.FirstBroken)
+		assert.Equal(t, int64(3), result.FirstBroken.Sequence)
+		assert.Contains(t, result.FirstBroken.Reason, "previous hash")
+	})
+
+	t.Run("deleted entry", func(t *testing.T) {
+		uc, repo, _ := newTestAuditIntegrityUseCase(t, 4)
+		repo.logs = append(repo.logs[:1], repo.logs[2:]...)
+
+		result, err := uc.VerifyChain(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, result.FirstBroken)
+		assert.Equal(t, int64(2), result.FirstBroken.Sequence)
+		assert.Equal(t, "entries 2 to 2 are missing", result.FirstBroken.Reason)
+	})
+
+	t.Run("archived prefix", func(t *testing.T) {
+		uc, repo, _, archives := newTestAuditIntegrityUseCaseWithArchives(t, 4)
+		archives.archives = []*entity.AuditArchive{{ID: uuid.New(), FromSequence: 1, ToSequence: 2, LastHash: repo.logs[1].Hash}}
+		repo.logs = repo.logs[2:]
+
+		result, err := uc.VerifyChain(ctx)
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.Equal(t, int64(2), result.Entries)
+		assert.Equal(t, int64(2), result.ArchivedThrough)
+		assert.Equal(t, int64(4), result.HeadSequence)
+
+		// Rows deleted beyond the archived range are still detected
+		repo.logs = repo.logs[1:]
+		result, err = uc.VerifyChain(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, result.FirstBroken)
+		assert.Equal(t, "entries 3 to 3 are missing", result.FirstBroken.Reason)
+	})
+
+	t.Run("truncated after a checkpoint", func(t *testing.T) {
+		uc, repo, _ := newTestAuditIntegrityUseCase(t, 3)
+		_, err := uc.CreateCheckpoint(ctx)
+		require.NoError(t, err)
+		repo.logs = repo.logs[:2]
+
+		result, err := uc.VerifyChain(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, result.FirstBroken)
+		assert.Equal(t, int64(3), result.FirstBroken.Sequence)
+		assert.Contains(t, result.FirstBroken.Reason, "missing")
+	})
+
+	t.Run("rewritten chain no longer matches the checkpoint", func(t *testing.T) {
+		uc, repo, _ := newTestAuditIntegrityUseCase(t, 2)
+		_, err := uc.CreateCheckpoint(ctx)
+		require.NoError(t, err)
+		for _, l := range repo.logs {
+			l.Metadata = `{"score":"100"}`
+			if l.Sequence > 1 {
+				l.PrevHash = repo.logs[l.Sequence-2].Hash
+			}
+			l.Hash = l.ComputeHash()
+		}
+
+		result, err := uc.VerifyChain(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, result.FirstBroken)
+		assert.Equal(t, int64(2), result.FirstBroken.Sequence)
+		assert.Contains(t, result.FirstBroken.Reason, "signed checkpoint")
+	})
+}
+
+func TestAuditIntegrityUseCase_CreateCheckpoint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("signs the head and reuses it while nothing changed", func(t *testing.T) {
+		uc, repo, checkpoints := newTestAuditIntegrityUseCase(t, 2)
+		first, err := uc.CreateCheckpoint(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), first.Sequence)
+		assert.Equal(t, repo.logs[1].Hash, first.Hash)
+		assert.Equal(t, "test-key", first.KeyID)
+
+		again, err := uc.CreateCheckpoint(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, again.ID)
+		assert.Len(t, checkpoints.checkpoints, 1)
+	})
+
+	t.Run("refuses to sign a tampered chain", func(t *testing.T) {
+		uc, repo, checkpoints := newTestAuditIntegrityUseCase(t, 2)
+		repo.logs[0].Action = "student:read"
+
+		_, err := uc.CreateCheckpoint(ctx)
+		assert.ErrorIs(t, err, domainErrors.ErrAuditChainBroken)
+		assert.Empty(t, checkpoints.checkpoints)
+	})
+
+	t.Run("empty log", func(t *testing.T) {
+		uc, _, _ := newTestAuditIntegrityUseCase(t, 0)
+		_, err := uc.CreateCheckpoint(ctx)
+		assert.ErrorIs(t, err, domainErrors.ErrAuditLogEmpty)
+	})
+}
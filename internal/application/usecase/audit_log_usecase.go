@@ -1,33 +1,69 @@
 package usecase
 
 import (
+	"bufio"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"io"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/export"
 )
 
 // AuditLogUseCase handles read-only audit log operations
 type AuditLogUseCase struct {
-	repo repository.AuditLogRepository
+	repo   repository.AuditLogRepository
+	writer service.AuditSink
+}
+
+// NewAuditLogUseCase creates a new audit log use case. writer is the sink
+// requests log through; its counters are reported by GetWriterStats.
+func NewAuditLogUseCase(repo repository.AuditLogRepository, writer service.AuditSink) *AuditLogUseCase {
+	return &AuditLogUseCase{repo: repo, writer: writer}
 }
 
-// NewAuditLogUseCase creates a new audit log use case
-func NewAuditLogUseCase(repo repository.AuditLogRepository) *AuditLogUseCase {
-	return &AuditLogUseCase{repo: repo}
+// Audit log export formats
+const (
+	AuditExportNDJSON = "ndjson"
+	AuditExportCSV    = "csv"
+)
+
+// auditExportBatchSize is how many entries are loaded at a time while exporting.
+const auditExportBatchSize = 1000
+
+var auditExportColumns = []string{
+	"sequence", "id", "created_at", "actor_id", "username", "actor_roles", "api_key_id",
+	"impersonator_id", "impersonator_username", "action", "resource", "target_id",
+	"request_method", "request_path", "request_id", "status_code", "ip_address", "user_agent",
+	"metadata", "changes", "hash",
 }
 
 // ListAuditLogs retrieves a paginated list of audit logs
-func (uc *AuditLogUseCase) ListAuditLogs(ctx context.Context, page, pageSize int, resource, action, actorUsername string) (*dto.ListAuditLogsResponse, error) {
-	filter := repository.AuditLogFilter{
-		Page:          page,
-		PageSize:      pageSize,
-		Resource:      resource,
-		Action:        action,
-		ActorUsername: actorUsername,
+func (uc *AuditLogUseCase) ListAuditLogs(ctx context.Context, req dto.ListAuditLogsRequest) (*dto.ListAuditLogsResponse, error) {
+	filter, err := toAuditLogFilter(req.AuditLogFilterRequest)
+	if err != nil {
+		return nil, err
+	}
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
 	}
+	filter.Page, filter.PageSize = page, pageSize
 
 	logs, total, err := uc.repo.List(ctx, filter)
 	if err != nil {
@@ -36,32 +72,7 @@ func (uc *AuditLogUseCase) ListAuditLogs(ctx context.Context, page, pageSize int
 
 	items := make([]dto.AuditLogResponse, 0, len(logs))
 	for _, l := range logs {
-		var actorIDStr string
-		if l.ActorID != nil {
-			actorIDStr = l.ActorID.String()
-		}
-
-		var roles []string
-		if l.ActorRoles != "" {
-			_ = json.Unmarshal([]byte(l.ActorRoles), &roles)
-		}
-
-		items = append(items, dto.AuditLogResponse{
-			ID:            l.ID.String(),
-			ActorID:       actorIDStr,
-			ActorUsername: l.ActorUsername,
-			ActorRoles:    roles,
-			Action:        l.Action,
-			Resource:      l.Resource,
-			TargetID:      l.TargetID,
-			RequestPath:   l.RequestPath,
-			RequestMethod: l.RequestMethod,
-			StatusCode:    l.StatusCode,
-			IPAddress:     l.IPAddress,
-			UserAgent:     l.UserAgent,
-			Metadata:      l.Metadata,
-			CreatedAt:     l.CreatedAt.Format(time.RFC3339),
-		})
+		items = append(items, toAuditLogResponse(l))
 	}
 
 	totalPages := int(total) / pageSize
@@ -77,3 +88,263 @@ func (uc *AuditLogUseCase) ListAuditLogs(ctx context.Context, page, pageSize int
 		TotalPages: totalPages,
 	}, nil
 }
+
+// ExportAuditLogs writes every entry matching the filters to w, oldest first,
+// as NDJSON (one AuditLogResponse per line) or CSV. Entries are loaded in
+// batches and flushed after each, so any range can be exported. Invalid
+// filters are reported before anything is written.
+func (uc *AuditLogUseCase) ExportAuditLogs(ctx context.Context, req dto.ExportAuditLogsRequest, w io.Writer) error {
+	filter, err := toAuditLogFilter(req.AuditLogFilterRequest)
+	if err != nil {
+		return err
+	}
+	format := req.Format
+	if format == "" {
+		format = AuditExportNDJSON
+	}
+	if format != AuditExportNDJSON && format != AuditExportCSV {
+		return domainErrors.ErrBadRequest
+	}
+
+	buf := bufio.NewWriter(w)
+	var writeBatch func([]*entity.AuditLog) error
+	if format == AuditExportCSV {
+		cw := csv.NewWriter(buf)
+		if err := cw.Write(auditExportColumns); err != nil {
+			return err
+		}
+		writeBatch = func(logs []*entity.AuditLog) error {
+			for _, l := range logs {
+				if err := cw.Write(auditExportRow(toAuditLogResponse(l))); err != nil {
+					return err
+				}
+			}
+			cw.Flush()
+			return cw.Error()
+		}
+	} else {
+		enc := json.NewEncoder(buf)
+		writeBatch = func(logs []*entity.AuditLog) error {
+			for _, l := range logs {
+				if err := enc.Encode(toAuditLogResponse(l)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	var exported int
+	err = uc.repo.Each(ctx, filter, auditExportBatchSize, func(logs []*entity.AuditLog) error {
+		if err := writeBatch(logs); err != nil {
+			return err
+		}
+		exported += len(logs)
+		return buf.Flush()
+	})
+	if err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+
+	if uc.writer != nil {
+		_ = uc.writer.Log(ctx, "audit_log", "audit_log:export", "", map[string]string{
+			"format":  format,
+			"entries": strconv.Itoa(exported),
+			"filters": describeAuditLogFilter(req.AuditLogFilterRequest),
+		})
+	}
+	return nil
+}
+
+// GetEntityHistory returns every audit entry recorded for one record, oldest
+// first, so its changes can be read as a timeline
+func (uc *AuditLogUseCase) GetEntityHistory(ctx context.Context, resource, targetID string) (*dto.EntityHistoryResponse, error) {
+	logs, err := uc.repo.ListByTarget(ctx, resource, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]dto.AuditLogResponse, 0, len(logs))
+	for _, l := range logs {
+		entries = append(entries, toAuditLogResponse(l))
+	}
+
+	return &dto.EntityHistoryResponse{
+		Resource: resource,
+		TargetID: targetID,
+		Entries:  entries,
+	}, nil
+}
+
+// GetWriterStats reports how many audit entries were written, failed,
+// spilled to disk or dropped since the process started
+func (uc *AuditLogUseCase) GetWriterStats(ctx context.Context) *dto.AuditWriterStatsResponse {
+	if uc.writer == nil {
+		return &dto.AuditWriterStatsResponse{}
+	}
+	stats := uc.writer.Stats()
+	return &dto.AuditWriterStatsResponse{
+		Mode:     stats.Mode,
+		Queued:   stats.Queued,
+		Written:  stats.Written,
+		Failed:   stats.Failed,
+		Spilled:  stats.Spilled,
+		Replayed: stats.Replayed,
+		Dropped:  stats.Dropped,
+	}
+}
+
+// toAuditLogFilter validates the filters and converts them for the repository.
+func toAuditLogFilter(req dto.AuditLogFilterRequest) (repository.AuditLogFilter, error) {
+	filter := repository.AuditLogFilter{
+		Resource:      req.Resource,
+		Action:        req.Action,
+		ActorUsername: req.ActorUsername,
+		TargetID:      req.TargetID,
+		IPAddress:     req.IPAddress,
+		RequestID:     req.RequestID,
+		StatusCode:    req.StatusCode,
+	}
+	if req.ActorID != "" {
+		actorID, err := uuid.Parse(req.ActorID)
+		if err != nil {
+			return filter, domainErrors.ErrBadRequest
+		}
+		filter.ActorID = &actorID
+	}
+	if req.From != "" {
+		from, err := parseAuditTime(req.From, false)
+		if err != nil {
+			return filter, domainErrors.ErrBadRequest
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := parseAuditTime(req.To, true)
+		if err != nil {
+			return filter, domainErrors.ErrBadRequest
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, domainErrors.ErrBadRequest
+	}
+	return filter, nil
+}
+
+// parseAuditTime accepts an RFC3339 timestamp or a YYYY-MM-DD date in the
+// server timezone. An end date is moved to the following midnight so the
+// exclusive bound still includes that day.
+func parseAuditTime(value string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date, nil
+}
+
+// describeAuditLogFilter renders the filters that were set, for the export's own audit entry.
+func describeAuditLogFilter(req dto.AuditLogFilterRequest) string {
+	filters := map[string]string{}
+	for key, value := range map[string]string{
+		"resource":       req.Resource,
+		"action":         req.Action,
+		"actor_username": req.ActorUsername,
+		"actor_id":       req.ActorID,
+		"target_id":      req.TargetID,
+		"ip_address":     req.IPAddress,
+		"request_id":     req.RequestID,
+		"from":           req.From,
+		"to":             req.To,
+	} {
+		if value != "" {
+			filters[key] = value
+		}
+	}
+	if req.StatusCode != 0 {
+		filters["status_code"] = strconv.Itoa(req.StatusCode)
+	}
+	b, _ := json.Marshal(filters)
+	return string(b)
+}
+
+func auditExportRow(r dto.AuditLogResponse) []string {
+	roles, _ := json.Marshal(r.ActorRoles)
+	if len(r.ActorRoles) == 0 {
+		roles = nil
+	}
+	var changes []byte
+	if len(r.Changes) > 0 {
+		changes, _ = json.Marshal(r.Changes)
+	}
+	row := []string{
+		strconv.FormatInt(r.Sequence, 10), r.ID, r.CreatedAt, r.ActorID, r.ActorUsername, string(roles), r.APIKeyID,
+		r.ImpersonatorID, r.ImpersonatorUsername, r.Action, r.Resource, r.TargetID,
+		r.RequestMethod, r.RequestPath, r.RequestID, strconv.Itoa(r.StatusCode), r.IPAddress, r.UserAgent,
+		r.Metadata, string(changes), r.Hash,
+	}
+	for i, cell := range row {
+		row[i] = export.CSVSafeCell(cell)
+	}
+	return row
+}
+
+func toAuditLogResponse(l *entity.AuditLog) dto.AuditLogResponse {
+	var actorIDStr string
+	if l.ActorID != nil {
+		actorIDStr = l.ActorID.String()
+	}
+
+	var apiKeyIDStr string
+	if l.APIKeyID != nil {
+		apiKeyIDStr = l.APIKeyID.String()
+	}
+
+	var impersonatorIDStr string
+	if l.ImpersonatorID != nil {
+		impersonatorIDStr = l.ImpersonatorID.String()
+	}
+
+	var roles []string
+	if l.ActorRoles != "" {
+		_ = json.Unmarshal([]byte(l.ActorRoles), &roles)
+	}
+
+	var changes map[string]dto.AuditFieldChange
+	if l.Changes != "" {
+		_ = json.Unmarshal([]byte(l.Changes), &changes)
+	}
+
+	return dto.AuditLogResponse{
+		ID:                   l.ID.String(),
+		Sequence:             l.Sequence,
+		Hash:                 l.Hash,
+		ActorID:              actorIDStr,
+		ActorUsername:        l.ActorUsername,
+		ActorRoles:           roles,
+		APIKeyID:             apiKeyIDStr,
+		ImpersonatorID:       impersonatorIDStr,
+		ImpersonatorUsername: l.ImpersonatorUsername,
+		Action:               l.Action,
+		Resource:             l.Resource,
+		TargetID:             l.TargetID,
+		RequestPath:          l.RequestPath,
+		RequestMethod:        l.RequestMethod,
+		RequestID:            l.RequestID,
+		StatusCode:           l.StatusCode,
+		IPAddress:            l.IPAddress,
+		UserAgent:            l.UserAgent,
+		Metadata:             l.Metadata,
+		Changes:              changes,
+		CreatedAt:            l.CreatedAt.Format(time.RFC3339),
+	}
+}
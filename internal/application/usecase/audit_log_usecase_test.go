@@ -1,13 +1,22 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 )
 
@@ -17,24 +26,29 @@ type inMemoryAuditLogRepo struct {
 }
 
 func (r *inMemoryAuditLogRepo) Create(ctx context.Context, log *entity.AuditLog) error {
+	log.Sequence, log.PrevHash = 1, ""
+	if n := len(r.logs); n > 0 {
+		log.Sequence, log.PrevHash = r.logs[n-1].Sequence+1, r.logs[n-1].Hash
+	}
+	log.Hash = log.ComputeHash()
 	r.logs = append(r.logs, log)
 	return nil
 }
 
+func (r *inMemoryAuditLogRepo) CreateBatch(ctx context.Context, logs []*entity.AuditLog) error {
+	for _, log := range logs {
+		_ = r.Create(ctx, log)
+	}
+	return nil
+}
+
 func (r *inMemoryAuditLogRepo) List(ctx context.Context, filter repository.AuditLogFilter) ([]*entity.AuditLog, int64, error) {
 	// very simple filter implementation for testing
 	filtered := make([]*entity.AuditLog, 0)
 	for _, l := range r.logs {
-		if filter.Resource != "" && l.Resource != filter.Resource {
-			continue
+		if matchesAuditLogFilter(l, filter) {
+			filtered = append(filtered, l)
 		}
-		if filter.Action != "" && l.Action != filter.Action {
-			continue
-		}
-		if filter.ActorUsername != "" && l.ActorUsername != filter.ActorUsername {
-			continue
-		}
-		filtered = append(filtered, l)
 	}
 
 	total := int64(len(filtered))
@@ -56,9 +70,98 @@ func (r *inMemoryAuditLogRepo) List(ctx context.Context, filter repository.Audit
 	return filtered[offset:end], total, nil
 }
 
+func (r *inMemoryAuditLogRepo) Each(ctx context.Context, filter repository.AuditLogFilter, batchSize int, fn func([]*entity.AuditLog) error) error {
+	batch := make([]*entity.AuditLog, 0, batchSize)
+	for _, l := range r.logs {
+		if !matchesAuditLogFilter(l, filter) {
+			continue
+		}
+		batch = append(batch, l)
+		if len(batch) == batchSize {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = make([]*entity.AuditLog, 0, batchSize)
+		}
+	}
+	if len(batch) > 0 {
+		return fn(batch)
+	}
+	return nil
+}
+
+func matchesAuditLogFilter(l *entity.AuditLog, filter repository.AuditLogFilter) bool {
+	switch {
+	case filter.Resource != "" && l.Resource != filter.Resource,
+		filter.Action != "" && l.Action != filter.Action,
+		filter.ActorUsername != "" && l.ActorUsername != filter.ActorUsername,
+		filter.ActorID != nil && (l.ActorID == nil || *l.ActorID != *filter.ActorID),
+		filter.TargetID != "" && l.TargetID != filter.TargetID,
+		filter.IPAddress != "" && l.IPAddress != filter.IPAddress,
+		filter.RequestID != "" && l.RequestID != filter.RequestID,
+		filter.StatusCode != 0 && l.StatusCode != filter.StatusCode,
+		filter.From != nil && l.CreatedAt.Before(*filter.From),
+		filter.To != nil && !l.CreatedAt.Before(*filter.To):
+		return false
+	}
+	return true
+}
+
+func (r *inMemoryAuditLogRepo) ListByTarget(ctx context.Context, resource, targetID string) ([]*entity.AuditLog, error) {
+	filtered := make([]*entity.AuditLog, 0)
+	for _, l := range r.logs {
+		if l.Resource == resource && l.TargetID == targetID {
+			filtered = append(filtered, l)
+		}
+	}
+	return filtered, nil
+}
+
+func (r *inMemoryAuditLogRepo) ListChain(ctx context.Context, afterSequence int64, limit int) ([]*entity.AuditLog, error) {
+	chain := make([]*entity.AuditLog, 0, limit)
+	for _, l := range r.logs {
+		if l.Sequence > afterSequence && len(chain) < limit {
+			chain = append(chain, l)
+		}
+	}
+	return chain, nil
+}
+
+func (r *inMemoryAuditLogRepo) GetLatest(ctx context.Context) (*entity.AuditLog, error) {
+	if len(r.logs) == 0 {
+		return nil, nil
+	}
+	return r.logs[len(r.logs)-1], nil
+}
+
+func (r *inMemoryAuditLogRepo) GetFirstSince(ctx context.Context, since time.Time) (*entity.AuditLog, error) {
+	for _, l := range r.logs {
+		if !l.CreatedAt.Before(since) {
+			return l, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *inMemoryAuditLogRepo) DeleteThrough(ctx context.Context, sequence int64) (int64, error) {
+	kept := make([]*entity.AuditLog, 0, len(r.logs))
+	for _, l := range r.logs {
+		if l.Sequence > sequence {
+			kept = append(kept, l)
+		}
+	}
+	deleted := int64(len(r.logs) - len(kept))
+	r.logs = kept
+	return deleted, nil
+}
+
+func auditListRequest(filter dto.AuditLogFilterRequest) dto.ListAuditLogsRequest {
+	return dto.ListAuditLogsRequest{AuditLogFilterRequest: filter, Page: 1, PageSize: 10}
+}
+
 func TestAuditLogUseCase_ListAuditLogs(t *testing.T) {
 	repo := &inMemoryAuditLogRepo{}
-	uc := NewAuditLogUseCase(repo)
+	uc := NewAuditLogUseCase(repo, nil)
 
 	now := time.Now()
 	// seed some logs
@@ -86,7 +189,7 @@ func TestAuditLogUseCase_ListAuditLogs(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	resp, err := uc.ListAuditLogs(ctx, 1, 10, "user", "user:create", "admin")
+	resp, err := uc.ListAuditLogs(ctx, auditListRequest(dto.AuditLogFilterRequest{Resource: "user", Action: "user:create", ActorUsername: "admin"}))
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, int64(1), resp.Total)
@@ -96,4 +199,159 @@ func TestAuditLogUseCase_ListAuditLogs(t *testing.T) {
 	assert.Equal(t, "user", logResp.Resource)
 	assert.Equal(t, "user:create", logResp.Action)
 	assert.Equal(t, "admin", logResp.ActorUsername)
+
+	repo.logs = append(repo.logs, &entity.AuditLog{
+		ID:        uuid.New(),
+		Action:    "auth:login_failed",
+		Resource:  "auth",
+		TargetID:  "ghost",
+		IPAddress: "10.0.0.1",
+		CreatedAt: now,
+	})
+	resp, err = uc.ListAuditLogs(ctx, auditListRequest(dto.AuditLogFilterRequest{Resource: "auth", TargetID: "ghost", IPAddress: "10.0.0.1"}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Total)
+	resp, err = uc.ListAuditLogs(ctx, auditListRequest(dto.AuditLogFilterRequest{Resource: "auth", IPAddress: "10.0.0.2"}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), resp.Total)
+}
+
+func TestAuditLogUseCase_ListAuditLogs_Filters(t *testing.T) {
+	repo := &inMemoryAuditLogRepo{}
+	uc := NewAuditLogUseCase(repo, nil)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	day := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	repo.logs = []*entity.AuditLog{
+		{ID: uuid.New(), Action: "attendance:submit", ActorID: &actorID, StatusCode: 200, CreatedAt: day.AddDate(0, 0, -1)},
+		{ID: uuid.New(), Action: "attendance:submit", ActorID: &actorID, StatusCode: 200, CreatedAt: day},
+		{ID: uuid.New(), Action: "attendance:submit", StatusCode: 403, RequestID: "req-403", CreatedAt: day},
+		{ID: uuid.New(), Action: "attendance:submit", ActorID: &actorID, StatusCode: 200, CreatedAt: day.AddDate(0, 0, 1)},
+	}
+
+	resp, err := uc.ListAuditLogs(ctx, auditListRequest(dto.AuditLogFilterRequest{ActorID: actorID.String(), From: "2026-03-10", To: "2026-03-10"}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Total)
+
+	resp, err = uc.ListAuditLogs(ctx, auditListRequest(dto.AuditLogFilterRequest{StatusCode: 403}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Total)
+
+	resp, err = uc.ListAuditLogs(ctx, auditListRequest(dto.AuditLogFilterRequest{RequestID: "req-403"}))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.Total)
+	assert.Equal(t, "req-403", resp.Logs[0].RequestID)
+
+	resp, err = uc.ListAuditLogs(ctx, auditListRequest(dto.AuditLogFilterRequest{From: day.Format(time.RFC3339)}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Total)
+
+	for _, filter := range []dto.AuditLogFilterRequest{
+		{From: "10/03/2026"},
+		{From: "2026-03-11", To: "2026-03-10"},
+		{ActorID: "not-a-uuid"},
+	} {
+		_, err = uc.ListAuditLogs(ctx, auditListRequest(filter))
+		assert.Equal(t, domainErrors.ErrBadRequest, err, filter)
+	}
+}
+
+func TestAuditLogUseCase_ExportAuditLogs(t *testing.T) {
+	repo := &inMemoryAuditLogRepo{}
+	recorder := &exportAuditRecorder{}
+	uc := NewAuditLogUseCase(repo, recorder)
+	ctx := context.Background()
+
+	for i := 0; i < auditExportBatchSize+5; i++ {
+		require.NoError(t, repo.Create(ctx, &entity.AuditLog{
+			ID:         uuid.New(),
+			Action:     "attendance:submit",
+			Resource:   "attendance",
+			ActorRoles: `["teacher"]`,
+			Metadata:   `{"note":"line, with comma"}`,
+			UserAgent:  `=HYPERLINK("http://evil.example","open")`,
+			CreatedAt:  time.Now(),
+		}))
+	}
+
+	t.Run("ndjson", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, uc.ExportAuditLogs(ctx, dto.ExportAuditLogsRequest{Format: AuditExportNDJSON}, &out))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, auditExportBatchSize+5)
+		var first dto.AuditLogResponse
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.Equal(t, int64(1), first.Sequence)
+		assert.Equal(t, []string{"teacher"}, first.ActorRoles)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var out bytes.Buffer
+		req := dto.ExportAuditLogsRequest{Format: AuditExportCSV, AuditLogFilterRequest: dto.AuditLogFilterRequest{Resource: "attendance"}}
+		require.NoError(t, uc.ExportAuditLogs(ctx, req, &out))
+
+		records, err := csv.NewReader(&out).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, auditExportBatchSize+6)
+		assert.Equal(t, auditExportColumns, records[0])
+		assert.Equal(t, "1", records[1][0])
+		metadata := slices.Index(auditExportColumns, "metadata")
+		assert.Equal(t, `{"note":"line, with comma"}`, records[1][metadata])
+		// Client-supplied cells cannot be evaluated as spreadsheet formulas
+		userAgent := slices.Index(auditExportColumns, "user_agent")
+		assert.Equal(t, `'=HYPERLINK("http://evil.example","open")`, records[1][userAgent])
+		assert.Equal(t, "'\r@SUM(A1)", auditExportRow(dto.AuditLogResponse{UserAgent: "\r@SUM(A1)"})[userAgent])
+	})
+
+	t.Run("invalid filters write nothing", func(t *testing.T) {
+		var out bytes.Buffer
+		err := uc.ExportAuditLogs(ctx, dto.ExportAuditLogsRequest{AuditLogFilterRequest: dto.AuditLogFilterRequest{To: "yesterday"}}, &out)
+		assert.Equal(t, domainErrors.ErrBadRequest, err)
+		assert.Zero(t, out.Len())
+	})
+
+	require.Len(t, recorder.entries, 2)
+	assert.Equal(t, "audit_log:export", recorder.entries[1].action)
+	assert.Equal(t, `{"resource":"attendance"}`, recorder.entries[1].metadata["filters"])
+}
+
+// exportAuditRecorder captures the audit entries the use case logs about itself
+type exportAuditRecorder struct {
+	service.AuditSink
+	entries []struct {
+		action   string
+		metadata map[string]string
+	}
+}
+
+func (r *exportAuditRecorder) Log(ctx context.Context, resource, action, targetID string, metadata map[string]string) error {
+	r.entries = append(r.entries, struct {
+		action   string
+		metadata map[string]string
+	}{action, metadata})
+	return nil
+}
+
+func TestAuditLogUseCase_GetEntityHistory(t *testing.T) {
+	repo := &inMemoryAuditLogRepo{}
+	uc := NewAuditLogUseCase(repo, nil)
+
+	studentID := uuid.New().String()
+	now := time.Now()
+	repo.logs = []*entity.AuditLog{
+		{ID: uuid.New(), Action: "student:create", Resource: "student", TargetID: studentID, CreatedAt: now},
+		{ID: uuid.New(), Action: "student:update", Resource: "student", TargetID: studentID, CreatedAt: now.Add(time.Minute),
+			Changes: `{"full_name":{"old":"Budi","new":"Budi Santoso"}}`},
+		{ID: uuid.New(), Action: "student:update", Resource: "student", TargetID: uuid.New().String(), CreatedAt: now},
+	}
+
+	resp, err := uc.GetEntityHistory(context.Background(), "student", studentID)
+	assert.NoError(t, err)
+	assert.Equal(t, studentID, resp.TargetID)
+	assert.Len(t, resp.Entries, 2)
+	assert.Empty(t, resp.Entries[0].Changes)
+	assert.Equal(t, "Budi", resp.Entries[1].Changes["full_name"].Old)
+	assert.Equal(t, "Budi Santoso", resp.Entries[1].Changes["full_name"].New)
 }
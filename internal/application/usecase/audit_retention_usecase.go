@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// AuditRetentionPolicy controls how long entries stay in the audit_logs table.
+type AuditRetentionPolicy struct {
+	// Months keeps entries younger than this many months in the table
+	// (AUDIT_RETENTION_MONTHS); 0 disables scheduled archiving.
+	Months int
+}
+
+// LoadAuditRetentionPolicy reads the retention policy from the environment.
+func LoadAuditRetentionPolicy() AuditRetentionPolicy {
+	policy := AuditRetentionPolicy{}
+	if v, err := strconv.Atoi(os.Getenv("AUDIT_RETENTION_MONTHS")); err == nil && v > 0 {
+		policy.Months = v
+	}
+	return policy
+}
+
+// AuditRetentionUseCase moves old audit entries into compressed archive files
+type AuditRetentionUseCase struct {
+	auditRepo   repository.AuditLogRepository
+	archiveRepo repository.AuditArchiveRepository
+	store       service.AuditArchiveStore
+	integrity   *AuditIntegrityUseCase
+	auditLogger appService.AuditLogger
+	policy      AuditRetentionPolicy
+	now         func() time.Time
+}
+
+// NewAuditRetentionUseCase creates a new audit retention use case
+func NewAuditRetentionUseCase(
+	auditRepo repository.AuditLogRepository,
+	archiveRepo repository.AuditArchiveRepository,
+	store service.AuditArchiveStore,
+	integrity *AuditIntegrityUseCase,
+	auditLogger appService.AuditLogger,
+	policy AuditRetentionPolicy,
+) *AuditRetentionUseCase {
+	return &AuditRetentionUseCase{
+		auditRepo:   auditRepo,
+		archiveRepo: archiveRepo,
+		store:       store,
+		integrity:   integrity,
+		auditLogger: auditLogger,
+		policy:      policy,
+		now:         time.Now,
+	}
+}
+
+// ArchiveEntries moves the entries older than req.OlderThanMonths (the
+// policy when zero) from the table into one archive file. Only a prefix of
+// the chain is archived: it ends before the first entry young enough to
+// keep, and never includes the chain head, which new entries link to. The
+// chain is verified first so a tampered chain is never archived. It returns
+// nil when nothing is old enough.
+func (uc *AuditRetentionUseCase) ArchiveEntries(ctx context.Context, req dto.ArchiveAuditLogsRequest) (*dto.AuditArchiveResponse, error) {
+	months := req.OlderThanMonths
+	if months <= 0 {
+		months = uc.policy.Months
+	}
+	if months <= 0 {
+		return nil, domainErrors.ErrAuditRetentionDisabled
+	}
+	cutoff := uc.now().AddDate(0, -months, 0)
+
+	latest, err := uc.archiveRepo.GetLatest(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	afterSequence, prevHash := archivedChainEnd(latest)
+	// Finish a previous run that recorded its archive but stopped before
+	// deleting the rows.
+	if _, err := uc.auditRepo.DeleteThrough(ctx, afterSequence); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	head, err := uc.auditRepo.GetLatest(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if head == nil {
+		return nil, nil
+	}
+	through := head.Sequence - 1
+	firstKept, err := uc.auditRepo.GetFirstSince(ctx, cutoff)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if firstKept != nil && firstKept.Sequence-1 < through {
+		through = firstKept.Sequence - 1
+	}
+	if through <= afterSequence {
+		return nil, nil
+	}
+
+	verification, err := uc.integrity.VerifyChain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !verification.Valid {
+		return nil, domainErrors.ErrAuditChainBroken
+	}
+
+	archive := &entity.AuditArchive{
+		ID:            uuid.New(),
+		FromSequence:  afterSequence + 1,
+		ToSequence:    through,
+		FirstPrevHash: prevHash,
+		OlderThan:     cutoff.UTC().Truncate(time.Microsecond),
+	}
+	if err := uc.writeArchive(ctx, archive); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	archive.CreatedAt = uc.now().UTC().Truncate(time.Microsecond)
+	if err := uc.archiveRepo.Create(ctx, archive); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if _, err := uc.auditRepo.DeleteThrough(ctx, archive.ToSequence); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	_ = uc.auditLogger.Log(ctx, "audit_log", "audit_log:archive", archive.ID.String(), map[string]string{
+		"from_sequence": strconv.FormatInt(archive.FromSequence, 10),
+		"to_sequence":   strconv.FormatInt(archive.ToSequence, 10),
+		"entries":       strconv.FormatInt(archive.Entries, 10),
+		"file":          archive.File,
+		"file_sha256":   archive.FileSHA256,
+	})
+
+	resp := toAuditArchiveResponse(archive)
+	return &resp, nil
+}
+
+// writeArchive copies the archive's range to a new NDJSON file, one stored
+// entry per line with its hashes, and fills in LastHash, Entries, File and
+// FileSHA256.
+func (uc *AuditRetentionUseCase) writeArchive(ctx context.Context, archive *entity.AuditArchive) error {
+	file, err := uc.store.Create(fmt.Sprintf("audit-%012d-%012d.ndjson", archive.FromSequence, archive.ToSequence))
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(file)
+	after := archive.FromSequence - 1
+	for after < archive.ToSequence {
+		entries, err := uc.auditRepo.ListChain(ctx, after, auditChainBatchSize)
+		if err != nil {
+			_ = file.Abort()
+			return err
+		}
+		if len(entries) == 0 {
+			break
+		}
+		for _, entry := range entries {
+			if entry.Sequence > archive.ToSequence {
+				break
+			}
+			if err := enc.Encode(entry); err != nil {
+				_ = file.Abort()
+				return err
+			}
+			archive.Entries++
+			archive.LastHash = entry.Hash
+			after = entry.Sequence
+		}
+		if len(entries) < auditChainBatchSize {
+			break
+		}
+	}
+	if after != archive.ToSequence {
+		_ = file.Abort()
+		return fmt.Errorf("audit entries %d to %d changed while archiving", after+1, archive.ToSequence)
+	}
+
+	archive.File, archive.FileSHA256, err = file.Commit()
+	return err
+}
+
+// ListArchives returns every archive, oldest range first
+func (uc *AuditRetentionUseCase) ListArchives(ctx context.Context) (*dto.ListAuditArchivesResponse, error) {
+	archives, err := uc.archiveRepo.List(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	items := make([]dto.AuditArchiveResponse, 0, len(archives))
+	for _, archive := range archives {
+		items = append(items, toAuditArchiveResponse(archive))
+	}
+	return &dto.ListAuditArchivesResponse{Archives: items}, nil
+}
+
+func toAuditArchiveResponse(archive *entity.AuditArchive) dto.AuditArchiveResponse {
+	return dto.AuditArchiveResponse{
+		ID:            archive.ID.String(),
+		FromSequence:  archive.FromSequence,
+		ToSequence:    archive.ToSequence,
+		FirstPrevHash: archive.FirstPrevHash,
+		LastHash:      archive.LastHash,
+		Entries:       archive.Entries,
+		File:          archive.File,
+		FileSHA256:    archive.FileSHA256,
+		OlderThan:     archive.OlderThan.Format(time.RFC3339),
+		CreatedAt:     archive.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// memoryArchiveStore keeps committed archives uncompressed by name
+type memoryArchiveStore struct {
+	files map[string][]byte
+}
+
+func (s *memoryArchiveStore) Create(name string) (service.AuditArchiveWriter, error) {
+	return &memoryArchiveWriter{store: s, name: name}, nil
+}
+
+type memoryArchiveWriter struct {
+	bytes.Buffer
+	store *memoryArchiveStore
+	name  string
+}
+
+func (w *memoryArchiveWriter) Commit() (string, string, error) {
+	if w.store.files == nil {
+		w.store.files = map[string][]byte{}
+	}
+	w.store.files[w.name] = w.Bytes()
+	return w.name, "checksum", nil
+}
+
+func (w *memoryArchiveWriter) Abort() error { return nil }
+
+func newTestAuditRetentionUseCase(t *testing.T, ages ...time.Duration) (*AuditRetentionUseCase, *inMemoryAuditLogRepo, *inMemoryArchiveRepo, *memoryArchiveStore) {
+	integrity, repo, _, archives := newTestAuditIntegrityUseCaseWithArchives(t, 0)
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	for _, age := range ages {
+		require.NoError(t, repo.Create(context.Background(), &entity.AuditLog{
+			ID: uuid.New(), Action: "attendance:submit", Resource: "attendance", CreatedAt: now.Add(-age),
+		}))
+	}
+	store := &memoryArchiveStore{}
+	uc := NewAuditRetentionUseCase(repo, archives, store, integrity, &noopAuditLogger{}, AuditRetentionPolicy{Months: 6})
+	uc.now = func() time.Time { return now }
+	return uc, repo, archives, store
+}
+
+func TestAuditRetentionUseCase_ArchiveEntries(t *testing.T) {
+	ctx := context.Background()
+	const month = 30 * 24 * time.Hour
+
+	t.Run("archives the old prefix and keeps the chain verifiable", func(t *testing.T) {
+		uc, repo, archives, store := newTestAuditRetentionUseCase(t, 9*month, 8*month, 7*month, time.Hour, time.Minute)
+		lastArchivedHash := repo.logs[2].Hash
+
+		archive, err := uc.ArchiveEntries(ctx, dto.ArchiveAuditLogsRequest{})
+		require.NoError(t, err)
+		require.NotNil(t, archive)
+		assert.Equal(t, int64(1), archive.FromSequence)
+		assert.Equal(t, int64(3), archive.ToSequence)
+		assert.Equal(t, int64(3), archive.Entries)
+		assert.Equal(t, lastArchivedHash, archive.LastHash)
+		require.Len(t, archives.archives, 1)
+		require.Len(t, repo.logs, 2)
+		assert.Equal(t, int64(4), repo.logs[0].Sequence)
+
+		// The file holds the stored entries with their hashes
+		dec := json.NewDecoder(bytes.NewReader(store.files["audit-000000000001-000000000003.ndjson"]))
+		var archived []entity.AuditLog
+		for dec.More() {
+			var entry entity.AuditLog
+			require.NoError(t, dec.Decode(&entry))
+			archived = append(archived, entry)
+		}
+		require.Len(t, archived, 3)
+		assert.Equal(t, lastArchivedHash, archived[2].ComputeHash())
+
+		result, err := uc.integrity.VerifyChain(ctx)
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.Equal(t, int64(3), result.ArchivedThrough)
+
+		again, err := uc.ArchiveEntries(ctx, dto.ArchiveAuditLogsRequest{})
+		require.NoError(t, err)
+		assert.Nil(t, again)
+	})
+
+	t.Run("never archives the chain head", func(t *testing.T) {
+		uc, repo, _, _ := newTestAuditRetentionUseCase(t, 9*month, 8*month)
+
+		archive, err := uc.ArchiveEntries(ctx, dto.ArchiveAuditLogsRequest{OlderThanMonths: 1})
+		require.NoError(t, err)
+		require.NotNil(t, archive)
+		assert.Equal(t, int64(1), archive.ToSequence)
+		require.Len(t, repo.logs, 1)
+		assert.Equal(t, int64(2), repo.logs[0].Sequence)
+	})
+
+	t.Run("refuses to archive a broken chain", func(t *testing.T) {
+		uc, repo, archives, _ := newTestAuditRetentionUseCase(t, 9*month, 8*month, time.Hour)
+		repo.logs[0].Action = "attendance:delete"
+
+		_, err := uc.ArchiveEntries(ctx, dto.ArchiveAuditLogsRequest{})
+		assert.ErrorIs(t, err, domainErrors.ErrAuditChainBroken)
+		assert.Empty(t, archives.archives)
+		assert.Len(t, repo.logs, 3)
+	})
+
+	t.Run("disabled without a retention period", func(t *testing.T) {
+		uc, _, _, _ := newTestAuditRetentionUseCase(t, 9*month, time.Hour)
+		uc.policy = AuditRetentionPolicy{}
+
+		_, err := uc.ArchiveEntries(ctx, dto.ArchiveAuditLogsRequest{})
+		assert.ErrorIs(t, err, domainErrors.ErrAuditRetentionDisabled)
+	})
+}
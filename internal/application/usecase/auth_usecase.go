@@ -2,35 +2,79 @@ package usecase
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
+// Authentication events are written to the audit log under this resource.
+const (
+	authEventResource       = "auth"
+	authEventLoginSuccess   = "auth:login_success"
+	authEventLoginFailed    = "auth:login_failed"
+	authEventLoginBlocked   = "auth:login_blocked"
+	authEventLockout        = "auth:lockout"
+	authEventUnlock         = "auth:unlock"
+	authEventRefresh        = "auth:refresh"
+	authEventRefreshFailed  = "auth:refresh_failed"
+	authEventLogout         = "auth:logout"
+	authEventPasswordChange = "auth:password_change"
+	authEventTwoFactor      = "auth:2fa_challenge"
+)
+
 // AuthUseCase handles authentication use cases
 type AuthUseCase struct {
-	userRepo     repository.UserRepository
-	tokenService service.TokenService
+	userRepo       repository.UserRepository
+	sessionRepo    repository.RefreshSessionRepository
+	tokenService   service.TokenService
+	throttleRepo   repository.LoginThrottleRepository
+	auditLogger    appService.AuditLogger
+	lockoutPolicy  LoginLockoutPolicy
+	registration   RegistrationPolicy
+	twoFactor      *TwoFactorUseCase
+	principalCache service.PrincipalCache
 }
 
 // NewAuthUseCase creates a new auth use case
 func NewAuthUseCase(
 	userRepo repository.UserRepository,
+	sessionRepo repository.RefreshSessionRepository,
 	tokenService service.TokenService,
+	throttleRepo repository.LoginThrottleRepository,
+	auditLogger appService.AuditLogger,
+	lockoutPolicy LoginLockoutPolicy,
+	registration RegistrationPolicy,
+	twoFactor *TwoFactorUseCase,
+	principalCache service.PrincipalCache,
 ) *AuthUseCase {
 	return &AuthUseCase{
-		userRepo:     userRepo,
-		tokenService: tokenService,
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		tokenService:   tokenService,
+		throttleRepo:   throttleRepo,
+		auditLogger:    auditLogger,
+		lockoutPolicy:  lockoutPolicy,
+		registration:   registration,
+		twoFactor:      twoFactor,
+		principalCache: principalCache,
 	}
 }
 
-// Register handles user registration
+// Register handles open self-registration. Unless the registration policy
+// allows it, users are onboarded through invitations instead.
 func (uc *AuthUseCase) Register(ctx context.Context, req dto.RegisterRequest) (*dto.AuthResponse, error) {
+	if !uc.registration.OpenRegistration {
+		return nil, domainErrors.ErrRegistrationClosed
+	}
+
 	// Check if user already exists
 	existingUser, _ := uc.userRepo.GetByUsername(ctx, req.Username)
 	if existingUser != nil {
@@ -70,50 +114,116 @@ func (uc *AuthUseCase) Register(ctx context.Context, req dto.RegisterRequest) (*
 		roles = append(roles, role.Name)
 	}
 
-	accessToken, err := uc.tokenService.GenerateAccessToken(user.ID, user.Username, roles)
+	// Start a new refresh-token family for this login
+	return uc.issueTokens(ctx, userWithRoles, roles, uuid.New(), uuid.New())
+}
+
+// Login handles user login. Failed attempts are counted per username and per
+// client IP; once either crosses the lockout policy threshold, further logins
+// are refused with ErrLoginLocked until the lockout expires or is lifted.
+func (uc *AuthUseCase) Login(ctx context.Context, req dto.LoginRequest) (*dto.AuthResponse, error) {
+	username := strings.ToLower(strings.TrimSpace(req.Username))
+	ipAddress, _ := ctx.Value(appService.CtxKeyIPAddress).(string)
+
+	locked, err := uc.loginLocked(ctx, username, ipAddress)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
+	if locked {
+		uc.logAuthEvent(ctx, authEventLoginBlocked, nil, username, nil)
+		return nil, domainErrors.ErrLoginLocked
+	}
 
-	refreshToken, err := uc.tokenService.GenerateRefreshToken(user.ID)
-	if err != nil {
-		return nil, domainErrors.ErrInternalServer
+	// Get user by Username
+	user, err := uc.userRepo.GetByUsername(ctx, req.Username)
+	if err != nil || user == nil {
+		return nil, uc.loginFailed(ctx, nil, username, ipAddress, "unknown_user")
 	}
 
-	return &dto.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    time.Now().Add(15 * time.Minute).Format(time.RFC3339),
-		User: dto.UserDTO{
-			ID:       user.ID.String(),
-			Username: user.Username,
-			Name:     user.Name,
-			Roles:    roles,
-		},
-	}, nil
+	// Check if user is active
+	if !user.IsActive {
+		uc.logAuthEvent(ctx, authEventLoginFailed, user, username, map[string]string{"reason": "inactive"})
+		return nil, domainErrors.ErrUserInactive
+	}
+
+	// Service accounts authenticate with API keys only
+	if user.IsServiceAccount {
+		return nil, uc.loginFailed(ctx, user, username, ipAddress, "service_account")
+	}
+
+	// Verify password
+	if !user.CheckPassword(req.Password) {
+		return nil, uc.loginFailed(ctx, user, username, ipAddress, "invalid_password")
+	}
+
+	// The password is only the first step for users with two-factor authentication
+	if user.TwoFactorEnabled {
+		token, err := uc.tokenService.GenerateTwoFactorToken(user.ID)
+		if err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
+		uc.logAuthEvent(ctx, authEventTwoFactor, user, user.Username, nil)
+		return &dto.AuthResponse{
+			TwoFactorRequired: true,
+			TwoFactorToken:    token,
+			User: dto.UserDTO{
+				ID:               user.ID.String(),
+				Username:         user.Username,
+				Name:             user.Name,
+				TwoFactorEnabled: true,
+			},
+		}, nil
+	}
+
+	return uc.completeLogin(ctx, user, username, nil)
 }
 
-// Login handles user login
-func (uc *AuthUseCase) Login(ctx context.Context, req dto.LoginRequest) (*dto.AuthResponse, error) {
-	// Get user by Username
-	user, err := uc.userRepo.GetByUsername(ctx, req.Username)
+// VerifyTwoFactor completes a login started with a password by checking a TOTP
+// or recovery code. Wrong codes count as failed logins for the lockout policy.
+func (uc *AuthUseCase) VerifyTwoFactor(ctx context.Context, req dto.LoginTwoFactorRequest) (*dto.AuthResponse, error) {
+	claims, err := uc.tokenService.ValidateTwoFactorToken(req.TwoFactorToken)
 	if err != nil {
-		return nil, domainErrors.ErrInvalidCredentials
+		return nil, domainErrors.ErrInvalidToken
 	}
 
-	// Check if user exists
-	if user == nil {
-		return nil, domainErrors.ErrInvalidCredentials
+	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil || user == nil {
+		return nil, domainErrors.ErrInvalidToken
 	}
+	username := strings.ToLower(user.Username)
+	ipAddress, _ := ctx.Value(appService.CtxKeyIPAddress).(string)
 
-	// Check if user is active
+	locked, err := uc.loginLocked(ctx, username, ipAddress)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if locked {
+		uc.logAuthEvent(ctx, authEventLoginBlocked, user, username, nil)
+		return nil, domainErrors.ErrLoginLocked
+	}
 	if !user.IsActive {
 		return nil, domainErrors.ErrUserInactive
 	}
+	if !user.TwoFactorEnabled {
+		return nil, domainErrors.ErrTwoFactorNotEnabled
+	}
 
-	// Verify password
-	if !user.CheckPassword(req.Password) {
-		return nil, domainErrors.ErrInvalidCredentials
+	method, err := uc.twoFactor.Verify(ctx, user, req.Code)
+	if err != nil {
+		if err == domainErrors.ErrTwoFactorCodeInvalid {
+			_ = uc.loginFailed(ctx, user, username, ipAddress, "invalid_2fa_code")
+		}
+		return nil, err
+	}
+
+	return uc.completeLogin(ctx, user, username, map[string]string{"two_factor": method})
+}
+
+// completeLogin clears the username's failure history and starts a new
+// refresh-token family for a fully authenticated user.
+func (uc *AuthUseCase) completeLogin(ctx context.Context, user *entity.User, username string, metadata map[string]string) (*dto.AuthResponse, error) {
+	if throttle, err := uc.throttleRepo.GetByKey(ctx, entity.LoginThrottleKindUsername, username); err == nil && throttle != nil {
+		_ = uc.throttleRepo.Delete(ctx, throttle.ID)
 	}
 
 	// Get user with roles
@@ -128,37 +238,110 @@ func (uc *AuthUseCase) Login(ctx context.Context, req dto.LoginRequest) (*dto.Au
 		roles = append(roles, role.Name)
 	}
 
-	accessToken, err := uc.tokenService.GenerateAccessToken(user.ID, user.Username, roles)
+	resp, err := uc.issueTokens(ctx, userWithRoles, roles, uuid.New(), uuid.New())
 	if err != nil {
-		return nil, domainErrors.ErrInternalServer
+		return nil, err
 	}
+	uc.logAuthEvent(ctx, authEventLoginSuccess, user, user.Username, metadata)
+	return resp, nil
+}
 
-	refreshToken, err := uc.tokenService.GenerateRefreshToken(user.ID)
-	if err != nil {
-		return nil, domainErrors.ErrInternalServer
+// loginLocked reports whether the username or the client IP is locked out.
+func (uc *AuthUseCase) loginLocked(ctx context.Context, username, ipAddress string) (bool, error) {
+	now := time.Now()
+	for _, key := range loginThrottleKeys(username, ipAddress) {
+		throttle, err := uc.throttleRepo.GetByKey(ctx, key[0], key[1])
+		if err != nil {
+			return false, err
+		}
+		if throttle != nil && throttle.IsLocked(now) {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
-	return &dto.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    time.Now().Add(15 * time.Minute).Format(time.RFC3339),
-		User: dto.UserDTO{
-			ID:       user.ID.String(),
-			Username: user.Username,
-			Name:     user.Name,
-			Roles:    roles,
-		},
-	}, nil
+// loginFailed records a failed attempt against the username and the client IP,
+// locking either out when it reaches its threshold. It always returns
+// ErrInvalidCredentials so callers cannot tell which check failed.
+func (uc *AuthUseCase) loginFailed(ctx context.Context, user *entity.User, username, ipAddress, reason string) error {
+	uc.logAuthEvent(ctx, authEventLoginFailed, user, username, map[string]string{"reason": reason})
+
+	now := time.Now()
+	for _, key := range loginThrottleKeys(username, ipAddress) {
+		existing, err := uc.throttleRepo.GetByKey(ctx, key[0], key[1])
+		if err != nil {
+			continue
+		}
+		throttle, locked := uc.lockoutPolicy.registerFailure(existing, key[0], key[1], now)
+		if err := uc.throttleRepo.Save(ctx, throttle); err != nil {
+			continue
+		}
+		if locked {
+			uc.logAuthEvent(ctx, authEventLockout, user, username, map[string]string{
+				"kind":         throttle.Kind,
+				"value":        throttle.Value,
+				"lockouts":     strconv.Itoa(throttle.Lockouts),
+				"locked_until": throttle.LockedUntil.Format(time.RFC3339),
+			})
+		}
+	}
+	return domainErrors.ErrInvalidCredentials
 }
 
-// RefreshToken handles token refresh
+func loginThrottleKeys(username, ipAddress string) [][2]string {
+	keys := [][2]string{{entity.LoginThrottleKindUsername, username}}
+	if ipAddress != "" {
+		keys = append(keys, [2]string{entity.LoginThrottleKindIP, ipAddress})
+	}
+	return keys
+}
+
+// logAuthEvent writes an authentication event to the audit log (best-effort).
+// Login and refresh happen before the request is authenticated, so the actor is
+// taken from user when known; otherwise the attempted username is the target.
+func (uc *AuthUseCase) logAuthEvent(ctx context.Context, action string, user *entity.User, username string, metadata map[string]string) {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata["username"] = username
+
+	targetID := username
+	if user != nil {
+		ctx = context.WithValue(ctx, appService.CtxKeyActorID, user.ID)
+		ctx = context.WithValue(ctx, appService.CtxKeyActorUsername, user.Username)
+		targetID = user.ID.String()
+	}
+	_ = uc.auditLogger.Log(ctx, authEventResource, action, targetID, metadata)
+}
+
+// RefreshToken rotates a refresh token. The presented token's session is
+// retired and a new one is issued in the same family. Presenting a token that
+// was already rotated is treated as theft and revokes the whole family.
 func (uc *AuthUseCase) RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (*dto.AuthResponse, error) {
 	// Validate refresh token
-	claims, err := uc.tokenService.ValidateToken(req.RefreshToken)
+	claims, err := uc.tokenService.ValidateRefreshToken(req.RefreshToken)
 	if err != nil {
 		return nil, domainErrors.ErrInvalidToken
 	}
 
+	session, err := uc.sessionRepo.GetByID(ctx, claims.TokenID)
+	if err != nil || session.UserID != claims.UserID {
+		return nil, domainErrors.ErrInvalidToken
+	}
+
+	if session.RevokedAt != nil {
+		if session.ReplacedByID != nil {
+			_ = uc.sessionRepo.RevokeFamily(ctx, session.FamilyID, entity.SessionRevokeReasonReuse)
+			uc.logRefreshReuse(ctx, session)
+			return nil, domainErrors.ErrTokenReused
+		}
+		return nil, domainErrors.ErrInvalidToken
+	}
+	if !session.IsActive(time.Now()) {
+		return nil, domainErrors.ErrTokenExpired
+	}
+
 	// Get user
 	user, err := uc.userRepo.GetWithRoles(ctx, claims.UserID)
 	if err != nil {
@@ -167,35 +350,170 @@ func (uc *AuthUseCase) RefreshToken(ctx context.Context, req dto.RefreshTokenReq
 
 	// Check if user is active
 	if !user.IsActive {
+		_, _ = uc.sessionRepo.RevokeAllByUser(ctx, user.ID, entity.SessionRevokeReasonDeactivated)
+		uc.logAuthEvent(ctx, authEventRefreshFailed, user, user.Username, map[string]string{"reason": "inactive"})
 		return nil, domainErrors.ErrUserInactive
 	}
 
-	// Generate new access token
 	roles := make([]string, 0)
 	for _, role := range user.Roles {
 		roles = append(roles, role.Name)
 	}
 
+	nextSessionID := uuid.New()
+	rotated, err := uc.sessionRepo.Rotate(ctx, session.ID, nextSessionID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if !rotated {
+		// Another request rotated this token first; treat as reuse.
+		_ = uc.sessionRepo.RevokeFamily(ctx, session.FamilyID, entity.SessionRevokeReasonReuse)
+		uc.logAuthEvent(ctx, authEventRefreshFailed, user, user.Username, map[string]string{
+			"reason":    "reuse_detected",
+			"family_id": session.FamilyID.String(),
+		})
+		return nil, domainErrors.ErrTokenReused
+	}
+
+	resp, err := uc.issueTokens(ctx, user, roles, nextSessionID, session.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	uc.logAuthEvent(ctx, authEventRefresh, user, user.Username, map[string]string{"family_id": session.FamilyID.String()})
+	return resp, nil
+}
+
+// logRefreshReuse records the replay of an already rotated refresh token.
+func (uc *AuthUseCase) logRefreshReuse(ctx context.Context, session *entity.RefreshSession) {
+	ctx = context.WithValue(ctx, appService.CtxKeyActorID, session.UserID)
+	_ = uc.auditLogger.Log(ctx, authEventResource, authEventRefreshFailed, session.UserID.String(), map[string]string{
+		"reason":    "reuse_detected",
+		"family_id": session.FamilyID.String(),
+	})
+}
+
+// Logout revokes the refresh-token family the presented token belongs to.
+// Logging out with an already revoked token is a no-op.
+func (uc *AuthUseCase) Logout(ctx context.Context, req dto.LogoutRequest) error {
+	claims, err := uc.tokenService.ValidateRefreshToken(req.RefreshToken)
+	if err != nil {
+		if err == domainErrors.ErrTokenExpired {
+			return nil
+		}
+		return domainErrors.ErrInvalidToken
+	}
+
+	session, err := uc.sessionRepo.GetByID(ctx, claims.TokenID)
+	if err != nil || session.UserID != claims.UserID {
+		return domainErrors.ErrInvalidToken
+	}
+
+	if err := uc.sessionRepo.RevokeFamily(ctx, session.FamilyID, entity.SessionRevokeReasonLogout); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	ctx = context.WithValue(ctx, appService.CtxKeyActorID, session.UserID)
+	_ = uc.auditLogger.Log(ctx, authEventResource, authEventLogout, session.UserID.String(), map[string]string{
+		"family_id": session.FamilyID.String(),
+	})
+	return nil
+}
+
+// ChangePassword replaces the user's password after verifying the current one.
+// Every existing refresh session is revoked and a fresh token pair is issued,
+// which also clears a pending forced password change.
+func (uc *AuthUseCase) ChangePassword(ctx context.Context, userID uuid.UUID, req dto.ChangePasswordRequest) (*dto.AuthResponse, error) {
+	user, err := uc.userRepo.GetWithRoles(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+	if !user.IsActive {
+		return nil, domainErrors.ErrUserInactive
+	}
+	if !user.CheckPassword(req.CurrentPassword) {
+		return nil, domainErrors.ErrCurrentPasswordInvalid
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return nil, domainErrors.ErrPasswordUnchanged
+	}
+
+	now := time.Now()
+	user.Password = req.NewPassword
+	if err := user.HashPassword(); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	user.MustChangePassword = false
+	user.PasswordChangedAt = &now
+	user.UpdatedAt = now
+
+	roles := make([]string, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		roles = append(roles, role.Name)
+	}
+	// Save must not rewrite the role associations loaded above.
+	loadedRoles := user.Roles
+	user.Roles = nil
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	user.Roles = loadedRoles
+	// Lifts the forced password change on the very next request
+	invalidatePrincipals(ctx, uc.principalCache, user.ID)
+
+	revoked, err := uc.sessionRepo.RevokeAllByUser(ctx, user.ID, entity.SessionRevokeReasonPassword)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	uc.logAuthEvent(ctx, authEventPasswordChange, user, user.Username, map[string]string{
+		"revoked_sessions": strconv.FormatInt(revoked, 10),
+	})
+
+	return uc.issueTokens(ctx, user, roles, uuid.New(), uuid.New())
+}
+
+// issueTokens persists a refresh session and returns the access/refresh token
+// pair bound to it.
+func (uc *AuthUseCase) issueTokens(ctx context.Context, user *entity.User, roles []string, sessionID, familyID uuid.UUID) (*dto.AuthResponse, error) {
 	accessToken, err := uc.tokenService.GenerateAccessToken(user.ID, user.Username, roles)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	// Generate new refresh token
-	refreshToken, err := uc.tokenService.GenerateRefreshToken(user.ID)
+	refreshToken, err := uc.tokenService.GenerateRefreshToken(user.ID, sessionID, familyID)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
 
+	now := time.Now()
+	ipAddress, _ := ctx.Value(appService.CtxKeyIPAddress).(string)
+	userAgent, _ := ctx.Value(appService.CtxKeyUserAgent).(string)
+	session := &entity.RefreshSession{
+		ID:        sessionID,
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(uc.tokenService.RefreshTokenExpiry()),
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
 	return &dto.AuthResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-		ExpiresAt:    time.Now().Add(15 * time.Minute).Format(time.RFC3339),
+		ExpiresAt:    now.Add(15 * time.Minute).Format(time.RFC3339),
 		User: dto.UserDTO{
-			ID:       user.ID.String(),
-			Username: user.Username,
-			Name:     user.Name,
-			Roles:    roles,
+			ID:                     user.ID.String(),
+			Username:               user.Username,
+			Name:                   user.Name,
+			Roles:                  roles,
+			MustChangePassword:     user.MustChangePassword,
+			TwoFactorEnabled:       user.TwoFactorEnabled,
+			TwoFactorSetupRequired: user.RequiresTwoFactorSetup(),
 		},
 	}, nil
 }
@@ -10,17 +10,41 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
+// authEventRecorder captures the actions written to the audit log.
+type authEventRecorder struct {
+	actions []string
+}
+
+func (r *authEventRecorder) Log(ctx context.Context, resource, action, targetID string, metadata map[string]string) error {
+	r.actions = append(r.actions, action)
+	return nil
+}
+
+func (r *authEventRecorder) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	return r.Log(ctx, resource, action, targetID, metadata)
+}
+
+// newTestAuthUseCase builds an AuthUseCase whose throttle repository has no recorded failures.
+func newTestAuthUseCase(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) *AuthUseCase {
+	throttleRepo := new(mocks.MockLoginThrottleRepository)
+	throttleRepo.On("GetByKey", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	throttleRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return NewAuthUseCase(userRepo, sessionRepo, tokenService, throttleRepo, &noopAuditLogger{}, DefaultLoginLockoutPolicy(), DefaultRegistrationPolicy(), nil, nil)
+}
+
 func TestAuthUseCase_Register(t *testing.T) {
 	tests := []struct {
 		name          string
 		req           dto.RegisterRequest
-		setupMocks    func(*mocks.MockUserRepository, *mocks.MockTokenService)
+		closed        bool
+		setupMocks    func(*mocks.MockUserRepository, *mocks.MockRefreshSessionRepository, *mocks.MockTokenService)
 		expectedError error
 	}{
 		{
@@ -30,7 +54,7 @@ func TestAuthUseCase_Register(t *testing.T) {
 				Password: "password123",
 				Name:     "New User",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
 				// User doesn't exist
 				userRepo.On("GetByUsername", mock.Anything, "newuser").Return(nil, domainErrors.ErrUserNotFound)
 
@@ -50,7 +74,9 @@ func TestAuthUseCase_Register(t *testing.T) {
 
 				// Generate tokens (tidak mengikat ke UUID tertentu)
 				tokenService.On("GenerateAccessToken", mock.Anything, "newuser", []string{}).Return("access_token", nil)
-				tokenService.On("GenerateRefreshToken", mock.Anything).Return("refresh_token", nil)
+				tokenService.On("GenerateRefreshToken", mock.Anything, mock.Anything, mock.Anything).Return("refresh_token", nil)
+				tokenService.On("RefreshTokenExpiry").Return(168 * time.Hour)
+				sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.RefreshSession")).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -61,7 +87,7 @@ func TestAuthUseCase_Register(t *testing.T) {
 				Password: "password123",
 				Name:     "Existing User",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
 				userRepo.On("GetByUsername", mock.Anything, "existing").Return(&entity.User{
 					ID:       uuid.New(),
 					Username: "existing",
@@ -69,15 +95,28 @@ func TestAuthUseCase_Register(t *testing.T) {
 			},
 			expectedError: domainErrors.ErrUserAlreadyExists,
 		},
+		{
+			name: "failure - registration closed",
+			req: dto.RegisterRequest{
+				Username: "newuser",
+				Password: "password123",
+				Name:     "New User",
+			},
+			closed:        true,
+			setupMocks:    func(*mocks.MockUserRepository, *mocks.MockRefreshSessionRepository, *mocks.MockTokenService) {},
+			expectedError: domainErrors.ErrRegistrationClosed,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			userRepo := new(mocks.MockUserRepository)
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
 			tokenService := new(mocks.MockTokenService)
-			tt.setupMocks(userRepo, tokenService)
+			tt.setupMocks(userRepo, sessionRepo, tokenService)
 
-			authUseCase := NewAuthUseCase(userRepo, tokenService)
+			authUseCase := newTestAuthUseCase(userRepo, sessionRepo, tokenService)
+			authUseCase.registration.OpenRegistration = !tt.closed
 			resp, err := authUseCase.Register(context.Background(), tt.req)
 
 			if tt.expectedError != nil {
@@ -93,6 +132,7 @@ func TestAuthUseCase_Register(t *testing.T) {
 			}
 
 			userRepo.AssertExpectations(t)
+			sessionRepo.AssertExpectations(t)
 			tokenService.AssertExpectations(t)
 		})
 	}
@@ -115,7 +155,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 	tests := []struct {
 		name          string
 		req           dto.LoginRequest
-		setupMocks    func(*mocks.MockUserRepository, *mocks.MockTokenService)
+		setupMocks    func(*mocks.MockUserRepository, *mocks.MockRefreshSessionRepository, *mocks.MockTokenService)
 		expectedError error
 	}{
 		{
@@ -124,7 +164,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 				Username: "user",
 				Password: "password123",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
 				user := &entity.User{
 					ID:       userID,
 					Username: "user",
@@ -145,7 +185,11 @@ func TestAuthUseCase_Login(t *testing.T) {
 				userRepo.On("GetWithRoles", mock.Anything, userID).Return(userWithRoles, nil)
 
 				tokenService.On("GenerateAccessToken", userID, "user", []string{"user"}).Return("access_token", nil)
-				tokenService.On("GenerateRefreshToken", userID).Return("refresh_token", nil)
+				tokenService.On("GenerateRefreshToken", userID, mock.Anything, mock.Anything).Return("refresh_token", nil)
+				tokenService.On("RefreshTokenExpiry").Return(168 * time.Hour)
+				sessionRepo.On("Create", mock.Anything, mock.MatchedBy(func(session *entity.RefreshSession) bool {
+					return session.UserID == userID && session.FamilyID != uuid.Nil
+				})).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -155,7 +199,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 				Username: "notfound",
 				Password: "password123",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
 				userRepo.On("GetByUsername", mock.Anything, "notfound").Return(nil, domainErrors.ErrUserNotFound)
 			},
 			expectedError: domainErrors.ErrInvalidCredentials,
@@ -166,7 +210,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 				Username: "inactive",
 				Password: "password123",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
 				user := &entity.User{
 					ID:       userID,
 					Username: "inactive",
@@ -183,7 +227,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 				Username: "user",
 				Password: "wrongpassword",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
 				user := &entity.User{
 					ID:       userID,
 					Username: "user",
@@ -199,10 +243,11 @@ func TestAuthUseCase_Login(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			userRepo := new(mocks.MockUserRepository)
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
 			tokenService := new(mocks.MockTokenService)
-			tt.setupMocks(userRepo, tokenService)
+			tt.setupMocks(userRepo, sessionRepo, tokenService)
 
-			authUseCase := NewAuthUseCase(userRepo, tokenService)
+			authUseCase := newTestAuthUseCase(userRepo, sessionRepo, tokenService)
 			resp, err := authUseCase.Login(context.Background(), tt.req)
 
 			if tt.expectedError != nil {
@@ -216,6 +261,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 			}
 
 			userRepo.AssertExpectations(t)
+			sessionRepo.AssertExpectations(t)
 			tokenService.AssertExpectations(t)
 		})
 	}
@@ -223,24 +269,41 @@ func TestAuthUseCase_Login(t *testing.T) {
 
 func TestAuthUseCase_RefreshToken(t *testing.T) {
 	userID := uuid.New()
+	sessionID := uuid.New()
+	familyID := uuid.New()
+
+	validClaims := func() *service.TokenClaims {
+		return &service.TokenClaims{
+			UserID:    userID,
+			Exp:       time.Now().Add(time.Hour).Unix(),
+			TokenType: service.TokenTypeRefresh,
+			TokenID:   sessionID,
+			FamilyID:  familyID,
+		}
+	}
+	activeSession := func() *entity.RefreshSession {
+		return &entity.RefreshSession{
+			ID:        sessionID,
+			UserID:    userID,
+			FamilyID:  familyID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
 
 	tests := []struct {
 		name          string
 		req           dto.RefreshTokenRequest
-		setupMocks    func(*mocks.MockUserRepository, *mocks.MockTokenService)
+		setupMocks    func(*mocks.MockUserRepository, *mocks.MockRefreshSessionRepository, *mocks.MockTokenService)
 		expectedError error
 	}{
 		{
-			name: "success - refresh token",
+			name: "success - rotates refresh token within family",
 			req: dto.RefreshTokenRequest{
 				RefreshToken: "valid_refresh_token",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
-				claims := &service.TokenClaims{
-					UserID: userID,
-					Exp:    time.Now().Add(time.Hour).Unix(),
-				}
-				tokenService.On("ValidateToken", "valid_refresh_token").Return(claims, nil)
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
+				tokenService.On("ValidateRefreshToken", "valid_refresh_token").Return(validClaims(), nil)
+				sessionRepo.On("GetByID", mock.Anything, sessionID).Return(activeSession(), nil)
 
 				userWithRoles := &entity.User{
 					ID:       userID,
@@ -250,9 +313,14 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 					Roles:    []entity.Role{{ID: uuid.New(), Name: "user"}},
 				}
 				userRepo.On("GetWithRoles", mock.Anything, userID).Return(userWithRoles, nil)
+				sessionRepo.On("Rotate", mock.Anything, sessionID, mock.Anything).Return(true, nil)
 
 				tokenService.On("GenerateAccessToken", userID, "user", []string{"user"}).Return("new_access_token", nil)
-				tokenService.On("GenerateRefreshToken", userID).Return("new_refresh_token", nil)
+				tokenService.On("GenerateRefreshToken", userID, mock.Anything, familyID).Return("new_refresh_token", nil)
+				tokenService.On("RefreshTokenExpiry").Return(168 * time.Hour)
+				sessionRepo.On("Create", mock.Anything, mock.MatchedBy(func(session *entity.RefreshSession) bool {
+					return session.FamilyID == familyID && session.ID != sessionID
+				})).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -261,22 +329,61 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 			req: dto.RefreshTokenRequest{
 				RefreshToken: "invalid_token",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
-				tokenService.On("ValidateToken", "invalid_token").Return(nil, domainErrors.ErrInvalidToken)
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
+				tokenService.On("ValidateRefreshToken", "invalid_token").Return(nil, domainErrors.ErrInvalidToken)
 			},
 			expectedError: domainErrors.ErrInvalidToken,
 		},
+		{
+			name: "failure - unknown session",
+			req: dto.RefreshTokenRequest{
+				RefreshToken: "valid_refresh_token",
+			},
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
+				tokenService.On("ValidateRefreshToken", "valid_refresh_token").Return(validClaims(), nil)
+				sessionRepo.On("GetByID", mock.Anything, sessionID).Return(nil, assert.AnError)
+			},
+			expectedError: domainErrors.ErrInvalidToken,
+		},
+		{
+			name: "failure - reuse of rotated token revokes family",
+			req: dto.RefreshTokenRequest{
+				RefreshToken: "rotated_refresh_token",
+			},
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
+				tokenService.On("ValidateRefreshToken", "rotated_refresh_token").Return(validClaims(), nil)
+				rotatedAt := time.Now().Add(-time.Minute)
+				replacedBy := uuid.New()
+				session := activeSession()
+				session.RevokedAt = &rotatedAt
+				session.ReplacedByID = &replacedBy
+				sessionRepo.On("GetByID", mock.Anything, sessionID).Return(session, nil)
+				sessionRepo.On("RevokeFamily", mock.Anything, familyID, entity.SessionRevokeReasonReuse).Return(nil)
+			},
+			expectedError: domainErrors.ErrTokenReused,
+		},
+		{
+			name: "failure - concurrent rotation revokes family",
+			req: dto.RefreshTokenRequest{
+				RefreshToken: "valid_refresh_token",
+			},
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
+				tokenService.On("ValidateRefreshToken", "valid_refresh_token").Return(validClaims(), nil)
+				sessionRepo.On("GetByID", mock.Anything, sessionID).Return(activeSession(), nil)
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "user", IsActive: true}, nil)
+				sessionRepo.On("Rotate", mock.Anything, sessionID, mock.Anything).Return(false, nil)
+				sessionRepo.On("RevokeFamily", mock.Anything, familyID, entity.SessionRevokeReasonReuse).Return(nil)
+			},
+			expectedError: domainErrors.ErrTokenReused,
+		},
 		{
 			name: "failure - user not found",
 			req: dto.RefreshTokenRequest{
 				RefreshToken: "valid_refresh_token",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
-				claims := &service.TokenClaims{
-					UserID: userID,
-					Exp:    time.Now().Add(time.Hour).Unix(),
-				}
-				tokenService.On("ValidateToken", "valid_refresh_token").Return(claims, nil)
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
+				tokenService.On("ValidateRefreshToken", "valid_refresh_token").Return(validClaims(), nil)
+				sessionRepo.On("GetByID", mock.Anything, sessionID).Return(activeSession(), nil)
 				userRepo.On("GetWithRoles", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
 			},
 			expectedError: domainErrors.ErrUserNotFound,
@@ -286,12 +393,9 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 			req: dto.RefreshTokenRequest{
 				RefreshToken: "valid_refresh_token",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
-				claims := &service.TokenClaims{
-					UserID: userID,
-					Exp:    time.Now().Add(time.Hour).Unix(),
-				}
-				tokenService.On("ValidateToken", "valid_refresh_token").Return(claims, nil)
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository, tokenService *mocks.MockTokenService) {
+				tokenService.On("ValidateRefreshToken", "valid_refresh_token").Return(validClaims(), nil)
+				sessionRepo.On("GetByID", mock.Anything, sessionID).Return(activeSession(), nil)
 
 				userWithRoles := &entity.User{
 					ID:       userID,
@@ -299,6 +403,7 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 					IsActive: false,
 				}
 				userRepo.On("GetWithRoles", mock.Anything, userID).Return(userWithRoles, nil)
+				sessionRepo.On("RevokeAllByUser", mock.Anything, userID, entity.SessionRevokeReasonDeactivated).Return(int64(1), nil)
 			},
 			expectedError: domainErrors.ErrUserInactive,
 		},
@@ -307,10 +412,11 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			userRepo := new(mocks.MockUserRepository)
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
 			tokenService := new(mocks.MockTokenService)
-			tt.setupMocks(userRepo, tokenService)
+			tt.setupMocks(userRepo, sessionRepo, tokenService)
 
-			authUseCase := NewAuthUseCase(userRepo, tokenService)
+			authUseCase := newTestAuthUseCase(userRepo, sessionRepo, tokenService)
 			resp, err := authUseCase.RefreshToken(context.Background(), tt.req)
 
 			if tt.expectedError != nil {
@@ -324,7 +430,212 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 			}
 
 			userRepo.AssertExpectations(t)
+			sessionRepo.AssertExpectations(t)
 			tokenService.AssertExpectations(t)
 		})
 	}
 }
+
+func TestAuthUseCase_Logout(t *testing.T) {
+	userID := uuid.New()
+	sessionID := uuid.New()
+	familyID := uuid.New()
+
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockRefreshSessionRepository)
+	tokenService := new(mocks.MockTokenService)
+
+	tokenService.On("ValidateRefreshToken", "refresh_token").Return(&service.TokenClaims{
+		UserID:   userID,
+		TokenID:  sessionID,
+		FamilyID: familyID,
+	}, nil)
+	tokenService.On("ValidateRefreshToken", "bogus").Return(nil, domainErrors.ErrInvalidToken)
+	sessionRepo.On("GetByID", mock.Anything, sessionID).Return(&entity.RefreshSession{ID: sessionID, UserID: userID, FamilyID: familyID}, nil)
+	sessionRepo.On("RevokeFamily", mock.Anything, familyID, entity.SessionRevokeReasonLogout).Return(nil)
+
+	authUseCase := newTestAuthUseCase(userRepo, sessionRepo, tokenService)
+
+	assert.NoError(t, authUseCase.Logout(context.Background(), dto.LogoutRequest{RefreshToken: "refresh_token"}))
+	assert.Equal(t, domainErrors.ErrInvalidToken, authUseCase.Logout(context.Background(), dto.LogoutRequest{RefreshToken: "bogus"}))
+
+	sessionRepo.AssertExpectations(t)
+	tokenService.AssertExpectations(t)
+}
+
+func TestAuthUseCase_ChangePassword(t *testing.T) {
+	userID := uuid.New()
+	newUser := func(t *testing.T) *entity.User {
+		user := &entity.User{ID: userID, Username: "staff", Password: "temporary1", IsActive: true, MustChangePassword: true}
+		require.NoError(t, user.HashPassword())
+		return user
+	}
+
+	t.Run("success", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		sessionRepo := new(mocks.MockRefreshSessionRepository)
+		tokenService := new(mocks.MockTokenService)
+
+		user := newUser(t)
+		user.Roles = []entity.Role{{Name: "teacher"}}
+		userRepo.On("GetWithRoles", mock.Anything, userID).Return(user, nil)
+		userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+			return !u.MustChangePassword && u.PasswordChangedAt != nil && u.CheckPassword("new-secret")
+		})).Return(nil)
+		sessionRepo.On("RevokeAllByUser", mock.Anything, userID, entity.SessionRevokeReasonPassword).Return(int64(2), nil)
+		tokenService.On("GenerateAccessToken", userID, "staff", []string{"teacher"}).Return("access_token", nil)
+		tokenService.On("GenerateRefreshToken", userID, mock.Anything, mock.Anything).Return("refresh_token", nil)
+		tokenService.On("RefreshTokenExpiry").Return(168 * time.Hour)
+		sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.RefreshSession")).Return(nil)
+
+		authUseCase := newTestAuthUseCase(userRepo, sessionRepo, tokenService)
+		resp, err := authUseCase.ChangePassword(context.Background(), userID, dto.ChangePasswordRequest{
+			CurrentPassword: "temporary1",
+			NewPassword:     "new-secret",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "access_token", resp.AccessToken)
+		assert.False(t, resp.User.MustChangePassword)
+		userRepo.AssertExpectations(t)
+		sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		userRepo.On("GetWithRoles", mock.Anything, userID).Return(newUser(t), nil)
+
+		authUseCase := newTestAuthUseCase(userRepo, new(mocks.MockRefreshSessionRepository), new(mocks.MockTokenService))
+		_, err := authUseCase.ChangePassword(context.Background(), userID, dto.ChangePasswordRequest{
+			CurrentPassword: "wrong",
+			NewPassword:     "new-secret",
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrCurrentPasswordInvalid)
+		userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("password unchanged", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		userRepo.On("GetWithRoles", mock.Anything, userID).Return(newUser(t), nil)
+
+		authUseCase := newTestAuthUseCase(userRepo, new(mocks.MockRefreshSessionRepository), new(mocks.MockTokenService))
+		_, err := authUseCase.ChangePassword(context.Background(), userID, dto.ChangePasswordRequest{
+			CurrentPassword: "temporary1",
+			NewPassword:     "temporary1",
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrPasswordUnchanged)
+	})
+}
+
+func TestAuthUseCase_LoginLockout(t *testing.T) {
+	user := &entity.User{ID: uuid.New(), Username: "staff", Password: "password123", IsActive: true}
+	require.NoError(t, user.HashPassword())
+	ctx := context.WithValue(context.Background(), appService.CtxKeyIPAddress, "10.0.0.1")
+	policy := LoginLockoutPolicy{MaxAttempts: 3, IPMaxAttempts: 10, Window: time.Minute, LockoutDuration: time.Minute, MaxLockoutDuration: time.Hour}
+
+	t.Run("locks the username at the threshold", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		throttleRepo := new(mocks.MockLoginThrottleRepository)
+		audit := &authEventRecorder{}
+		existing := &entity.LoginThrottle{
+			ID:             uuid.New(),
+			Kind:           entity.LoginThrottleKindUsername,
+			Value:          "staff",
+			FailedAttempts: 2,
+			LastFailedAt:   time.Now().Add(-10 * time.Second),
+		}
+
+		userRepo.On("GetByUsername", mock.Anything, "Staff").Return(user, nil)
+		throttleRepo.On("GetByKey", mock.Anything, entity.LoginThrottleKindUsername, "staff").Return(existing, nil)
+		throttleRepo.On("GetByKey", mock.Anything, entity.LoginThrottleKindIP, "10.0.0.1").Return(nil, nil)
+		throttleRepo.On("Save", mock.Anything, mock.MatchedBy(func(th *entity.LoginThrottle) bool {
+			return th.Kind == entity.LoginThrottleKindUsername && th.Lockouts == 1 && th.IsLocked(time.Now())
+		})).Return(nil).Once()
+		throttleRepo.On("Save", mock.Anything, mock.MatchedBy(func(th *entity.LoginThrottle) bool {
+			return th.Kind == entity.LoginThrottleKindIP && th.FailedAttempts == 1 && th.LockedUntil == nil
+		})).Return(nil).Once()
+
+		authUseCase := NewAuthUseCase(userRepo, new(mocks.MockRefreshSessionRepository), new(mocks.MockTokenService), throttleRepo, audit, policy, DefaultRegistrationPolicy(), nil, nil)
+		_, err := authUseCase.Login(ctx, dto.LoginRequest{Username: "Staff", Password: "wrong"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidCredentials)
+		assert.Equal(t, []string{authEventLoginFailed, authEventLockout}, audit.actions)
+		throttleRepo.AssertExpectations(t)
+	})
+
+	t.Run("refuses a locked username without checking the password", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		throttleRepo := new(mocks.MockLoginThrottleRepository)
+		audit := &authEventRecorder{}
+		lockedUntil := time.Now().Add(time.Minute)
+
+		throttleRepo.On("GetByKey", mock.Anything, entity.LoginThrottleKindUsername, "staff").Return(&entity.LoginThrottle{
+			ID:          uuid.New(),
+			Kind:        entity.LoginThrottleKindUsername,
+			Value:       "staff",
+			LockedUntil: &lockedUntil,
+		}, nil)
+
+		authUseCase := NewAuthUseCase(userRepo, new(mocks.MockRefreshSessionRepository), new(mocks.MockTokenService), throttleRepo, audit, policy, DefaultRegistrationPolicy(), nil, nil)
+		_, err := authUseCase.Login(ctx, dto.LoginRequest{Username: "staff", Password: "password123"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrLoginLocked)
+		assert.Equal(t, []string{authEventLoginBlocked}, audit.actions)
+		userRepo.AssertNotCalled(t, "GetByUsername", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success clears the username failures", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		sessionRepo := new(mocks.MockRefreshSessionRepository)
+		tokenService := new(mocks.MockTokenService)
+		throttleRepo := new(mocks.MockLoginThrottleRepository)
+		audit := &authEventRecorder{}
+		throttle := &entity.LoginThrottle{ID: uuid.New(), Kind: entity.LoginThrottleKindUsername, Value: "staff", FailedAttempts: 2}
+
+		throttleRepo.On("GetByKey", mock.Anything, entity.LoginThrottleKindUsername, "staff").Return(throttle, nil)
+		throttleRepo.On("GetByKey", mock.Anything, entity.LoginThrottleKindIP, "10.0.0.1").Return(nil, nil)
+		throttleRepo.On("Delete", mock.Anything, throttle.ID).Return(nil)
+		userRepo.On("GetByUsername", mock.Anything, "staff").Return(user, nil)
+		userRepo.On("GetWithRoles", mock.Anything, user.ID).Return(user, nil)
+		tokenService.On("GenerateAccessToken", user.ID, "staff", []string{}).Return("access_token", nil)
+		tokenService.On("GenerateRefreshToken", user.ID, mock.Anything, mock.Anything).Return("refresh_token", nil)
+		tokenService.On("RefreshTokenExpiry").Return(time.Hour)
+		sessionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		authUseCase := NewAuthUseCase(userRepo, sessionRepo, tokenService, throttleRepo, audit, policy, DefaultRegistrationPolicy(), nil, nil)
+		_, err := authUseCase.Login(ctx, dto.LoginRequest{Username: "staff", Password: "password123"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{authEventLoginSuccess}, audit.actions)
+		throttleRepo.AssertExpectations(t)
+	})
+}
+
+func TestLoginLockoutPolicy_RegisterFailure(t *testing.T) {
+	policy := LoginLockoutPolicy{MaxAttempts: 2, IPMaxAttempts: 5, Window: time.Minute, LockoutDuration: time.Minute, MaxLockoutDuration: 3 * time.Minute}
+	now := time.Now()
+
+	throttle, locked := policy.registerFailure(nil, entity.LoginThrottleKindUsername, "staff", now)
+	assert.False(t, locked)
+	assert.Equal(t, 1, throttle.FailedAttempts)
+
+	// Failures outside the window are forgotten
+	now = now.Add(2 * time.Minute)
+	throttle, locked = policy.registerFailure(throttle, entity.LoginThrottleKindUsername, "staff", now)
+	assert.False(t, locked)
+	assert.Equal(t, 1, throttle.FailedAttempts)
+
+	// Consecutive lockouts double up to the maximum
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute}
+	for i, duration := range expected {
+		if i > 0 {
+			throttle, _ = policy.registerFailure(throttle, entity.LoginThrottleKindUsername, "staff", now)
+		}
+		throttle, locked = policy.registerFailure(throttle, entity.LoginThrottleKindUsername, "staff", now)
+		require.True(t, locked)
+		assert.Equal(t, i+1, throttle.Lockouts)
+		assert.Equal(t, now.Add(duration), *throttle.LockedUntil)
+	}
+}
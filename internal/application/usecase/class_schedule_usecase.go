@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -44,78 +45,113 @@ func NewClassScheduleUseCase(
 	}
 }
 
+// Conflict reasons reported when a schedule clashes with another one.
+const (
+	ClassScheduleConflictTeacher  = "teacher"
+	ClassScheduleConflictClass    = "class"
+	ClassScheduleConflictLocation = "location"
+)
+
+// ClassScheduleConflictError carries every clash found for a create/update request.
+// It unwraps to ErrClassScheduleConflict so callers can keep matching on the domain error.
+type ClassScheduleConflictError struct {
+	Conflicts []dto.ClassScheduleConflict
+}
+
+func (e *ClassScheduleConflictError) Error() string {
+	return domainErrors.ErrClassScheduleConflict.Error()
+}
+
+func (e *ClassScheduleConflictError) Unwrap() error {
+	return domainErrors.ErrClassScheduleConflict
+}
+
 // CreateClassSchedule creates schedule entries linking a class to a teacher/slot.
 func (uc *ClassScheduleUseCase) CreateClassSchedule(ctx context.Context, req dto.CreateClassScheduleRequest) (*dto.ClassScheduleResponse, error) {
-	classID, err := uuid.Parse(req.ClassID)
+	schedule, err := uc.buildClassSchedule(ctx, req)
 	if err != nil {
-		return nil, domainErrors.ErrBadRequest
-	}
-	if _, err := uc.classRepo.GetByID(ctx, classID); err != nil {
-		return nil, domainErrors.ErrClassNotFound
+		return nil, err
 	}
 
-	teacherID, err := uuid.Parse(req.TeacherID)
+	conflicts, err := uc.findConflicts(ctx, schedule)
 	if err != nil {
-		return nil, domainErrors.ErrBadRequest
+		return nil, err
 	}
-	if teacher, err := uc.teacherRepo.GetByID(ctx, teacherID); err != nil || teacher == nil {
-		return nil, domainErrors.ErrTeacherNotFound
-	} else if !teacher.IsActive {
-		return nil, domainErrors.ErrBadRequest
+	if len(conflicts) > 0 {
+		return nil, &ClassScheduleConflictError{Conflicts: conflicts}
 	}
 
-	dormID, err := uuid.Parse(req.DormitoryID)
-	if err != nil {
-		return nil, domainErrors.ErrBadRequest
+	if err := uc.scheduleRepo.Create(ctx, schedule); err != nil {
+		return nil, domainErrors.ErrInternalServer
 	}
-	if _, err := uc.dormRepo.GetByID(ctx, dormID); err != nil {
-		return nil, domainErrors.ErrDormitoryNotFound
+
+	_ = uc.auditLogger.Log(ctx, "class_schedule", "class_schedule:create", schedule.ID.String(), map[string]string{
+		"class_id":   schedule.ClassID.String(),
+		"teacher_id": schedule.TeacherID.String(),
+	})
+
+	return uc.toClassScheduleResponse(schedule), nil
+}
+
+// BulkCreateClassSchedules creates several schedules atomically. Conflicts against stored
+// schedules and between entries of the request are all collected before anything is saved.
+func (uc *ClassScheduleUseCase) BulkCreateClassSchedules(ctx context.Context, req dto.BulkCreateClassSchedulesRequest) (*dto.BulkCreateClassSchedulesResponse, error) {
+	if len(req.Schedules) == 0 {
+		return nil, domainErrors.ErrBadRequest
 	}
 
-	var subjectID *uuid.UUID
-	if req.SubjectID != nil {
-		parsed, err := uuid.Parse(*req.SubjectID)
+	schedules := make([]*entity.ClassSchedule, 0, len(req.Schedules))
+	for _, item := range req.Schedules {
+		schedule, err := uc.buildClassSchedule(ctx, item)
 		if err != nil {
-			return nil, domainErrors.ErrBadRequest
-		}
-		if _, err := uc.subjectRepo.GetByID(ctx, parsed); err != nil {
-			return nil, domainErrors.ErrSubjectNotFound
+			return nil, err
 		}
-		subjectID = &parsed
+		schedules = append(schedules, schedule)
 	}
 
-	startTime, endTime, slotID, err := uc.resolveScheduleTiming(ctx, dormID, req.SlotID, req.StartTime, req.EndTime)
-	if err != nil {
-		return nil, err
+	var conflicts []dto.ClassScheduleConflict
+	for i, schedule := range schedules {
+		index := i
+		found, err := uc.findConflicts(ctx, schedule)
+		if err != nil {
+			return nil, err
+		}
+		for _, conflict := range found {
+			conflict.Index = &index
+			conflicts = append(conflicts, conflict)
+		}
+		for j := 0; j < i; j++ {
+			reasons := scheduleConflictReasons(schedule, schedules[j])
+			if len(reasons) == 0 || !schedulesOverlap(schedule, schedules[j]) {
+				continue
+			}
+			other := j
+			conflicts = append(conflicts, dto.ClassScheduleConflict{
+				Index:            &index,
+				Reasons:          reasons,
+				ConflictingIndex: &other,
+			})
+		}
 	}
-
-	schedule := &entity.ClassSchedule{
-		ID:          uuid.New(),
-		ClassID:     classID,
-		DormitoryID: dormID,
-		SubjectID:   subjectID,
-		TeacherID:   teacherID,
-		SlotID:      slotID,
-		DayOfWeek:   req.DayOfWeek,
-		StartTime:   startTime,
-		EndTime:     endTime,
-		Location:    req.Location,
-		Notes:       req.Notes,
-		IsActive:    true,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	if len(conflicts) > 0 {
+		return nil, &ClassScheduleConflictError{Conflicts: conflicts}
 	}
 
-	if err := uc.scheduleRepo.Create(ctx, schedule); err != nil {
+	if err := uc.scheduleRepo.CreateBatch(ctx, schedules); err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "class_schedule", "class_schedule:create", schedule.ID.String(), map[string]string{
-		"class_id":   schedule.ClassID.String(),
-		"teacher_id": schedule.TeacherID.String(),
-	})
+	responses := make([]dto.ClassScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		_ = uc.auditLogger.Log(ctx, "class_schedule", "class_schedule:create", schedule.ID.String(), map[string]string{
+			"class_id":   schedule.ClassID.String(),
+			"teacher_id": schedule.TeacherID.String(),
+			"bulk":       "true",
+		})
+		responses = append(responses, *uc.toClassScheduleResponse(schedule))
+	}
 
-	return uc.toClassScheduleResponse(schedule), nil
+	return &dto.BulkCreateClassSchedulesResponse{Schedules: responses}, nil
 }
 
 // GetClassSchedule fetches a schedule by ID.
@@ -124,6 +160,9 @@ func (uc *ClassScheduleUseCase) GetClassSchedule(ctx context.Context, id uuid.UU
 	if err != nil {
 		return nil, domainErrors.ErrClassScheduleNotFound
 	}
+	if err := ensureDormitoryInScope(ctx, schedule.DormitoryID); err != nil {
+		return nil, err
+	}
 	return uc.toClassScheduleResponse(schedule), nil
 }
 
@@ -159,7 +198,12 @@ func (uc *ClassScheduleUseCase) ListClassSchedules(
 		if err != nil {
 			return nil, domainErrors.ErrBadRequest
 		}
+		if err := ensureDormitoryInScope(ctx, dormID); err != nil {
+			return nil, err
+		}
 		filter.DormitoryID = dormID
+	} else {
+		filter.DormitoryIDs = scopedDormitoryIDs(ctx)
 	}
 
 	schedules, total, err := uc.scheduleRepo.List(ctx, filter)
@@ -188,6 +232,10 @@ func (uc *ClassScheduleUseCase) UpdateClassSchedule(ctx context.Context, id uuid
 	if err != nil {
 		return nil, domainErrors.ErrClassScheduleNotFound
 	}
+	if err := ensureDormitoryInScope(ctx, schedule.DormitoryID); err != nil {
+		return nil, err
+	}
+	before := *schedule
 
 	if req.SubjectID != nil {
 		parsed, err := uuid.Parse(*req.SubjectID)
@@ -237,11 +285,19 @@ func (uc *ClassScheduleUseCase) UpdateClassSchedule(ctx context.Context, id uuid
 	}
 	schedule.UpdatedAt = time.Now()
 
+	conflicts, err := uc.findConflicts(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		return nil, &ClassScheduleConflictError{Conflicts: conflicts}
+	}
+
 	if err := uc.scheduleRepo.Update(ctx, schedule); err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "class_schedule", "class_schedule:update", schedule.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "class_schedule", "class_schedule:update", schedule.ID.String(), &before, schedule, map[string]string{
 		"class_id": schedule.ClassID.String(),
 	})
 
@@ -250,16 +306,155 @@ func (uc *ClassScheduleUseCase) UpdateClassSchedule(ctx context.Context, id uuid
 
 // DeleteClassSchedule deletes schedule entry.
 func (uc *ClassScheduleUseCase) DeleteClassSchedule(ctx context.Context, id uuid.UUID) error {
-	if _, err := uc.scheduleRepo.GetByID(ctx, id); err != nil {
+	schedule, err := uc.scheduleRepo.GetByID(ctx, id)
+	if err != nil {
 		return domainErrors.ErrClassScheduleNotFound
 	}
+	if err := ensureDormitoryInScope(ctx, schedule.DormitoryID); err != nil {
+		return err
+	}
 	if err := uc.scheduleRepo.Delete(ctx, id); err != nil {
 		return domainErrors.ErrInternalServer
 	}
-	_ = uc.auditLogger.Log(ctx, "class_schedule", "class_schedule:delete", id.String(), nil)
+	_ = uc.auditLogger.LogChange(ctx, "class_schedule", "class_schedule:delete", id.String(), schedule, nil, nil)
 	return nil
 }
 
+// buildClassSchedule validates references in the request and resolves its timing.
+func (uc *ClassScheduleUseCase) buildClassSchedule(ctx context.Context, req dto.CreateClassScheduleRequest) (*entity.ClassSchedule, error) {
+	classID, err := uuid.Parse(req.ClassID)
+	if err != nil {
+		return nil, domainErrors.ErrBadRequest
+	}
+	if _, err := uc.classRepo.GetByID(ctx, classID); err != nil {
+		return nil, domainErrors.ErrClassNotFound
+	}
+
+	teacherID, err := uuid.Parse(req.TeacherID)
+	if err != nil {
+		return nil, domainErrors.ErrBadRequest
+	}
+	if teacher, err := uc.teacherRepo.GetByID(ctx, teacherID); err != nil || teacher == nil {
+		return nil, domainErrors.ErrTeacherNotFound
+	} else if !teacher.IsActive {
+		return nil, domainErrors.ErrBadRequest
+	}
+
+	dormID, err := uuid.Parse(req.DormitoryID)
+	if err != nil {
+		return nil, domainErrors.ErrBadRequest
+	}
+	if _, err := uc.dormRepo.GetByID(ctx, dormID); err != nil {
+		return nil, domainErrors.ErrDormitoryNotFound
+	}
+	if err := ensureDormitoryInScope(ctx, dormID); err != nil {
+		return nil, err
+	}
+
+	var subjectID *uuid.UUID
+	if req.SubjectID != nil {
+		parsed, err := uuid.Parse(*req.SubjectID)
+		if err != nil {
+			return nil, domainErrors.ErrBadRequest
+		}
+		if _, err := uc.subjectRepo.GetByID(ctx, parsed); err != nil {
+			return nil, domainErrors.ErrSubjectNotFound
+		}
+		subjectID = &parsed
+	}
+
+	startTime, endTime, slotID, err := uc.resolveScheduleTiming(ctx, dormID, req.SlotID, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &entity.ClassSchedule{
+		ID:          uuid.New(),
+		ClassID:     classID,
+		DormitoryID: dormID,
+		SubjectID:   subjectID,
+		TeacherID:   teacherID,
+		SlotID:      slotID,
+		DayOfWeek:   req.DayOfWeek,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Location:    req.Location,
+		Notes:       req.Notes,
+		IsActive:    true,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	return schedule, nil
+}
+
+// findConflicts reports stored schedules that share a teacher, class or location with
+// the given schedule on the same day and overlap its time window.
+func (uc *ClassScheduleUseCase) findConflicts(ctx context.Context, schedule *entity.ClassSchedule) ([]dto.ClassScheduleConflict, error) {
+	if !schedule.IsActive {
+		return nil, nil
+	}
+
+	candidates, err := uc.scheduleRepo.ListConflictCandidates(ctx, repository.ClassScheduleConflictFilter{
+		ExcludeID: schedule.ID,
+		DayOfWeek: schedule.DayOfWeek,
+		TeacherID: schedule.TeacherID,
+		ClassID:   schedule.ClassID,
+		Location:  schedule.Location,
+	})
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	var conflicts []dto.ClassScheduleConflict
+	for _, candidate := range candidates {
+		reasons := scheduleConflictReasons(schedule, candidate)
+		if len(reasons) == 0 || !schedulesOverlap(schedule, candidate) {
+			continue
+		}
+		conflicts = append(conflicts, dto.ClassScheduleConflict{
+			Reasons:  reasons,
+			Schedule: uc.toClassScheduleResponse(candidate),
+		})
+	}
+	return conflicts, nil
+}
+
+func scheduleConflictReasons(a, b *entity.ClassSchedule) []string {
+	if a.DayOfWeek != b.DayOfWeek {
+		return nil
+	}
+	var reasons []string
+	if a.TeacherID == b.TeacherID {
+		reasons = append(reasons, ClassScheduleConflictTeacher)
+	}
+	if a.ClassID == b.ClassID {
+		reasons = append(reasons, ClassScheduleConflictClass)
+	}
+	locA := strings.TrimSpace(a.Location)
+	if locA != "" && strings.EqualFold(locA, strings.TrimSpace(b.Location)) {
+		reasons = append(reasons, ClassScheduleConflictLocation)
+	}
+	return reasons
+}
+
+// schedulesOverlap compares weekly schedules by time of day; schedules bound to the
+// same slot always overlap, schedules without resolved times never do.
+func schedulesOverlap(a, b *entity.ClassSchedule) bool {
+	if a.SlotID != nil && b.SlotID != nil && *a.SlotID == *b.SlotID {
+		return true
+	}
+	if a.StartTime == nil || a.EndTime == nil || b.StartTime == nil || b.EndTime == nil {
+		return false
+	}
+	return timesOverlap(clockTime(*a.StartTime), clockTime(*a.EndTime), clockTime(*b.StartTime), clockTime(*b.EndTime))
+}
+
+// clockTime strips the date so recurring schedules are compared on wall-clock time only.
+func clockTime(t time.Time) time.Time {
+	return time.Date(0, 1, 1, t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
 func (uc *ClassScheduleUseCase) resolveScheduleTiming(
 	ctx context.Context,
 	dormID uuid.UUID,
@@ -21,6 +21,10 @@ func (n *classScheduleNoopAuditLogger) Log(ctx context.Context, resource, action
 	return nil
 }
 
+func (n *classScheduleNoopAuditLogger) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	return nil
+}
+
 func newClassScheduleUCForTest() (*ClassScheduleUseCase, *mocks.ClassScheduleRepositoryMock, *mocks.ClassRepositoryMock, *mocks.MockTeacherRepository, *mocks.SubjectRepositoryMock, *mocks.MockScheduleSlotRepository, *mocks.MockDormitoryRepository) {
 	scheduleRepo := new(mocks.ClassScheduleRepositoryMock)
 	classRepo := new(mocks.ClassRepositoryMock)
@@ -43,6 +47,7 @@ func TestClassScheduleUseCase_Create_WithSlot(t *testing.T) {
 	teacherRepo.On("GetByID", mock.Anything, teacherID).Return(&entity.Teacher{ID: teacherID, IsActive: true}, nil)
 	dormRepo.On("GetByID", mock.Anything, dormID).Return(&entity.Dormitory{ID: dormID}, nil)
 	slotRepo.On("GetByID", mock.Anything, slotID).Return(&entity.ScheduleSlot{ID: slotID, DormitoryID: dormID, IsActive: true, StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)}, nil)
+	scheduleRepo.On("ListConflictCandidates", mock.Anything, mock.Anything).Return([]*entity.ClassSchedule{}, nil)
 	scheduleRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *entity.ClassSchedule) bool {
 		return s.ClassID == classID && s.TeacherID == teacherID && s.SlotID != nil && *s.SlotID == slotID
 	})).Return(nil)
@@ -70,6 +75,9 @@ func TestClassScheduleUseCase_Create_ManualTime(t *testing.T) {
 	classRepo.On("GetByID", mock.Anything, classID).Return(&entity.Class{ID: classID}, nil)
 	teacherRepo.On("GetByID", mock.Anything, teacherID).Return(&entity.Teacher{ID: teacherID, IsActive: true}, nil)
 	dormRepo.On("GetByID", mock.Anything, dormID).Return(&entity.Dormitory{ID: dormID}, nil)
+	scheduleRepo.On("ListConflictCandidates", mock.Anything, mock.MatchedBy(func(filter repository.ClassScheduleConflictFilter) bool {
+		return filter.DayOfWeek == "tue" && filter.TeacherID == teacherID && filter.ClassID == classID
+	})).Return([]*entity.ClassSchedule{}, nil)
 	scheduleRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *entity.ClassSchedule) bool {
 		return s.SlotID == nil && s.StartTime != nil && s.EndTime != nil
 	})).Return(nil)
@@ -173,6 +181,137 @@ func TestClassScheduleUseCase_Create_InvalidTeacher(t *testing.T) {
 	assert.ErrorIs(t, err, domainErrors.ErrTeacherNotFound)
 }
 
+func TestClassScheduleUseCase_Create_Conflict(t *testing.T) {
+	uc, scheduleRepo, classRepo, teacherRepo, _, _, dormRepo := newClassScheduleUCForTest()
+	classID := uuid.New()
+	teacherID := uuid.New()
+	dormID := uuid.New()
+
+	classRepo.On("GetByID", mock.Anything, classID).Return(&entity.Class{ID: classID}, nil)
+	teacherRepo.On("GetByID", mock.Anything, teacherID).Return(&entity.Teacher{ID: teacherID, IsActive: true}, nil)
+	dormRepo.On("GetByID", mock.Anything, dormID).Return(&entity.Dormitory{ID: dormID}, nil)
+
+	// Existing lesson on another date but overlapping wall-clock window (08:30-09:30 vs 08:00-09:00)
+	existingStart := time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC)
+	existingEnd := existingStart.Add(time.Hour)
+	laterStart := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	laterEnd := laterStart.Add(time.Hour)
+	existing := &entity.ClassSchedule{ID: uuid.New(), ClassID: uuid.New(), TeacherID: teacherID, DormitoryID: dormID, DayOfWeek: "mon", StartTime: &existingStart, EndTime: &existingEnd, Location: "Hall", IsActive: true}
+	nonOverlapping := &entity.ClassSchedule{ID: uuid.New(), ClassID: classID, TeacherID: uuid.New(), DormitoryID: dormID, DayOfWeek: "mon", StartTime: &laterStart, EndTime: &laterEnd, IsActive: true}
+	scheduleRepo.On("ListConflictCandidates", mock.Anything, mock.Anything).Return([]*entity.ClassSchedule{existing, nonOverlapping}, nil)
+
+	start := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	end := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	_, err := uc.CreateClassSchedule(context.Background(), dto.CreateClassScheduleRequest{
+		ClassID:     classID.String(),
+		DormitoryID: dormID.String(),
+		TeacherID:   teacherID.String(),
+		DayOfWeek:   "mon",
+		StartTime:   &start,
+		EndTime:     &end,
+		Location:    " hall ",
+	})
+	assert.ErrorIs(t, err, domainErrors.ErrClassScheduleConflict)
+
+	var conflictErr *ClassScheduleConflictError
+	if assert.ErrorAs(t, err, &conflictErr) {
+		assert.Len(t, conflictErr.Conflicts, 1)
+		assert.Equal(t, existing.ID.String(), conflictErr.Conflicts[0].Schedule.ID)
+		assert.Equal(t, []string{ClassScheduleConflictTeacher, ClassScheduleConflictLocation}, conflictErr.Conflicts[0].Reasons)
+	}
+	scheduleRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestClassScheduleUseCase_Update_Conflict(t *testing.T) {
+	uc, scheduleRepo, _, _, _, _, _ := newClassScheduleUCForTest()
+	scheduleID := uuid.New()
+	classID := uuid.New()
+	slotID := uuid.New()
+
+	scheduleRepo.On("GetByID", mock.Anything, scheduleID).Return(&entity.ClassSchedule{
+		ID:        scheduleID,
+		ClassID:   classID,
+		TeacherID: uuid.New(),
+		SlotID:    &slotID,
+		DayOfWeek: "wed",
+		IsActive:  true,
+	}, nil)
+	other := &entity.ClassSchedule{ID: uuid.New(), ClassID: classID, TeacherID: uuid.New(), SlotID: &slotID, DayOfWeek: "thu", IsActive: true}
+	scheduleRepo.On("ListConflictCandidates", mock.Anything, mock.MatchedBy(func(filter repository.ClassScheduleConflictFilter) bool {
+		return filter.ExcludeID == scheduleID && filter.DayOfWeek == "thu"
+	})).Return([]*entity.ClassSchedule{other}, nil)
+
+	_, err := uc.UpdateClassSchedule(context.Background(), scheduleID, dto.UpdateClassScheduleRequest{DayOfWeek: stringPtrCS("thu")})
+	assert.ErrorIs(t, err, domainErrors.ErrClassScheduleConflict)
+	scheduleRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestClassScheduleUseCase_BulkCreate(t *testing.T) {
+	uc, scheduleRepo, classRepo, teacherRepo, _, slotRepo, dormRepo := newClassScheduleUCForTest()
+	classA, classB := uuid.New(), uuid.New()
+	teacherA, teacherB := uuid.New(), uuid.New()
+	dormID := uuid.New()
+	slotID := uuid.New()
+
+	classRepo.On("GetByID", mock.Anything, mock.Anything).Return(&entity.Class{}, nil)
+	teacherRepo.On("GetByID", mock.Anything, mock.Anything).Return(&entity.Teacher{IsActive: true}, nil)
+	dormRepo.On("GetByID", mock.Anything, dormID).Return(&entity.Dormitory{ID: dormID}, nil)
+	slotRepo.On("GetByID", mock.Anything, slotID).Return(&entity.ScheduleSlot{ID: slotID, DormitoryID: dormID, IsActive: true, StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)}, nil)
+
+	newReq := func(classID, teacherID uuid.UUID) dto.CreateClassScheduleRequest {
+		return dto.CreateClassScheduleRequest{
+			ClassID:     classID.String(),
+			DormitoryID: dormID.String(),
+			TeacherID:   teacherID.String(),
+			SlotID:      stringPtrCS(slotID.String()),
+			DayOfWeek:   "mon",
+		}
+	}
+
+	t.Run("reports every conflict", func(t *testing.T) {
+		stored := &entity.ClassSchedule{ID: uuid.New(), ClassID: uuid.New(), TeacherID: teacherB, SlotID: &slotID, DayOfWeek: "mon", IsActive: true}
+		scheduleRepo.On("ListConflictCandidates", mock.Anything, mock.MatchedBy(func(filter repository.ClassScheduleConflictFilter) bool {
+			return filter.TeacherID == teacherB
+		})).Return([]*entity.ClassSchedule{stored}, nil).Once()
+		scheduleRepo.On("ListConflictCandidates", mock.Anything, mock.Anything).Return([]*entity.ClassSchedule{}, nil).Twice()
+
+		_, err := uc.BulkCreateClassSchedules(context.Background(), dto.BulkCreateClassSchedulesRequest{
+			Schedules: []dto.CreateClassScheduleRequest{
+				newReq(classA, teacherA),
+				newReq(classA, teacherB),
+			},
+		})
+
+		var conflictErr *ClassScheduleConflictError
+		if assert.ErrorAs(t, err, &conflictErr) {
+			assert.Len(t, conflictErr.Conflicts, 2)
+			for _, conflict := range conflictErr.Conflicts {
+				assert.Equal(t, 1, *conflict.Index)
+			}
+			assert.Equal(t, stored.ID.String(), conflictErr.Conflicts[0].Schedule.ID)
+			assert.Equal(t, 0, *conflictErr.Conflicts[1].ConflictingIndex)
+			assert.Equal(t, []string{ClassScheduleConflictClass}, conflictErr.Conflicts[1].Reasons)
+		}
+		scheduleRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates all schedules", func(t *testing.T) {
+		scheduleRepo.On("ListConflictCandidates", mock.Anything, mock.Anything).Return([]*entity.ClassSchedule{}, nil)
+		scheduleRepo.On("CreateBatch", mock.Anything, mock.MatchedBy(func(schedules []*entity.ClassSchedule) bool {
+			return len(schedules) == 2
+		})).Return(nil).Once()
+
+		resp, err := uc.BulkCreateClassSchedules(context.Background(), dto.BulkCreateClassSchedulesRequest{
+			Schedules: []dto.CreateClassScheduleRequest{
+				newReq(classA, teacherA),
+				newReq(classB, teacherB),
+			},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Schedules, 2)
+	})
+}
+
 func stringPtrCS(val string) *string {
 	return &val
 }
@@ -116,6 +116,7 @@ func (uc *ClassUseCase) UpdateClass(ctx context.Context, id uuid.UUID, req dto.U
 	if err != nil {
 		return nil, domainErrors.ErrClassNotFound
 	}
+	before := *class
 
 	if req.Name != nil {
 		class.Name = *req.Name
@@ -132,7 +133,7 @@ func (uc *ClassUseCase) UpdateClass(ctx context.Context, id uuid.UUID, req dto.U
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "class", "class:update", class.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "class", "class:update", class.ID.String(), &before, class, map[string]string{
 		"name": class.Name,
 	})
 
@@ -141,7 +142,8 @@ func (uc *ClassUseCase) UpdateClass(ctx context.Context, id uuid.UUID, req dto.U
 
 // DeleteClass deletes a class by ID.
 func (uc *ClassUseCase) DeleteClass(ctx context.Context, id uuid.UUID) error {
-	if _, err := uc.classRepo.GetByID(ctx, id); err != nil {
+	class, err := uc.classRepo.GetByID(ctx, id)
+	if err != nil {
 		return domainErrors.ErrClassNotFound
 	}
 
@@ -149,7 +151,7 @@ func (uc *ClassUseCase) DeleteClass(ctx context.Context, id uuid.UUID) error {
 		return domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "class", "class:delete", id.String(), nil)
+	_ = uc.auditLogger.LogChange(ctx, "class", "class:delete", id.String(), class, nil, nil)
 	return nil
 }
 
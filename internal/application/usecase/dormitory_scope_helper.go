@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// scopedDormitoryIDs returns the dormitories list queries must be restricted to.
+// A nil result means the actor is unrestricted; a non-nil empty slice matches nothing.
+func scopedDormitoryIDs(ctx context.Context) []uuid.UUID {
+	scope := appService.DormitoryScopeFromContext(ctx)
+	if scope.All {
+		return nil
+	}
+	return append([]uuid.UUID{}, scope.DormitoryIDs...)
+}
+
+// resolveDormitoryFilter combines an explicitly requested dormitory with the actor's scope.
+// It returns the requested dormitory when allowed, otherwise the scope restriction.
+func resolveDormitoryFilter(ctx context.Context, requested *uuid.UUID) (*uuid.UUID, []uuid.UUID, error) {
+	if requested != nil {
+		if err := ensureDormitoryInScope(ctx, *requested); err != nil {
+			return nil, nil, err
+		}
+		return requested, nil, nil
+	}
+	return nil, scopedDormitoryIDs(ctx), nil
+}
+
+// ensureDormitoryInScope rejects dormitories outside the actor's scope.
+func ensureDormitoryInScope(ctx context.Context, dormitoryID uuid.UUID) error {
+	if !appService.DormitoryScopeFromContext(ctx).Allows(dormitoryID) {
+		return domainErrors.ErrDormitoryAccessDenied
+	}
+	return nil
+}
+
+// ensureStudentInScope rejects students whose current dormitory is outside the actor's scope.
+// Students without an active dormitory placement are only reachable by unrestricted actors.
+func ensureStudentInScope(ctx context.Context, studentRepo repository.StudentRepository, studentID uuid.UUID) error {
+	scope := appService.DormitoryScopeFromContext(ctx)
+	if scope.All {
+		return nil
+	}
+
+	history, err := studentRepo.GetActiveHistory(ctx, studentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainErrors.ErrDormitoryAccessDenied
+		}
+		return domainErrors.ErrInternalServer
+	}
+	if history == nil || !scope.Allows(history.DormitoryID) {
+		return domainErrors.ErrDormitoryAccessDenied
+	}
+	return nil
+}
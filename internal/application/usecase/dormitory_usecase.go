@@ -10,13 +10,15 @@ import (
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
 // DormitoryUseCase handles dormitory management use cases
 type DormitoryUseCase struct {
-	dormitoryRepo repository.DormitoryRepository
-	userRepo      repository.UserRepository
-	auditLogger   appService.AuditLogger
+	dormitoryRepo  repository.DormitoryRepository
+	userRepo       repository.UserRepository
+	auditLogger    appService.AuditLogger
+	principalCache service.PrincipalCache
 }
 
 // NewDormitoryUseCase creates a new dormitory use case
@@ -24,11 +26,13 @@ func NewDormitoryUseCase(
 	dormitoryRepo repository.DormitoryRepository,
 	userRepo repository.UserRepository,
 	auditLogger appService.AuditLogger,
+	principalCache service.PrincipalCache,
 ) *DormitoryUseCase {
 	return &DormitoryUseCase{
-		dormitoryRepo: dormitoryRepo,
-		userRepo:      userRepo,
-		auditLogger:   auditLogger,
+		dormitoryRepo:  dormitoryRepo,
+		userRepo:       userRepo,
+		auditLogger:    auditLogger,
+		principalCache: principalCache,
 	}
 }
 
@@ -75,6 +79,7 @@ func (uc *DormitoryUseCase) UpdateDormitory(ctx context.Context, id uuid.UUID, r
 	if err != nil {
 		return nil, domainErrors.ErrDormitoryNotFound
 	}
+	before := *dormitory
 
 	if req.Name != "" {
 		dormitory.Name = req.Name
@@ -100,9 +105,11 @@ func (uc *DormitoryUseCase) UpdateDormitory(ctx context.Context, id uuid.UUID, r
 	if err := uc.dormitoryRepo.Update(ctx, dormitory); err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
+	// Members' principals embed the dormitory
+	invalidateAllPrincipals(ctx, uc.principalCache)
 
 	// Audit log (best-effort)
-	_ = uc.auditLogger.Log(ctx, "dormitory", "dorm:update", dormitory.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "dormitory", "dorm:update", dormitory.ID.String(), &before, dormitory, map[string]string{
 		"name":        dormitory.Name,
 		"description": dormitory.Description,
 	})
@@ -120,9 +127,10 @@ func (uc *DormitoryUseCase) DeleteDormitory(ctx context.Context, id uuid.UUID) e
 	if err := uc.dormitoryRepo.Delete(ctx, id); err != nil {
 		return err
 	}
+	invalidateAllPrincipals(ctx, uc.principalCache)
 
 	// Audit log (best-effort)
-	_ = uc.auditLogger.Log(ctx, "dormitory", "dorm:delete", id.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "dormitory", "dorm:delete", id.String(), dormitory, nil, map[string]string{
 		"name":        dormitory.Name,
 		"description": dormitory.Description,
 	})
@@ -196,6 +204,7 @@ func (uc *DormitoryUseCase) AssignUser(ctx context.Context, dormitoryID, userID
 	if err := uc.dormitoryRepo.AssignToUser(ctx, userID, dormitoryID); err != nil {
 		return err
 	}
+	invalidatePrincipals(ctx, uc.principalCache, userID)
 
 	_ = uc.auditLogger.Log(ctx, "dormitory", "dorm:assign-user", dormitoryID.String(), map[string]string{
 		"user_id": userID.String(),
@@ -217,6 +226,7 @@ func (uc *DormitoryUseCase) RemoveUser(ctx context.Context, dormitoryID, userID
 	if err := uc.dormitoryRepo.RemoveFromUser(ctx, userID, dormitoryID); err != nil {
 		return err
 	}
+	invalidatePrincipals(ctx, uc.principalCache, userID)
 
 	_ = uc.auditLogger.Log(ctx, "dormitory", "dorm:remove-user", dormitoryID.String(), map[string]string{
 		"user_id": userID.String(),
@@ -45,7 +45,7 @@ func TestDormitoryUseCase_CreateDormitory(t *testing.T) {
 			tt.setupMocks(dormRepo)
 
 			auditLogger := &noopAuditLogger{}
-			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, nil)
 			resp, err := dormUseCase.CreateDormitory(context.Background(), tt.req)
 
 			if tt.expectedError != nil {
@@ -76,7 +76,7 @@ func TestDormitoryUseCase_AssignAndRemoveUser(t *testing.T) {
 		dormRepo.On("AssignToUser", mock.Anything, userID, dormitoryID).Return(nil)
 
 		auditLogger := &noopAuditLogger{}
-		dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+		dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, nil)
 
 		err := dormUseCase.AssignUser(context.Background(), dormitoryID, userID)
 		assert.NoError(t, err)
@@ -93,7 +93,7 @@ func TestDormitoryUseCase_AssignAndRemoveUser(t *testing.T) {
 		dormRepo.On("RemoveFromUser", mock.Anything, userID, dormitoryID).Return(nil)
 
 		auditLogger := &noopAuditLogger{}
-		dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+		dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, nil)
 
 		err := dormUseCase.RemoveUser(context.Background(), dormitoryID, userID)
 		assert.NoError(t, err)
@@ -145,7 +145,7 @@ func TestDormitoryUseCase_GetDormitoryByID(t *testing.T) {
 			tt.setupMocks(dormRepo)
 
 			auditLogger := &noopAuditLogger{}
-			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, nil)
 			resp, err := dormUseCase.GetDormitoryByID(context.Background(), tt.dormitoryID)
 
 			if tt.expectedError != nil {
@@ -214,7 +214,7 @@ func TestDormitoryUseCase_UpdateDormitory(t *testing.T) {
 			tt.setupMocks(dormRepo)
 
 			auditLogger := &noopAuditLogger{}
-			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, nil)
 			resp, err := dormUseCase.UpdateDormitory(context.Background(), tt.dormitoryID, tt.req)
 
 			if tt.expectedError != nil {
@@ -268,7 +268,7 @@ func TestDormitoryUseCase_DeleteDormitory(t *testing.T) {
 			tt.setupMocks(dormRepo)
 
 			auditLogger := &noopAuditLogger{}
-			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, nil)
 			err := dormUseCase.DeleteDormitory(context.Background(), tt.dormitoryID)
 
 			if tt.expectedError != nil {
@@ -313,7 +313,7 @@ func TestDormitoryUseCase_ListDormitories(t *testing.T) {
 			tt.setupMocks(dormRepo)
 
 			auditLogger := &noopAuditLogger{}
-			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, nil)
 			resp, err := dormUseCase.ListDormitories(context.Background(), tt.page, tt.pageSize)
 
 			if tt.expectedError != nil {
@@ -106,6 +106,7 @@ func (uc *FanUseCase) UpdateFan(ctx context.Context, id uuid.UUID, req dto.Updat
 	if err != nil {
 		return nil, domainErrors.ErrFanNotFound
 	}
+	before := *fan
 
 	if req.DormitoryID != nil {
 		dormitoryID, parseErr := uuid.Parse(*req.DormitoryID)
@@ -133,7 +134,7 @@ func (uc *FanUseCase) UpdateFan(ctx context.Context, id uuid.UUID, req dto.Updat
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "fan", "fan:update", fan.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "fan", "fan:update", fan.ID.String(), &before, fan, map[string]string{
 		"name": fan.Name,
 	})
 
@@ -142,7 +143,8 @@ func (uc *FanUseCase) UpdateFan(ctx context.Context, id uuid.UUID, req dto.Updat
 
 // DeleteFan removes a fan by ID.
 func (uc *FanUseCase) DeleteFan(ctx context.Context, id uuid.UUID) error {
-	if _, err := uc.fanRepo.GetByID(ctx, id); err != nil {
+	fan, err := uc.fanRepo.GetByID(ctx, id)
+	if err != nil {
 		return domainErrors.ErrFanNotFound
 	}
 
@@ -150,7 +152,7 @@ func (uc *FanUseCase) DeleteFan(ctx context.Context, id uuid.UUID) error {
 		return domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "fan", "fan:delete", id.String(), nil)
+	_ = uc.auditLogger.LogChange(ctx, "fan", "fan:delete", id.String(), fan, nil, nil)
 	return nil
 }
 
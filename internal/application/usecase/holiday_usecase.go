@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// HolidayUseCase manages the holiday calendar used by attendance generation.
+type HolidayUseCase struct {
+	holidayRepo repository.HolidayRepository
+	dormRepo    repository.DormitoryRepository
+	auditLogger appService.AuditLogger
+}
+
+// NewHolidayUseCase creates a new HolidayUseCase instance.
+func NewHolidayUseCase(holidayRepo repository.HolidayRepository, dormRepo repository.DormitoryRepository, auditLogger appService.AuditLogger) *HolidayUseCase {
+	return &HolidayUseCase{holidayRepo: holidayRepo, dormRepo: dormRepo, auditLogger: auditLogger}
+}
+
+// CreateHoliday registers a global or dormitory-specific holiday.
+func (uc *HolidayUseCase) CreateHoliday(ctx context.Context, req dto.CreateHolidayRequest) (*dto.HolidayResponse, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, domainErrors.ErrBadRequest
+	}
+
+	var dormID *uuid.UUID
+	if req.DormitoryID != nil {
+		parsed, err := uuid.Parse(*req.DormitoryID)
+		if err != nil {
+			return nil, domainErrors.ErrBadRequest
+		}
+		if _, err := uc.dormRepo.GetByID(ctx, parsed); err != nil {
+			return nil, domainErrors.ErrDormitoryNotFound
+		}
+		dormID = &parsed
+	}
+
+	now := time.Now()
+	holiday := &entity.Holiday{
+		ID:          uuid.New(),
+		DormitoryID: dormID,
+		Date:        date,
+		Name:        req.Name,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := uc.holidayRepo.Create(ctx, holiday); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	_ = uc.auditLogger.Log(ctx, "holiday", "holiday:create", holiday.ID.String(), map[string]string{
+		"date": req.Date,
+		"name": holiday.Name,
+	})
+
+	return toHolidayResponse(holiday), nil
+}
+
+// ListHolidays returns holidays within an inclusive date range.
+func (uc *HolidayUseCase) ListHolidays(ctx context.Context, startStr, endStr, dormitoryIDStr string) (*dto.ListHolidaysResponse, error) {
+	start, err := time.Parse("2006-01-02", startStr)
+	if err != nil {
+		return nil, domainErrors.ErrBadRequest
+	}
+	end, err := time.Parse("2006-01-02", endStr)
+	if err != nil || end.Before(start) {
+		return nil, domainErrors.ErrBadRequest
+	}
+
+	filter := repository.HolidayFilter{StartDate: start, EndDate: end}
+	if dormitoryIDStr != "" {
+		parsed, err := uuid.Parse(dormitoryIDStr)
+		if err != nil {
+			return nil, domainErrors.ErrBadRequest
+		}
+		filter.DormitoryID = &parsed
+	}
+
+	holidays, err := uc.holidayRepo.List(ctx, filter)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	responses := make([]dto.HolidayResponse, 0, len(holidays))
+	for _, holiday := range holidays {
+		responses = append(responses, *toHolidayResponse(holiday))
+	}
+	return &dto.ListHolidaysResponse{Holidays: responses}, nil
+}
+
+// DeleteHoliday removes a holiday.
+func (uc *HolidayUseCase) DeleteHoliday(ctx context.Context, id uuid.UUID) error {
+	holiday, err := uc.holidayRepo.GetByID(ctx, id)
+	if err != nil {
+		return domainErrors.ErrHolidayNotFound
+	}
+	if err := uc.holidayRepo.Delete(ctx, id); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	_ = uc.auditLogger.LogChange(ctx, "holiday", "holiday:delete", id.String(), holiday, nil, nil)
+	return nil
+}
+
+func toHolidayResponse(holiday *entity.Holiday) *dto.HolidayResponse {
+	var dormID *string
+	if holiday.DormitoryID != nil {
+		val := holiday.DormitoryID.String()
+		dormID = &val
+	}
+	return &dto.HolidayResponse{
+		ID:          holiday.ID.String(),
+		DormitoryID: dormID,
+		Date:        holiday.Date.Format("2006-01-02"),
+		Name:        holiday.Name,
+		CreatedAt:   holiday.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   holiday.UpdatedAt.Format(time.RFC3339),
+	}
+}
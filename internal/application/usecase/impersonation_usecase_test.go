@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+func newImpersonationUser(username, roleName string, permissions ...string) *entity.User {
+	role := entity.Role{Name: roleName}
+	for _, name := range permissions {
+		role.Permissions = append(role.Permissions, entity.Permission{Name: name})
+	}
+	return &entity.User{
+		ID:       uuid.New(),
+		Username: username,
+		IsActive: true,
+		Roles:    []entity.Role{role},
+	}
+}
+
+func TestImpersonationUseCase_Impersonate(t *testing.T) {
+	dormA := entity.Dormitory{ID: uuid.New(), Name: "Asrama A"}
+	dormB := entity.Dormitory{ID: uuid.New(), Name: "Asrama B"}
+
+	t.Run("issues a token when the admin covers the target", func(t *testing.T) {
+		admin := newImpersonationUser("admin", "admin", "*")
+		target := newImpersonationUser("secretary", "secretary", "student:read", "reports:*")
+		target.Dormitories = []entity.Dormitory{dormA}
+
+		userRepo := new(mocks.MockUserRepository)
+		tokenService := new(mocks.MockTokenService)
+		uc := NewImpersonationUseCase(userRepo, tokenService, &noopAuditLogger{})
+		userRepo.On("GetWithRolesAndDormitories", mock.Anything, target.ID).Return(target, nil)
+		tokenService.On("GenerateImpersonationToken", target.ID, target.Username, admin.ID, admin.Username).Return("imp-token", nil)
+
+		resp, err := uc.Impersonate(context.Background(), admin, target.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "imp-token", resp.AccessToken)
+		assert.Equal(t, target.ID.String(), resp.UserID)
+		assert.Equal(t, admin.Username, resp.ImpersonatorUsername)
+		assert.NotEmpty(t, resp.ExpiresAt)
+		tokenService.AssertExpectations(t)
+	})
+
+	t.Run("unknown target", func(t *testing.T) {
+		admin := newImpersonationUser("admin", "admin", "*")
+		targetID := uuid.New()
+		userRepo := new(mocks.MockUserRepository)
+		uc := NewImpersonationUseCase(userRepo, new(mocks.MockTokenService), &noopAuditLogger{})
+		userRepo.On("GetWithRolesAndDormitories", mock.Anything, targetID).Return(nil, domainErrors.ErrUserNotFound)
+
+		_, err := uc.Impersonate(context.Background(), admin, targetID)
+		assert.ErrorIs(t, err, domainErrors.ErrUserNotFound)
+	})
+
+	rejected := []struct {
+		name   string
+		admin  func() *entity.User
+		target func(admin *entity.User) *entity.User
+	}{
+		{
+			name:   "self",
+			admin:  func() *entity.User { return newImpersonationUser("admin", "admin", "*") },
+			target: func(admin *entity.User) *entity.User { return admin },
+		},
+		{
+			name:  "service account",
+			admin: func() *entity.User { return newImpersonationUser("admin", "admin", "*") },
+			target: func(*entity.User) *entity.User {
+				account := newImpersonationUser("kiosk", "kiosk", "student:read")
+				account.IsServiceAccount = true
+				return account
+			},
+		},
+		{
+			name:  "inactive user",
+			admin: func() *entity.User { return newImpersonationUser("admin", "admin", "*") },
+			target: func(*entity.User) *entity.User {
+				user := newImpersonationUser("former", "staff", "student:read")
+				user.IsActive = false
+				return user
+			},
+		},
+		{
+			name: "target holds a permission the admin lacks",
+			admin: func() *entity.User {
+				return newImpersonationUser("support", "support", "user:impersonate", "student:read")
+			},
+			target: func(*entity.User) *entity.User {
+				return newImpersonationUser("secretary", "secretary", "student:read", "user:delete")
+			},
+		},
+		{
+			name: "target covers a dormitory the admin cannot access",
+			admin: func() *entity.User {
+				support := newImpersonationUser("support", "support", "user:impersonate", "student:read")
+				support.Dormitories = []entity.Dormitory{dormA}
+				return support
+			},
+			target: func(*entity.User) *entity.User {
+				user := newImpersonationUser("staff", "staff", "student:read")
+				user.Dormitories = []entity.Dormitory{dormA, dormB}
+				return user
+			},
+		},
+		{
+			name:  "target has all-dormitory access the admin lacks",
+			admin: func() *entity.User { return newImpersonationUser("support", "support", "*") },
+			target: func(*entity.User) *entity.User {
+				return newImpersonationUser("other-admin", "admin", "student:read")
+			},
+		},
+	}
+
+	for _, tt := range rejected {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			admin := tt.admin()
+			target := tt.target(admin)
+			userRepo := new(mocks.MockUserRepository)
+			tokenService := new(mocks.MockTokenService)
+			uc := NewImpersonationUseCase(userRepo, tokenService, &noopAuditLogger{})
+			userRepo.On("GetWithRolesAndDormitories", mock.Anything, target.ID).Return(target, nil)
+
+			_, err := uc.Impersonate(context.Background(), admin, target.ID)
+			assert.ErrorIs(t, err, domainErrors.ErrImpersonationNotAllowed)
+			tokenService.AssertNotCalled(t, "GenerateImpersonationToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// Registration modes selectable with REGISTRATION_MODE.
+const (
+	RegistrationModeInvite = "invite"
+	RegistrationModeOpen   = "open"
+)
+
+const invitationTokenLength = 40
+
+// RegistrationPolicy controls how new users get an account
+type RegistrationPolicy struct {
+	// OpenRegistration keeps POST /api/auth/register available to anyone.
+	OpenRegistration bool
+	// InvitationTTL is how long an invitation token can be redeemed.
+	InvitationTTL time.Duration
+}
+
+// DefaultRegistrationPolicy returns the policy used when nothing is configured:
+// invitations only, valid for seven days.
+func DefaultRegistrationPolicy() RegistrationPolicy {
+	return RegistrationPolicy{InvitationTTL: 7 * 24 * time.Hour}
+}
+
+// LoadRegistrationPolicy reads REGISTRATION_MODE and INVITATION_TTL from the
+// environment, falling back to the defaults.
+func LoadRegistrationPolicy() RegistrationPolicy {
+	policy := DefaultRegistrationPolicy()
+	policy.OpenRegistration = strings.EqualFold(strings.TrimSpace(os.Getenv("REGISTRATION_MODE")), RegistrationModeOpen)
+	if v, err := time.ParseDuration(os.Getenv("INVITATION_TTL")); err == nil && v > 0 {
+		policy.InvitationTTL = v
+	}
+	return policy
+}
+
+// InvitationUseCase onboards users through admin-issued, single-use invitations
+type InvitationUseCase struct {
+	invitationRepo repository.InvitationRepository
+	userRepo       repository.UserRepository
+	roleRepo       repository.RoleRepository
+	dormitoryRepo  repository.DormitoryRepository
+	auditLogger    appService.AuditLogger
+	policy         RegistrationPolicy
+}
+
+// NewInvitationUseCase creates a new invitation use case
+func NewInvitationUseCase(
+	invitationRepo repository.InvitationRepository,
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	dormitoryRepo repository.DormitoryRepository,
+	auditLogger appService.AuditLogger,
+	policy RegistrationPolicy,
+) *InvitationUseCase {
+	return &InvitationUseCase{
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		dormitoryRepo:  dormitoryRepo,
+		auditLogger:    auditLogger,
+		policy:         policy,
+	}
+}
+
+// CreateInvitation issues an invitation for a username, role and optional
+// dormitories. The inviter must hold every permission of the role and access
+// to every dormitory, so invitations cannot hand out more than the inviter has.
+// The token is only part of this response.
+func (uc *InvitationUseCase) CreateInvitation(ctx context.Context, inviter *entity.User, req dto.CreateInvitationRequest) (*dto.CreateInvitationResponse, error) {
+	username := strings.ToLower(strings.TrimSpace(req.Username))
+	now := time.Now()
+
+	if existing, _ := uc.userRepo.GetByUsername(ctx, username); existing != nil {
+		return nil, domainErrors.ErrUserAlreadyExists
+	}
+	pending, err := uc.invitationRepo.HasPendingForUsername(ctx, username, now)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if pending {
+		return nil, domainErrors.ErrInvitationPending
+	}
+
+	roleID, err := uuid.Parse(req.RoleID)
+	if err != nil {
+		return nil, domainErrors.ErrRoleNotFound
+	}
+	role, err := uc.roleRepo.GetWithPermissions(ctx, roleID)
+	if err != nil || role == nil {
+		return nil, domainErrors.ErrRoleNotFound
+	}
+	rolePermissions := make([]string, 0, len(role.Permissions))
+	for _, perm := range role.Permissions {
+		rolePermissions = append(rolePermissions, perm.Name)
+	}
+	if !inviter.HasAllPermissions(rolePermissions...) {
+		return nil, domainErrors.ErrInvitationNotAllowed
+	}
+	// Admin roles see every dormitory, so only inviters with the same reach may hand them out
+	roleHolder := entity.User{Roles: []entity.Role{*role}}
+	if roleHolder.HasAllDormitoryAccess() && !inviter.HasAllDormitoryAccess() {
+		return nil, domainErrors.ErrInvitationNotAllowed
+	}
+
+	dormitoryIDs := make([]uuid.UUID, 0, len(req.DormitoryIDs))
+	seen := map[uuid.UUID]bool{}
+	for _, idStr := range req.DormitoryIDs {
+		dormitoryID, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, domainErrors.ErrDormitoryNotFound
+		}
+		if seen[dormitoryID] {
+			continue
+		}
+		if _, err := uc.dormitoryRepo.GetByID(ctx, dormitoryID); err != nil {
+			return nil, domainErrors.ErrDormitoryNotFound
+		}
+		if !inviter.CanAccessDormitory(dormitoryID) {
+			return nil, domainErrors.ErrInvitationNotAllowed
+		}
+		seen[dormitoryID] = true
+		dormitoryIDs = append(dormitoryIDs, dormitoryID)
+	}
+
+	token, err := randomString(apiKeyAlphabet, invitationTokenLength)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	invitation := &entity.Invitation{
+		ID:           uuid.New(),
+		Username:     username,
+		TokenHash:    entity.HashAPIKey(token),
+		RoleID:       role.ID,
+		DormitoryIDs: dormitoryIDs,
+		ExpiresAt:    now.Add(uc.policy.InvitationTTL),
+		CreatedBy:    &inviter.ID,
+		CreatedAt:    now,
+	}
+	if err := uc.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	invitation.Role = role
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "invitation", "invitation:create", invitation.ID.String(), map[string]string{
+		"username":   username,
+		"role":       role.Name,
+		"expires_at": invitation.ExpiresAt.Format(time.RFC3339),
+	})
+
+	return &dto.CreateInvitationResponse{InvitationResponse: toInvitationResponse(invitation), Token: token}, nil
+}
+
+// ListPendingInvitations returns invitations that can still be redeemed, newest first
+func (uc *InvitationUseCase) ListPendingInvitations(ctx context.Context) (*dto.ListInvitationsResponse, error) {
+	invitations, err := uc.invitationRepo.ListPending(ctx, time.Now())
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	items := make([]dto.InvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		items = append(items, toInvitationResponse(invitation))
+	}
+	return &dto.ListInvitationsResponse{Invitations: items}, nil
+}
+
+// RevokeInvitation cancels a pending invitation
+func (uc *InvitationUseCase) RevokeInvitation(ctx context.Context, id uuid.UUID) error {
+	invitation, err := uc.invitationRepo.GetByID(ctx, id)
+	if err != nil || !invitation.IsPending(time.Now()) {
+		return domainErrors.ErrInvitationNotFound
+	}
+	if err := uc.invitationRepo.Revoke(ctx, invitation.ID, time.Now()); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "invitation", "invitation:revoke", invitation.ID.String(), map[string]string{
+		"username": invitation.Username,
+	})
+	return nil
+}
+
+// AcceptInvitation redeems an invitation token, creating the active user with
+// the invited role and dormitories. The user then logs in normally, which also
+// takes them through 2FA enrolment when their role requires it.
+func (uc *InvitationUseCase) AcceptInvitation(ctx context.Context, req dto.AcceptInvitationRequest) (*dto.UserResponse, error) {
+	invitation, err := uc.invitationRepo.GetByTokenHash(ctx, entity.HashAPIKey(strings.TrimSpace(req.Token)))
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	now := time.Now()
+	if invitation == nil || !invitation.IsPending(now) {
+		return nil, domainErrors.ErrInvitationInvalid
+	}
+	if existing, _ := uc.userRepo.GetByUsername(ctx, invitation.Username); existing != nil {
+		return nil, domainErrors.ErrUserAlreadyExists
+	}
+	role, err := uc.roleRepo.GetByID(ctx, invitation.RoleID)
+	if err != nil || role == nil {
+		return nil, domainErrors.ErrInvitationInvalid
+	}
+
+	user := &entity.User{
+		ID:        uuid.New(),
+		Username:  invitation.Username,
+		Password:  req.Password,
+		Name:      strings.TrimSpace(req.Name),
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := user.HashPassword(); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	user.Roles = []entity.Role{*role}
+
+	dormitoryIDs := make([]uuid.UUID, 0, len(invitation.DormitoryIDs))
+	dormitories := make([]dto.UserDormitorySummary, 0, len(invitation.DormitoryIDs))
+	for _, dormitoryID := range invitation.DormitoryIDs {
+		dormitory, err := uc.dormitoryRepo.GetByID(ctx, dormitoryID)
+		if err != nil {
+			// Deleted since the invitation was issued
+			continue
+		}
+		dormitoryIDs = append(dormitoryIDs, dormitory.ID)
+		dormitories = append(dormitories, dto.UserDormitorySummary{ID: dormitory.ID.String(), Name: dormitory.Name})
+	}
+
+	// Claim the token, create the user and assign the dormitories together, so a
+	// failure leaves the invitation pending and the invitee can retry
+	accepted, err := uc.invitationRepo.Accept(ctx, invitation.ID, user, dormitoryIDs, now)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if !accepted {
+		return nil, domainErrors.ErrInvitationInvalid
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "invitation", "invitation:accept", invitation.ID.String(), map[string]string{
+		"username": user.Username,
+		"user_id":  user.ID.String(),
+		"role":     role.Name,
+	})
+
+	return &dto.UserResponse{
+		ID:          user.ID.String(),
+		Username:    user.Username,
+		Name:        user.Name,
+		IsActive:    user.IsActive,
+		Roles:       []string{role.Name},
+		Dormitories: dormitories,
+		CreatedAt:   user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   user.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func toInvitationResponse(invitation *entity.Invitation) dto.InvitationResponse {
+	dormitoryIDs := make([]string, 0, len(invitation.DormitoryIDs))
+	for _, id := range invitation.DormitoryIDs {
+		dormitoryIDs = append(dormitoryIDs, id.String())
+	}
+	roleName := ""
+	if invitation.Role != nil {
+		roleName = invitation.Role.Name
+	}
+	return dto.InvitationResponse{
+		ID:           invitation.ID.String(),
+		Username:     invitation.Username,
+		RoleID:       invitation.RoleID.String(),
+		RoleName:     roleName,
+		DormitoryIDs: dormitoryIDs,
+		ExpiresAt:    invitation.ExpiresAt.Format(time.RFC3339),
+		CreatedBy:    uuidPtrToString(invitation.CreatedBy),
+		CreatedAt:    invitation.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+func newTestInvitationUseCase() (*InvitationUseCase, *mocks.MockInvitationRepository, *mocks.MockUserRepository, *mocks.MockRoleRepository, *mocks.MockDormitoryRepository) {
+	invitationRepo := new(mocks.MockInvitationRepository)
+	userRepo := new(mocks.MockUserRepository)
+	roleRepo := new(mocks.MockRoleRepository)
+	dormitoryRepo := new(mocks.MockDormitoryRepository)
+	uc := NewInvitationUseCase(invitationRepo, userRepo, roleRepo, dormitoryRepo, &noopAuditLogger{}, DefaultRegistrationPolicy())
+	return uc, invitationRepo, userRepo, roleRepo, dormitoryRepo
+}
+
+func TestInvitationUseCase_CreateInvitation(t *testing.T) {
+	role := &entity.Role{ID: uuid.New(), Name: "secretary", Permissions: []entity.Permission{{Name: "student:read"}}}
+	dorm := &entity.Dormitory{ID: uuid.New(), Name: "Asrama A"}
+
+	t.Run("issues a hashed, expiring token", func(t *testing.T) {
+		uc, invitationRepo, userRepo, roleRepo, dormitoryRepo := newTestInvitationUseCase()
+		inviter := newImpersonationUser("admin", "admin", "*")
+		userRepo.On("GetByUsername", mock.Anything, "newstaff").Return(nil, domainErrors.ErrUserNotFound)
+		invitationRepo.On("HasPendingForUsername", mock.Anything, "newstaff", mock.Anything).Return(false, nil)
+		roleRepo.On("GetWithPermissions", mock.Anything, role.ID).Return(role, nil)
+		dormitoryRepo.On("GetByID", mock.Anything, dorm.ID).Return(dorm, nil)
+		var stored *entity.Invitation
+		invitationRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*entity.Invitation)
+		}).Return(nil)
+
+		resp, err := uc.CreateInvitation(context.Background(), inviter, dto.CreateInvitationRequest{
+			Username:     " NewStaff ",
+			RoleID:       role.ID.String(),
+			DormitoryIDs: []string{dorm.ID.String(), dorm.ID.String()},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, "newstaff", resp.Username)
+		assert.Equal(t, []string{dorm.ID.String()}, resp.DormitoryIDs)
+		assert.Equal(t, entity.HashAPIKey(resp.Token), stored.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(DefaultRegistrationPolicy().InvitationTTL), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("rejects a role with permissions the inviter lacks", func(t *testing.T) {
+		uc, invitationRepo, userRepo, roleRepo, _ := newTestInvitationUseCase()
+		inviter := newImpersonationUser("staff", "staff", "user:create")
+		userRepo.On("GetByUsername", mock.Anything, "newstaff").Return(nil, domainErrors.ErrUserNotFound)
+		invitationRepo.On("HasPendingForUsername", mock.Anything, "newstaff", mock.Anything).Return(false, nil)
+		roleRepo.On("GetWithPermissions", mock.Anything, role.ID).Return(role, nil)
+
+		_, err := uc.CreateInvitation(context.Background(), inviter, dto.CreateInvitationRequest{Username: "newstaff", RoleID: role.ID.String()})
+		assert.ErrorIs(t, err, domainErrors.ErrInvitationNotAllowed)
+		invitationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a username with a pending invitation", func(t *testing.T) {
+		uc, invitationRepo, userRepo, _, _ := newTestInvitationUseCase()
+		userRepo.On("GetByUsername", mock.Anything, "newstaff").Return(nil, domainErrors.ErrUserNotFound)
+		invitationRepo.On("HasPendingForUsername", mock.Anything, "newstaff", mock.Anything).Return(true, nil)
+
+		_, err := uc.CreateInvitation(context.Background(), newImpersonationUser("admin", "admin", "*"), dto.CreateInvitationRequest{Username: "newstaff", RoleID: role.ID.String()})
+		assert.ErrorIs(t, err, domainErrors.ErrInvitationPending)
+	})
+}
+
+func TestInvitationUseCase_AcceptInvitation(t *testing.T) {
+	role := &entity.Role{ID: uuid.New(), Name: "secretary"}
+	newInvitation := func(expiresAt time.Time) *entity.Invitation {
+		return &entity.Invitation{ID: uuid.New(), Username: "newstaff", TokenHash: entity.HashAPIKey("token"), RoleID: role.ID, ExpiresAt: expiresAt}
+	}
+	req := dto.AcceptInvitationRequest{Token: "token", Name: "New Staff", Password: "password123"}
+
+	t.Run("creates the user with the invited role", func(t *testing.T) {
+		uc, invitationRepo, userRepo, roleRepo, _ := newTestInvitationUseCase()
+		invitation := newInvitation(time.Now().Add(time.Hour))
+		invitationRepo.On("GetByTokenHash", mock.Anything, entity.HashAPIKey("token")).Return(invitation, nil)
+		userRepo.On("GetByUsername", mock.Anything, "newstaff").Return(nil, domainErrors.ErrUserNotFound)
+		roleRepo.On("GetByID", mock.Anything, role.ID).Return(role, nil)
+		invitationRepo.On("Accept", mock.Anything, invitation.ID, mock.MatchedBy(func(u *entity.User) bool {
+			return u.Username == "newstaff" && u.IsActive && len(u.Roles) == 1 && u.CheckPassword("password123")
+		}), []uuid.UUID{}, mock.Anything).Return(true, nil)
+
+		resp, err := uc.AcceptInvitation(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "New Staff", resp.Name)
+		assert.Equal(t, []string{"secretary"}, resp.Roles)
+		invitationRepo.AssertExpectations(t)
+	})
+
+	t.Run("skips dormitories deleted since the invitation was issued", func(t *testing.T) {
+		uc, invitationRepo, userRepo, roleRepo, dormitoryRepo := newTestInvitationUseCase()
+		invitation := newInvitation(time.Now().Add(time.Hour))
+		dorm := &entity.Dormitory{ID: uuid.New(), Name: "Asrama A"}
+		deleted := uuid.New()
+		invitation.DormitoryIDs = []uuid.UUID{dorm.ID, deleted}
+		invitationRepo.On("GetByTokenHash", mock.Anything, entity.HashAPIKey("token")).Return(invitation, nil)
+		userRepo.On("GetByUsername", mock.Anything, "newstaff").Return(nil, domainErrors.ErrUserNotFound)
+		roleRepo.On("GetByID", mock.Anything, role.ID).Return(role, nil)
+		dormitoryRepo.On("GetByID", mock.Anything, dorm.ID).Return(dorm, nil)
+		dormitoryRepo.On("GetByID", mock.Anything, deleted).Return(nil, domainErrors.ErrDormitoryNotFound)
+		invitationRepo.On("Accept", mock.Anything, invitation.ID, mock.Anything, []uuid.UUID{dorm.ID}, mock.Anything).Return(true, nil)
+
+		resp, err := uc.AcceptInvitation(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, []dto.UserDormitorySummary{{ID: dorm.ID.String(), Name: "Asrama A"}}, resp.Dormitories)
+		invitationRepo.AssertExpectations(t)
+	})
+
+	t.Run("reports a failed acceptance", func(t *testing.T) {
+		uc, invitationRepo, userRepo, roleRepo, _ := newTestInvitationUseCase()
+		invitation := newInvitation(time.Now().Add(time.Hour))
+		invitationRepo.On("GetByTokenHash", mock.Anything, entity.HashAPIKey("token")).Return(invitation, nil)
+		userRepo.On("GetByUsername", mock.Anything, "newstaff").Return(nil, domainErrors.ErrUserNotFound)
+		roleRepo.On("GetByID", mock.Anything, role.ID).Return(role, nil)
+		invitationRepo.On("Accept", mock.Anything, invitation.ID, mock.Anything, mock.Anything, mock.Anything).Return(false, assert.AnError)
+
+		_, err := uc.AcceptInvitation(context.Background(), req)
+		assert.ErrorIs(t, err, domainErrors.ErrInternalServer)
+	})
+
+	t.Run("rejects expired invitations", func(t *testing.T) {
+		uc, invitationRepo, userRepo, _, _ := newTestInvitationUseCase()
+		invitationRepo.On("GetByTokenHash", mock.Anything, entity.HashAPIKey("token")).Return(newInvitation(time.Now().Add(-time.Minute)), nil)
+
+		_, err := uc.AcceptInvitation(context.Background(), req)
+		assert.ErrorIs(t, err, domainErrors.ErrInvitationInvalid)
+		userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a token redeemed concurrently", func(t *testing.T) {
+		uc, invitationRepo, userRepo, roleRepo, _ := newTestInvitationUseCase()
+		invitation := newInvitation(time.Now().Add(time.Hour))
+		invitationRepo.On("GetByTokenHash", mock.Anything, entity.HashAPIKey("token")).Return(invitation, nil)
+		userRepo.On("GetByUsername", mock.Anything, "newstaff").Return(nil, domainErrors.ErrUserNotFound)
+		roleRepo.On("GetByID", mock.Anything, role.ID).Return(role, nil)
+		invitationRepo.On("Accept", mock.Anything, invitation.ID, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+		_, err := uc.AcceptInvitation(context.Background(), req)
+		assert.ErrorIs(t, err, domainErrors.ErrInvitationInvalid)
+	})
+}
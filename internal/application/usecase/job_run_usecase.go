@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// JobRunUseCase exposes the scheduler's run history.
+type JobRunUseCase struct {
+	runRepo repository.JobRunRepository
+}
+
+// NewJobRunUseCase creates a new JobRunUseCase instance.
+func NewJobRunUseCase(runRepo repository.JobRunRepository) *JobRunUseCase {
+	return &JobRunUseCase{runRepo: runRepo}
+}
+
+// ListJobRuns returns paginated job runs, newest first.
+func (uc *JobRunUseCase) ListJobRuns(ctx context.Context, req dto.ListJobRunsRequest) (*dto.ListJobRunsResponse, error) {
+	filter := repository.JobRunFilter{}
+
+	if req.JobName != nil && *req.JobName != "" {
+		filter.JobName = req.JobName
+	}
+
+	if req.Status != nil && *req.Status != "" {
+		status := entity.JobRunStatus(*req.Status)
+		switch status {
+		case entity.JobRunStatusRunning, entity.JobRunStatusSucceeded, entity.JobRunStatusFailed:
+			filter.Status = &status
+		default:
+			return nil, domainErrors.ErrBadRequest
+		}
+	}
+
+	page, pageSize := normalizePagination(req.Page, req.PageSize)
+	filter.Limit = pageSize
+	filter.Offset = (page - 1) * pageSize
+
+	runs, total, err := uc.runRepo.List(ctx, filter)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	responses := make([]dto.JobRunResponse, 0, len(runs))
+	for _, run := range runs {
+		responses = append(responses, toJobRunResponse(run))
+	}
+
+	return &dto.ListJobRunsResponse{
+		Runs:       responses,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: calcTotalPages(total, pageSize),
+	}, nil
+}
+
+func toJobRunResponse(run *entity.JobRun) dto.JobRunResponse {
+	var finishedAt *string
+	if run.FinishedAt != nil {
+		val := run.FinishedAt.Format(time.RFC3339)
+		finishedAt = &val
+	}
+	return dto.JobRunResponse{
+		ID:          run.ID.String(),
+		JobName:     run.JobName,
+		Status:      string(run.Status),
+		Holder:      run.Holder,
+		ScheduledAt: run.ScheduledAt.Format(time.RFC3339),
+		StartedAt:   run.StartedAt.Format(time.RFC3339),
+		FinishedAt:  finishedAt,
+		DurationMs:  run.DurationMs,
+		Message:     run.Message,
+		Error:       run.Error,
+	}
+}
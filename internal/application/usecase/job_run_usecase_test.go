@@ -0,0 +1,52 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+func TestJobRunUseCase_ListJobRuns(t *testing.T) {
+	runRepo := new(mocks.JobRunRepositoryMock)
+	uc := usecase.NewJobRunUseCase(runRepo)
+
+	started := time.Date(2025, 1, 6, 23, 55, 0, 0, time.UTC)
+	finished := started.Add(2 * time.Second)
+	jobName := "lock_attendance_sessions"
+	status := "failed"
+
+	runRepo.On("List", mock.Anything, mock.MatchedBy(func(filter repository.JobRunFilter) bool {
+		return *filter.JobName == jobName && *filter.Status == entity.JobRunStatusFailed && filter.Limit == 20 && filter.Offset == 20
+	})).Return([]*entity.JobRun{
+		{ID: uuid.New(), JobName: jobName, Status: entity.JobRunStatusFailed, Holder: "api-1", ScheduledAt: started, StartedAt: started, FinishedAt: &finished, DurationMs: 2000, Error: "boom"},
+	}, int64(21), nil)
+
+	resp, err := uc.ListJobRuns(context.Background(), dto.ListJobRunsRequest{JobName: &jobName, Status: &status, Page: 2, PageSize: 20})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Runs, 1)
+	assert.Equal(t, "boom", resp.Runs[0].Error)
+	assert.Equal(t, "2025-01-06T23:55:02Z", *resp.Runs[0].FinishedAt)
+	assert.Equal(t, 2, resp.TotalPages)
+	runRepo.AssertExpectations(t)
+}
+
+func TestJobRunUseCase_ListJobRuns_InvalidStatus(t *testing.T) {
+	uc := usecase.NewJobRunUseCase(new(mocks.JobRunRepositoryMock))
+	status := "skipped"
+
+	_, err := uc.ListJobRuns(context.Background(), dto.ListJobRunsRequest{Status: &status})
+	assert.ErrorIs(t, err, domainErrors.ErrBadRequest)
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -69,6 +70,9 @@ func (uc *LeavePermitUseCase) CreateLeavePermit(ctx context.Context, req dto.Cre
 	if _, err := uc.studentRepo.GetByID(ctx, studentID); err != nil {
 		return nil, domainErrors.ErrStudentNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, studentID); err != nil {
+		return nil, err
+	}
 
 	actorID, err := requireActorID(ctx)
 	if err != nil {
@@ -112,7 +116,7 @@ func (uc *LeavePermitUseCase) CreateLeavePermit(ctx context.Context, req dto.Cre
 
 // ListLeavePermits returns paginated leave permits.
 func (uc *LeavePermitUseCase) ListLeavePermits(ctx context.Context, req dto.ListLeavePermitsRequest) (*dto.ListLeavePermitsResponse, error) {
-	filter := repository.LeavePermitFilter{}
+	filter := repository.LeavePermitFilter{DormitoryIDs: scopedDormitoryIDs(ctx)}
 
 	if req.StudentID != nil && *req.StudentID != "" {
 		studentID, err := uuid.Parse(*req.StudentID)
@@ -176,6 +180,9 @@ func (uc *LeavePermitUseCase) UpdateLeavePermitStatus(ctx context.Context, permi
 	if err != nil {
 		return nil, domainErrors.ErrLeavePermitNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, permit.StudentID); err != nil {
+		return nil, err
+	}
 
 	newStatus, err := parseLeavePermitStatus(req.Status)
 	if err != nil {
@@ -187,6 +194,7 @@ func (uc *LeavePermitUseCase) UpdateLeavePermitStatus(ctx context.Context, permi
 		return nil, err
 	}
 
+	before := *permit
 	now := time.Now()
 	switch newStatus {
 	case entity.LeavePermitStatusApproved:
@@ -218,7 +226,7 @@ func (uc *LeavePermitUseCase) UpdateLeavePermitStatus(ctx context.Context, permi
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "leave_permit", "leave_permit:update_status", permit.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "leave_permit", "leave_permit:update_status", permit.ID.String(), &before, permit, map[string]string{
 		"status": req.Status,
 	})
 
@@ -226,6 +234,24 @@ func (uc *LeavePermitUseCase) UpdateLeavePermitStatus(ctx context.Context, permi
 	return &resp, nil
 }
 
+// ExpireLeavePermits marks pending and approved permits that ended before asOf as expired.
+func (uc *LeavePermitUseCase) ExpireLeavePermits(ctx context.Context, asOf time.Time) (int64, error) {
+	date := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	expired, err := uc.leaveRepo.ExpireEndedBefore(ctx, date)
+	if err != nil {
+		return 0, domainErrors.ErrInternalServer
+	}
+
+	if expired > 0 {
+		_ = uc.auditLogger.Log(ctx, "leave_permit", "leave_permit:expire", "", map[string]string{
+			"ended_before": date.Format(isoDateLayout),
+			"count":        strconv.FormatInt(expired, 10),
+		})
+	}
+
+	return expired, nil
+}
+
 // GetActivePermitForDate returns active permit overlapping a date (attendance hook helper).
 func (uc *LeavePermitUseCase) GetActivePermitForDate(ctx context.Context, studentID uuid.UUID, date time.Time) (*entity.LeavePermit, error) {
 	permit, err := uc.leaveRepo.ActiveByDate(ctx, studentID, date)
@@ -265,6 +291,9 @@ func (uc *HealthStatusUseCase) CreateHealthStatus(ctx context.Context, req dto.C
 	if _, err := uc.studentRepo.GetByID(ctx, studentID); err != nil {
 		return nil, domainErrors.ErrStudentNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, studentID); err != nil {
+		return nil, err
+	}
 
 	actorID, err := requireActorID(ctx)
 	if err != nil {
@@ -305,7 +334,7 @@ func (uc *HealthStatusUseCase) CreateHealthStatus(ctx context.Context, req dto.C
 
 // ListHealthStatuses returns paginated health statuses.
 func (uc *HealthStatusUseCase) ListHealthStatuses(ctx context.Context, req dto.ListHealthStatusesRequest) (*dto.ListHealthStatusesResponse, error) {
-	filter := repository.HealthStatusFilter{}
+	filter := repository.HealthStatusFilter{DormitoryIDs: scopedDormitoryIDs(ctx)}
 
 	if req.StudentID != nil && *req.StudentID != "" {
 		studentID, err := uuid.Parse(*req.StudentID)
@@ -361,6 +390,9 @@ func (uc *HealthStatusUseCase) RevokeHealthStatus(ctx context.Context, statusID
 	if err != nil {
 		return nil, domainErrors.ErrHealthStatusNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, status.StudentID); err != nil {
+		return nil, err
+	}
 
 	if status.Status != entity.HealthStatusStateActive {
 		return nil, domainErrors.ErrHealthStatusForbidden
@@ -371,6 +403,7 @@ func (uc *HealthStatusUseCase) RevokeHealthStatus(ctx context.Context, statusID
 		return nil, err
 	}
 
+	before := *status
 	now := time.Now()
 	status.Status = entity.HealthStatusStateRevoked
 	status.RevokedBy = &actorID
@@ -385,7 +418,7 @@ func (uc *HealthStatusUseCase) RevokeHealthStatus(ctx context.Context, statusID
 	if req.Reason != "" {
 		metadata["reason"] = req.Reason
 	}
-	_ = uc.auditLogger.Log(ctx, "health_status", "health_status:revoke", status.ID.String(), metadata)
+	_ = uc.auditLogger.LogChange(ctx, "health_status", "health_status:revoke", status.ID.String(), &before, status, metadata)
 
 	resp := toHealthStatusResponse(status)
 	return &resp, nil
@@ -423,7 +456,8 @@ func parseLeavePermitStatus(status string) (entity.LeavePermitStatus, error) {
 	case entity.LeavePermitStatusPending,
 		entity.LeavePermitStatusApproved,
 		entity.LeavePermitStatusRejected,
-		entity.LeavePermitStatusCompleted:
+		entity.LeavePermitStatusCompleted,
+		entity.LeavePermitStatusExpired:
 		return entity.LeavePermitStatus(status), nil
 	default:
 		return "", errors.New("invalid leave permit status")
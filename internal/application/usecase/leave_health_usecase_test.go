@@ -24,6 +24,10 @@ func (leaveHealthAuditLogger) Log(ctx context.Context, resource, action, targetI
 	return nil
 }
 
+func (leaveHealthAuditLogger) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	return nil
+}
+
 func ctxWithActor() context.Context {
 	// Production code uses service.CtxKeyActorID (a string) as the context key, so we must
 	// match it in tests even though staticcheck warns about string keys.
@@ -95,6 +99,26 @@ func TestLeavePermitUseCase_CreateLeavePermit_Overlap(t *testing.T) {
 	studentRepo.AssertExpectations(t)
 }
 
+func TestLeavePermitUseCase_CreateLeavePermit_OutsideDormitoryScope(t *testing.T) {
+	ctx := service.WithDormitoryScope(ctxWithActor(), service.DormitoryScope{DormitoryIDs: []uuid.UUID{uuid.New()}})
+	uc, leaveRepo, studentRepo := newLeavePermitUseCase(t)
+	studentID := uuid.New()
+
+	studentRepo.On("GetByID", mock.Anything, studentID).Return(&entity.Student{ID: studentID}, nil)
+	studentRepo.On("GetActiveHistory", mock.Anything, studentID).Return(&entity.StudentDormitoryHistory{StudentID: studentID, DormitoryID: uuid.New()}, nil)
+
+	resp, err := uc.CreateLeavePermit(ctx, dto.CreateLeavePermitRequest{
+		StudentID: studentID.String(),
+		Type:      string(entity.LeavePermitTypeHomeLeave),
+		StartDate: "2025-11-01",
+		EndDate:   "2025-11-02",
+	})
+	assert.ErrorIs(t, err, domainErrors.ErrDormitoryAccessDenied)
+	assert.Nil(t, resp)
+	leaveRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	studentRepo.AssertExpectations(t)
+}
+
 func TestLeavePermitUseCase_UpdateLeavePermitStatus(t *testing.T) {
 	ctx := ctxWithActor()
 	uc, leaveRepo, studentRepo := newLeavePermitUseCase(t)
@@ -156,6 +180,20 @@ func TestLeavePermitUseCase_ListLeavePermits(t *testing.T) {
 	leaveRepo.AssertExpectations(t)
 }
 
+func TestLeavePermitUseCase_ListLeavePermits_DormitoryScope(t *testing.T) {
+	uc, leaveRepo, _ := newLeavePermitUseCase(t)
+	dormID := uuid.New()
+	ctx := service.WithDormitoryScope(context.Background(), service.DormitoryScope{DormitoryIDs: []uuid.UUID{dormID}})
+
+	leaveRepo.On("List", mock.Anything, mock.MatchedBy(func(filter repository.LeavePermitFilter) bool {
+		return len(filter.DormitoryIDs) == 1 && filter.DormitoryIDs[0] == dormID
+	})).Return([]*entity.LeavePermit{}, int64(0), nil)
+
+	_, err := uc.ListLeavePermits(ctx, dto.ListLeavePermitsRequest{Page: 1, PageSize: 10})
+	assert.NoError(t, err)
+	leaveRepo.AssertExpectations(t)
+}
+
 func TestLeavePermitUseCase_GetActivePermitForDate_NotFound(t *testing.T) {
 	uc, leaveRepo, _ := newLeavePermitUseCase(t)
 	leaveRepo.On("ActiveByDate", mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
@@ -166,6 +204,28 @@ func TestLeavePermitUseCase_GetActivePermitForDate_NotFound(t *testing.T) {
 	leaveRepo.AssertExpectations(t)
 }
 
+func TestLeavePermitUseCase_ExpireLeavePermits(t *testing.T) {
+	uc, leaveRepo, _ := newLeavePermitUseCase(t)
+	asOf := time.Date(2025, 1, 10, 0, 10, 0, 0, time.FixedZone("WIB", 7*3600))
+	leaveRepo.On("ExpireEndedBefore", mock.Anything, time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)).Return(int64(3), nil)
+
+	expired, err := uc.ExpireLeavePermits(context.Background(), asOf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), expired)
+	leaveRepo.AssertExpectations(t)
+}
+
+func TestLeavePermitUseCase_ListLeavePermits_ExpiredStatus(t *testing.T) {
+	uc, leaveRepo, _ := newLeavePermitUseCase(t)
+	leaveRepo.On("List", mock.Anything, mock.MatchedBy(func(filter repository.LeavePermitFilter) bool {
+		return filter.Status != nil && *filter.Status == entity.LeavePermitStatusExpired
+	})).Return([]*entity.LeavePermit{}, int64(0), nil)
+
+	_, err := uc.ListLeavePermits(context.Background(), dto.ListLeavePermitsRequest{Status: stringPtrLH("expired")})
+	assert.NoError(t, err)
+	leaveRepo.AssertExpectations(t)
+}
+
 // ----- Health status tests -----
 
 func TestHealthStatusUseCase_CreateHealthStatus_Success(t *testing.T) {
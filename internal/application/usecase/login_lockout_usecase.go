@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// LoginLockoutPolicy controls how failed logins lock out usernames and client IPs.
+type LoginLockoutPolicy struct {
+	// MaxAttempts is the number of failures per username before a lockout (LOGIN_MAX_ATTEMPTS, default 5).
+	MaxAttempts int
+	// IPMaxAttempts is the number of failures per client IP before a lockout (LOGIN_IP_MAX_ATTEMPTS, default 20).
+	IPMaxAttempts int
+	// Window forgets failures older than this (LOGIN_ATTEMPT_WINDOW, default 15m).
+	Window time.Duration
+	// LockoutDuration is the first lockout; each consecutive lockout doubles it (LOGIN_LOCKOUT_DURATION, default 15m).
+	LockoutDuration time.Duration
+	// MaxLockoutDuration caps progressive lockouts and resets them after a quiet period (LOGIN_LOCKOUT_MAX, default 24h).
+	MaxLockoutDuration time.Duration
+}
+
+// DefaultLoginLockoutPolicy returns the lockout policy used when nothing is configured.
+func DefaultLoginLockoutPolicy() LoginLockoutPolicy {
+	return LoginLockoutPolicy{
+		MaxAttempts:        5,
+		IPMaxAttempts:      20,
+		Window:             15 * time.Minute,
+		LockoutDuration:    15 * time.Minute,
+		MaxLockoutDuration: 24 * time.Hour,
+	}
+}
+
+// LoadLoginLockoutPolicy reads the lockout policy from the environment, falling back to the defaults.
+func LoadLoginLockoutPolicy() LoginLockoutPolicy {
+	policy := DefaultLoginLockoutPolicy()
+	if v, err := strconv.Atoi(os.Getenv("LOGIN_MAX_ATTEMPTS")); err == nil && v > 0 {
+		policy.MaxAttempts = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("LOGIN_IP_MAX_ATTEMPTS")); err == nil && v > 0 {
+		policy.IPMaxAttempts = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("LOGIN_ATTEMPT_WINDOW")); err == nil && v > 0 {
+		policy.Window = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("LOGIN_LOCKOUT_DURATION")); err == nil && v > 0 {
+		policy.LockoutDuration = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("LOGIN_LOCKOUT_MAX")); err == nil && v > 0 {
+		policy.MaxLockoutDuration = v
+	}
+	return policy
+}
+
+func (p LoginLockoutPolicy) threshold(kind string) int {
+	if kind == entity.LoginThrottleKindIP {
+		return p.IPMaxAttempts
+	}
+	return p.MaxAttempts
+}
+
+// lockoutDuration returns how long the nth consecutive lockout lasts.
+func (p LoginLockoutPolicy) lockoutDuration(lockouts int) time.Duration {
+	duration := p.LockoutDuration
+	for i := 1; i < lockouts && duration < p.MaxLockoutDuration; i++ {
+		duration *= 2
+	}
+	if duration > p.MaxLockoutDuration {
+		duration = p.MaxLockoutDuration
+	}
+	return duration
+}
+
+// registerFailure counts a failed login against throttle (nil for a first failure) and
+// reports whether it triggered a lockout.
+func (p LoginLockoutPolicy) registerFailure(throttle *entity.LoginThrottle, kind, value string, now time.Time) (*entity.LoginThrottle, bool) {
+	if throttle == nil {
+		throttle = &entity.LoginThrottle{ID: uuid.New(), Kind: kind, Value: value, CreatedAt: now}
+	}
+	if now.Sub(throttle.LastFailedAt) > p.MaxLockoutDuration {
+		throttle.Lockouts = 0
+	}
+	if now.Sub(throttle.LastFailedAt) > p.Window {
+		throttle.FailedAttempts = 0
+	}
+
+	throttle.FailedAttempts++
+	throttle.LastFailedAt = now
+	throttle.UpdatedAt = now
+
+	if throttle.FailedAttempts < p.threshold(kind) {
+		return throttle, false
+	}
+	throttle.Lockouts++
+	lockedUntil := now.Add(p.lockoutDuration(throttle.Lockouts))
+	throttle.LockedUntil = &lockedUntil
+	throttle.FailedAttempts = 0
+	return throttle, true
+}
+
+// LoginLockoutUseCase lets administrators review and lift login lockouts.
+type LoginLockoutUseCase struct {
+	throttleRepo repository.LoginThrottleRepository
+	auditLogger  appService.AuditLogger
+}
+
+// NewLoginLockoutUseCase creates a new login lockout use case.
+func NewLoginLockoutUseCase(throttleRepo repository.LoginThrottleRepository, auditLogger appService.AuditLogger) *LoginLockoutUseCase {
+	return &LoginLockoutUseCase{
+		throttleRepo: throttleRepo,
+		auditLogger:  auditLogger,
+	}
+}
+
+// ListLockouts returns usernames and IPs that are currently locked out.
+func (uc *LoginLockoutUseCase) ListLockouts(ctx context.Context) (*dto.ListLoginLockoutsResponse, error) {
+	throttles, err := uc.throttleRepo.ListLocked(ctx, time.Now())
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	items := make([]dto.LoginLockoutResponse, 0, len(throttles))
+	for _, throttle := range throttles {
+		items = append(items, toLoginLockoutResponse(throttle))
+	}
+	return &dto.ListLoginLockoutsResponse{Lockouts: items}, nil
+}
+
+// Unlock lifts a lockout and forgets the recorded failures.
+func (uc *LoginLockoutUseCase) Unlock(ctx context.Context, id uuid.UUID) error {
+	throttle, err := uc.throttleRepo.GetByID(ctx, id)
+	if err != nil {
+		return domainErrors.ErrLoginThrottleNotFound
+	}
+	if err := uc.throttleRepo.Delete(ctx, id); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, authEventResource, authEventUnlock, throttle.Value, map[string]string{
+		"kind":  throttle.Kind,
+		"value": throttle.Value,
+	})
+	return nil
+}
+
+func toLoginLockoutResponse(throttle *entity.LoginThrottle) dto.LoginLockoutResponse {
+	resp := dto.LoginLockoutResponse{
+		ID:           throttle.ID.String(),
+		Kind:         throttle.Kind,
+		Value:        throttle.Value,
+		Lockouts:     throttle.Lockouts,
+		LastFailedAt: throttle.LastFailedAt.Format(time.RFC3339),
+	}
+	if throttle.LockedUntil != nil {
+		resp.LockedUntil = throttle.LockedUntil.Format(time.RFC3339)
+	}
+	return resp
+}
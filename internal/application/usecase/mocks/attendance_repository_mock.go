@@ -53,8 +53,14 @@ func (m *AttendanceSessionRepositoryMock) List(ctx context.Context, filter repos
 	return sessions, total, args.Error(2)
 }
 
-func (m *AttendanceSessionRepositoryMock) LockSessionsByDate(ctx context.Context, date time.Time) error {
+func (m *AttendanceSessionRepositoryMock) ListScheduleIDsByDate(ctx context.Context, date time.Time) ([]uuid.UUID, error) {
 	args := m.Called(ctx, date)
+	ids, _ := args.Get(0).([]uuid.UUID)
+	return ids, args.Error(1)
+}
+
+func (m *AttendanceSessionRepositoryMock) LockSessionsByDate(ctx context.Context, date time.Time, dormitoryIDs []uuid.UUID) error {
+	args := m.Called(ctx, date, dormitoryIDs)
 	return args.Error(0)
 }
 
@@ -33,6 +33,12 @@ func (m *ClassRepositoryMock) ListByFan(ctx context.Context, fanID uuid.UUID, li
 	return classes, total, args.Error(2)
 }
 
+func (m *ClassRepositoryMock) ListByDormitory(ctx context.Context, dormitoryID uuid.UUID) ([]*entity.Class, error) {
+	args := m.Called(ctx, dormitoryID)
+	classes, _ := args.Get(0).([]*entity.Class)
+	return classes, args.Error(1)
+}
+
 func (m *ClassRepositoryMock) Update(ctx context.Context, class *entity.Class) error {
 	args := m.Called(ctx, class)
 	return args.Error(0)
@@ -21,6 +21,11 @@ func (m *ClassScheduleRepositoryMock) Create(ctx context.Context, schedule *enti
 	return args.Error(0)
 }
 
+func (m *ClassScheduleRepositoryMock) CreateBatch(ctx context.Context, schedules []*entity.ClassSchedule) error {
+	args := m.Called(ctx, schedules)
+	return args.Error(0)
+}
+
 func (m *ClassScheduleRepositoryMock) Update(ctx context.Context, schedule *entity.ClassSchedule) error {
 	args := m.Called(ctx, schedule)
 	return args.Error(0)
@@ -45,3 +50,15 @@ func (m *ClassScheduleRepositoryMock) Delete(ctx context.Context, id uuid.UUID)
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *ClassScheduleRepositoryMock) ListConflictCandidates(ctx context.Context, filter repository.ClassScheduleConflictFilter) ([]*entity.ClassSchedule, error) {
+	args := m.Called(ctx, filter)
+	schedules, _ := args.Get(0).([]*entity.ClassSchedule)
+	return schedules, args.Error(1)
+}
+
+func (m *ClassScheduleRepositoryMock) ListActiveByDay(ctx context.Context, dayOfWeek string, dormitoryID uuid.UUID) ([]*entity.ClassSchedule, error) {
+	args := m.Called(ctx, dayOfWeek, dormitoryID)
+	schedules, _ := args.Get(0).([]*entity.ClassSchedule)
+	return schedules, args.Error(1)
+}
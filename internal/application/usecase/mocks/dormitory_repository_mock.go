@@ -30,6 +30,14 @@ func (m *MockDormitoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*e
 	return args.Get(0).(*entity.Dormitory), args.Error(1)
 }
 
+func (m *MockDormitoryRepository) GetByCode(ctx context.Context, code string) (*entity.Dormitory, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Dormitory), args.Error(1)
+}
+
 func (m *MockDormitoryRepository) Update(ctx context.Context, dormitory *entity.Dormitory) error {
 	args := m.Called(ctx, dormitory)
 	return args.Error(0)
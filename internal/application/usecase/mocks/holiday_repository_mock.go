@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// HolidayRepositoryMock mocks the HolidayRepository interface.
+type HolidayRepositoryMock struct {
+	mock.Mock
+}
+
+var _ repository.HolidayRepository = (*HolidayRepositoryMock)(nil)
+
+func (m *HolidayRepositoryMock) Create(ctx context.Context, holiday *entity.Holiday) error {
+	args := m.Called(ctx, holiday)
+	return args.Error(0)
+}
+
+func (m *HolidayRepositoryMock) GetByID(ctx context.Context, id uuid.UUID) (*entity.Holiday, error) {
+	args := m.Called(ctx, id)
+	if holiday, ok := args.Get(0).(*entity.Holiday); ok {
+		return holiday, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *HolidayRepositoryMock) List(ctx context.Context, filter repository.HolidayFilter) ([]*entity.Holiday, error) {
+	args := m.Called(ctx, filter)
+	holidays, _ := args.Get(0).([]*entity.Holiday)
+	return holidays, args.Error(1)
+}
+
+func (m *HolidayRepositoryMock) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
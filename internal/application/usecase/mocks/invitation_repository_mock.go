@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// MockInvitationRepository is a mock implementation of InvitationRepository
+type MockInvitationRepository struct {
+	mock.Mock
+}
+
+// Ensure MockInvitationRepository implements repository.InvitationRepository
+var _ repository.InvitationRepository = (*MockInvitationRepository)(nil)
+
+func (m *MockInvitationRepository) Create(ctx context.Context, invitation *entity.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Invitation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) ListPending(ctx context.Context, now time.Time) ([]*entity.Invitation, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) HasPendingForUsername(ctx context.Context, username string, now time.Time) (bool, error) {
+	args := m.Called(ctx, username, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockInvitationRepository) Accept(ctx context.Context, id uuid.UUID, user *entity.User, dormitoryIDs []uuid.UUID, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, user, dormitoryIDs, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockInvitationRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
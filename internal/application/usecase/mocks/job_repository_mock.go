@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// JobRunRepositoryMock mocks repository.JobRunRepository.
+type JobRunRepositoryMock struct {
+	mock.Mock
+}
+
+var _ repository.JobRunRepository = (*JobRunRepositoryMock)(nil)
+
+func (m *JobRunRepositoryMock) Create(ctx context.Context, run *entity.JobRun) error {
+	return m.Called(ctx, run).Error(0)
+}
+
+func (m *JobRunRepositoryMock) Update(ctx context.Context, run *entity.JobRun) error {
+	return m.Called(ctx, run).Error(0)
+}
+
+func (m *JobRunRepositoryMock) List(ctx context.Context, filter repository.JobRunFilter) ([]*entity.JobRun, int64, error) {
+	args := m.Called(ctx, filter)
+	runs, _ := args.Get(0).([]*entity.JobRun)
+	var total int64
+	if v := args.Get(1); v != nil {
+		total = v.(int64)
+	}
+	return runs, total, args.Error(2)
+}
+
+// JobLeaseRepositoryMock mocks repository.JobLeaseRepository.
+type JobLeaseRepositoryMock struct {
+	mock.Mock
+}
+
+var _ repository.JobLeaseRepository = (*JobLeaseRepositoryMock)(nil)
+
+func (m *JobLeaseRepositoryMock) Acquire(ctx context.Context, jobName, holder string, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, jobName, holder, ttl)
+	return args.Bool(0), args.Error(1)
+}
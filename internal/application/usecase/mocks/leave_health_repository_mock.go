@@ -57,6 +57,15 @@ func (m *LeavePermitRepositoryMock) ActiveByDate(ctx context.Context, studentID
 	return nil, args.Error(1)
 }
 
+func (m *LeavePermitRepositoryMock) ExpireEndedBefore(ctx context.Context, date time.Time) (int64, error) {
+	args := m.Called(ctx, date)
+	var affected int64
+	if v := args.Get(0); v != nil {
+		affected = v.(int64)
+	}
+	return affected, args.Error(1)
+}
+
 // HealthStatusRepositoryMock mocks repository.HealthStatusRepository.
 type HealthStatusRepositoryMock struct {
 	mock.Mock
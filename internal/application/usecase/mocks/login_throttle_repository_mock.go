@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// MockLoginThrottleRepository is a mock implementation of LoginThrottleRepository
+type MockLoginThrottleRepository struct {
+	mock.Mock
+}
+
+// Ensure MockLoginThrottleRepository implements repository.LoginThrottleRepository
+var _ repository.LoginThrottleRepository = (*MockLoginThrottleRepository)(nil)
+
+func (m *MockLoginThrottleRepository) GetByKey(ctx context.Context, kind, value string) (*entity.LoginThrottle, error) {
+	args := m.Called(ctx, kind, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.LoginThrottle, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) Save(ctx context.Context, throttle *entity.LoginThrottle) error {
+	args := m.Called(ctx, throttle)
+	return args.Error(0)
+}
+
+func (m *MockLoginThrottleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLoginThrottleRepository) ListLocked(ctx context.Context, now time.Time) ([]*entity.LoginThrottle, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.LoginThrottle), args.Error(1)
+}
@@ -37,6 +37,14 @@ func (m *PermissionRepositoryMock) GetBySlug(ctx context.Context, slug string) (
 	return nil, args.Error(1)
 }
 
+func (m *PermissionRepositoryMock) GetByName(ctx context.Context, name string) (*entity.Permission, error) {
+	args := m.Called(ctx, name)
+	if val := args.Get(0); val != nil {
+		return val.(*entity.Permission), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *PermissionRepositoryMock) Update(ctx context.Context, permission *entity.Permission) error {
 	args := m.Called(ctx, permission)
 	return args.Error(0)
@@ -53,3 +61,9 @@ func (m *PermissionRepositoryMock) List(ctx context.Context, limit, offset int)
 	total, _ := args.Get(1).(int64)
 	return perms, total, args.Error(2)
 }
+
+func (m *PermissionRepositoryMock) ListNames(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	names, _ := args.Get(0).([]string)
+	return names, args.Error(1)
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// MockPrincipalCache is a mock implementation of PrincipalCache
+type MockPrincipalCache struct {
+	mock.Mock
+}
+
+// Ensure MockPrincipalCache implements service.PrincipalCache
+var _ service.PrincipalCache = (*MockPrincipalCache)(nil)
+
+func (m *MockPrincipalCache) Get(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockPrincipalCache) Set(ctx context.Context, user *entity.User, loadedAt time.Time) error {
+	args := m.Called(ctx, user, loadedAt)
+	return args.Error(0)
+}
+
+func (m *MockPrincipalCache) Invalidate(ctx context.Context, userIDs ...uuid.UUID) error {
+	args := m.Called(ctx, userIDs)
+	return args.Error(0)
+}
+
+func (m *MockPrincipalCache) InvalidateAll(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
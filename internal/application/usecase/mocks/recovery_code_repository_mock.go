@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// MockRecoveryCodeRepository is a mock implementation of RecoveryCodeRepository
+type MockRecoveryCodeRepository struct {
+	mock.Mock
+}
+
+// Ensure MockRecoveryCodeRepository implements repository.RecoveryCodeRepository
+var _ repository.RecoveryCodeRepository = (*MockRecoveryCodeRepository)(nil)
+
+func (m *MockRecoveryCodeRepository) ReplaceForUser(ctx context.Context, userID uuid.UUID, codes []*entity.RecoveryCode) error {
+	args := m.Called(ctx, userID, codes)
+	return args.Error(0)
+}
+
+func (m *MockRecoveryCodeRepository) Consume(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRecoveryCodeRepository) CountUnused(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRecoveryCodeRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// MockRefreshSessionRepository is a mock implementation of RefreshSessionRepository
+type MockRefreshSessionRepository struct {
+	mock.Mock
+}
+
+// Ensure MockRefreshSessionRepository implements repository.RefreshSessionRepository
+var _ repository.RefreshSessionRepository = (*MockRefreshSessionRepository)(nil)
+
+func (m *MockRefreshSessionRepository) Create(ctx context.Context, session *entity.RefreshSession) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockRefreshSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.RefreshSession, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RefreshSession), args.Error(1)
+}
+
+func (m *MockRefreshSessionRepository) Rotate(ctx context.Context, id, replacedByID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id, replacedByID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshSessionRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID, reason string) error {
+	args := m.Called(ctx, familyID, reason)
+	return args.Error(0)
+}
+
+func (m *MockRefreshSessionRepository) RevokeAllByUser(ctx context.Context, userID uuid.UUID, reason string) (int64, error) {
+	args := m.Called(ctx, userID, reason)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -50,3 +50,9 @@ func (m *ReportRepositoryMock) ListMutationHistory(ctx context.Context, filter d
 	rows, _ := args.Get(0).([]domainRepo.MutationHistoryRow)
 	return rows, args.Error(1)
 }
+
+func (m *ReportRepositoryMock) ResolveLabels(ctx context.Context, query domainRepo.ReportLabelQuery) (domainRepo.ReportLabels, error) {
+	args := m.Called(ctx, query)
+	labels, _ := args.Get(0).(domainRepo.ReportLabels)
+	return labels, args.Error(1)
+}
@@ -40,8 +40,8 @@ func (m *MockStudentRepository) GetByStudentNumber(ctx context.Context, studentN
 	return args.Get(0).(*entity.Student), args.Error(1)
 }
 
-func (m *MockStudentRepository) List(ctx context.Context, limit, offset int) ([]*entity.Student, int64, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockStudentRepository) List(ctx context.Context, filter repository.StudentFilter) ([]*entity.Student, int64, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
@@ -63,6 +63,17 @@ func (m *MockStudentRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return args.Error(0)
 }
 
+func (m *MockStudentRepository) ExistingStudentNumbers(ctx context.Context, studentNumbers []string) ([]string, error) {
+	args := m.Called(ctx, studentNumbers)
+	existing, _ := args.Get(0).([]string)
+	return existing, args.Error(1)
+}
+
+func (m *MockStudentRepository) CreateImport(ctx context.Context, records []repository.StudentImportRecord) error {
+	args := m.Called(ctx, records)
+	return args.Error(0)
+}
+
 func (m *MockStudentRepository) CreateHistory(ctx context.Context, history *entity.StudentDormitoryHistory) error {
 	args := m.Called(ctx, history)
 	return args.Error(0)
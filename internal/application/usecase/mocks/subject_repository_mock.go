@@ -53,3 +53,8 @@ func (m *SubjectRepositoryMock) Delete(ctx context.Context, id uuid.UUID) error
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *SubjectRepositoryMock) CountUsage(ctx context.Context, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/go-backend-starter/internal/domain/service"
@@ -19,8 +21,8 @@ func (m *MockTokenService) GenerateAccessToken(userID uuid.UUID, username string
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockTokenService) GenerateRefreshToken(userID uuid.UUID) (string, error) {
-	args := m.Called(userID)
+func (m *MockTokenService) GenerateRefreshToken(userID, sessionID, familyID uuid.UUID) (string, error) {
+	args := m.Called(userID, sessionID, familyID)
 	return args.String(0), args.Error(1)
 }
 
@@ -32,7 +34,41 @@ func (m *MockTokenService) ValidateToken(tokenString string) (*service.TokenClai
 	return args.Get(0).(*service.TokenClaims), args.Error(1)
 }
 
-func (m *MockTokenService) RefreshAccessToken(refreshToken string) (string, error) {
-	args := m.Called(refreshToken)
+func (m *MockTokenService) ValidateRefreshToken(tokenString string) (*service.TokenClaims, error) {
+	args := m.Called(tokenString)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.TokenClaims), args.Error(1)
+}
+
+func (m *MockTokenService) RefreshTokenExpiry() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockTokenService) GenerateTwoFactorToken(userID uuid.UUID) (string, error) {
+	args := m.Called(userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTokenService) ValidateTwoFactorToken(tokenString string) (*service.TokenClaims, error) {
+	args := m.Called(tokenString)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.TokenClaims), args.Error(1)
+}
+
+func (m *MockTokenService) VerificationKeys() []service.JSONWebKey {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]service.JSONWebKey)
+}
+
+func (m *MockTokenService) GenerateImpersonationToken(userID uuid.UUID, username string, impersonatorID uuid.UUID, impersonatorUsername string) (string, error) {
+	args := m.Called(userID, username, impersonatorID, impersonatorUsername)
 	return args.String(0), args.Error(1)
 }
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// MockTOTPService is a mock implementation of TOTPService
+type MockTOTPService struct {
+	mock.Mock
+}
+
+// Ensure MockTOTPService implements service.TOTPService
+var _ service.TOTPService = (*MockTOTPService)(nil)
+
+func (m *MockTOTPService) GenerateSecret() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTOTPService) ProvisioningURI(accountName, secret string) string {
+	args := m.Called(accountName, secret)
+	return args.String(0)
+}
+
+func (m *MockTOTPService) Validate(secret, code string, at time.Time) (int64, bool) {
+	args := m.Called(secret, code, at)
+	return args.Get(0).(int64), args.Bool(1)
+}
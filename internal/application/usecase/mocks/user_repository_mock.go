@@ -56,6 +56,14 @@ func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*en
 	return args.Get(0).([]*entity.User), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockUserRepository) ListServiceAccounts(ctx context.Context) ([]*entity.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetWithRoles(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -81,3 +89,8 @@ func (m *MockUserRepository) RemoveRole(ctx context.Context, userID, roleID uuid
 	args := m.Called(ctx, userID, roleID)
 	return args.Error(0)
 }
+
+func (m *MockUserRepository) AdvanceTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
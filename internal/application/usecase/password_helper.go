@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// temporaryPasswordAlphabet omits characters that are easy to misread when a
+// password is handed over on paper (0/O, 1/l/I).
+const temporaryPasswordAlphabet = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+const temporaryPasswordLength = 12
+
+// generateTemporaryPassword returns a random one-time password for accounts that
+// must change it on first login.
+func generateTemporaryPassword() (string, error) {
+	return randomString(temporaryPasswordAlphabet, temporaryPasswordLength)
+}
+
+// randomString returns length characters drawn uniformly from alphabet.
+func randomString(alphabet string, length int) (string, error) {
+	max := big.NewInt(int64(len(alphabet)))
+	value := make([]byte, length)
+	for i := range value {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		value[i] = alphabet[n.Int64()]
+	}
+	return string(value), nil
+}
@@ -2,12 +2,18 @@ package usecase
 
 import (
 	"context"
+	"sort"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/permission"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 )
 
-// PermissionUseCase handles read-only permission use cases
+// PermissionUseCase handles read-only permission use cases and keeps the
+// permissions table in sync with the code-defined catalog
 type PermissionUseCase struct {
 	permissionRepo repository.PermissionRepository
 }
@@ -60,3 +66,60 @@ func (uc *PermissionUseCase) ListPermissions(ctx context.Context, page, pageSize
 		TotalPages:  totalPages,
 	}, nil
 }
+
+// GetEffectivePermissions expands the user's role grants against all known
+// permissions, so clients can show exactly what the user may do.
+func (uc *PermissionUseCase) GetEffectivePermissions(ctx context.Context, user *entity.User) (*dto.EffectivePermissionsResponse, error) {
+	catalog, err := uc.permissionRepo.ListNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := user.PermissionNames()
+	sort.Strings(grants)
+	return &dto.EffectivePermissionsResponse{
+		Permissions: user.EffectivePermissions(catalog),
+		Grants:      grants,
+	}, nil
+}
+
+// SyncPermissions creates the catalog permissions missing from the database
+// and reports the database permissions the catalog no longer defines.
+func (uc *PermissionUseCase) SyncPermissions(ctx context.Context) (*dto.SyncPermissionsResponse, error) {
+	names, err := uc.permissionRepo.ListNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	resp := &dto.SyncPermissionsResponse{Created: []string{}, Orphaned: []string{}}
+	for _, def := range permission.Definitions() {
+		if existing[def.Name] {
+			continue
+		}
+		now := time.Now()
+		perm := &entity.Permission{
+			ID:        uuid.New(),
+			Name:      def.Name,
+			Slug:      def.Slug(),
+			Resource:  def.Resource,
+			Action:    def.Action,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := uc.permissionRepo.Create(ctx, perm); err != nil {
+			return nil, err
+		}
+		resp.Created = append(resp.Created, def.Name)
+	}
+
+	for _, name := range names {
+		if !permission.Known(name) {
+			resp.Orphaned = append(resp.Orphaned, name)
+		}
+	}
+	return resp, nil
+}
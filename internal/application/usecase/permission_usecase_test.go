@@ -7,8 +7,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/permission"
 )
 
 func TestPermissionUseCase_ListPermissions_NormalizesPagination(t *testing.T) {
@@ -44,3 +46,53 @@ func TestPermissionUseCase_ListPermissions_WhenRepoFails(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestPermissionUseCase_GetEffectivePermissions_ExpandsWildcards(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mocks.PermissionRepositoryMock)
+	mockRepo.On("ListNames", ctx).Return([]string{
+		"attendance_sessions:*",
+		"attendance_sessions:lock",
+		"attendance_sessions:read",
+		"student:create",
+		"student:read",
+	}, nil)
+
+	user := &entity.User{Roles: []entity.Role{{
+		Name:        "musyrif",
+		Permissions: []entity.Permission{{Name: "student:read"}, {Name: "attendance_sessions:*"}},
+	}}}
+
+	uc := NewPermissionUseCase(mockRepo)
+	resp, err := uc.GetEffectivePermissions(ctx, user)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"attendance_sessions:lock", "attendance_sessions:read", "student:read"}, resp.Permissions)
+	assert.Equal(t, []string{"attendance_sessions:*", "student:read"}, resp.Grants)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPermissionUseCase_SyncPermissions(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mocks.PermissionRepositoryMock)
+
+	// Everything but user:read is already there, plus one permission no longer in the catalog
+	existing := []string{"legacy:export"}
+	for _, name := range permission.Names() {
+		if name != permission.UserRead {
+			existing = append(existing, name)
+		}
+	}
+	mockRepo.On("ListNames", ctx).Return(existing, nil)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(p *entity.Permission) bool {
+		return p.Name == permission.UserRead && p.Slug == "user-read" && p.Resource == "user" && p.Action == "read"
+	})).Return(nil).Once()
+
+	uc := NewPermissionUseCase(mockRepo)
+	resp, err := uc.SyncPermissions(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{permission.UserRead}, resp.Created)
+	assert.Equal(t, []string{"legacy:export"}, resp.Orphaned)
+
+	mockRepo.AssertExpectations(t)
+}
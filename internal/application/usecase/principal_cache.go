@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// invalidatePrincipals drops cached principals after their roles, dormitories
+// or account state changed. Best-effort like audit logging: a failed
+// invalidation only delays the change until the cache entry expires. A nil
+// cache means caching is disabled.
+func invalidatePrincipals(ctx context.Context, cache service.PrincipalCache, userIDs ...uuid.UUID) {
+	if cache != nil {
+		_ = cache.Invalidate(ctx, userIDs...)
+	}
+}
+
+// invalidateAllPrincipals is used for changes that can affect any number of
+// users, such as a role's permissions.
+func invalidateAllPrincipals(ctx context.Context, cache service.PrincipalCache) {
+	if cache != nil {
+		_ = cache.InvalidateAll(ctx)
+	}
+}
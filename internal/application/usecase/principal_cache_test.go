@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+func TestPrincipalCacheInvalidation(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	roleID := uuid.New()
+	dormID := uuid.New()
+
+	t.Run("role assignment invalidates the user", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		roleRepo := new(mocks.MockRoleRepository)
+		cache := new(mocks.MockPrincipalCache)
+		userRepo.On("GetWithRoles", ctx, userID).Return(&entity.User{ID: userID}, nil)
+		roleRepo.On("GetByID", ctx, roleID).Return(&entity.Role{ID: roleID}, nil)
+		userRepo.On("AssignRole", ctx, userID, roleID).Return(nil)
+		cache.On("Invalidate", ctx, []uuid.UUID{userID}).Return(nil)
+
+		uc := NewUserUseCase(userRepo, roleRepo, new(mocks.MockRefreshSessionRepository), &noopAuditLogger{}, cache)
+		require.NoError(t, uc.AssignRoleToUser(ctx, userID, roleID))
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("failed role assignment keeps the cache", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		roleRepo := new(mocks.MockRoleRepository)
+		cache := new(mocks.MockPrincipalCache)
+		userRepo.On("GetWithRoles", ctx, userID).Return(&entity.User{ID: userID}, nil)
+		roleRepo.On("GetByID", ctx, roleID).Return(&entity.Role{ID: roleID}, nil)
+		userRepo.On("RemoveRole", ctx, userID, roleID).Return(errors.New("db down"))
+
+		uc := NewUserUseCase(userRepo, roleRepo, new(mocks.MockRefreshSessionRepository), &noopAuditLogger{}, cache)
+		assert.Error(t, uc.RemoveRoleFromUser(ctx, userID, roleID))
+		cache.AssertNotCalled(t, "Invalidate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("role permission change invalidates everyone", func(t *testing.T) {
+		roleRepo := new(mocks.MockRoleRepository)
+		permissionRepo := new(mocks.PermissionRepositoryMock)
+		cache := new(mocks.MockPrincipalCache)
+		permissionID := uuid.New()
+		roleRepo.On("GetWithPermissions", ctx, roleID).Return(&entity.Role{ID: roleID}, nil)
+		permissionRepo.On("GetByID", ctx, permissionID).Return(&entity.Permission{ID: permissionID}, nil)
+		roleRepo.On("AssignPermission", ctx, roleID, permissionID).Return(nil)
+		cache.On("InvalidateAll", ctx).Return(nil)
+
+		uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{}, cache)
+		require.NoError(t, uc.AssignPermission(ctx, roleID, permissionID))
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("dormitory assignment invalidates the user", func(t *testing.T) {
+		dormRepo := new(mocks.MockDormitoryRepository)
+		userRepo := new(mocks.MockUserRepository)
+		cache := new(mocks.MockPrincipalCache)
+		dormRepo.On("GetByID", ctx, dormID).Return(&entity.Dormitory{ID: dormID}, nil)
+		userRepo.On("GetByID", ctx, userID).Return(&entity.User{ID: userID}, nil)
+		dormRepo.On("AssignToUser", ctx, userID, dormID).Return(nil)
+		cache.On("Invalidate", ctx, []uuid.UUID{userID}).Return(nil)
+
+		uc := NewDormitoryUseCase(dormRepo, userRepo, &noopAuditLogger{}, cache)
+		require.NoError(t, uc.AssignUser(ctx, dormID, userID))
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("password change lifts the forced change immediately", func(t *testing.T) {
+		user := &entity.User{ID: userID, Username: "staff", Password: "temporary", IsActive: true, MustChangePassword: true}
+		require.NoError(t, user.HashPassword())
+
+		userRepo := new(mocks.MockUserRepository)
+		sessionRepo := new(mocks.MockRefreshSessionRepository)
+		tokenService := new(mocks.MockTokenService)
+		cache := new(mocks.MockPrincipalCache)
+		userRepo.On("GetWithRoles", ctx, userID).Return(user, nil)
+		userRepo.On("Update", ctx, mock.Anything).Return(nil)
+		sessionRepo.On("RevokeAllByUser", ctx, userID, entity.SessionRevokeReasonPassword).Return(int64(1), nil)
+		sessionRepo.On("Create", ctx, mock.Anything).Return(nil)
+		tokenService.On("GenerateAccessToken", userID, "staff", []string{}).Return("access_token", nil)
+		tokenService.On("GenerateRefreshToken", userID, mock.Anything, mock.Anything).Return("refresh_token", nil)
+		tokenService.On("RefreshTokenExpiry").Return(time.Hour)
+		cache.On("Invalidate", ctx, []uuid.UUID{userID}).Return(nil)
+
+		uc := NewAuthUseCase(userRepo, sessionRepo, tokenService, new(mocks.MockLoginThrottleRepository), &noopAuditLogger{}, DefaultLoginLockoutPolicy(), DefaultRegistrationPolicy(), nil, cache)
+		_, err := uc.ChangePassword(ctx, userID, dto.ChangePasswordRequest{CurrentPassword: "temporary", NewPassword: "a-new-password"})
+		require.NoError(t, err)
+		cache.AssertExpectations(t)
+	})
+}
@@ -29,10 +29,13 @@ func (uc *ReportUseCase) GetStudentAttendanceReport(ctx context.Context, req dto
 	}
 
 	filter := repository.StudentAttendanceReportFilter{
-		Date:        date,
-		DormitoryID: parseUUIDPtr(req.DormitoryID),
-		ClassID:     parseUUIDPtr(req.ClassID),
-		FanID:       parseUUIDPtr(req.FanID),
+		Date:    date,
+		ClassID: parseUUIDPtr(req.ClassID),
+		FanID:   parseUUIDPtr(req.FanID),
+	}
+	filter.DormitoryID, filter.DormitoryIDs, err = resolveDormitoryFilter(ctx, parseUUIDPtr(req.DormitoryID))
+	if err != nil {
+		return nil, err
 	}
 
 	aggregations, err := uc.reportRepo.AggregateStudentAttendance(ctx, filter)
@@ -40,17 +43,31 @@ func (uc *ReportUseCase) GetStudentAttendanceReport(ctx context.Context, req dto
 		return nil, domainErrors.ErrInternalServer
 	}
 
+	var query repository.ReportLabelQuery
+	for _, agg := range aggregations {
+		query.DormitoryIDs = appendLabelID(query.DormitoryIDs, agg.DormitoryID)
+		query.ClassIDs = appendLabelID(query.ClassIDs, agg.ClassID)
+		query.FanIDs = appendLabelID(query.FanIDs, agg.FanID)
+	}
+	labels, err := uc.resolveLabels(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
 	rows := make([]dto.StudentAttendanceReportRow, 0, len(aggregations))
 	for _, agg := range aggregations {
 		rows = append(rows, dto.StudentAttendanceReportRow{
-			DormitoryID: uuidPtrToString(agg.DormitoryID),
-			ClassID:     uuidPtrToString(agg.ClassID),
-			FanID:       uuidPtrToString(agg.FanID),
-			Total:       agg.Total,
-			Present:     agg.Present,
-			Absent:      agg.Absent,
-			Permit:      agg.Permit,
-			Sick:        agg.Sick,
+			DormitoryID:   uuidPtrToString(agg.DormitoryID),
+			DormitoryName: labelFor(labels.Dormitories, agg.DormitoryID),
+			ClassID:       uuidPtrToString(agg.ClassID),
+			ClassName:     labelFor(labels.Classes, agg.ClassID),
+			FanID:         uuidPtrToString(agg.FanID),
+			FanName:       labelFor(labels.Fans, agg.FanID),
+			Total:         agg.Total,
+			Present:       agg.Present,
+			Absent:        agg.Absent,
+			Permit:        agg.Permit,
+			Sick:          agg.Sick,
 		})
 	}
 
@@ -69,9 +86,10 @@ func (uc *ReportUseCase) GetTeacherAttendanceReport(ctx context.Context, req dto
 	}
 
 	filter := repository.TeacherAttendanceReportFilter{
-		Date:      date,
-		SlotID:    parseUUIDPtr(req.SlotID),
-		TeacherID: parseUUIDPtr(req.TeacherID),
+		Date:         date,
+		SlotID:       parseUUIDPtr(req.SlotID),
+		TeacherID:    parseUUIDPtr(req.TeacherID),
+		DormitoryIDs: scopedDormitoryIDs(ctx),
 	}
 
 	aggregations, err := uc.reportRepo.AggregateTeacherAttendance(ctx, filter)
@@ -79,13 +97,23 @@ func (uc *ReportUseCase) GetTeacherAttendanceReport(ctx context.Context, req dto
 		return nil, domainErrors.ErrInternalServer
 	}
 
+	var query repository.ReportLabelQuery
+	for _, agg := range aggregations {
+		query.TeacherIDs = appendLabelID(query.TeacherIDs, &agg.TeacherID)
+	}
+	labels, err := uc.resolveLabels(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
 	rows := make([]dto.TeacherAttendanceReportRow, 0, len(aggregations))
 	for _, agg := range aggregations {
 		rows = append(rows, dto.TeacherAttendanceReportRow{
-			TeacherID: agg.TeacherID.String(),
-			Total:     agg.Total,
-			Present:   agg.Present,
-			Absent:    agg.Absent,
+			TeacherID:   agg.TeacherID.String(),
+			TeacherName: labelFor(labels.Teachers, &agg.TeacherID),
+			Total:       agg.Total,
+			Present:     agg.Present,
+			Absent:      agg.Absent,
 		})
 	}
 
@@ -104,10 +132,13 @@ func (uc *ReportUseCase) GetLeavePermitReport(ctx context.Context, req dto.Leave
 	}
 
 	filter := repository.LeavePermitReportFilter{
-		Status:      req.Status,
-		Type:        req.Type,
-		DormitoryID: parseUUIDPtr(req.DormitoryID),
-		DateRange:   dateRange,
+		Status:    req.Status,
+		Type:      req.Type,
+		DateRange: dateRange,
+	}
+	filter.DormitoryID, filter.DormitoryIDs, err = resolveDormitoryFilter(ctx, parseUUIDPtr(req.DormitoryID))
+	if err != nil {
+		return nil, err
 	}
 
 	aggregations, err := uc.reportRepo.AggregateLeavePermits(ctx, filter)
@@ -115,13 +146,23 @@ func (uc *ReportUseCase) GetLeavePermitReport(ctx context.Context, req dto.Leave
 		return nil, domainErrors.ErrInternalServer
 	}
 
+	var query repository.ReportLabelQuery
+	for _, agg := range aggregations {
+		query.DormitoryIDs = appendLabelID(query.DormitoryIDs, agg.DormitoryID)
+	}
+	labels, err := uc.resolveLabels(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
 	rows := make([]dto.LeavePermitReportRow, 0, len(aggregations))
 	for _, agg := range aggregations {
 		rows = append(rows, dto.LeavePermitReportRow{
-			DormitoryID: uuidPtrToString(agg.DormitoryID),
-			Type:        agg.Type,
-			Status:      agg.Status,
-			Total:       agg.Total,
+			DormitoryID:   uuidPtrToString(agg.DormitoryID),
+			DormitoryName: labelFor(labels.Dormitories, agg.DormitoryID),
+			Type:          agg.Type,
+			Status:        agg.Status,
+			Total:         agg.Total,
 		})
 	}
 
@@ -140,9 +181,12 @@ func (uc *ReportUseCase) GetHealthStatusReport(ctx context.Context, req dto.Heal
 	}
 
 	filter := repository.HealthStatusReportFilter{
-		Status:      req.Status,
-		DormitoryID: parseUUIDPtr(req.DormitoryID),
-		DateRange:   dateRange,
+		Status:    req.Status,
+		DateRange: dateRange,
+	}
+	filter.DormitoryID, filter.DormitoryIDs, err = resolveDormitoryFilter(ctx, parseUUIDPtr(req.DormitoryID))
+	if err != nil {
+		return nil, err
 	}
 
 	aggregations, err := uc.reportRepo.AggregateHealthStatuses(ctx, filter)
@@ -150,13 +194,23 @@ func (uc *ReportUseCase) GetHealthStatusReport(ctx context.Context, req dto.Heal
 		return nil, domainErrors.ErrInternalServer
 	}
 
+	var query repository.ReportLabelQuery
+	for _, agg := range aggregations {
+		query.DormitoryIDs = appendLabelID(query.DormitoryIDs, agg.DormitoryID)
+	}
+	labels, err := uc.resolveLabels(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
 	rows := make([]dto.HealthStatusReportRow, 0, len(aggregations))
 	for _, agg := range aggregations {
 		rows = append(rows, dto.HealthStatusReportRow{
-			DormitoryID: uuidPtrToString(agg.DormitoryID),
-			Status:      agg.Status,
-			Total:       agg.Total,
-			Consecutive: agg.Consecutive,
+			DormitoryID:   uuidPtrToString(agg.DormitoryID),
+			DormitoryName: labelFor(labels.Dormitories, agg.DormitoryID),
+			Status:        agg.Status,
+			Total:         agg.Total,
+			Consecutive:   agg.Consecutive,
 		})
 	}
 
@@ -175,10 +229,11 @@ func (uc *ReportUseCase) GetSKSReport(ctx context.Context, req dto.SKSReportRequ
 	}
 
 	filter := repository.SKSReportFilter{
-		FanID:     parseUUIDPtr(req.FanID),
-		SKSID:     parseUUIDPtr(req.SKSID),
-		IsPassed:  req.IsPassed,
-		DateRange: dateRange,
+		FanID:        parseUUIDPtr(req.FanID),
+		SKSID:        parseUUIDPtr(req.SKSID),
+		IsPassed:     req.IsPassed,
+		DormitoryIDs: scopedDormitoryIDs(ctx),
+		DateRange:    dateRange,
 	}
 
 	aggregations, err := uc.reportRepo.AggregateSKSResults(ctx, filter)
@@ -186,11 +241,23 @@ func (uc *ReportUseCase) GetSKSReport(ctx context.Context, req dto.SKSReportRequ
 		return nil, domainErrors.ErrInternalServer
 	}
 
+	var query repository.ReportLabelQuery
+	for _, agg := range aggregations {
+		query.FanIDs = appendLabelID(query.FanIDs, agg.FanID)
+		query.SKSIDs = appendLabelID(query.SKSIDs, agg.SKSID)
+	}
+	labels, err := uc.resolveLabels(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
 	rows := make([]dto.SKSReportRow, 0, len(aggregations))
 	for _, agg := range aggregations {
 		rows = append(rows, dto.SKSReportRow{
 			FanID:   uuidPtrToString(agg.FanID),
+			FanName: labelFor(labels.Fans, agg.FanID),
 			SKSID:   uuidPtrToString(agg.SKSID),
+			SKSName: labelFor(labels.SKS, agg.SKSID),
 			Total:   agg.Total,
 			Passed:  agg.Passed,
 			Failed:  agg.Failed,
@@ -213,10 +280,13 @@ func (uc *ReportUseCase) GetMutationReport(ctx context.Context, req dto.Mutation
 	}
 
 	filter := repository.MutationReportFilter{
-		StudentID:   parseUUIDPtr(req.StudentID),
-		FanID:       parseUUIDPtr(req.FanID),
-		DormitoryID: parseUUIDPtr(req.DormitoryID),
-		DateRange:   dateRange,
+		StudentID: parseUUIDPtr(req.StudentID),
+		FanID:     parseUUIDPtr(req.FanID),
+		DateRange: dateRange,
+	}
+	filter.DormitoryID, filter.DormitoryIDs, err = resolveDormitoryFilter(ctx, parseUUIDPtr(req.DormitoryID))
+	if err != nil {
+		return nil, err
 	}
 
 	rowsData, err := uc.reportRepo.ListMutationHistory(ctx, filter)
@@ -224,16 +294,32 @@ func (uc *ReportUseCase) GetMutationReport(ctx context.Context, req dto.Mutation
 		return nil, domainErrors.ErrInternalServer
 	}
 
+	var query repository.ReportLabelQuery
+	for _, row := range rowsData {
+		query.StudentIDs = appendLabelID(query.StudentIDs, &row.StudentID)
+		query.DormitoryIDs = appendLabelID(appendLabelID(query.DormitoryIDs, row.FromDormID), row.ToDormID)
+		query.ClassIDs = appendLabelID(appendLabelID(query.ClassIDs, row.FromClassID), row.ToClassID)
+	}
+	labels, err := uc.resolveLabels(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
 	rows := make([]dto.MutationReportRow, 0, len(rowsData))
 	for _, row := range rowsData {
 		rows = append(rows, dto.MutationReportRow{
-			StudentID:   row.StudentID.String(),
-			FromDormID:  uuidPtrToString(row.FromDormID),
-			ToDormID:    uuidPtrToString(row.ToDormID),
-			FromClassID: uuidPtrToString(row.FromClassID),
-			ToClassID:   uuidPtrToString(row.ToClassID),
-			StartDate:   row.StartDate.Format("2006-01-02"),
-			EndDate:     timePtrToString(row.EndDate),
+			StudentID:     row.StudentID.String(),
+			StudentName:   labelFor(labels.Students, &row.StudentID),
+			FromDormID:    uuidPtrToString(row.FromDormID),
+			FromDormName:  labelFor(labels.Dormitories, row.FromDormID),
+			ToDormID:      uuidPtrToString(row.ToDormID),
+			ToDormName:    labelFor(labels.Dormitories, row.ToDormID),
+			FromClassID:   uuidPtrToString(row.FromClassID),
+			FromClassName: labelFor(labels.Classes, row.FromClassID),
+			ToClassID:     uuidPtrToString(row.ToClassID),
+			ToClassName:   labelFor(labels.Classes, row.ToClassID),
+			StartDate:     row.StartDate.Format("2006-01-02"),
+			EndDate:       timePtrToString(row.EndDate),
 		})
 	}
 
@@ -244,6 +330,38 @@ func (uc *ReportUseCase) GetMutationReport(ctx context.Context, req dto.Mutation
 	}, nil
 }
 
+// resolveLabels looks up display names for the IDs referenced by report rows.
+func (uc *ReportUseCase) resolveLabels(ctx context.Context, query repository.ReportLabelQuery) (repository.ReportLabels, error) {
+	if len(query.DormitoryIDs)+len(query.ClassIDs)+len(query.FanIDs)+len(query.TeacherIDs)+len(query.SKSIDs)+len(query.StudentIDs) == 0 {
+		return repository.ReportLabels{}, nil
+	}
+	labels, err := uc.reportRepo.ResolveLabels(ctx, query)
+	if err != nil {
+		return repository.ReportLabels{}, domainErrors.ErrInternalServer
+	}
+	return labels, nil
+}
+
+// appendLabelID adds id to ids unless it is nil or already present.
+func appendLabelID(ids []uuid.UUID, id *uuid.UUID) []uuid.UUID {
+	if id == nil {
+		return ids
+	}
+	for _, existing := range ids {
+		if existing == *id {
+			return ids
+		}
+	}
+	return append(ids, *id)
+}
+
+func labelFor(names map[uuid.UUID]string, id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return names[*id]
+}
+
 func parseISODate(value string) (time.Time, error) {
 	date, err := time.Parse("2006-01-02", value)
 	if err != nil {
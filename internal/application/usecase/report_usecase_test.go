@@ -2,15 +2,19 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 )
 
@@ -20,10 +24,11 @@ func TestReportUseCase_GetStudentAttendanceReport(t *testing.T) {
 	ctx := context.Background()
 	date := "2025-11-21"
 	filter := repository.StudentAttendanceReportFilter{Date: mustParseDate(t, date)}
+	dormID, classID, fanID := uuid.New(), uuid.New(), uuid.New()
 	rows := []repository.StudentAttendanceAggregation{{
-		DormitoryID: uuidPtr(uuid.New()),
-		ClassID:     uuidPtr(uuid.New()),
-		FanID:       uuidPtr(uuid.New()),
+		DormitoryID: uuidPtr(dormID),
+		ClassID:     uuidPtr(classID),
+		FanID:       uuidPtr(fanID),
 		Total:       30,
 		Present:     25,
 		Absent:      3,
@@ -31,11 +36,41 @@ func TestReportUseCase_GetStudentAttendanceReport(t *testing.T) {
 		Sick:        1,
 	}}
 	repo.On("AggregateStudentAttendance", ctx, filter).Return(rows, nil)
+	repo.On("ResolveLabels", ctx, repository.ReportLabelQuery{
+		DormitoryIDs: []uuid.UUID{dormID},
+		ClassIDs:     []uuid.UUID{classID},
+		FanIDs:       []uuid.UUID{fanID},
+	}).Return(repository.ReportLabels{
+		Dormitories: map[uuid.UUID]string{dormID: "Putra 1"},
+		Classes:     map[uuid.UUID]string{classID: "1A"},
+	}, nil)
 
 	resp, err := uc.GetStudentAttendanceReport(ctx, dto.StudentAttendanceReportRequest{Date: date})
 	assert.NoError(t, err)
 	require.Len(t, resp.Rows, 1)
 	assert.Equal(t, 30, resp.Rows[0].Total)
+	assert.Equal(t, "Putra 1", resp.Rows[0].DormitoryName)
+	assert.Equal(t, "1A", resp.Rows[0].ClassName)
+	assert.Empty(t, resp.Rows[0].FanName)
+	repo.AssertExpectations(t)
+}
+
+func TestReportUseCase_GetStudentAttendanceReport_DormitoryScope(t *testing.T) {
+	repo := new(mocks.ReportRepositoryMock)
+	uc := NewReportUseCase(repo)
+	dormID := uuid.New()
+	ctx := appService.WithDormitoryScope(context.Background(), appService.DormitoryScope{DormitoryIDs: []uuid.UUID{dormID}})
+	date := "2025-11-21"
+
+	filter := repository.StudentAttendanceReportFilter{Date: mustParseDate(t, date), DormitoryIDs: []uuid.UUID{dormID}}
+	repo.On("AggregateStudentAttendance", ctx, filter).Return([]repository.StudentAttendanceAggregation{}, nil)
+
+	_, err := uc.GetStudentAttendanceReport(ctx, dto.StudentAttendanceReportRequest{Date: date})
+	assert.NoError(t, err)
+
+	otherDorm := uuid.New().String()
+	_, err = uc.GetStudentAttendanceReport(ctx, dto.StudentAttendanceReportRequest{Date: date, DormitoryID: &otherDorm})
+	assert.ErrorIs(t, err, domainErrors.ErrDormitoryAccessDenied)
 	repo.AssertExpectations(t)
 }
 
@@ -45,18 +80,22 @@ func TestReportUseCase_GetTeacherAttendanceReport(t *testing.T) {
 	ctx := context.Background()
 	date := "2025-11-21"
 	filter := repository.TeacherAttendanceReportFilter{Date: mustParseDate(t, date)}
+	teacherID := uuid.New()
 	rows := []repository.TeacherAttendanceAggregation{{
-		TeacherID: uuid.New(),
+		TeacherID: teacherID,
 		Total:     5,
 		Present:   4,
 		Absent:    1,
 	}}
 	repo.On("AggregateTeacherAttendance", ctx, filter).Return(rows, nil)
+	repo.On("ResolveLabels", ctx, repository.ReportLabelQuery{TeacherIDs: []uuid.UUID{teacherID}}).
+		Return(repository.ReportLabels{Teachers: map[uuid.UUID]string{teacherID: "Ustadz Hasan"}}, nil)
 
 	resp, err := uc.GetTeacherAttendanceReport(ctx, dto.TeacherAttendanceReportRequest{Date: date})
 	assert.NoError(t, err)
 	require.Len(t, resp.Rows, 1)
 	assert.Equal(t, 4, resp.Rows[0].Present)
+	assert.Equal(t, "Ustadz Hasan", resp.Rows[0].TeacherName)
 	repo.AssertExpectations(t)
 }
 
@@ -113,20 +152,47 @@ func TestReportUseCase_GetMutationReport(t *testing.T) {
 	ctx := context.Background()
 	filter := repository.MutationReportFilter{}
 	now := time.Now()
+	studentID, fromDorm, toDorm := uuid.New(), uuid.New(), uuid.New()
 	rows := []repository.MutationHistoryRow{{
-		StudentID: uuid.New(),
-		StartDate: now,
-		EndDate:   &now,
+		StudentID:  studentID,
+		FromDormID: &fromDorm,
+		ToDormID:   &toDorm,
+		StartDate:  now,
+		EndDate:    &now,
 	}}
 	repo.On("ListMutationHistory", ctx, filter).Return(rows, nil)
+	repo.On("ResolveLabels", ctx, repository.ReportLabelQuery{
+		DormitoryIDs: []uuid.UUID{fromDorm, toDorm},
+		StudentIDs:   []uuid.UUID{studentID},
+	}).Return(repository.ReportLabels{
+		Dormitories: map[uuid.UUID]string{fromDorm: "Putra 1", toDorm: "Putra 2"},
+		Students:    map[uuid.UUID]string{studentID: "Ahmad Fauzi"},
+	}, nil)
 
 	resp, err := uc.GetMutationReport(ctx, dto.MutationReportRequest{})
 	assert.NoError(t, err)
 	require.Len(t, resp.Rows, 1)
 	assert.Equal(t, now.Format("2006-01-02"), resp.Rows[0].StartDate)
+	assert.Equal(t, "Ahmad Fauzi", resp.Rows[0].StudentName)
+	assert.Equal(t, "Putra 1", resp.Rows[0].FromDormName)
+	assert.Equal(t, "Putra 2", resp.Rows[0].ToDormName)
 	repo.AssertExpectations(t)
 }
 
+func TestReportUseCase_ResolveLabelsError(t *testing.T) {
+	repo := new(mocks.ReportRepositoryMock)
+	uc := NewReportUseCase(repo)
+	ctx := context.Background()
+	filter := repository.LeavePermitReportFilter{}
+	rows := []repository.LeavePermitAggregation{{DormitoryID: uuidPtr(uuid.New()), Type: "home_leave", Status: "approved", Total: 3}}
+	repo.On("AggregateLeavePermits", ctx, filter).Return(rows, nil)
+	repo.On("ResolveLabels", ctx, mock.Anything).Return(repository.ReportLabels{}, errors.New("db down"))
+
+	resp, err := uc.GetLeavePermitReport(ctx, dto.LeavePermitReportRequest{})
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, domainErrors.ErrInternalServer)
+}
+
 func TestReportUseCase_InvalidDate(t *testing.T) {
 	repo := new(mocks.ReportRepositoryMock)
 	uc := NewReportUseCase(repo)
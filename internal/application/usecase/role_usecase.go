@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
 // RoleUseCase handles role management use cases
@@ -18,6 +20,7 @@ type RoleUseCase struct {
 	roleRepo       repository.RoleRepository
 	permissionRepo repository.PermissionRepository
 	auditLogger    appService.AuditLogger
+	principalCache service.PrincipalCache
 }
 
 // NewRoleUseCase creates a new role use case
@@ -25,11 +28,13 @@ func NewRoleUseCase(
 	roleRepo repository.RoleRepository,
 	permissionRepo repository.PermissionRepository,
 	auditLogger appService.AuditLogger,
+	principalCache service.PrincipalCache,
 ) *RoleUseCase {
 	return &RoleUseCase{
 		roleRepo:       roleRepo,
 		permissionRepo: permissionRepo,
 		auditLogger:    auditLogger,
+		principalCache: principalCache,
 	}
 }
 
@@ -43,13 +48,14 @@ func (uc *RoleUseCase) CreateRole(ctx context.Context, req dto.CreateRoleRequest
 
 	// Create role
 	role := &entity.Role{
-		ID:          uuid.New(),
-		Name:        req.Name,
-		Slug:        strings.ToLower(req.Slug),
-		IsActive:    req.IsActive,
-		IsProtected: req.IsProtected,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:                uuid.New(),
+		Name:              req.Name,
+		Slug:              strings.ToLower(req.Slug),
+		IsActive:          req.IsActive,
+		IsProtected:       req.IsProtected,
+		TwoFactorRequired: req.TwoFactorRequired,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
 	}
 
 	// Assign permissions if provided
@@ -106,6 +112,7 @@ func (uc *RoleUseCase) UpdateRole(ctx context.Context, id uuid.UUID, req dto.Upd
 	if err != nil {
 		return nil, domainErrors.ErrRoleNotFound
 	}
+	before := *role
 
 	// Update fields
 	if req.Name != "" {
@@ -122,6 +129,9 @@ func (uc *RoleUseCase) UpdateRole(ctx context.Context, id uuid.UUID, req dto.Upd
 	if req.IsActive != nil {
 		role.IsActive = *req.IsActive
 	}
+	if req.TwoFactorRequired != nil {
+		role.TwoFactorRequired = *req.TwoFactorRequired
+	}
 
 	role.UpdatedAt = time.Now()
 
@@ -129,6 +139,8 @@ func (uc *RoleUseCase) UpdateRole(ctx context.Context, id uuid.UUID, req dto.Upd
 	if err := uc.roleRepo.Update(ctx, role); err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
+	// Name, activity and the 2FA requirement are part of every member's principal
+	invalidateAllPrincipals(ctx, uc.principalCache)
 
 	// Get updated role with permissions
 	roleWithPerms, err := uc.roleRepo.GetWithPermissions(ctx, role.ID)
@@ -137,9 +149,10 @@ func (uc *RoleUseCase) UpdateRole(ctx context.Context, id uuid.UUID, req dto.Upd
 	}
 
 	// Audit log (best-effort)
-	_ = uc.auditLogger.Log(ctx, "role", "role:update", role.ID.String(), map[string]string{
-		"name": role.Name,
-		"slug": role.Slug,
+	_ = uc.auditLogger.LogChange(ctx, "role", "role:update", role.ID.String(), &before, role, map[string]string{
+		"name":                role.Name,
+		"slug":                role.Slug,
+		"two_factor_required": strconv.FormatBool(role.TwoFactorRequired),
 	})
 
 	return uc.toRoleResponse(roleWithPerms), nil
@@ -162,9 +175,10 @@ func (uc *RoleUseCase) DeleteRole(ctx context.Context, id uuid.UUID) error {
 	if err := uc.roleRepo.Delete(ctx, id); err != nil {
 		return err
 	}
+	invalidateAllPrincipals(ctx, uc.principalCache)
 
 	// Audit log (best-effort)
-	_ = uc.auditLogger.Log(ctx, "role", "role:delete", id.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "role", "role:delete", id.String(), role, nil, map[string]string{
 		"name": role.Name,
 		"slug": role.Slug,
 	})
@@ -213,8 +227,8 @@ func (uc *RoleUseCase) ListRoles(ctx context.Context, page, pageSize int) (*dto.
 
 // AssignPermission assigns a permission to a role
 func (uc *RoleUseCase) AssignPermission(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) error {
-	// Check if role exists
-	role, err := uc.roleRepo.GetByID(ctx, roleID)
+	// Check if role exists, loading the current permissions for the audit entry
+	role, err := uc.roleRepo.GetWithPermissions(ctx, roleID)
 	if err != nil {
 		return domainErrors.ErrRoleNotFound
 	}
@@ -225,19 +239,31 @@ func (uc *RoleUseCase) AssignPermission(ctx context.Context, roleID uuid.UUID, p
 	}
 
 	// Check if permission exists
-	_, err = uc.permissionRepo.GetByID(ctx, permissionID)
+	permission, err := uc.permissionRepo.GetByID(ctx, permissionID)
 	if err != nil {
 		return domainErrors.ErrPermissionNotFound
 	}
 
 	// Assign permission
-	return uc.roleRepo.AssignPermission(ctx, roleID, permissionID)
+	if err := uc.roleRepo.AssignPermission(ctx, roleID, permissionID); err != nil {
+		return err
+	}
+	invalidateAllPrincipals(ctx, uc.principalCache)
+
+	// Audit log (best-effort)
+	before := roleAuditView{Role: *role, PermissionIDs: rolePermissionIDs(role)}
+	after := roleAuditView{Role: *role, PermissionIDs: withID(before.PermissionIDs, permissionID)}
+	_ = uc.auditLogger.LogChange(ctx, "role", "role:assign_permission", roleID.String(), &before, &after, map[string]string{
+		"name":       role.Name,
+		"permission": permission.Name,
+	})
+	return nil
 }
 
 // RemovePermission removes a permission from a role
 func (uc *RoleUseCase) RemovePermission(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) error {
-	// Check if role exists
-	role, err := uc.roleRepo.GetByID(ctx, roleID)
+	// Check if role exists, loading the current permissions for the audit entry
+	role, err := uc.roleRepo.GetWithPermissions(ctx, roleID)
 	if err != nil {
 		return domainErrors.ErrRoleNotFound
 	}
@@ -248,7 +274,34 @@ func (uc *RoleUseCase) RemovePermission(ctx context.Context, roleID uuid.UUID, p
 	}
 
 	// Remove permission
-	return uc.roleRepo.RemovePermission(ctx, roleID, permissionID)
+	if err := uc.roleRepo.RemovePermission(ctx, roleID, permissionID); err != nil {
+		return err
+	}
+	invalidateAllPrincipals(ctx, uc.principalCache)
+
+	// Audit log (best-effort)
+	before := roleAuditView{Role: *role, PermissionIDs: rolePermissionIDs(role)}
+	after := roleAuditView{Role: *role, PermissionIDs: withoutID(before.PermissionIDs, permissionID)}
+	_ = uc.auditLogger.LogChange(ctx, "role", "role:remove_permission", roleID.String(), &before, &after, map[string]string{
+		"name":          role.Name,
+		"permission_id": permissionID.String(),
+	})
+	return nil
+}
+
+// roleAuditView is the audited view of a role: its own fields plus the
+// granted permission IDs, which DiffFields skips as a relation.
+type roleAuditView struct {
+	entity.Role
+	PermissionIDs []uuid.UUID `json:"permission_ids"`
+}
+
+func rolePermissionIDs(role *entity.Role) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		ids = append(ids, permission.ID)
+	}
+	return sortedIDs(ids)
 }
 
 // toRoleResponse converts entity.Role to dto.RoleResponse
@@ -259,13 +312,14 @@ func (uc *RoleUseCase) toRoleResponse(role *entity.Role) *dto.RoleResponse {
 	}
 
 	return &dto.RoleResponse{
-		ID:          role.ID.String(),
-		Name:        role.Name,
-		Slug:        role.Slug,
-		IsActive:    role.IsActive,
-		IsProtected: role.IsProtected,
-		Permissions: permissions,
-		CreatedAt:   role.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   role.UpdatedAt.Format(time.RFC3339),
+		ID:                role.ID.String(),
+		Name:              role.Name,
+		Slug:              role.Slug,
+		IsActive:          role.IsActive,
+		IsProtected:       role.IsProtected,
+		TwoFactorRequired: role.TwoFactorRequired,
+		Permissions:       permissions,
+		CreatedAt:         role.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         role.UpdatedAt.Format(time.RFC3339),
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
@@ -21,6 +22,10 @@ func (n *roleNoopAuditLogger) Log(ctx context.Context, resource, action, targetI
 	return nil
 }
 
+func (n *roleNoopAuditLogger) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	return nil
+}
+
 func TestRoleUseCase_CreateRole_Success(t *testing.T) {
 	ctx := context.Background()
 	roleRepo := new(mocks.MockRoleRepository)
@@ -30,7 +35,7 @@ func TestRoleUseCase_CreateRole_Success(t *testing.T) {
 	roleRepo.On("Create", ctx, mock.Anything).Return(nil)
 	roleRepo.On("GetWithPermissions", ctx, mock.Anything).Return(&entity.Role{ID: uuid.New(), Name: "Admin", Slug: "admin"}, nil)
 
-	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{})
+	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{}, nil)
 	resp, err := uc.CreateRole(ctx, dto.CreateRoleRequest{Name: "Admin", Slug: "admin"})
 	assert.NoError(t, err)
 	assert.Equal(t, "Admin", resp.Name)
@@ -43,7 +48,7 @@ func TestRoleUseCase_CreateRole_DuplicateSlug(t *testing.T) {
 	permissionRepo := new(mocks.PermissionRepositoryMock)
 	roleRepo.On("GetBySlug", ctx, "existing").Return(&entity.Role{ID: uuid.New()}, nil)
 
-	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{})
+	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{}, nil)
 	resp, err := uc.CreateRole(ctx, dto.CreateRoleRequest{Name: "Existing", Slug: "existing"})
 	assert.Nil(t, resp)
 	assert.ErrorIs(t, err, domainErrors.ErrRoleAlreadyExists)
@@ -57,7 +62,7 @@ func TestRoleUseCase_UpdateRole_SlugConflict(t *testing.T) {
 	roleRepo.On("GetByID", ctx, roleID).Return(&entity.Role{ID: roleID, Slug: "old"}, nil)
 	roleRepo.On("GetBySlug", ctx, "taken").Return(&entity.Role{ID: uuid.New()}, nil)
 
-	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{})
+	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{}, nil)
 	resp, err := uc.UpdateRole(ctx, roleID, dto.UpdateRoleRequest{Slug: "taken"})
 	assert.Nil(t, resp)
 	assert.ErrorIs(t, err, domainErrors.ErrRoleAlreadyExists)
@@ -69,7 +74,7 @@ func TestRoleUseCase_DeleteRole_Protected(t *testing.T) {
 	roleID := uuid.New()
 	roleRepo.On("GetByID", ctx, roleID).Return(&entity.Role{ID: roleID, IsProtected: true}, nil)
 
-	uc := NewRoleUseCase(roleRepo, new(mocks.PermissionRepositoryMock), &roleNoopAuditLogger{})
+	uc := NewRoleUseCase(roleRepo, new(mocks.PermissionRepositoryMock), &roleNoopAuditLogger{}, nil)
 	err := uc.DeleteRole(ctx, roleID)
 	assert.ErrorIs(t, err, domainErrors.ErrProtectedRole)
 }
@@ -81,7 +86,7 @@ func TestRoleUseCase_ListRoles_Paginates(t *testing.T) {
 	roleRepo.On("List", ctx, 10, 0).Return([]*entity.Role{{ID: uuid.New(), Name: "Viewer"}}, int64(1), nil)
 	roleRepo.On("GetWithPermissions", ctx, mock.Anything).Return(&entity.Role{ID: uuid.New(), Name: "Viewer", Permissions: []entity.Permission{{Name: "read"}}}, nil)
 
-	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{})
+	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{}, nil)
 	resp, err := uc.ListRoles(ctx, 0, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, resp.Page)
@@ -96,14 +101,40 @@ func TestRoleUseCase_AssignPermission(t *testing.T) {
 	permissionRepo := new(mocks.PermissionRepositoryMock)
 	roleID := uuid.New()
 	permID := uuid.New()
-	roleRepo.On("GetByID", ctx, roleID).Return(&entity.Role{ID: roleID, IsProtected: false}, nil)
-	permissionRepo.On("GetByID", ctx, permID).Return(&entity.Permission{ID: permID}, nil)
+	existing := entity.Permission{ID: uuid.New(), Name: "student:read"}
+	roleRepo.On("GetWithPermissions", ctx, roleID).Return(&entity.Role{ID: roleID, IsProtected: false, Permissions: []entity.Permission{existing}}, nil)
+	permissionRepo.On("GetByID", ctx, permID).Return(&entity.Permission{ID: permID, Name: "student:update"}, nil)
 	roleRepo.On("AssignPermission", ctx, roleID, permID).Return(nil)
 
-	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{})
+	recorder := &changeRecorder{}
+	uc := NewRoleUseCase(roleRepo, permissionRepo, recorder, nil)
 	assert.NoError(t, uc.AssignPermission(ctx, roleID, permID))
 	roleRepo.AssertExpectations(t)
 	permissionRepo.AssertExpectations(t)
+
+	changes := recorder.changes["role:assign_permission"]
+	require.Len(t, changes, 1)
+	assert.Equal(t, []string{existing.ID.String()}, changes["permission_ids"].Old)
+	assert.ElementsMatch(t, []string{existing.ID.String(), permID.String()}, changes["permission_ids"].New)
+}
+
+func TestRoleUseCase_RemovePermission(t *testing.T) {
+	ctx := context.Background()
+	roleRepo := new(mocks.MockRoleRepository)
+	roleID := uuid.New()
+	kept := entity.Permission{ID: uuid.New(), Name: "student:read"}
+	removed := entity.Permission{ID: uuid.New(), Name: "student:update"}
+	roleRepo.On("GetWithPermissions", ctx, roleID).Return(&entity.Role{ID: roleID, Permissions: []entity.Permission{kept, removed}}, nil)
+	roleRepo.On("RemovePermission", ctx, roleID, removed.ID).Return(nil)
+
+	recorder := &changeRecorder{}
+	uc := NewRoleUseCase(roleRepo, new(mocks.PermissionRepositoryMock), recorder, nil)
+	assert.NoError(t, uc.RemovePermission(ctx, roleID, removed.ID))
+
+	changes := recorder.changes["role:remove_permission"]
+	require.Len(t, changes, 1)
+	assert.ElementsMatch(t, []string{kept.ID.String(), removed.ID.String()}, changes["permission_ids"].Old)
+	assert.Equal(t, []string{kept.ID.String()}, changes["permission_ids"].New)
 }
 
 func TestRoleUseCase_RemovePermission_Protected(t *testing.T) {
@@ -111,9 +142,9 @@ func TestRoleUseCase_RemovePermission_Protected(t *testing.T) {
 	roleRepo := new(mocks.MockRoleRepository)
 	roleID := uuid.New()
 	permissionID := uuid.New()
-	roleRepo.On("GetByID", ctx, roleID).Return(&entity.Role{ID: roleID, IsProtected: true}, nil)
+	roleRepo.On("GetWithPermissions", ctx, roleID).Return(&entity.Role{ID: roleID, IsProtected: true}, nil)
 
-	uc := NewRoleUseCase(roleRepo, new(mocks.PermissionRepositoryMock), &roleNoopAuditLogger{})
+	uc := NewRoleUseCase(roleRepo, new(mocks.PermissionRepositoryMock), &roleNoopAuditLogger{}, nil)
 	err := uc.RemovePermission(ctx, roleID, permissionID)
 	assert.ErrorIs(t, err, domainErrors.ErrProtectedRole)
 }
@@ -128,7 +159,7 @@ func TestRoleUseCase_UpdateRole_Success(t *testing.T) {
 	roleRepo.On("Update", ctx, mock.Anything).Return(nil)
 	roleRepo.On("GetWithPermissions", ctx, roleID).Return(updated, nil)
 
-	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{})
+	uc := NewRoleUseCase(roleRepo, permissionRepo, &roleNoopAuditLogger{}, nil)
 	resp, err := uc.UpdateRole(ctx, roleID, dto.UpdateRoleRequest{Name: "Updated"})
 	assert.NoError(t, err)
 	assert.Equal(t, "Updated", resp.Name)
@@ -139,7 +170,7 @@ func TestRoleUseCase_ListRoles_RepoError(t *testing.T) {
 	roleRepo := new(mocks.MockRoleRepository)
 	roleRepo.On("List", ctx, 5, 5).Return(nil, int64(0), errors.New("db"))
 
-	uc := NewRoleUseCase(roleRepo, new(mocks.PermissionRepositoryMock), &roleNoopAuditLogger{})
+	uc := NewRoleUseCase(roleRepo, new(mocks.PermissionRepositoryMock), &roleNoopAuditLogger{}, nil)
 	resp, err := uc.ListRoles(ctx, 2, 5)
 	assert.Nil(t, resp)
 	assert.Error(t, err)
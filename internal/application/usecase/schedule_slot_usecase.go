@@ -38,6 +38,9 @@ func (uc *ScheduleSlotUseCase) CreateScheduleSlot(ctx context.Context, req dto.C
 	if _, err := uc.dormRepo.GetByID(ctx, dormID); err != nil {
 		return nil, domainErrors.ErrDormitoryNotFound
 	}
+	if err := ensureDormitoryInScope(ctx, dormID); err != nil {
+		return nil, err
+	}
 
 	startTime, endTime, err := parseSlotTimes(req.StartTime, req.EndTime)
 	if err != nil {
@@ -80,20 +83,27 @@ func (uc *ScheduleSlotUseCase) CreateScheduleSlot(ctx context.Context, req dto.C
 // ListScheduleSlots lists slots with filters.
 func (uc *ScheduleSlotUseCase) ListScheduleSlots(ctx context.Context, dormitoryID string, page, pageSize int, isActive *bool) (*dto.ListScheduleSlotsResponse, error) {
 	var dormID uuid.UUID
+	var scopeIDs []uuid.UUID
 	var err error
 	if dormitoryID != "" {
 		dormID, err = uuid.Parse(dormitoryID)
 		if err != nil {
 			return nil, domainErrors.ErrBadRequest
 		}
+		if err := ensureDormitoryInScope(ctx, dormID); err != nil {
+			return nil, err
+		}
+	} else {
+		scopeIDs = scopedDormitoryIDs(ctx)
 	}
 
 	page, pageSize = normalizePagination(page, pageSize)
 	slots, total, err := uc.slotRepo.List(ctx, repository.ScheduleSlotFilter{
-		DormitoryID: dormID,
-		IsActive:    isActive,
-		Page:        page,
-		PageSize:    pageSize,
+		DormitoryID:  dormID,
+		DormitoryIDs: scopeIDs,
+		IsActive:     isActive,
+		Page:         page,
+		PageSize:     pageSize,
 	})
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
@@ -119,6 +129,9 @@ func (uc *ScheduleSlotUseCase) GetScheduleSlot(ctx context.Context, id uuid.UUID
 	if err != nil {
 		return nil, domainErrors.ErrScheduleSlotNotFound
 	}
+	if err := ensureDormitoryInScope(ctx, slot.DormitoryID); err != nil {
+		return nil, err
+	}
 	return uc.toScheduleSlotResponse(slot), nil
 }
 
@@ -128,6 +141,10 @@ func (uc *ScheduleSlotUseCase) UpdateScheduleSlot(ctx context.Context, id uuid.U
 	if err != nil {
 		return nil, domainErrors.ErrScheduleSlotNotFound
 	}
+	if err := ensureDormitoryInScope(ctx, slot.DormitoryID); err != nil {
+		return nil, err
+	}
+	before := *slot
 
 	if req.SlotNumber != nil && *req.SlotNumber != slot.SlotNumber {
 		if existing, _ := uc.slotRepo.GetByDormAndNumber(ctx, slot.DormitoryID, *req.SlotNumber); existing != nil && existing.ID != slot.ID {
@@ -178,7 +195,7 @@ func (uc *ScheduleSlotUseCase) UpdateScheduleSlot(ctx context.Context, id uuid.U
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "schedule_slot", "schedule_slot:update", slot.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "schedule_slot", "schedule_slot:update", slot.ID.String(), &before, slot, map[string]string{
 		"slot_number": formatInt(slot.SlotNumber),
 	})
 
@@ -187,13 +204,17 @@ func (uc *ScheduleSlotUseCase) UpdateScheduleSlot(ctx context.Context, id uuid.U
 
 // DeleteScheduleSlot soft deletes a slot.
 func (uc *ScheduleSlotUseCase) DeleteScheduleSlot(ctx context.Context, id uuid.UUID) error {
-	if _, err := uc.slotRepo.GetByID(ctx, id); err != nil {
+	slot, err := uc.slotRepo.GetByID(ctx, id)
+	if err != nil {
 		return domainErrors.ErrScheduleSlotNotFound
 	}
+	if err := ensureDormitoryInScope(ctx, slot.DormitoryID); err != nil {
+		return err
+	}
 	if err := uc.slotRepo.SoftDelete(ctx, id); err != nil {
 		return domainErrors.ErrInternalServer
 	}
-	_ = uc.auditLogger.Log(ctx, "schedule_slot", "schedule_slot:delete", id.String(), nil)
+	_ = uc.auditLogger.LogChange(ctx, "schedule_slot", "schedule_slot:delete", id.String(), slot, nil, nil)
 	return nil
 }
 
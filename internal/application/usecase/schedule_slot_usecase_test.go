@@ -21,6 +21,10 @@ func (n *scheduleSlotNoopAuditLogger) Log(ctx context.Context, resource, action,
 	return nil
 }
 
+func (n *scheduleSlotNoopAuditLogger) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	return nil
+}
+
 func TestScheduleSlotUseCase_CreateSlot_Success(t *testing.T) {
 	slotRepo := new(mocks.MockScheduleSlotRepository)
 	dormRepo := new(mocks.MockDormitoryRepository)
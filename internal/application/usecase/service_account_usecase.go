@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+const (
+	// apiKeyIDLength random characters follow entity.APIKeyPrefix to form the
+	// public key prefix, e.g. "sigap_k3m9x2qa".
+	apiKeyIDLength     = 8
+	apiKeySecretLength = 40
+	apiKeyIDAlphabet   = "abcdefghijkmnpqrstuvwxyz23456789"
+	apiKeyAlphabet     = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// apiKeyTouchInterval limits last-used updates to one write per key and interval.
+	apiKeyTouchInterval = time.Minute
+)
+
+// ServiceAccountUseCase manages machine users and their API keys
+type ServiceAccountUseCase struct {
+	userRepo    repository.UserRepository
+	roleRepo    repository.RoleRepository
+	apiKeyRepo  repository.APIKeyRepository
+	auditLogger appService.AuditLogger
+}
+
+// NewServiceAccountUseCase creates a new service account use case
+func NewServiceAccountUseCase(
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	auditLogger appService.AuditLogger,
+) *ServiceAccountUseCase {
+	return &ServiceAccountUseCase{
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		apiKeyRepo:  apiKeyRepo,
+		auditLogger: auditLogger,
+	}
+}
+
+// CreateServiceAccount creates a user that can only authenticate with API keys.
+// Its permissions come from the given roles like for any other user.
+func (uc *ServiceAccountUseCase) CreateServiceAccount(ctx context.Context, req dto.CreateServiceAccountRequest) (*dto.ServiceAccountResponse, error) {
+	existingUser, _ := uc.userRepo.GetByUsername(ctx, req.Username)
+	if existingUser != nil {
+		return nil, domainErrors.ErrUserAlreadyExists
+	}
+
+	// The password is random and never disclosed; password login is refused anyway.
+	password, err := randomString(apiKeyAlphabet, apiKeySecretLength)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	now := time.Now()
+	user := &entity.User{
+		ID:               uuid.New(),
+		Username:         req.Username,
+		Password:         password,
+		Name:             req.Name,
+		IsActive:         true,
+		IsServiceAccount: true,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := user.HashPassword(); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	for _, roleIDStr := range req.RoleIDs {
+		roleID, err := uuid.Parse(roleIDStr)
+		if err != nil {
+			return nil, domainErrors.ErrRoleNotFound
+		}
+		role, err := uc.roleRepo.GetByID(ctx, roleID)
+		if err != nil || role == nil {
+			return nil, domainErrors.ErrRoleNotFound
+		}
+		user.Roles = append(user.Roles, *role)
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	created, err := uc.userRepo.GetWithRoles(ctx, user.ID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "service_account", "service_account:create", user.ID.String(), map[string]string{
+		"username": user.Username,
+		"name":     user.Name,
+	})
+
+	return toServiceAccountResponse(created), nil
+}
+
+// ListServiceAccounts returns all service accounts
+func (uc *ServiceAccountUseCase) ListServiceAccounts(ctx context.Context) (*dto.ListServiceAccountsResponse, error) {
+	users, err := uc.userRepo.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	items := make([]dto.ServiceAccountResponse, 0, len(users))
+	for _, user := range users {
+		items = append(items, *toServiceAccountResponse(user))
+	}
+	return &dto.ListServiceAccountsResponse{ServiceAccounts: items}, nil
+}
+
+// CreateAPIKey issues a new key for a service account. The full key is only
+// part of this response; afterwards only its prefix is known.
+func (uc *ServiceAccountUseCase) CreateAPIKey(ctx context.Context, accountID uuid.UUID, req dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+	account, err := uc.getServiceAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]string, 0, len(req.Permissions))
+	seen := map[string]bool{}
+	for _, name := range req.Permissions {
+		name = strings.TrimSpace(name)
+		// A scope may name a wildcard only if the account holds one at least as broad
+		if name == "" || !account.HasPermission(name) {
+			return nil, domainErrors.ErrAPIKeyScopeInvalid
+		}
+		if !seen[name] {
+			seen[name] = true
+			permissions = append(permissions, name)
+		}
+	}
+	sort.Strings(permissions)
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, domainErrors.ErrBadRequest
+	}
+
+	keyID, err := randomString(apiKeyIDAlphabet, apiKeyIDLength)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	secret, err := randomString(apiKeyAlphabet, apiKeySecretLength)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	prefix := entity.APIKeyPrefix + keyID
+	raw := prefix + "_" + secret
+
+	key := &entity.APIKey{
+		ID:          uuid.New(),
+		UserID:      account.ID,
+		Name:        req.Name,
+		Prefix:      prefix,
+		KeyHash:     entity.HashAPIKey(raw),
+		Permissions: permissions,
+		ExpiresAt:   req.ExpiresAt,
+		CreatedAt:   now,
+	}
+	key.CreatedBy, _ = actorIDFromContext(ctx)
+	if err := uc.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "service_account", "service_account:create_api_key", account.ID.String(), map[string]string{
+		"username":    account.Username,
+		"api_key_id":  key.ID.String(),
+		"prefix":      key.Prefix,
+		"permissions": strings.Join(permissions, ","),
+	})
+
+	return &dto.CreateAPIKeyResponse{APIKeyResponse: toAPIKeyResponse(key), Key: raw}, nil
+}
+
+// ListAPIKeys returns the keys of a service account, newest first
+func (uc *ServiceAccountUseCase) ListAPIKeys(ctx context.Context, accountID uuid.UUID) (*dto.ListAPIKeysResponse, error) {
+	if _, err := uc.getServiceAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+	keys, err := uc.apiKeyRepo.ListByUser(ctx, accountID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	items := make([]dto.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, toAPIKeyResponse(key))
+	}
+	return &dto.ListAPIKeysResponse{APIKeys: items}, nil
+}
+
+// RevokeAPIKey permanently disables a key
+func (uc *ServiceAccountUseCase) RevokeAPIKey(ctx context.Context, accountID, keyID uuid.UUID) error {
+	key, err := uc.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil || key.UserID != accountID {
+		return domainErrors.ErrAPIKeyNotFound
+	}
+	if err := uc.apiKeyRepo.Revoke(ctx, key.ID, time.Now()); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "service_account", "service_account:revoke_api_key", accountID.String(), map[string]string{
+		"api_key_id": key.ID.String(),
+		"prefix":     key.Prefix,
+	})
+	return nil
+}
+
+// Authenticate resolves a raw API key to its service account. The returned
+// user only holds the permissions the key is scoped to.
+func (uc *ServiceAccountUseCase) Authenticate(ctx context.Context, raw string) (*entity.User, *entity.APIKey, error) {
+	prefix, ok := apiKeyLookupPrefix(raw)
+	if !ok {
+		return nil, nil, domainErrors.ErrInvalidAPIKey
+	}
+	key, err := uc.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, nil, domainErrors.ErrInternalServer
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(entity.HashAPIKey(raw))) != 1 {
+		return nil, nil, domainErrors.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !key.IsUsable(now) {
+		return nil, nil, domainErrors.ErrInvalidAPIKey
+	}
+
+	user, err := uc.userRepo.GetWithRolesAndDormitories(ctx, key.UserID)
+	if err != nil || !user.IsServiceAccount {
+		return nil, nil, domainErrors.ErrInvalidAPIKey
+	}
+	user.RestrictPermissions(key.Permissions)
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		// Best-effort; a failed write must not reject the request
+		if err := uc.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err == nil {
+			key.LastUsedAt = &now
+		}
+	}
+	return user, key, nil
+}
+
+func (uc *ServiceAccountUseCase) getServiceAccount(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	user, err := uc.userRepo.GetWithRoles(ctx, id)
+	if err != nil || !user.IsServiceAccount {
+		return nil, domainErrors.ErrServiceAccountNotFound
+	}
+	return user, nil
+}
+
+// apiKeyLookupPrefix extracts the stored prefix ("sigap_<id>") from a raw key.
+func apiKeyLookupPrefix(raw string) (string, bool) {
+	length := len(entity.APIKeyPrefix) + apiKeyIDLength
+	if !strings.HasPrefix(raw, entity.APIKeyPrefix) || len(raw) <= length+1 || raw[length] != '_' {
+		return "", false
+	}
+	return raw[:length], true
+}
+
+func toServiceAccountResponse(user *entity.User) *dto.ServiceAccountResponse {
+	roles := make([]string, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		roles = append(roles, role.Name)
+	}
+	permissions := user.PermissionNames()
+	sort.Strings(permissions)
+
+	return &dto.ServiceAccountResponse{
+		ID:          user.ID.String(),
+		Username:    user.Username,
+		Name:        user.Name,
+		IsActive:    user.IsActive,
+		Roles:       roles,
+		Permissions: permissions,
+		CreatedAt:   user.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toAPIKeyResponse(key *entity.APIKey) dto.APIKeyResponse {
+	permissions := key.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return dto.APIKeyResponse{
+		ID:          key.ID.String(),
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		Permissions: permissions,
+		ExpiresAt:   formatTimePtr(key.ExpiresAt),
+		LastUsedAt:  formatTimePtr(key.LastUsedAt),
+		RevokedAt:   formatTimePtr(key.RevokedAt),
+		CreatedAt:   key.CreatedAt.Format(time.RFC3339),
+	}
+}
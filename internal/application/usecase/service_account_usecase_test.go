@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+func newServiceAccount() *entity.User {
+	return &entity.User{
+		ID:               uuid.New(),
+		Username:         "kiosk",
+		IsActive:         true,
+		IsServiceAccount: true,
+		Roles: []entity.Role{{
+			Name:        "kiosk",
+			Permissions: []entity.Permission{{Name: "attendance_sessions:update"}, {Name: "student:read"}},
+		}},
+	}
+}
+
+func TestServiceAccountUseCase_CreateAPIKey(t *testing.T) {
+	account := newServiceAccount()
+
+	t.Run("issues a prefixed key scoped to a subset", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		keyRepo := new(mocks.MockAPIKeyRepository)
+		uc := NewServiceAccountUseCase(userRepo, nil, keyRepo, &noopAuditLogger{})
+
+		var stored *entity.APIKey
+		userRepo.On("GetWithRoles", mock.Anything, account.ID).Return(account, nil)
+		keyRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*entity.APIKey)
+		}).Return(nil)
+
+		resp, err := uc.CreateAPIKey(context.Background(), account.ID, dto.CreateAPIKeyRequest{
+			Name:        "Gate kiosk",
+			Permissions: []string{"student:read", "student:read"},
+		})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(resp.Key, resp.Prefix+"_"))
+		assert.True(t, strings.HasPrefix(resp.Prefix, entity.APIKeyPrefix))
+		assert.Equal(t, []string{"student:read"}, resp.Permissions)
+		require.NotNil(t, stored)
+		assert.Equal(t, entity.HashAPIKey(resp.Key), stored.KeyHash)
+		assert.NotContains(t, stored.KeyHash, resp.Key)
+	})
+
+	t.Run("rejects permissions the account does not hold", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		keyRepo := new(mocks.MockAPIKeyRepository)
+		uc := NewServiceAccountUseCase(userRepo, nil, keyRepo, &noopAuditLogger{})
+		userRepo.On("GetWithRoles", mock.Anything, account.ID).Return(account, nil)
+
+		_, err := uc.CreateAPIKey(context.Background(), account.ID, dto.CreateAPIKeyRequest{
+			Name:        "Too broad",
+			Permissions: []string{"student:read", "user:delete"},
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrAPIKeyScopeInvalid)
+		keyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("accepts wildcard scopes covered by a wildcard grant only", func(t *testing.T) {
+		wildcard := newServiceAccount()
+		wildcard.Roles[0].Permissions = []entity.Permission{{Name: "attendance_sessions:*"}, {Name: "student:read"}}
+		userRepo := new(mocks.MockUserRepository)
+		keyRepo := new(mocks.MockAPIKeyRepository)
+		uc := NewServiceAccountUseCase(userRepo, nil, keyRepo, &noopAuditLogger{})
+		userRepo.On("GetWithRoles", mock.Anything, wildcard.ID).Return(wildcard, nil)
+		keyRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		resp, err := uc.CreateAPIKey(context.Background(), wildcard.ID, dto.CreateAPIKeyRequest{
+			Name:        "Attendance",
+			Permissions: []string{"attendance_sessions:lock", "attendance_sessions:*"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"attendance_sessions:*", "attendance_sessions:lock"}, resp.Permissions)
+
+		_, err = uc.CreateAPIKey(context.Background(), wildcard.ID, dto.CreateAPIKeyRequest{
+			Name:        "Students",
+			Permissions: []string{"student:*"},
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrAPIKeyScopeInvalid)
+	})
+
+	t.Run("only for service accounts", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		uc := NewServiceAccountUseCase(userRepo, nil, new(mocks.MockAPIKeyRepository), &noopAuditLogger{})
+		human := &entity.User{ID: uuid.New(), IsActive: true}
+		userRepo.On("GetWithRoles", mock.Anything, human.ID).Return(human, nil)
+
+		_, err := uc.CreateAPIKey(context.Background(), human.ID, dto.CreateAPIKeyRequest{Name: "x", Permissions: []string{"student:read"}})
+		assert.ErrorIs(t, err, domainErrors.ErrServiceAccountNotFound)
+	})
+}
+
+func TestServiceAccountUseCase_Authenticate(t *testing.T) {
+	const raw = "sigap_abcd2345_secretsecretsecret"
+	newKey := func() *entity.APIKey {
+		return &entity.APIKey{
+			ID:          uuid.New(),
+			Prefix:      "sigap_abcd2345",
+			KeyHash:     entity.HashAPIKey(raw),
+			Permissions: []string{"student:read"},
+		}
+	}
+
+	setup := func(key *entity.APIKey) (*ServiceAccountUseCase, *mocks.MockUserRepository, *mocks.MockAPIKeyRepository) {
+		userRepo := new(mocks.MockUserRepository)
+		keyRepo := new(mocks.MockAPIKeyRepository)
+		account := newServiceAccount()
+		key.UserID = account.ID
+		keyRepo.On("GetByPrefix", mock.Anything, key.Prefix).Return(key, nil)
+		userRepo.On("GetWithRolesAndDormitories", mock.Anything, account.ID).Return(account, nil)
+		return NewServiceAccountUseCase(userRepo, nil, keyRepo, &noopAuditLogger{}), userRepo, keyRepo
+	}
+
+	t.Run("valid key restricts permissions and records use", func(t *testing.T) {
+		key := newKey()
+		uc, _, keyRepo := setup(key)
+		keyRepo.On("TouchLastUsed", mock.Anything, key.ID, mock.Anything).Return(nil).Once()
+
+		user, got, err := uc.Authenticate(context.Background(), raw)
+		require.NoError(t, err)
+		assert.Equal(t, key.ID, got.ID)
+		assert.True(t, user.HasPermission("student:read"))
+		assert.False(t, user.HasPermission("attendance_sessions:update"))
+
+		// A second request within the interval does not write again
+		_, _, err = uc.Authenticate(context.Background(), raw)
+		require.NoError(t, err)
+		keyRepo.AssertNumberOfCalls(t, "TouchLastUsed", 1)
+	})
+
+	t.Run("touch failures do not reject the request", func(t *testing.T) {
+		key := newKey()
+		uc, _, keyRepo := setup(key)
+		keyRepo.On("TouchLastUsed", mock.Anything, key.ID, mock.Anything).Return(errors.New("db down"))
+
+		_, _, err := uc.Authenticate(context.Background(), raw)
+		assert.NoError(t, err)
+	})
+
+	past := time.Now().Add(-time.Minute)
+	for name, mutate := range map[string]func(*entity.APIKey){
+		"expired": func(k *entity.APIKey) { k.ExpiresAt = &past },
+		"revoked": func(k *entity.APIKey) { k.RevokedAt = &past },
+	} {
+		t.Run(name, func(t *testing.T) {
+			key := newKey()
+			mutate(key)
+			uc, _, _ := setup(key)
+			_, _, err := uc.Authenticate(context.Background(), raw)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidAPIKey)
+		})
+	}
+
+	t.Run("wrong secret", func(t *testing.T) {
+		uc, _, _ := setup(newKey())
+		_, _, err := uc.Authenticate(context.Background(), "sigap_abcd2345_wrongsecretwrongsecret")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidAPIKey)
+	})
+
+	t.Run("malformed key", func(t *testing.T) {
+		uc := NewServiceAccountUseCase(nil, nil, nil, &noopAuditLogger{})
+		for _, value := range []string{"", "sigap_", "sigap_abcd2345", "sigap_abcd2345x", "other_abcd2345_secret"} {
+			_, _, err := uc.Authenticate(context.Background(), value)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidAPIKey, value)
+		}
+	})
+}
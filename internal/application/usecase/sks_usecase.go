@@ -146,6 +146,7 @@ func (uc *SKSDefinitionUseCase) UpdateSKSDefinition(ctx context.Context, id uuid
 	if err != nil {
 		return nil, domainErrors.ErrSKSDefinitionNotFound
 	}
+	before := *definition
 
 	if req.SubjectID != nil {
 		if *req.SubjectID == "" {
@@ -181,7 +182,7 @@ func (uc *SKSDefinitionUseCase) UpdateSKSDefinition(ctx context.Context, id uuid
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "sks_definition", "sks_definition:update", definition.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "sks_definition", "sks_definition:update", definition.ID.String(), &before, definition, map[string]string{
 		"code": definition.Code,
 	})
 
@@ -190,13 +191,14 @@ func (uc *SKSDefinitionUseCase) UpdateSKSDefinition(ctx context.Context, id uuid
 
 // DeleteSKSDefinition deletes a definition by ID.
 func (uc *SKSDefinitionUseCase) DeleteSKSDefinition(ctx context.Context, id uuid.UUID) error {
-	if _, err := uc.sksRepo.GetByID(ctx, id); err != nil {
+	definition, err := uc.sksRepo.GetByID(ctx, id)
+	if err != nil {
 		return domainErrors.ErrSKSDefinitionNotFound
 	}
 	if err := uc.sksRepo.Delete(ctx, id); err != nil {
 		return domainErrors.ErrInternalServer
 	}
-	_ = uc.auditLogger.Log(ctx, "sks_definition", "sks_definition:delete", id.String(), nil)
+	_ = uc.auditLogger.LogChange(ctx, "sks_definition", "sks_definition:delete", id.String(), definition, nil, nil)
 	return nil
 }
 
@@ -353,6 +355,7 @@ func (uc *SKSExamScheduleUseCase) UpdateSKSExamSchedule(ctx context.Context, id
 	if err != nil {
 		return nil, domainErrors.ErrSKSExamScheduleNotFound
 	}
+	before := *exam
 
 	if req.ExaminerID != nil {
 		if *req.ExaminerID == "" {
@@ -400,7 +403,7 @@ func (uc *SKSExamScheduleUseCase) UpdateSKSExamSchedule(ctx context.Context, id
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "sks_exam", "sks_exam:update", exam.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "sks_exam", "sks_exam:update", exam.ID.String(), &before, exam, map[string]string{
 		"sks_id": exam.SKSID.String(),
 	})
 
@@ -409,13 +412,14 @@ func (uc *SKSExamScheduleUseCase) UpdateSKSExamSchedule(ctx context.Context, id
 
 // DeleteSKSExamSchedule deletes an exam schedule.
 func (uc *SKSExamScheduleUseCase) DeleteSKSExamSchedule(ctx context.Context, id uuid.UUID) error {
-	if _, err := uc.examRepo.GetByID(ctx, id); err != nil {
+	exam, err := uc.examRepo.GetByID(ctx, id)
+	if err != nil {
 		return domainErrors.ErrSKSExamScheduleNotFound
 	}
 	if err := uc.examRepo.Delete(ctx, id); err != nil {
 		return domainErrors.ErrInternalServer
 	}
-	_ = uc.auditLogger.Log(ctx, "sks_exam", "sks_exam:delete", id.String(), nil)
+	_ = uc.auditLogger.LogChange(ctx, "sks_exam", "sks_exam:delete", id.String(), exam, nil, nil)
 	return nil
 }
 
@@ -20,6 +20,10 @@ func (n *sksNoopAuditLogger) Log(ctx context.Context, resource, action, targetID
 	return nil
 }
 
+func (n *sksNoopAuditLogger) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	return nil
+}
+
 func newSKSDefinitionUC() (*SKSDefinitionUseCase, *mocks.SKSDefinitionRepositoryMock, *mocks.FanRepositoryMock, *mocks.SubjectRepositoryMock) {
 	sksRepo := new(mocks.SKSDefinitionRepositoryMock)
 	fanRepo := new(mocks.FanRepositoryMock)
@@ -0,0 +1,442 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// Columns recognised in student import files. Header names are matched case-insensitively.
+const (
+	importColStudentNumber = "student_number"
+	importColFullName      = "full_name"
+	importColBirthDate     = "birth_date"
+	importColGender        = "gender"
+	importColParentName    = "parent_name"
+	importColDormitoryCode = "dormitory_code"
+	importColClass         = "class"
+)
+
+var requiredImportColumns = []string{
+	importColStudentNumber,
+	importColFullName,
+	importColBirthDate,
+	importColGender,
+	importColParentName,
+	importColDormitoryCode,
+}
+
+// excelEpoch is day zero of spreadsheet date serial numbers.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// StudentImportUseCase bulk-creates students together with their initial dormitory
+// placement and class enrollment.
+type StudentImportUseCase struct {
+	studentRepo repository.StudentRepository
+	dormRepo    repository.DormitoryRepository
+	classRepo   repository.ClassRepository
+	auditLogger appService.AuditLogger
+	validate    *validator.Validate
+}
+
+// NewStudentImportUseCase builds StudentImportUseCase instance.
+func NewStudentImportUseCase(
+	studentRepo repository.StudentRepository,
+	dormRepo repository.DormitoryRepository,
+	classRepo repository.ClassRepository,
+	auditLogger appService.AuditLogger,
+) *StudentImportUseCase {
+	// Rows are checked against the same binding rules as POST /api/students.
+	validate := validator.New()
+	validate.SetTagName("binding")
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	})
+
+	return &StudentImportUseCase{
+		studentRepo: studentRepo,
+		dormRepo:    dormRepo,
+		classRepo:   classRepo,
+		auditLogger: auditLogger,
+		validate:    validate,
+	}
+}
+
+// studentImportRow is one parsed data row; number is its 1-based line in the file.
+type studentImportRow struct {
+	number        int
+	request       dto.CreateStudentRequest
+	dormitoryCode string
+	class         string
+	errors        []string
+}
+
+// ImportStudents validates every row and, unless DryRun is set, stores them all in one
+// transaction. When any row is invalid nothing is stored and the per-row errors are
+// returned together with ErrStudentImportInvalid.
+func (uc *StudentImportUseCase) ImportStudents(ctx context.Context, req dto.ImportStudentsRequest) (*dto.ImportStudentsResponse, error) {
+	resp := &dto.ImportStudentsResponse{DryRun: req.DryRun, Errors: []dto.StudentImportRowError{}}
+
+	if len(req.Rows) == 0 {
+		resp.Errors = append(resp.Errors, dto.StudentImportRowError{Row: 1, Errors: []string{"file is empty"}})
+		return uc.invalidImport(resp)
+	}
+
+	columns := indexImportHeader(req.Rows[0])
+	var missing []string
+	for _, column := range requiredImportColumns {
+		if _, ok := columns[column]; !ok {
+			missing = append(missing, "missing column "+column)
+		}
+	}
+	if len(missing) > 0 {
+		resp.Errors = append(resp.Errors, dto.StudentImportRowError{Row: 1, Errors: missing})
+		return uc.invalidImport(resp)
+	}
+
+	rows := make([]*studentImportRow, 0, len(req.Rows)-1)
+	for i, cells := range req.Rows[1:] {
+		if isBlankRow(cells) {
+			continue
+		}
+		rows = append(rows, uc.parseImportRow(columns, cells, i+2))
+	}
+	resp.TotalRows = len(rows)
+	if len(rows) == 0 {
+		resp.Errors = append(resp.Errors, dto.StudentImportRowError{Row: 2, Errors: []string{"file contains no student rows"}})
+		return uc.invalidImport(resp)
+	}
+
+	if err := uc.checkStudentNumbers(ctx, rows); err != nil {
+		return nil, err
+	}
+
+	resolver := &importPlacementResolver{
+		uc:          uc,
+		scope:       appService.DormitoryScopeFromContext(ctx),
+		dormitories: make(map[string]*entity.Dormitory),
+		classes:     make(map[uuid.UUID][]*entity.Class),
+	}
+	now := time.Now()
+	records := make([]repository.StudentImportRecord, 0, len(rows))
+	for _, row := range rows {
+		dormitory, err := resolver.dormitory(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		var class *entity.Class
+		if dormitory != nil && row.class != "" {
+			if class, err = resolver.class(ctx, row, dormitory); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(row.errors) > 0 {
+			resp.Errors = append(resp.Errors, dto.StudentImportRowError{
+				Row:           row.number,
+				StudentNumber: row.request.StudentNumber,
+				Errors:        row.errors,
+			})
+			continue
+		}
+		records = append(records, buildImportRecord(row.request, dormitory, class, now))
+	}
+	resp.ValidRows = len(records)
+
+	if len(resp.Errors) > 0 {
+		return uc.invalidImport(resp)
+	}
+	if req.DryRun {
+		return resp, nil
+	}
+
+	if err := uc.studentRepo.CreateImport(ctx, records); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	resp.Imported = len(records)
+
+	for _, record := range records {
+		metadata := map[string]string{
+			"student_number": record.Student.StudentNumber,
+			"full_name":      record.Student.FullName,
+			"dormitory_id":   record.History.DormitoryID.String(),
+			"source":         "import",
+		}
+		if record.Enrollment != nil {
+			metadata["class_id"] = record.Enrollment.ClassID.String()
+		}
+		_ = uc.auditLogger.Log(ctx, "student", "student:create", record.Student.ID.String(), metadata)
+	}
+
+	return resp, nil
+}
+
+// invalidImport returns the report as-is for dry runs and flags it as a failure otherwise.
+func (uc *StudentImportUseCase) invalidImport(resp *dto.ImportStudentsResponse) (*dto.ImportStudentsResponse, error) {
+	if resp.DryRun {
+		return resp, nil
+	}
+	return resp, domainErrors.ErrStudentImportInvalid
+}
+
+func (uc *StudentImportUseCase) parseImportRow(columns map[string]int, cells []string, number int) *studentImportRow {
+	value := func(column string) string {
+		idx, ok := columns[column]
+		if !ok || idx >= len(cells) {
+			return ""
+		}
+		return strings.TrimSpace(cells[idx])
+	}
+
+	row := &studentImportRow{
+		number: number,
+		request: dto.CreateStudentRequest{
+			StudentNumber: value(importColStudentNumber),
+			FullName:      value(importColFullName),
+			Gender:        strings.ToLower(value(importColGender)),
+			ParentName:    value(importColParentName),
+		},
+		dormitoryCode: value(importColDormitoryCode),
+		class:         value(importColClass),
+	}
+
+	invalidDate := false
+	if raw := value(importColBirthDate); raw != "" {
+		birthDate, err := parseImportDate(raw)
+		if err != nil {
+			row.errors = append(row.errors, "birth_date: invalid date, use YYYY-MM-DD")
+			invalidDate = true
+		} else {
+			row.request.BirthDate = birthDate
+		}
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if err := uc.validate.Struct(row.request); errors.As(err, &fieldErrors) {
+		for _, fe := range fieldErrors {
+			if invalidDate && fe.Field() == importColBirthDate {
+				continue
+			}
+			row.errors = append(row.errors, describeImportFieldError(fe))
+		}
+	}
+
+	if row.dormitoryCode == "" {
+		row.errors = append(row.errors, "dormitory_code: is required")
+	}
+	return row
+}
+
+// checkStudentNumbers flags numbers repeated within the file or already registered.
+func (uc *StudentImportUseCase) checkStudentNumbers(ctx context.Context, rows []*studentImportRow) error {
+	firstSeen := make(map[string]int, len(rows))
+	numbers := make([]string, 0, len(rows))
+	for _, row := range rows {
+		number := row.request.StudentNumber
+		if number == "" {
+			continue
+		}
+		if first, ok := firstSeen[number]; ok {
+			row.errors = append(row.errors, fmt.Sprintf("student_number: duplicates row %d", first))
+			continue
+		}
+		firstSeen[number] = row.number
+		numbers = append(numbers, number)
+	}
+
+	existing, err := uc.studentRepo.ExistingStudentNumbers(ctx, numbers)
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, number := range existing {
+		taken[number] = true
+	}
+	for _, row := range rows {
+		if taken[row.request.StudentNumber] {
+			row.errors = append(row.errors, "student_number: already exists")
+		}
+	}
+	return nil
+}
+
+// importPlacementResolver looks up dormitories and classes once per import.
+type importPlacementResolver struct {
+	uc          *StudentImportUseCase
+	scope       appService.DormitoryScope
+	dormitories map[string]*entity.Dormitory
+	classes     map[uuid.UUID][]*entity.Class
+}
+
+// dormitory resolves the row's dormitory code, recording a row error when it cannot be used.
+func (r *importPlacementResolver) dormitory(ctx context.Context, row *studentImportRow) (*entity.Dormitory, error) {
+	if row.dormitoryCode == "" {
+		return nil, nil
+	}
+
+	dormitory, cached := r.dormitories[row.dormitoryCode]
+	if !cached {
+		found, err := r.uc.dormRepo.GetByCode(ctx, row.dormitoryCode)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrInternalServer
+		}
+		dormitory = found
+		r.dormitories[row.dormitoryCode] = dormitory
+	}
+
+	switch {
+	case dormitory == nil:
+		row.errors = append(row.errors, fmt.Sprintf("dormitory_code: unknown dormitory %q", row.dormitoryCode))
+	case !r.scope.Allows(dormitory.ID):
+		row.errors = append(row.errors, fmt.Sprintf("dormitory_code: access denied to dormitory %q", row.dormitoryCode))
+	case !dormitory.IsActive:
+		row.errors = append(row.errors, fmt.Sprintf("dormitory_code: dormitory %q is inactive", row.dormitoryCode))
+	default:
+		return dormitory, nil
+	}
+	return nil, nil
+}
+
+// class matches the row's class by ID or name among the dormitory's classes.
+func (r *importPlacementResolver) class(ctx context.Context, row *studentImportRow, dormitory *entity.Dormitory) (*entity.Class, error) {
+	classes, cached := r.classes[dormitory.ID]
+	if !cached {
+		found, err := r.uc.classRepo.ListByDormitory(ctx, dormitory.ID)
+		if err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
+		classes = found
+		r.classes[dormitory.ID] = classes
+	}
+
+	var matches []*entity.Class
+	if classID, err := uuid.Parse(row.class); err == nil {
+		for _, class := range classes {
+			if class.ID == classID {
+				matches = append(matches, class)
+			}
+		}
+	} else {
+		for _, class := range classes {
+			if strings.EqualFold(class.Name, row.class) {
+				matches = append(matches, class)
+			}
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		row.errors = append(row.errors, fmt.Sprintf("class: %q not found in dormitory %q", row.class, dormitory.Code))
+	case len(matches) > 1:
+		row.errors = append(row.errors, fmt.Sprintf("class: %q matches several classes, use the class ID", row.class))
+	case !matches[0].IsActive:
+		row.errors = append(row.errors, fmt.Sprintf("class: %q is inactive", row.class))
+	default:
+		return matches[0], nil
+	}
+	return nil, nil
+}
+
+func buildImportRecord(req dto.CreateStudentRequest, dormitory *entity.Dormitory, class *entity.Class, now time.Time) repository.StudentImportRecord {
+	student := &entity.Student{
+		ID:            uuid.New(),
+		StudentNumber: req.StudentNumber,
+		FullName:      req.FullName,
+		BirthDate:     req.BirthDate,
+		Gender:        req.Gender,
+		ParentName:    req.ParentName,
+		Status:        entity.StudentStatusActive,
+		IsActive:      true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	record := repository.StudentImportRecord{
+		Student: student,
+		History: &entity.StudentDormitoryHistory{
+			ID:          uuid.New(),
+			StudentID:   student.ID,
+			DormitoryID: dormitory.ID,
+			StartDate:   now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		},
+	}
+	if class != nil {
+		record.Enrollment = &entity.StudentClassEnrollment{
+			ID:         uuid.New(),
+			ClassID:    class.ID,
+			StudentID:  student.ID,
+			EnrolledAt: now,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+	}
+	return record
+}
+
+// indexImportHeader maps normalised column names to their position.
+func indexImportHeader(header []string) map[string]int {
+	columns := make(map[string]int, len(header))
+	for idx, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		key = strings.ReplaceAll(key, " ", "_")
+		if _, exists := columns[key]; !exists && key != "" {
+			columns[key] = idx
+		}
+	}
+	return columns
+}
+
+func isBlankRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseImportDate accepts ISO dates, DD/MM/YYYY and spreadsheet date serial numbers.
+func parseImportDate(raw string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "02/01/2006"} {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return parsed, nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(raw, 64); err == nil && serial >= 1 {
+		return excelEpoch.AddDate(0, 0, int(serial)), nil
+	}
+	return time.Time{}, domainErrors.ErrBadRequest
+}
+
+func describeImportFieldError(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fe.Field() + ": is required"
+	case "oneof":
+		return fmt.Sprintf("%s: must be one of %s", fe.Field(), strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "min":
+		return fmt.Sprintf("%s: must be at least %s characters", fe.Field(), fe.Param())
+	case "max":
+		return fmt.Sprintf("%s: must be at most %s characters", fe.Field(), fe.Param())
+	case "alphanumunicode":
+		return fe.Field() + ": must contain only letters and digits"
+	default:
+		return fmt.Sprintf("%s: failed %s rule", fe.Field(), fe.Tag())
+	}
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+var studentImportHeader = []string{"Student Number", "full_name", "birth_date", "gender", "parent_name", "dormitory_code", "class"}
+
+func newStudentImportUseCase() (*StudentImportUseCase, *mocks.MockStudentRepository, *mocks.MockDormitoryRepository, *mocks.ClassRepositoryMock) {
+	studentRepo := new(mocks.MockStudentRepository)
+	dormRepo := new(mocks.MockDormitoryRepository)
+	classRepo := new(mocks.ClassRepositoryMock)
+	return NewStudentImportUseCase(studentRepo, dormRepo, classRepo, &noopAuditLogger{}), studentRepo, dormRepo, classRepo
+}
+
+func TestStudentImportUseCase_ImportStudents(t *testing.T) {
+	dorm := &entity.Dormitory{ID: uuid.New(), Code: "PUTRA1", IsActive: true}
+	class := &entity.Class{ID: uuid.New(), Name: "1A", IsActive: true}
+
+	validRows := [][]string{
+		studentImportHeader,
+		{"S001", "Ahmad Fauzi", "2010-05-01", "Male", "Bapak Fauzi", "PUTRA1", "1a"},
+		{},
+		{"S002", "Zaki Hamdan", "40179", "male", "Bapak Hamdan", "PUTRA1", ""},
+	}
+
+	t.Run("commits valid rows in one batch", func(t *testing.T) {
+		uc, studentRepo, dormRepo, classRepo := newStudentImportUseCase()
+		studentRepo.On("ExistingStudentNumbers", mock.Anything, []string{"S001", "S002"}).Return([]string{}, nil)
+		dormRepo.On("GetByCode", mock.Anything, "PUTRA1").Return(dorm, nil).Once()
+		classRepo.On("ListByDormitory", mock.Anything, dorm.ID).Return([]*entity.Class{class}, nil).Once()
+
+		var stored []repository.StudentImportRecord
+		studentRepo.On("CreateImport", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).([]repository.StudentImportRecord)
+		}).Return(nil)
+
+		resp, err := uc.ImportStudents(context.Background(), dto.ImportStudentsRequest{Rows: validRows})
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.TotalRows)
+		assert.Equal(t, 2, resp.Imported)
+		assert.Empty(t, resp.Errors)
+
+		require.Len(t, stored, 2)
+		assert.Equal(t, "male", stored[0].Student.Gender)
+		assert.Equal(t, dorm.ID, stored[0].History.DormitoryID)
+		assert.Equal(t, stored[0].Student.ID, stored[0].History.StudentID)
+		require.NotNil(t, stored[0].Enrollment)
+		assert.Equal(t, class.ID, stored[0].Enrollment.ClassID)
+		assert.Nil(t, stored[1].Enrollment)
+		assert.Equal(t, "2010-01-01", stored[1].Student.BirthDate.Format("2006-01-02"))
+
+		studentRepo.AssertExpectations(t)
+		dormRepo.AssertExpectations(t)
+		classRepo.AssertExpectations(t)
+	})
+
+	t.Run("dry run validates without storing", func(t *testing.T) {
+		uc, studentRepo, dormRepo, classRepo := newStudentImportUseCase()
+		studentRepo.On("ExistingStudentNumbers", mock.Anything, mock.Anything).Return([]string{}, nil)
+		dormRepo.On("GetByCode", mock.Anything, "PUTRA1").Return(dorm, nil)
+		classRepo.On("ListByDormitory", mock.Anything, dorm.ID).Return([]*entity.Class{class}, nil)
+
+		resp, err := uc.ImportStudents(context.Background(), dto.ImportStudentsRequest{Rows: validRows, DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, 2, resp.ValidRows)
+		assert.Zero(t, resp.Imported)
+		studentRepo.AssertNotCalled(t, "CreateImport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reports every invalid row and stores nothing", func(t *testing.T) {
+		uc, studentRepo, dormRepo, classRepo := newStudentImportUseCase()
+		studentRepo.On("ExistingStudentNumbers", mock.Anything, []string{"S001", "S003", "S004"}).Return([]string{"S003"}, nil)
+		dormRepo.On("GetByCode", mock.Anything, "PUTRA1").Return(dorm, nil)
+		dormRepo.On("GetByCode", mock.Anything, "NOPE").Return(nil, gorm.ErrRecordNotFound)
+		classRepo.On("ListByDormitory", mock.Anything, dorm.ID).Return([]*entity.Class{class}, nil)
+
+		rows := [][]string{
+			studentImportHeader,
+			{"S001", "Ahmad Fauzi", "2010-05-01", "male", "Bapak Fauzi", "PUTRA1", "1A"},
+			{"S001", "Ahmad Lagi", "2010-05-01", "male", "Bapak Fauzi", "PUTRA1", ""},
+			{"S003", "Al", "01-31-2010", "other", "Bu", "NOPE", ""},
+			{"S004", "Budi Santoso", "2011-02-03", "male", "Bapak Santoso", "PUTRA1", "9Z"},
+		}
+
+		resp, err := uc.ImportStudents(context.Background(), dto.ImportStudentsRequest{Rows: rows})
+		assert.ErrorIs(t, err, domainErrors.ErrStudentImportInvalid)
+		require.NotNil(t, resp)
+		assert.Equal(t, 4, resp.TotalRows)
+		assert.Equal(t, 1, resp.ValidRows)
+		require.Len(t, resp.Errors, 3)
+
+		assert.Equal(t, 3, resp.Errors[0].Row)
+		assert.Equal(t, []string{"student_number: duplicates row 2"}, resp.Errors[0].Errors)
+
+		assert.Equal(t, 4, resp.Errors[1].Row)
+		assert.ElementsMatch(t, []string{
+			"birth_date: invalid date, use YYYY-MM-DD",
+			"full_name: must be at least 3 characters",
+			"gender: must be one of male, female",
+			"parent_name: must be at least 3 characters",
+			"student_number: already exists",
+			`dormitory_code: unknown dormitory "NOPE"`,
+		}, resp.Errors[1].Errors)
+
+		assert.Equal(t, 5, resp.Errors[2].Row)
+		assert.Equal(t, []string{`class: "9Z" not found in dormitory "PUTRA1"`}, resp.Errors[2].Errors)
+
+		studentRepo.AssertNotCalled(t, "CreateImport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects dormitories outside the actor scope", func(t *testing.T) {
+		uc, studentRepo, dormRepo, _ := newStudentImportUseCase()
+		studentRepo.On("ExistingStudentNumbers", mock.Anything, mock.Anything).Return([]string{}, nil)
+		dormRepo.On("GetByCode", mock.Anything, "PUTRA1").Return(dorm, nil)
+
+		ctx := appService.WithDormitoryScope(context.Background(), appService.DormitoryScope{DormitoryIDs: []uuid.UUID{uuid.New()}})
+		rows := [][]string{studentImportHeader, {"S001", "Ahmad Fauzi", "2010-05-01", "male", "Bapak Fauzi", "PUTRA1", ""}}
+
+		resp, err := uc.ImportStudents(ctx, dto.ImportStudentsRequest{Rows: rows, DryRun: true})
+		require.NoError(t, err)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, []string{`dormitory_code: access denied to dormitory "PUTRA1"`}, resp.Errors[0].Errors)
+	})
+
+	t.Run("missing required columns", func(t *testing.T) {
+		uc, _, _, _ := newStudentImportUseCase()
+
+		resp, err := uc.ImportStudents(context.Background(), dto.ImportStudentsRequest{Rows: [][]string{{"student_number", "full_name"}}})
+		assert.ErrorIs(t, err, domainErrors.ErrStudentImportInvalid)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, 1, resp.Errors[0].Row)
+		assert.Contains(t, resp.Errors[0].Errors, "missing column dormitory_code")
+	})
+}
@@ -52,6 +52,9 @@ func (uc *StudentSKSResultUseCase) CreateStudentSKSResult(ctx context.Context, r
 	if _, err := uc.studentRepo.GetByID(ctx, studentID); err != nil {
 		return nil, domainErrors.ErrStudentNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, studentID); err != nil {
+		return nil, err
+	}
 
 	sksID, err := uuid.Parse(req.SKSID)
 	if err != nil {
@@ -105,12 +108,16 @@ func (uc *StudentSKSResultUseCase) UpdateStudentSKSResult(ctx context.Context, i
 	if err != nil {
 		return nil, domainErrors.ErrStudentSKSResultNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, result.StudentID); err != nil {
+		return nil, err
+	}
 
 	definition, err := uc.sksRepo.GetByID(ctx, result.SKSID)
 	if err != nil {
 		return nil, domainErrors.ErrSKSDefinitionNotFound
 	}
 
+	before := *result
 	if req.Score != nil {
 		result.Score = *req.Score
 	}
@@ -142,7 +149,7 @@ func (uc *StudentSKSResultUseCase) UpdateStudentSKSResult(ctx context.Context, i
 	}
 
 	uc.updateFanCompletion(ctx, result.StudentID, definition.FanID)
-	uc.logAudit(ctx, "sks_result:update", result.ID, map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "sks_result", "sks_result:update", result.ID.String(), &before, result, map[string]string{
 		"student_id": result.StudentID.String(),
 		"sks_id":     result.SKSID.String(),
 	})
@@ -155,6 +162,9 @@ func (uc *StudentSKSResultUseCase) ListStudentSKSResults(ctx context.Context, st
 	if _, err := uc.studentRepo.GetByID(ctx, studentID); err != nil {
 		return nil, domainErrors.ErrStudentNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, studentID); err != nil {
+		return nil, err
+	}
 
 	var fanID uuid.UUID
 	if fanIDStr != "" {
@@ -199,6 +209,9 @@ func (uc *StudentSKSResultUseCase) ListFanCompletionStatuses(ctx context.Context
 	if _, err := uc.studentRepo.GetByID(ctx, studentID); err != nil {
 		return nil, domainErrors.ErrStudentNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, studentID); err != nil {
+		return nil, err
+	}
 	statuses, err := uc.fanStatusRepo.ListByStudent(ctx, studentID)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
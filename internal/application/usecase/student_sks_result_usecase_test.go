@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
@@ -75,7 +76,8 @@ func TestStudentSKSResultUseCase_UpdateStudentSKSResult(t *testing.T) {
 	resultRepo.On("CountPassedByStudentFan", mock.Anything, studentID, fanID).Return(int64(1), nil)
 	fanRepo.On("Upsert", mock.Anything, mock.Anything).Return(nil)
 
-	uc := NewStudentSKSResultUseCase(resultRepo, fanRepo, studentRepo, sksRepo, teacherRepo, &noopAuditLogger{})
+	recorder := &changeRecorder{}
+	uc := NewStudentSKSResultUseCase(resultRepo, fanRepo, studentRepo, sksRepo, teacherRepo, recorder)
 	score := 60.0
 	resp, err := uc.UpdateStudentSKSResult(ctx, resultID, dto.UpdateStudentSKSResultRequest{Score: &score})
 
@@ -83,6 +85,9 @@ func TestStudentSKSResultUseCase_UpdateStudentSKSResult(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Equal(t, score, resp.Score)
 	assert.False(t, resp.IsPassed)
+	assert.Equal(t, map[string]appService.FieldChange{
+		"score": {Old: 70.0, New: 60.0},
+	}, recorder.changes["sks_result:update"])
 
 	resultRepo.AssertExpectations(t)
 	fanRepo.AssertExpectations(t)
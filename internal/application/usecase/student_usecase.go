@@ -67,25 +67,62 @@ func (uc *StudentUseCase) GetStudentByID(ctx context.Context, id uuid.UUID) (*dt
 	if err != nil {
 		return nil, domainErrors.ErrStudentNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, id); err != nil {
+		return nil, err
+	}
 
 	histories, _ := uc.studentRepo.ListHistory(ctx, id)
 	return uc.toStudentResponse(student, histories), nil
 }
 
-// ListStudents returns paginated students.
-func (uc *StudentUseCase) ListStudents(ctx context.Context, page, pageSize int) (*dto.ListStudentsResponse, error) {
-	if page < 1 {
-		page = 1
+// ListStudents returns paginated students matching the search, filters and sort order.
+// Results are limited to the dormitories in the caller's scope.
+func (uc *StudentUseCase) ListStudents(ctx context.Context, req dto.ListStudentsRequest) (*dto.ListStudentsResponse, error) {
+	page, pageSize := normalizePagination(req.Page, req.PageSize)
+
+	filter := repository.StudentFilter{
+		Search:        req.Search,
+		Status:        req.Status,
+		Gender:        req.Gender,
+		BirthYearFrom: req.BirthYearFrom,
+		BirthYearTo:   req.BirthYearTo,
+		SortBy:        req.SortBy,
+		SortDesc:      req.SortOrder == "desc",
+		Limit:         pageSize,
+		Offset:        (page - 1) * pageSize,
+	}
+	if req.SortBy == "" {
+		filter.SortBy = repository.StudentSortCreatedAt
+		filter.SortDesc = req.SortOrder != "asc"
+	}
+	if req.BirthYearFrom != nil && req.BirthYearTo != nil && *req.BirthYearFrom > *req.BirthYearTo {
+		return nil, domainErrors.ErrBadRequest
+	}
+
+	var err error
+	if filter.ClassID, err = parseOptionalUUID(req.ClassID); err != nil {
+		return nil, domainErrors.ErrBadRequest
 	}
-	if pageSize < 1 {
-		pageSize = 10
+	if filter.FanID, err = parseOptionalUUID(req.FanID); err != nil {
+		return nil, domainErrors.ErrBadRequest
 	}
-	if pageSize > 100 {
-		pageSize = 100
+	dormID, err := parseOptionalUUID(req.DormitoryID)
+	if err != nil {
+		return nil, domainErrors.ErrBadRequest
 	}
-	offset := (page - 1) * pageSize
 
-	students, total, err := uc.studentRepo.List(ctx, pageSize, offset)
+	dormID, filter.DormitoryIDs, err = resolveDormitoryFilter(ctx, dormID)
+	if err != nil {
+		return nil, err
+	}
+	if dormID != nil {
+		filter.DormitoryIDs = []uuid.UUID{*dormID}
+	}
+	if filter.DormitoryIDs != nil && len(filter.DormitoryIDs) == 0 {
+		return &dto.ListStudentsResponse{Students: []dto.StudentResponse{}, Page: page, PageSize: pageSize}, nil
+	}
+
+	students, total, err := uc.studentRepo.List(ctx, filter)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
@@ -115,6 +152,10 @@ func (uc *StudentUseCase) UpdateStudent(ctx context.Context, id uuid.UUID, req d
 	if err != nil {
 		return nil, domainErrors.ErrStudentNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, id); err != nil {
+		return nil, err
+	}
+	before := *student
 
 	if req.FullName != nil {
 		student.FullName = *req.FullName
@@ -135,7 +176,7 @@ func (uc *StudentUseCase) UpdateStudent(ctx context.Context, id uuid.UUID, req d
 	}
 
 	histories, _ := uc.studentRepo.ListHistory(ctx, id)
-	_ = uc.auditLogger.Log(ctx, "student", "student:update", student.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "student", "student:update", student.ID.String(), &before, student, map[string]string{
 		"student_number": student.StudentNumber,
 	})
 
@@ -148,7 +189,11 @@ func (uc *StudentUseCase) UpdateStudentStatus(ctx context.Context, id uuid.UUID,
 	if err != nil {
 		return nil, domainErrors.ErrStudentNotFound
 	}
+	if err := ensureStudentInScope(ctx, uc.studentRepo, id); err != nil {
+		return nil, err
+	}
 
+	before := *student
 	isActive := status == entity.StudentStatusActive
 	if err := uc.studentRepo.UpdateStatus(ctx, id, status, isActive); err != nil {
 		return nil, domainErrors.ErrInternalServer
@@ -159,7 +204,7 @@ func (uc *StudentUseCase) UpdateStudentStatus(ctx context.Context, id uuid.UUID,
 	student.UpdatedAt = time.Now()
 
 	histories, _ := uc.studentRepo.ListHistory(ctx, id)
-	_ = uc.auditLogger.Log(ctx, "student", "student:update-status", student.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "student", "student:update-status", student.ID.String(), &before, student, map[string]string{
 		"status": status,
 	})
 
@@ -167,6 +212,8 @@ func (uc *StudentUseCase) UpdateStudentStatus(ctx context.Context, id uuid.UUID,
 }
 
 // MutateStudentDormitory creates dormitory history entry.
+// Dormitory-bound actors may only move students between their own dormitories
+// or place a student that has no active dormitory yet.
 func (uc *StudentUseCase) MutateStudentDormitory(ctx context.Context, studentID, dormitoryID uuid.UUID, startDate time.Time) (*dto.StudentResponse, error) {
 	student, err := uc.studentRepo.GetByID(ctx, studentID)
 	if err != nil {
@@ -176,13 +223,23 @@ func (uc *StudentUseCase) MutateStudentDormitory(ctx context.Context, studentID,
 	if _, err := uc.dormRepo.GetByID(ctx, dormitoryID); err != nil {
 		return nil, domainErrors.ErrDormitoryNotFound
 	}
+	if err := ensureDormitoryInScope(ctx, dormitoryID); err != nil {
+		return nil, err
+	}
+
+	currentHistory, err := uc.studentRepo.GetActiveHistory(ctx, studentID)
+	if err == nil && currentHistory != nil {
+		if err := ensureDormitoryInScope(ctx, currentHistory.DormitoryID); err != nil {
+			return nil, err
+		}
+	}
 
 	if startDate.IsZero() {
 		startDate = time.Now()
 	}
 	now := time.Now()
 
-	if currentHistory, err := uc.studentRepo.GetActiveHistory(ctx, studentID); err == nil && currentHistory != nil {
+	if err == nil && currentHistory != nil {
 		if err := uc.studentRepo.CloseHistory(ctx, currentHistory.ID, startDate); err != nil {
 			return nil, domainErrors.ErrInternalServer
 		}
@@ -201,14 +258,23 @@ func (uc *StudentUseCase) MutateStudentDormitory(ctx context.Context, studentID,
 		return nil, domainErrors.ErrInternalServer
 	}
 
+	before := studentDormitoryChange{}
+	if err == nil && currentHistory != nil {
+		before.DormitoryID = &currentHistory.DormitoryID
+	}
 	histories, _ := uc.studentRepo.ListHistory(ctx, studentID)
-	_ = uc.auditLogger.Log(ctx, "student", "student:mutate-dorm", student.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "student", "student:mutate-dorm", student.ID.String(), &before, &studentDormitoryChange{DormitoryID: &dormitoryID}, map[string]string{
 		"dormitory_id": dormitoryID.String(),
 	})
 
 	return uc.toStudentResponse(student, histories), nil
 }
 
+// studentDormitoryChange is the audited view of a dormitory mutation.
+type studentDormitoryChange struct {
+	DormitoryID *uuid.UUID `json:"dormitory_id"`
+}
+
 func (uc *StudentUseCase) toStudentResponse(student *entity.Student, histories []*entity.StudentDormitoryHistory) *dto.StudentResponse {
 	var historyResponses []dto.StudentDormitoryEvent
 	if len(histories) > 0 {
@@ -244,3 +310,14 @@ func (uc *StudentUseCase) toStudentResponse(student *entity.Student, histories [
 		DormitoryHistory: historyResponses,
 	}
 }
+
+func parseOptionalUUID(val *string) (*uuid.UUID, error) {
+	if val == nil || *val == "" {
+		return nil, nil
+	}
+	parsed, err := uuid.Parse(*val)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
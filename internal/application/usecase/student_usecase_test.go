@@ -9,9 +9,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
 )
 
 func TestStudentUseCase_CreateStudent(t *testing.T) {
@@ -138,22 +140,93 @@ func TestStudentUseCase_ListStudents(t *testing.T) {
 	studentRepo := new(mocks.MockStudentRepository)
 	dormRepo := new(mocks.MockDormitoryRepository)
 
-	studentRepo.On("List", mock.Anything, 10, 0).Return([]*entity.Student{{ID: uuid.New(), StudentNumber: "S1"}}, int64(1), nil)
+	defaultFilter := repository.StudentFilter{SortBy: repository.StudentSortCreatedAt, SortDesc: true, Limit: 10, Offset: 0}
+	studentRepo.On("List", mock.Anything, defaultFilter).Return([]*entity.Student{{ID: uuid.New(), StudentNumber: "S1"}}, int64(1), nil)
 
 	uc := NewStudentUseCase(studentRepo, dormRepo, &noopAuditLogger{})
-	resp, err := uc.ListStudents(ctx, 1, 10)
+	resp, err := uc.ListStudents(ctx, dto.ListStudentsRequest{Page: 1, PageSize: 10})
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), resp.Total)
 	studentRepo.AssertExpectations(t)
 
 	t.Run("repo error", func(t *testing.T) {
 		repo := new(mocks.MockStudentRepository)
-		repo.On("List", mock.Anything, 10, 0).Return(nil, int64(0), assert.AnError)
+		repo.On("List", mock.Anything, defaultFilter).Return(nil, int64(0), assert.AnError)
 		ucErr := NewStudentUseCase(repo, dormRepo, &noopAuditLogger{})
-		resp, err := ucErr.ListStudents(ctx, 1, 10)
+		resp, err := ucErr.ListStudents(ctx, dto.ListStudentsRequest{Page: 1, PageSize: 10})
 		assert.ErrorIs(t, err, domainErrors.ErrInternalServer)
 		assert.Nil(t, resp)
 	})
+
+	t.Run("maps filters and sort", func(t *testing.T) {
+		repo := new(mocks.MockStudentRepository)
+		classID := uuid.New()
+		status := entity.StudentStatusActive
+		from, to := 2008, 2012
+		repo.On("List", mock.Anything, repository.StudentFilter{
+			Search:        "ali",
+			ClassID:       &classID,
+			Status:        &status,
+			BirthYearFrom: &from,
+			BirthYearTo:   &to,
+			SortBy:        repository.StudentSortFullName,
+			Limit:         20,
+			Offset:        20,
+		}).Return([]*entity.Student{}, int64(0), nil)
+
+		classIDStr := classID.String()
+		ucFilter := NewStudentUseCase(repo, dormRepo, &noopAuditLogger{})
+		resp, err := ucFilter.ListStudents(ctx, dto.ListStudentsRequest{
+			Search:        "ali",
+			ClassID:       &classIDStr,
+			Status:        &status,
+			BirthYearFrom: &from,
+			BirthYearTo:   &to,
+			SortBy:        "full_name",
+			SortOrder:     "asc",
+			Page:          2,
+			PageSize:      20,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Page)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid birth year range", func(t *testing.T) {
+		from, to := 2012, 2008
+		resp, err := uc.ListStudents(ctx, dto.ListStudentsRequest{BirthYearFrom: &from, BirthYearTo: &to})
+		assert.ErrorIs(t, err, domainErrors.ErrBadRequest)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("restricted to scoped dormitories", func(t *testing.T) {
+		repo := new(mocks.MockStudentRepository)
+		dormID := uuid.New()
+		scoped := appService.WithDormitoryScope(ctx, appService.DormitoryScope{DormitoryIDs: []uuid.UUID{dormID}})
+		repo.On("List", mock.Anything, mock.MatchedBy(func(f repository.StudentFilter) bool {
+			return len(f.DormitoryIDs) == 1 && f.DormitoryIDs[0] == dormID
+		})).Return([]*entity.Student{}, int64(0), nil)
+
+		ucScoped := NewStudentUseCase(repo, dormRepo, &noopAuditLogger{})
+		_, err := ucScoped.ListStudents(scoped, dto.ListStudentsRequest{})
+		assert.NoError(t, err)
+
+		other := uuid.New().String()
+		resp, err := ucScoped.ListStudents(scoped, dto.ListStudentsRequest{DormitoryID: &other})
+		assert.ErrorIs(t, err, domainErrors.ErrDormitoryAccessDenied)
+		assert.Nil(t, resp)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("empty scope returns no students", func(t *testing.T) {
+		repo := new(mocks.MockStudentRepository)
+		ucEmpty := NewStudentUseCase(repo, dormRepo, &noopAuditLogger{})
+		resp, err := ucEmpty.ListStudents(appService.WithDormitoryScope(ctx, appService.DormitoryScope{}), dto.ListStudentsRequest{})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Students)
+		assert.Equal(t, int64(0), resp.Total)
+		repo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
 }
 
 func TestStudentUseCase_MutateStudentDormitory(t *testing.T) {
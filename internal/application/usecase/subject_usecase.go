@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,6 +26,10 @@ func NewSubjectUseCase(subjectRepo repository.SubjectRepository, auditLogger app
 
 // CreateSubject creates a new subject entry.
 func (uc *SubjectUseCase) CreateSubject(ctx context.Context, req dto.CreateSubjectRequest) (*dto.SubjectResponse, error) {
+	if existing, _ := uc.subjectRepo.GetByName(ctx, req.Name); existing != nil {
+		return nil, domainErrors.ErrSubjectAlreadyExists
+	}
+
 	now := time.Now()
 	subject := &entity.Subject{
 		ID:          uuid.New(),
@@ -85,8 +90,12 @@ func (uc *SubjectUseCase) UpdateSubject(ctx context.Context, id uuid.UUID, req d
 	if err != nil {
 		return nil, domainErrors.ErrSubjectNotFound
 	}
+	before := *subject
 
 	if req.Name != nil {
+		if existing, _ := uc.subjectRepo.GetByName(ctx, *req.Name); existing != nil && existing.ID != subject.ID {
+			return nil, domainErrors.ErrSubjectAlreadyExists
+		}
 		subject.Name = *req.Name
 	}
 	if req.Description != nil {
@@ -101,25 +110,47 @@ func (uc *SubjectUseCase) UpdateSubject(ctx context.Context, id uuid.UUID, req d
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "subject", "subject:update", subject.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "subject", "subject:update", subject.ID.String(), &before, subject, map[string]string{
 		"name": subject.Name,
 	})
 
 	return uc.toSubjectResponse(subject), nil
 }
 
-// DeleteSubject deletes a subject.
-func (uc *SubjectUseCase) DeleteSubject(ctx context.Context, id uuid.UUID) error {
-	if _, err := uc.subjectRepo.GetByID(ctx, id); err != nil {
-		return domainErrors.ErrSubjectNotFound
+// DeleteSubject deletes a subject. Subjects still referenced by class schedules or SKS
+// definitions are deactivated instead and returned so the caller can tell the difference.
+func (uc *SubjectUseCase) DeleteSubject(ctx context.Context, id uuid.UUID) (*dto.SubjectResponse, error) {
+	subject, err := uc.subjectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, domainErrors.ErrSubjectNotFound
+	}
+
+	usage, err := uc.subjectRepo.CountUsage(ctx, id)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	if usage > 0 {
+		before := *subject
+		subject.IsActive = false
+		subject.UpdatedAt = time.Now()
+		if err := uc.subjectRepo.Update(ctx, subject); err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
+
+		_ = uc.auditLogger.LogChange(ctx, "subject", "subject:deactivate", id.String(), &before, subject, map[string]string{
+			"name":  subject.Name,
+			"usage": strconv.FormatInt(usage, 10),
+		})
+		return uc.toSubjectResponse(subject), nil
 	}
 
 	if err := uc.subjectRepo.Delete(ctx, id); err != nil {
-		return domainErrors.ErrInternalServer
+		return nil, domainErrors.ErrInternalServer
 	}
 
-	_ = uc.auditLogger.Log(ctx, "subject", "subject:delete", id.String(), nil)
-	return nil
+	_ = uc.auditLogger.LogChange(ctx, "subject", "subject:delete", id.String(), subject, nil, nil)
+	return nil, nil
 }
 
 func (uc *SubjectUseCase) toSubjectResponse(subject *entity.Subject) *dto.SubjectResponse {
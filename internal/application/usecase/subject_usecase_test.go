@@ -19,11 +19,16 @@ func (n *subjectNoopAuditLogger) Log(ctx context.Context, resource, action, targ
 	return nil
 }
 
+func (n *subjectNoopAuditLogger) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	return nil
+}
+
 func TestSubjectUseCase_CreateSubject(t *testing.T) {
 	repo := new(mocks.SubjectRepositoryMock)
 	logger := &subjectNoopAuditLogger{}
 	uc := NewSubjectUseCase(repo, logger)
 
+	repo.On("GetByName", mock.Anything, "Fiqh").Return(nil, assert.AnError)
 	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
 
 	resp, err := uc.CreateSubject(context.Background(), dto.CreateSubjectRequest{Name: "Fiqh"})
@@ -73,6 +78,7 @@ func TestSubjectUseCase_UpdateSubject(t *testing.T) {
 
 	id := uuid.New()
 	repo.On("GetByID", mock.Anything, id).Return(&entity.Subject{ID: id, Name: "Fiqh"}, nil)
+	repo.On("GetByName", mock.Anything, "Tafsir").Return(nil, assert.AnError)
 	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
 
 	newName := "Tafsir"
@@ -97,16 +103,50 @@ func TestSubjectUseCase_DeleteSubject(t *testing.T) {
 
 	id := uuid.New()
 	repo.On("GetByID", mock.Anything, id).Return(&entity.Subject{ID: id}, nil)
+	repo.On("CountUsage", mock.Anything, id).Return(int64(0), nil)
 	repo.On("Delete", mock.Anything, id).Return(nil)
 
-	err := uc.DeleteSubject(context.Background(), id)
+	deactivated, err := uc.DeleteSubject(context.Background(), id)
 	assert.NoError(t, err)
+	assert.Nil(t, deactivated)
 	repo.AssertExpectations(t)
 
 	repoFail := new(mocks.SubjectRepositoryMock)
 	ucFail := NewSubjectUseCase(repoFail, logger)
 	repoFail.On("GetByID", mock.Anything, id).Return(nil, assert.AnError)
 
-	err = ucFail.DeleteSubject(context.Background(), id)
+	_, err = ucFail.DeleteSubject(context.Background(), id)
 	assert.ErrorIs(t, err, domainErrors.ErrSubjectNotFound)
 }
+
+func TestSubjectUseCase_DeleteSubject_InUseDeactivates(t *testing.T) {
+	repo := new(mocks.SubjectRepositoryMock)
+	uc := NewSubjectUseCase(repo, &subjectNoopAuditLogger{})
+
+	id := uuid.New()
+	repo.On("GetByID", mock.Anything, id).Return(&entity.Subject{ID: id, Name: "Fiqh", IsActive: true}, nil)
+	repo.On("CountUsage", mock.Anything, id).Return(int64(2), nil)
+	repo.On("Update", mock.Anything, mock.MatchedBy(func(subject *entity.Subject) bool {
+		return subject.ID == id && !subject.IsActive
+	})).Return(nil)
+
+	deactivated, err := uc.DeleteSubject(context.Background(), id)
+	assert.NoError(t, err)
+	if assert.NotNil(t, deactivated) {
+		assert.False(t, deactivated.IsActive)
+	}
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestSubjectUseCase_CreateSubject_Duplicate(t *testing.T) {
+	repo := new(mocks.SubjectRepositoryMock)
+	uc := NewSubjectUseCase(repo, &subjectNoopAuditLogger{})
+
+	repo.On("GetByName", mock.Anything, "Fiqh").Return(&entity.Subject{ID: uuid.New(), Name: "Fiqh"}, nil)
+
+	resp, err := uc.CreateSubject(context.Background(), dto.CreateSubjectRequest{Name: "Fiqh"})
+	assert.ErrorIs(t, err, domainErrors.ErrSubjectAlreadyExists)
+	assert.Nil(t, resp)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -12,14 +12,16 @@ import (
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
 // TeacherUseCase orchestrates teacher operations.
 type TeacherUseCase struct {
-	teacherRepo repository.TeacherRepository
-	userRepo    repository.UserRepository
-	roleRepo    repository.RoleRepository
-	auditLogger appService.AuditLogger
+	teacherRepo    repository.TeacherRepository
+	userRepo       repository.UserRepository
+	roleRepo       repository.RoleRepository
+	auditLogger    appService.AuditLogger
+	principalCache service.PrincipalCache
 }
 
 // NewTeacherUseCase constructs TeacherUseCase.
@@ -28,12 +30,14 @@ func NewTeacherUseCase(
 	userRepo repository.UserRepository,
 	roleRepo repository.RoleRepository,
 	auditLogger appService.AuditLogger,
+	principalCache service.PrincipalCache,
 ) *TeacherUseCase {
 	return &TeacherUseCase{
-		teacherRepo: teacherRepo,
-		userRepo:    userRepo,
-		roleRepo:    roleRepo,
-		auditLogger: auditLogger,
+		teacherRepo:    teacherRepo,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		auditLogger:    auditLogger,
+		principalCache: principalCache,
 	}
 }
 
@@ -45,6 +49,7 @@ func (uc *TeacherUseCase) CreateTeacher(ctx context.Context, req dto.CreateTeach
 
 	var user *entity.User
 	var err error
+	var temporaryPassword string
 
 	if req.ExistingUsername != "" {
 		user, err = uc.userRepo.GetByUsername(ctx, strings.ToLower(req.ExistingUsername))
@@ -58,15 +63,21 @@ func (uc *TeacherUseCase) CreateTeacher(ctx context.Context, req dto.CreateTeach
 			return nil, err
 		}
 	} else {
+		// New accounts get a one-time password that must be changed on first login.
+		temporaryPassword, err = generateTemporaryPassword()
+		if err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
 		username := uc.deriveUsername(ctx, req.FullName)
 		user = &entity.User{
-			ID:        uuid.New(),
-			Username:  username,
-			Password:  "ppdf2025",
-			Name:      req.FullName,
-			IsActive:  true,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			ID:                 uuid.New(),
+			Username:           username,
+			Password:           temporaryPassword,
+			Name:               req.FullName,
+			IsActive:           true,
+			MustChangePassword: true,
+			CreatedAt:          time.Now(),
+			UpdatedAt:          time.Now(),
 		}
 		if err := user.HashPassword(); err != nil {
 			return nil, domainErrors.ErrInternalServer
@@ -105,7 +116,9 @@ func (uc *TeacherUseCase) CreateTeacher(ctx context.Context, req dto.CreateTeach
 		"teacher_code": teacher.TeacherCode,
 	})
 
-	return uc.toTeacherResponse(teacher, user), nil
+	resp := uc.toTeacherResponse(teacher, user)
+	resp.TemporaryPassword = temporaryPassword
+	return resp, nil
 }
 
 // GetTeacher retrieves teacher by id.
@@ -156,6 +169,7 @@ func (uc *TeacherUseCase) UpdateTeacher(ctx context.Context, id uuid.UUID, req d
 	if err != nil {
 		return nil, domainErrors.ErrTeacherNotFound
 	}
+	before := *teacher
 
 	if req.FullName != nil {
 		teacher.FullName = *req.FullName
@@ -201,12 +215,14 @@ func (uc *TeacherUseCase) UpdateTeacher(ctx context.Context, id uuid.UUID, req d
 			}
 			if updated {
 				user.UpdatedAt = time.Now()
-				_ = uc.userRepo.Update(ctx, user)
+				if err := uc.userRepo.Update(ctx, user); err == nil {
+					invalidatePrincipals(ctx, uc.principalCache, user.ID)
+				}
 			}
 		}
 	}
 
-	_ = uc.auditLogger.Log(ctx, "teacher", "teachers:update", teacher.ID.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "teacher", "teachers:update", teacher.ID.String(), &before, teacher, map[string]string{
 		"teacher_code": teacher.TeacherCode,
 	})
 
@@ -215,13 +231,16 @@ func (uc *TeacherUseCase) UpdateTeacher(ctx context.Context, id uuid.UUID, req d
 
 // DeactivateTeacher soft-deletes teacher.
 func (uc *TeacherUseCase) DeactivateTeacher(ctx context.Context, id uuid.UUID) error {
-	if _, err := uc.teacherRepo.GetByID(ctx, id); err != nil {
+	teacher, err := uc.teacherRepo.GetByID(ctx, id)
+	if err != nil {
 		return domainErrors.ErrTeacherNotFound
 	}
 	if err := uc.teacherRepo.SoftDelete(ctx, id); err != nil {
 		return domainErrors.ErrInternalServer
 	}
-	_ = uc.auditLogger.Log(ctx, "teacher", "teachers:deactivate", id.String(), nil)
+	after := *teacher
+	after.IsActive = false
+	_ = uc.auditLogger.LogChange(ctx, "teacher", "teachers:deactivate", id.String(), teacher, &after, nil)
 	return nil
 }
 
@@ -230,7 +249,11 @@ func (uc *TeacherUseCase) ensureTeacherRole(ctx context.Context, userID uuid.UUI
 	if err != nil || teacherRole == nil {
 		return domainErrors.ErrRoleNotFound
 	}
-	return uc.userRepo.AssignRole(ctx, userID, teacherRole.ID)
+	if err := uc.userRepo.AssignRole(ctx, userID, teacherRole.ID); err != nil {
+		return err
+	}
+	invalidatePrincipals(ctx, uc.principalCache, userID)
+	return nil
 }
 
 func (uc *TeacherUseCase) deriveUsername(ctx context.Context, fullName string) string {
@@ -21,7 +21,7 @@ func TestTeacherUseCase_CreateTeacher_ExistingUserNotFound(t *testing.T) {
 	teacherRepo := new(mocks.MockTeacherRepository)
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, &teacherNoopAuditLogger{})
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, &teacherNoopAuditLogger{}, nil)
 
 	teacherRepo.On("GetByCode", mock.Anything, "TCH-ERR").Return(nil, domainErrors.ErrTeacherNotFound)
 	userRepo.On("GetByUsername", mock.Anything, "missing").Return(nil, assert.AnError)
@@ -40,7 +40,7 @@ func TestTeacherUseCase_CreateTeacher_UserAlreadyAssigned(t *testing.T) {
 	teacherRepo := new(mocks.MockTeacherRepository)
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, &teacherNoopAuditLogger{})
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, &teacherNoopAuditLogger{}, nil)
 
 	userID := uuid.New()
 	teacherRepo.On("GetByCode", mock.Anything, "TCH-DUP").Return(nil, domainErrors.ErrTeacherNotFound)
@@ -60,7 +60,7 @@ func TestTeacherUseCase_CreateTeacher_MissingTeacherRole(t *testing.T) {
 	teacherRepo := new(mocks.MockTeacherRepository)
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, &teacherNoopAuditLogger{})
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, &teacherNoopAuditLogger{}, nil)
 
 	teacherRepo.On("GetByCode", mock.Anything, "TCH-NOROLE").Return(nil, domainErrors.ErrTeacherNotFound)
 	userRepo.On("GetByUsername", mock.Anything, "norole").Return(nil, domainErrors.ErrUserNotFound)
@@ -78,7 +78,7 @@ func TestTeacherUseCase_CreateTeacher_UserCreateFails(t *testing.T) {
 	teacherRepo := new(mocks.MockTeacherRepository)
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, &teacherNoopAuditLogger{})
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, &teacherNoopAuditLogger{}, nil)
 
 	teacherRepo.On("GetByCode", mock.Anything, "TCH-USRERR").Return(nil, domainErrors.ErrTeacherNotFound)
 	userRepo.On("GetByUsername", mock.Anything, "userfails").Return(nil, domainErrors.ErrUserNotFound)
@@ -97,7 +97,7 @@ func TestTeacherUseCase_EnsureTeacherRole_Error(t *testing.T) {
 	teacherRepo := new(mocks.MockTeacherRepository)
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, &teacherNoopAuditLogger{})
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, &teacherNoopAuditLogger{}, nil)
 
 	roleRepo.On("GetBySlug", mock.Anything, "teacher").Return(nil, assert.AnError)
 	err := uc.ensureTeacherRole(context.Background(), uuid.New())
@@ -109,7 +109,7 @@ func TestTeacherUseCase_GetTeacher_WithUser(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
 	logger := &teacherNoopAuditLogger{}
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger)
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger, nil)
 
 	teacherID := uuid.New()
 	userID := uuid.New()
@@ -127,7 +127,7 @@ func TestTeacherUseCase_UpdateTeacher_SyncsUserName(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
 	logger := &teacherNoopAuditLogger{}
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger)
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger, nil)
 
 	teacherID := uuid.New()
 	userID := uuid.New()
@@ -158,7 +158,7 @@ func TestTeacherUseCase_DeactivateTeacher(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
 	logger := &teacherNoopAuditLogger{}
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger)
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger, nil)
 
 	teacherID := uuid.New()
 	teacherRepo.On("GetByID", mock.Anything, teacherID).Return(&entity.Teacher{ID: teacherID}, nil)
@@ -173,7 +173,7 @@ func TestTeacherUseCase_DeactivateTeacher_NotFound(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
 	logger := &teacherNoopAuditLogger{}
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger)
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger, nil)
 
 	teacherID := uuid.New()
 	teacherRepo.On("GetByID", mock.Anything, teacherID).Return(nil, assert.AnError)
@@ -186,19 +186,23 @@ func (n *teacherNoopAuditLogger) Log(ctx context.Context, resource, action, targ
 	return nil
 }
 
+func (n *teacherNoopAuditLogger) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	return nil
+}
+
 func TestTeacherUseCase_CreateTeacher_AutoUser(t *testing.T) {
 	teacherRepo := new(mocks.MockTeacherRepository)
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
 	logger := &teacherNoopAuditLogger{}
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger)
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger, nil)
 
 	teacherRepo.On("GetByCode", mock.Anything, "TCH-01").Return(nil, domainErrors.ErrTeacherNotFound)
 	userRepo.On("GetByUsername", mock.Anything, "johndoe").Return(nil, domainErrors.ErrUserNotFound)
 	teacherRoleID := uuid.New()
 	roleRepo.On("GetBySlug", mock.Anything, "teacher").Return(&entity.Role{ID: teacherRoleID}, nil)
 	userRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
-		return u.Username == "johndoe" && u.Name == "John Doe"
+		return u.Username == "johndoe" && u.Name == "John Doe" && u.MustChangePassword
 	})).Return(nil)
 	teacherRepo.On("Create", mock.Anything, mock.MatchedBy(func(tch *entity.Teacher) bool {
 		return tch.TeacherCode == "TCH-01" && tch.FullName == "John Doe"
@@ -213,6 +217,7 @@ func TestTeacherUseCase_CreateTeacher_AutoUser(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Equal(t, "TCH-01", resp.TeacherCode)
 	assert.Equal(t, "John Doe", resp.FullName)
+	assert.Len(t, resp.TemporaryPassword, temporaryPasswordLength)
 	teacherRepo.AssertExpectations(t)
 	userRepo.AssertExpectations(t)
 	roleRepo.AssertExpectations(t)
@@ -223,7 +228,7 @@ func TestTeacherUseCase_CreateTeacher_WithExistingUser(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
 	logger := &teacherNoopAuditLogger{}
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger)
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger, nil)
 
 	userID := uuid.New()
 	teacherRoleID := uuid.New()
@@ -254,7 +259,7 @@ func TestTeacherUseCase_ListTeachers_NormalizesPagination(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	roleRepo := new(mocks.MockRoleRepository)
 	logger := &teacherNoopAuditLogger{}
-	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger)
+	uc := NewTeacherUseCase(teacherRepo, userRepo, roleRepo, logger, nil)
 
 	teacherID := uuid.New()
 	userID := uuid.New()
@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+const (
+	recoveryCodeCount      = 10
+	recoveryCodeHalfLength = 5
+	// recoveryCodeAlphabet is lower case only so codes survive case-insensitive entry.
+	recoveryCodeAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+)
+
+// Two-factor method names recorded in auth event metadata.
+const (
+	twoFactorMethodTOTP         = "totp"
+	twoFactorMethodRecoveryCode = "recovery_code"
+)
+
+// TwoFactorUseCase handles TOTP enrolment, recovery codes and second-factor verification
+type TwoFactorUseCase struct {
+	userRepo       repository.UserRepository
+	recoveryRepo   repository.RecoveryCodeRepository
+	totpService    service.TOTPService
+	auditLogger    appService.AuditLogger
+	principalCache service.PrincipalCache
+}
+
+// NewTwoFactorUseCase creates a new two-factor use case
+func NewTwoFactorUseCase(
+	userRepo repository.UserRepository,
+	recoveryRepo repository.RecoveryCodeRepository,
+	totpService service.TOTPService,
+	auditLogger appService.AuditLogger,
+	principalCache service.PrincipalCache,
+) *TwoFactorUseCase {
+	return &TwoFactorUseCase{
+		userRepo:       userRepo,
+		recoveryRepo:   recoveryRepo,
+		totpService:    totpService,
+		auditLogger:    auditLogger,
+		principalCache: principalCache,
+	}
+}
+
+// BeginSetup stores a new pending secret for the user. It only takes effect
+// once confirmed with a code from the authenticator app.
+func (uc *TwoFactorUseCase) BeginSetup(ctx context.Context, userID uuid.UUID) (*dto.TwoFactorSetupResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+	if user.TwoFactorEnabled {
+		return nil, domainErrors.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := uc.totpService.GenerateSecret()
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	user.TwoFactorSecret = secret
+	user.TwoFactorLastStep = 0
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: uc.totpService.ProvisioningURI(user.Username, secret),
+	}, nil
+}
+
+// ConfirmSetup enables two-factor authentication after verifying the first
+// code and returns the initial recovery codes.
+func (uc *TwoFactorUseCase) ConfirmSetup(ctx context.Context, userID uuid.UUID, req dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+	if user.TwoFactorEnabled {
+		return nil, domainErrors.ErrTwoFactorAlreadyEnabled
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, domainErrors.ErrTwoFactorSetupNotStarted
+	}
+
+	step, ok := uc.totpService.Validate(user.TwoFactorSecret, req.Code, time.Now())
+	if !ok {
+		return nil, domainErrors.ErrTwoFactorCodeInvalid
+	}
+
+	user.TwoFactorEnabled = true
+	user.TwoFactorLastStep = step
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	// Lifts a pending enrolment requirement on the very next request
+	invalidatePrincipals(ctx, uc.principalCache, user.ID)
+
+	codes, err := uc.replaceRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log(ctx, user, "auth:2fa_enabled", nil)
+	return codes, nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after verifying a TOTP code.
+func (uc *TwoFactorUseCase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, req dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+	if !user.TwoFactorEnabled {
+		return nil, domainErrors.ErrTwoFactorNotEnabled
+	}
+	if err := uc.verifyTOTP(ctx, user, req.Code); err != nil {
+		return nil, err
+	}
+
+	codes, err := uc.replaceRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log(ctx, user, "auth:2fa_recovery_codes", nil)
+	return codes, nil
+}
+
+// Disable turns two-factor authentication off. Both the password and a
+// current code are required, and roles that demand 2FA block it.
+func (uc *TwoFactorUseCase) Disable(ctx context.Context, userID uuid.UUID, req dto.DisableTwoFactorRequest) error {
+	user, err := uc.userRepo.GetWithRoles(ctx, userID)
+	if err != nil {
+		return domainErrors.ErrUserNotFound
+	}
+	if !user.TwoFactorEnabled {
+		return domainErrors.ErrTwoFactorNotEnabled
+	}
+	for _, role := range user.Roles {
+		if role.TwoFactorRequired {
+			return domainErrors.ErrTwoFactorEnforced
+		}
+	}
+	if !user.CheckPassword(req.Password) {
+		return domainErrors.ErrCurrentPasswordInvalid
+	}
+	if _, err := uc.Verify(ctx, user, req.Code); err != nil {
+		return err
+	}
+
+	// Save must not rewrite the role associations loaded above.
+	user.Roles = nil
+	if err := uc.clear(ctx, user); err != nil {
+		return err
+	}
+
+	uc.log(ctx, user, "auth:2fa_disabled", nil)
+	return nil
+}
+
+// Reset lets an administrator remove a user's second factor, e.g. after a lost
+// device. Users whose roles require 2FA must enrol again on their next login.
+func (uc *TwoFactorUseCase) Reset(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domainErrors.ErrUserNotFound
+	}
+	if err := uc.clear(ctx, user); err != nil {
+		return err
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "user", "user:reset_2fa", user.ID.String(), map[string]string{
+		"username": user.Username,
+	})
+	return nil
+}
+
+// Verify checks a second-factor code for a user with 2FA enabled. Six-digit
+// codes are treated as TOTP codes, anything else as a recovery code. It
+// returns the method that matched.
+func (uc *TwoFactorUseCase) Verify(ctx context.Context, user *entity.User, code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == 6 {
+		if err := uc.verifyTOTP(ctx, user, code); err != nil {
+			return "", err
+		}
+		return twoFactorMethodTOTP, nil
+	}
+
+	used, err := uc.recoveryRepo.Consume(ctx, user.ID, hashRecoveryCode(code))
+	if err != nil {
+		return "", domainErrors.ErrInternalServer
+	}
+	if !used {
+		return "", domainErrors.ErrTwoFactorCodeInvalid
+	}
+	return twoFactorMethodRecoveryCode, nil
+}
+
+// verifyTOTP accepts a code only for a time step later than the last accepted
+// one, so an observed code cannot be replayed. The step is advanced with a
+// conditional update, so concurrent attempts with the same code accept one.
+func (uc *TwoFactorUseCase) verifyTOTP(ctx context.Context, user *entity.User, code string) error {
+	step, ok := uc.totpService.Validate(user.TwoFactorSecret, code, time.Now())
+	if !ok || step <= user.TwoFactorLastStep {
+		return domainErrors.ErrTwoFactorCodeInvalid
+	}
+
+	advanced, err := uc.userRepo.AdvanceTwoFactorStep(ctx, user.ID, step)
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	if !advanced {
+		return domainErrors.ErrTwoFactorCodeInvalid
+	}
+	user.TwoFactorLastStep = step
+	return nil
+}
+
+func (uc *TwoFactorUseCase) clear(ctx context.Context, user *entity.User) error {
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = ""
+	user.TwoFactorLastStep = 0
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	invalidatePrincipals(ctx, uc.principalCache, user.ID)
+	if err := uc.recoveryRepo.DeleteByUser(ctx, user.ID); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	return nil
+}
+
+func (uc *TwoFactorUseCase) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) (*dto.RecoveryCodesResponse, error) {
+	now := time.Now()
+	plain := make([]string, 0, recoveryCodeCount)
+	records := make([]*entity.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
+		plain = append(plain, code)
+		records = append(records, &entity.RecoveryCode{
+			ID:        uuid.New(),
+			UserID:    userID,
+			CodeHash:  hashRecoveryCode(code),
+			CreatedAt: now,
+		})
+	}
+
+	if err := uc.recoveryRepo.ReplaceForUser(ctx, userID, records); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	return &dto.RecoveryCodesResponse{RecoveryCodes: plain}, nil
+}
+
+func (uc *TwoFactorUseCase) log(ctx context.Context, user *entity.User, action string, metadata map[string]string) {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata["username"] = user.Username
+	ctx = context.WithValue(ctx, appService.CtxKeyActorID, user.ID)
+	ctx = context.WithValue(ctx, appService.CtxKeyActorUsername, user.Username)
+	_ = uc.auditLogger.Log(ctx, authEventResource, action, user.ID.String(), metadata)
+}
+
+// generateRecoveryCode returns a code like "abcde-fghjk".
+func generateRecoveryCode() (string, error) {
+	first, err := randomString(recoveryCodeAlphabet, recoveryCodeHalfLength)
+	if err != nil {
+		return "", err
+	}
+	second, err := randomString(recoveryCodeAlphabet, recoveryCodeHalfLength)
+	if err != nil {
+		return "", err
+	}
+	return first + "-" + second, nil
+}
+
+// hashRecoveryCode normalises case and separators before hashing so codes can
+// be typed loosely.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
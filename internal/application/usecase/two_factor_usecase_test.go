@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+func newTwoFactorUseCase() (*TwoFactorUseCase, *mocks.MockUserRepository, *mocks.MockRecoveryCodeRepository, *mocks.MockTOTPService) {
+	userRepo := new(mocks.MockUserRepository)
+	recoveryRepo := new(mocks.MockRecoveryCodeRepository)
+	totp := new(mocks.MockTOTPService)
+	return NewTwoFactorUseCase(userRepo, recoveryRepo, totp, &noopAuditLogger{}, nil), userRepo, recoveryRepo, totp
+}
+
+func TestTwoFactorUseCase_Setup(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("begin stores a pending secret", func(t *testing.T) {
+		uc, userRepo, _, totp := newTwoFactorUseCase()
+		userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "admin"}, nil)
+		totp.On("GenerateSecret").Return("SECRET", nil)
+		totp.On("ProvisioningURI", "admin", "SECRET").Return("otpauth://totp/SIGAP:admin?secret=SECRET")
+		userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+			return u.TwoFactorSecret == "SECRET" && !u.TwoFactorEnabled
+		})).Return(nil)
+
+		resp, err := uc.BeginSetup(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, "SECRET", resp.Secret)
+		assert.Contains(t, resp.ProvisioningURI, "otpauth://totp/")
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("confirm enables and issues recovery codes", func(t *testing.T) {
+		uc, userRepo, recoveryRepo, totp := newTwoFactorUseCase()
+		userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "admin", TwoFactorSecret: "SECRET"}, nil)
+		totp.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(100), true)
+		userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+			return u.TwoFactorEnabled && u.TwoFactorLastStep == 100
+		})).Return(nil)
+		recoveryRepo.On("ReplaceForUser", mock.Anything, userID, mock.MatchedBy(func(codes []*entity.RecoveryCode) bool {
+			return len(codes) == recoveryCodeCount && len(codes[0].CodeHash) == 64
+		})).Return(nil)
+
+		resp, err := uc.ConfirmSetup(context.Background(), userID, dto.TwoFactorCodeRequest{Code: "123456"})
+		require.NoError(t, err)
+		require.Len(t, resp.RecoveryCodes, recoveryCodeCount)
+		assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, resp.RecoveryCodes[0])
+		recoveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("confirm rejects a wrong code", func(t *testing.T) {
+		uc, userRepo, _, totp := newTwoFactorUseCase()
+		userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, TwoFactorSecret: "SECRET"}, nil)
+		totp.On("Validate", "SECRET", "000000", mock.Anything).Return(int64(0), false)
+
+		_, err := uc.ConfirmSetup(context.Background(), userID, dto.TwoFactorCodeRequest{Code: "000000"})
+		assert.ErrorIs(t, err, domainErrors.ErrTwoFactorCodeInvalid)
+		userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("confirm requires setup first", func(t *testing.T) {
+		uc, userRepo, _, _ := newTwoFactorUseCase()
+		userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+
+		_, err := uc.ConfirmSetup(context.Background(), userID, dto.TwoFactorCodeRequest{Code: "123456"})
+		assert.ErrorIs(t, err, domainErrors.ErrTwoFactorSetupNotStarted)
+	})
+}
+
+func TestTwoFactorUseCase_Verify(t *testing.T) {
+	user := &entity.User{ID: uuid.New(), TwoFactorEnabled: true, TwoFactorSecret: "SECRET", TwoFactorLastStep: 100}
+
+	t.Run("rejects a replayed time step", func(t *testing.T) {
+		uc, userRepo, _, totp := newTwoFactorUseCase()
+		totp.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(100), true)
+
+		_, err := uc.Verify(context.Background(), user, "123456")
+		assert.ErrorIs(t, err, domainErrors.ErrTwoFactorCodeInvalid)
+		userRepo.AssertNotCalled(t, "AdvanceTwoFactorStep", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("accepts a new time step", func(t *testing.T) {
+		uc, userRepo, _, totp := newTwoFactorUseCase()
+		candidate := *user
+		totp.On("Validate", "SECRET", "654321", mock.Anything).Return(int64(101), true)
+		userRepo.On("AdvanceTwoFactorStep", mock.Anything, user.ID, int64(101)).Return(true, nil)
+
+		method, err := uc.Verify(context.Background(), &candidate, " 654321 ")
+		require.NoError(t, err)
+		assert.Equal(t, twoFactorMethodTOTP, method)
+		assert.Equal(t, int64(101), candidate.TwoFactorLastStep)
+		userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a time step accepted concurrently", func(t *testing.T) {
+		uc, userRepo, _, totp := newTwoFactorUseCase()
+		candidate := *user
+		totp.On("Validate", "SECRET", "654321", mock.Anything).Return(int64(101), true)
+		userRepo.On("AdvanceTwoFactorStep", mock.Anything, user.ID, int64(101)).Return(false, nil)
+
+		_, err := uc.Verify(context.Background(), &candidate, "654321")
+		assert.ErrorIs(t, err, domainErrors.ErrTwoFactorCodeInvalid)
+	})
+
+	t.Run("consumes recovery codes regardless of case and dashes", func(t *testing.T) {
+		uc, _, recoveryRepo, _ := newTwoFactorUseCase()
+		recoveryRepo.On("Consume", mock.Anything, user.ID, hashRecoveryCode("abcde-fghjk")).Return(true, nil).Once()
+		recoveryRepo.On("Consume", mock.Anything, user.ID, hashRecoveryCode("abcde-fghjk")).Return(false, nil)
+
+		method, err := uc.Verify(context.Background(), user, "ABCDEFGHJK")
+		require.NoError(t, err)
+		assert.Equal(t, twoFactorMethodRecoveryCode, method)
+
+		_, err = uc.Verify(context.Background(), user, "abcde-fghjk")
+		assert.ErrorIs(t, err, domainErrors.ErrTwoFactorCodeInvalid)
+	})
+}
+
+func TestTwoFactorUseCase_Disable(t *testing.T) {
+	newUser := func(t *testing.T, required bool) *entity.User {
+		user := &entity.User{
+			ID:                uuid.New(),
+			Password:          "password123",
+			TwoFactorEnabled:  true,
+			TwoFactorSecret:   "SECRET",
+			TwoFactorLastStep: 1,
+			Roles:             []entity.Role{{Name: "staff", TwoFactorRequired: required}},
+		}
+		require.NoError(t, user.HashPassword())
+		return user
+	}
+
+	t.Run("blocked by a role that requires 2FA", func(t *testing.T) {
+		uc, userRepo, _, _ := newTwoFactorUseCase()
+		user := newUser(t, true)
+		userRepo.On("GetWithRoles", mock.Anything, user.ID).Return(user, nil)
+
+		err := uc.Disable(context.Background(), user.ID, dto.DisableTwoFactorRequest{Password: "password123", Code: "123456"})
+		assert.ErrorIs(t, err, domainErrors.ErrTwoFactorEnforced)
+	})
+
+	t.Run("clears the secret and recovery codes", func(t *testing.T) {
+		uc, userRepo, recoveryRepo, totp := newTwoFactorUseCase()
+		user := newUser(t, false)
+		userRepo.On("GetWithRoles", mock.Anything, user.ID).Return(user, nil)
+		totp.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(2), true)
+		userRepo.On("AdvanceTwoFactorStep", mock.Anything, user.ID, int64(2)).Return(true, nil)
+		userRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		recoveryRepo.On("DeleteByUser", mock.Anything, user.ID).Return(nil)
+
+		err := uc.Disable(context.Background(), user.ID, dto.DisableTwoFactorRequest{Password: "password123", Code: "123456"})
+		require.NoError(t, err)
+		assert.False(t, user.TwoFactorEnabled)
+		assert.Empty(t, user.TwoFactorSecret)
+		assert.Nil(t, user.Roles)
+		recoveryRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthUseCase_LoginWithTwoFactor(t *testing.T) {
+	user := &entity.User{ID: uuid.New(), Username: "admin", Password: "password123", IsActive: true, TwoFactorEnabled: true, TwoFactorSecret: "SECRET"}
+	require.NoError(t, user.HashPassword())
+
+	userRepo := new(mocks.MockUserRepository)
+	sessionRepo := new(mocks.MockRefreshSessionRepository)
+	tokenService := new(mocks.MockTokenService)
+	throttleRepo := new(mocks.MockLoginThrottleRepository)
+	recoveryRepo := new(mocks.MockRecoveryCodeRepository)
+	totp := new(mocks.MockTOTPService)
+	audit := &authEventRecorder{}
+
+	twoFactor := NewTwoFactorUseCase(userRepo, recoveryRepo, totp, audit, nil)
+	authUseCase := NewAuthUseCase(userRepo, sessionRepo, tokenService, throttleRepo, audit, DefaultLoginLockoutPolicy(), DefaultRegistrationPolicy(), twoFactor, nil)
+
+	throttleRepo.On("GetByKey", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	throttleRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	userRepo.On("GetByUsername", mock.Anything, "admin").Return(user, nil)
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	userRepo.On("GetWithRoles", mock.Anything, user.ID).Return(user, nil)
+	userRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	tokenService.On("GenerateTwoFactorToken", user.ID).Return("2fa_token", nil)
+	tokenService.On("ValidateTwoFactorToken", "2fa_token").Return(&service.TokenClaims{UserID: user.ID}, nil)
+	tokenService.On("GenerateAccessToken", user.ID, "admin", []string{}).Return("access_token", nil)
+	tokenService.On("GenerateRefreshToken", user.ID, mock.Anything, mock.Anything).Return("refresh_token", nil)
+	tokenService.On("RefreshTokenExpiry").Return(time.Hour)
+	sessionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	totp.On("Validate", "SECRET", "111111", mock.Anything).Return(int64(0), false)
+	totp.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(50), true)
+	userRepo.On("AdvanceTwoFactorStep", mock.Anything, user.ID, int64(50)).Return(true, nil)
+
+	// The password alone only yields a two-factor token
+	resp, err := authUseCase.Login(context.Background(), dto.LoginRequest{Username: "admin", Password: "password123"})
+	require.NoError(t, err)
+	assert.True(t, resp.TwoFactorRequired)
+	assert.Equal(t, "2fa_token", resp.TwoFactorToken)
+	assert.Empty(t, resp.AccessToken)
+
+	// A wrong code counts as a failed login
+	_, err = authUseCase.VerifyTwoFactor(context.Background(), dto.LoginTwoFactorRequest{TwoFactorToken: "2fa_token", Code: "111111"})
+	assert.ErrorIs(t, err, domainErrors.ErrTwoFactorCodeInvalid)
+	throttleRepo.AssertCalled(t, "Save", mock.Anything, mock.Anything)
+
+	resp, err = authUseCase.VerifyTwoFactor(context.Background(), dto.LoginTwoFactorRequest{TwoFactorToken: "2fa_token", Code: "123456"})
+	require.NoError(t, err)
+	assert.Equal(t, "access_token", resp.AccessToken)
+	assert.True(t, resp.User.TwoFactorEnabled)
+	assert.Equal(t, []string{authEventTwoFactor, authEventLoginFailed, authEventLoginSuccess}, audit.actions)
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,25 +12,32 @@ import (
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
 // UserUseCase handles user management use cases
 type UserUseCase struct {
-	userRepo    repository.UserRepository
-	roleRepo    repository.RoleRepository
-	auditLogger appService.AuditLogger
+	userRepo       repository.UserRepository
+	roleRepo       repository.RoleRepository
+	sessionRepo    repository.RefreshSessionRepository
+	auditLogger    appService.AuditLogger
+	principalCache service.PrincipalCache
 }
 
 // NewUserUseCase creates a new user use case
 func NewUserUseCase(
 	userRepo repository.UserRepository,
 	roleRepo repository.RoleRepository,
+	sessionRepo repository.RefreshSessionRepository,
 	auditLogger appService.AuditLogger,
+	principalCache service.PrincipalCache,
 ) *UserUseCase {
 	return &UserUseCase{
-		userRepo:    userRepo,
-		roleRepo:    roleRepo,
-		auditLogger: auditLogger,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		sessionRepo:    sessionRepo,
+		auditLogger:    auditLogger,
+		principalCache: principalCache,
 	}
 }
 
@@ -42,13 +51,14 @@ func (uc *UserUseCase) CreateUser(ctx context.Context, req dto.CreateUserRequest
 
 	// Create user
 	user := &entity.User{
-		ID:        uuid.New(),
-		Username:  req.Username,
-		Password:  req.Password,
-		Name:      req.Name,
-		IsActive:  true,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:                 uuid.New(),
+		Username:           req.Username,
+		Password:           req.Password,
+		Name:               req.Name,
+		IsActive:           true,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+		MustChangePassword: req.MustChangePassword,
 	}
 
 	// Hash password
@@ -116,6 +126,7 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, id uuid.UUID, req dto.Upd
 	if err != nil {
 		return nil, domainErrors.ErrUserNotFound
 	}
+	before := *user
 
 	// Update fields
 	if req.Name != "" {
@@ -129,14 +140,26 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, id uuid.UUID, req dto.Upd
 		}
 		user.Username = req.Username
 	}
+	deactivated := false
 	if req.IsActive != nil {
+		deactivated = user.IsActive && !*req.IsActive
 		user.IsActive = *req.IsActive
 	}
+	if req.MustChangePassword != nil {
+		user.MustChangePassword = *req.MustChangePassword
+	}
 
 	user.UpdatedAt = time.Now()
 
 	// Update roles if provided
+	var beforeRoleIDs []uuid.UUID
 	if len(req.RoleIDs) > 0 {
+		current, err := uc.userRepo.GetWithRoles(ctx, id)
+		if err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
+		beforeRoleIDs = userRoleIDs(current)
+
 		roles := make([]entity.Role, 0)
 		for _, roleIDStr := range req.RoleIDs {
 			roleID, err := uuid.Parse(roleIDStr)
@@ -156,6 +179,14 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, id uuid.UUID, req dto.Upd
 	if err := uc.userRepo.Update(ctx, user); err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
+	invalidatePrincipals(ctx, uc.principalCache, user.ID)
+
+	// Cut off refresh tokens of deactivated users immediately
+	if deactivated {
+		if _, err := uc.sessionRepo.RevokeAllByUser(ctx, user.ID, entity.SessionRevokeReasonDeactivated); err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
+	}
 
 	// Get updated user with roles
 	userWithRoles, err := uc.userRepo.GetWithRoles(ctx, user.ID)
@@ -163,8 +194,13 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, id uuid.UUID, req dto.Upd
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	// Audit log (best-effort)
-	_ = uc.auditLogger.Log(ctx, "user", "user:update", user.ID.String(), map[string]string{
+	// Audit log (best-effort); role IDs are compared only when roles were submitted
+	beforeView := userAuditView{User: before, RoleIDs: beforeRoleIDs}
+	afterView := userAuditView{User: *user}
+	if len(req.RoleIDs) > 0 {
+		afterView.RoleIDs = userRoleIDs(userWithRoles)
+	}
+	_ = uc.auditLogger.LogChange(ctx, "user", "user:update", user.ID.String(), &beforeView, &afterView, map[string]string{
 		"username": user.Username,
 		"name":     user.Name,
 	})
@@ -172,6 +208,44 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, id uuid.UUID, req dto.Upd
 	return uc.toUserResponse(userWithRoles), nil
 }
 
+// userAuditView is the audited view of a user: its own fields plus the
+// assigned role IDs, which DiffFields skips as a relation.
+type userAuditView struct {
+	entity.User
+	RoleIDs []uuid.UUID `json:"role_ids"`
+}
+
+func userRoleIDs(user *entity.User) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		ids = append(ids, role.ID)
+	}
+	return sortedIDs(ids)
+}
+
+// sortedIDs sorts ids in place so audited ID sets compare independent of load order.
+func sortedIDs(ids []uuid.UUID) []uuid.UUID {
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
+}
+
+// withID returns a sorted copy of ids that contains id.
+func withID(ids []uuid.UUID, id uuid.UUID) []uuid.UUID {
+	result := withoutID(ids, id)
+	return sortedIDs(append(result, id))
+}
+
+// withoutID returns a sorted copy of ids without id.
+func withoutID(ids []uuid.UUID, id uuid.UUID) []uuid.UUID {
+	result := make([]uuid.UUID, 0, len(ids)+1)
+	for _, existing := range ids {
+		if existing != id {
+			result = append(result, existing)
+		}
+	}
+	return sortedIDs(result)
+}
+
 // DeleteUser deletes a user (soft delete)
 func (uc *UserUseCase) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	// Check if user exists
@@ -184,9 +258,14 @@ func (uc *UserUseCase) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	if err := uc.userRepo.Delete(ctx, id); err != nil {
 		return err
 	}
+	invalidatePrincipals(ctx, uc.principalCache, id)
+
+	if _, err := uc.sessionRepo.RevokeAllByUser(ctx, id, entity.SessionRevokeReasonDeactivated); err != nil {
+		return domainErrors.ErrInternalServer
+	}
 
 	// Audit log (best-effort)
-	_ = uc.auditLogger.Log(ctx, "user", "user:delete", id.String(), map[string]string{
+	_ = uc.auditLogger.LogChange(ctx, "user", "user:delete", id.String(), user, nil, map[string]string{
 		"username": user.Username,
 		"name":     user.Name,
 	})
@@ -235,38 +314,126 @@ func (uc *UserUseCase) ListUsers(ctx context.Context, page, pageSize int) (*dto.
 
 // AssignRoleToUser assigns a role to a user
 func (uc *UserUseCase) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) error {
-	// Check if user exists
-	_, err := uc.userRepo.GetByID(ctx, userID)
+	// Check if user exists, loading the current roles for the audit entry
+	user, err := uc.userRepo.GetWithRoles(ctx, userID)
 	if err != nil {
 		return domainErrors.ErrUserNotFound
 	}
 
 	// Check if role exists
-	_, err = uc.roleRepo.GetByID(ctx, roleID)
+	role, err := uc.roleRepo.GetByID(ctx, roleID)
 	if err != nil {
 		return domainErrors.ErrRoleNotFound
 	}
 
 	// Assign role
-	return uc.userRepo.AssignRole(ctx, userID, roleID)
+	if err := uc.userRepo.AssignRole(ctx, userID, roleID); err != nil {
+		return err
+	}
+	invalidatePrincipals(ctx, uc.principalCache, userID)
+
+	// Audit log (best-effort)
+	before := userAuditView{User: *user, RoleIDs: userRoleIDs(user)}
+	after := userAuditView{User: *user, RoleIDs: withID(before.RoleIDs, roleID)}
+	_ = uc.auditLogger.LogChange(ctx, "user", "user:assign_role", userID.String(), &before, &after, map[string]string{
+		"username": user.Username,
+		"role":     role.Name,
+	})
+	return nil
 }
 
 // RemoveRoleFromUser removes a role from a user
 func (uc *UserUseCase) RemoveRoleFromUser(ctx context.Context, userID, roleID uuid.UUID) error {
-	// Check if user exists
-	_, err := uc.userRepo.GetByID(ctx, userID)
+	// Check if user exists, loading the current roles for the audit entry
+	user, err := uc.userRepo.GetWithRoles(ctx, userID)
 	if err != nil {
 		return domainErrors.ErrUserNotFound
 	}
 
 	// Check if role exists
-	_, err = uc.roleRepo.GetByID(ctx, roleID)
+	role, err := uc.roleRepo.GetByID(ctx, roleID)
 	if err != nil {
 		return domainErrors.ErrRoleNotFound
 	}
 
 	// Remove role
-	return uc.userRepo.RemoveRole(ctx, userID, roleID)
+	if err := uc.userRepo.RemoveRole(ctx, userID, roleID); err != nil {
+		return err
+	}
+	invalidatePrincipals(ctx, uc.principalCache, userID)
+
+	// Audit log (best-effort)
+	before := userAuditView{User: *user, RoleIDs: userRoleIDs(user)}
+	after := userAuditView{User: *user, RoleIDs: withoutID(before.RoleIDs, roleID)}
+	_ = uc.auditLogger.LogChange(ctx, "user", "user:remove_role", userID.String(), &before, &after, map[string]string{
+		"username": user.Username,
+		"role":     role.Name,
+	})
+	return nil
+}
+
+// RevokeUserSessions revokes every active refresh session of a user
+func (uc *UserUseCase) RevokeUserSessions(ctx context.Context, userID uuid.UUID) (*dto.RevokeSessionsResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	revoked, err := uc.sessionRepo.RevokeAllByUser(ctx, userID, entity.SessionRevokeReasonAdmin)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "user", "user:revoke_sessions", userID.String(), map[string]string{
+		"username":         user.Username,
+		"revoked_sessions": strconv.FormatInt(revoked, 10),
+	})
+
+	return &dto.RevokeSessionsResponse{RevokedSessions: revoked}, nil
+}
+
+// ResetPassword replaces a user's password with a one-time temporary password
+// that must be changed on the next login. All refresh sessions are revoked.
+func (uc *UserUseCase) ResetPassword(ctx context.Context, userID uuid.UUID) (*dto.ResetPasswordResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	temporaryPassword, err := generateTemporaryPassword()
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	user.Password = temporaryPassword
+	if err := user.HashPassword(); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	user.MustChangePassword = true
+	user.UpdatedAt = time.Now()
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	invalidatePrincipals(ctx, uc.principalCache, user.ID)
+
+	revoked, err := uc.sessionRepo.RevokeAllByUser(ctx, userID, entity.SessionRevokeReasonPassword)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort); the temporary password itself is never logged
+	_ = uc.auditLogger.Log(ctx, "user", "user:reset_password", userID.String(), map[string]string{
+		"username":         user.Username,
+		"revoked_sessions": strconv.FormatInt(revoked, 10),
+	})
+
+	return &dto.ResetPasswordResponse{
+		TemporaryPassword:  temporaryPassword,
+		MustChangePassword: true,
+		RevokedSessions:    revoked,
+	}, nil
 }
 
 // toUserResponse converts entity.User to dto.UserResponse
@@ -277,12 +444,14 @@ func (uc *UserUseCase) toUserResponse(user *entity.User) *dto.UserResponse {
 	}
 
 	return &dto.UserResponse{
-		ID:        user.ID.String(),
-		Username:  user.Username,
-		Name:      user.Name,
-		IsActive:  user.IsActive,
-		Roles:     roles,
-		CreatedAt: user.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
+		ID:                 user.ID.String(),
+		Username:           user.Username,
+		Name:               user.Name,
+		IsActive:           user.IsActive,
+		Roles:              roles,
+		CreatedAt:          user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          user.UpdatedAt.Format(time.RFC3339),
+		MustChangePassword: user.MustChangePassword,
+		TwoFactorEnabled:   user.TwoFactorEnabled,
 	}
 }
@@ -7,7 +7,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
@@ -19,6 +21,27 @@ func (n *noopAuditLogger) Log(ctx context.Context, resource, action, targetID st
 	return nil
 }
 
+func (n *noopAuditLogger) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	return nil
+}
+
+// changeRecorder captures the field changes written with LogChange, keyed by action.
+type changeRecorder struct {
+	changes map[string]map[string]appService.FieldChange
+}
+
+func (r *changeRecorder) Log(ctx context.Context, resource, action, targetID string, metadata map[string]string) error {
+	return nil
+}
+
+func (r *changeRecorder) LogChange(ctx context.Context, resource, action, targetID string, before, after interface{}, metadata map[string]string) error {
+	if r.changes == nil {
+		r.changes = map[string]map[string]appService.FieldChange{}
+	}
+	r.changes[action] = appService.DiffFields(before, after)
+	return nil
+}
+
 func TestUserUseCase_AssignRoleToUser(t *testing.T) {
 	userID := uuid.New()
 	roleID := uuid.New()
@@ -31,7 +54,7 @@ func TestUserUseCase_AssignRoleToUser(t *testing.T) {
 		{
 			name: "success",
 			setupMocks: func(userRepo *mocks.MockUserRepository, roleRepo *mocks.MockRoleRepository) {
-				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
 				roleRepo.On("GetByID", mock.Anything, roleID).Return(&entity.Role{ID: roleID}, nil)
 				userRepo.On("AssignRole", mock.Anything, userID, roleID).Return(nil)
 			},
@@ -40,14 +63,14 @@ func TestUserUseCase_AssignRoleToUser(t *testing.T) {
 		{
 			name: "user not found",
 			setupMocks: func(userRepo *mocks.MockUserRepository, roleRepo *mocks.MockRoleRepository) {
-				userRepo.On("GetByID", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
 			},
 			expectedError: domainErrors.ErrUserNotFound,
 		},
 		{
 			name: "role not found",
 			setupMocks: func(userRepo *mocks.MockUserRepository, roleRepo *mocks.MockRoleRepository) {
-				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
 				roleRepo.On("GetByID", mock.Anything, roleID).Return(nil, domainErrors.ErrRoleNotFound)
 			},
 			expectedError: domainErrors.ErrRoleNotFound,
@@ -59,7 +82,8 @@ func TestUserUseCase_AssignRoleToUser(t *testing.T) {
 			userRepo := new(mocks.MockUserRepository)
 			roleRepo := new(mocks.MockRoleRepository)
 			tt.setupMocks(userRepo, roleRepo)
-			uc := NewUserUseCase(userRepo, roleRepo, &noopAuditLogger{})
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
+			uc := NewUserUseCase(userRepo, roleRepo, sessionRepo, &noopAuditLogger{}, nil)
 			err := uc.AssignRoleToUser(context.Background(), userID, roleID)
 
 			if tt.expectedError != nil {
@@ -86,7 +110,7 @@ func TestUserUseCase_RemoveRoleFromUser(t *testing.T) {
 		{
 			name: "success",
 			setupMocks: func(userRepo *mocks.MockUserRepository, roleRepo *mocks.MockRoleRepository) {
-				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
 				roleRepo.On("GetByID", mock.Anything, roleID).Return(&entity.Role{ID: roleID}, nil)
 				userRepo.On("RemoveRole", mock.Anything, userID, roleID).Return(nil)
 			},
@@ -95,14 +119,14 @@ func TestUserUseCase_RemoveRoleFromUser(t *testing.T) {
 		{
 			name: "user not found",
 			setupMocks: func(userRepo *mocks.MockUserRepository, roleRepo *mocks.MockRoleRepository) {
-				userRepo.On("GetByID", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
 			},
 			expectedError: domainErrors.ErrUserNotFound,
 		},
 		{
 			name: "role not found",
 			setupMocks: func(userRepo *mocks.MockUserRepository, roleRepo *mocks.MockRoleRepository) {
-				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
 				roleRepo.On("GetByID", mock.Anything, roleID).Return(nil, domainErrors.ErrRoleNotFound)
 			},
 			expectedError: domainErrors.ErrRoleNotFound,
@@ -114,7 +138,8 @@ func TestUserUseCase_RemoveRoleFromUser(t *testing.T) {
 			userRepo := new(mocks.MockUserRepository)
 			roleRepo := new(mocks.MockRoleRepository)
 			tt.setupMocks(userRepo, roleRepo)
-			uc := NewUserUseCase(userRepo, roleRepo, &noopAuditLogger{})
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
+			uc := NewUserUseCase(userRepo, roleRepo, sessionRepo, &noopAuditLogger{}, nil)
 			err := uc.RemoveRoleFromUser(context.Background(), userID, roleID)
 
 			if tt.expectedError != nil {
@@ -216,7 +241,8 @@ func TestUserUseCase_CreateUser(t *testing.T) {
 			tt.setupMocks(userRepo, roleRepo)
 
 			auditLogger := &noopAuditLogger{}
-			userUseCase := NewUserUseCase(userRepo, roleRepo, auditLogger)
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
+			userUseCase := NewUserUseCase(userRepo, roleRepo, sessionRepo, auditLogger, nil)
 			resp, err := userUseCase.CreateUser(context.Background(), tt.req)
 
 			if tt.expectedError != nil {
@@ -275,7 +301,8 @@ func TestUserUseCase_GetUserByID(t *testing.T) {
 			roleRepo := new(mocks.MockRoleRepository)
 			tt.setupMocks(userRepo)
 			auditLogger := &noopAuditLogger{}
-			userUseCase := NewUserUseCase(userRepo, roleRepo, auditLogger)
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
+			userUseCase := NewUserUseCase(userRepo, roleRepo, sessionRepo, auditLogger, nil)
 			resp, err := userUseCase.GetUserByID(context.Background(), tt.userID)
 
 			if tt.expectedError != nil {
@@ -386,7 +413,8 @@ func TestUserUseCase_UpdateUser(t *testing.T) {
 			roleRepo := new(mocks.MockRoleRepository)
 			tt.setupMocks(userRepo, roleRepo)
 			auditLogger := &noopAuditLogger{}
-			userUseCase := NewUserUseCase(userRepo, roleRepo, auditLogger)
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
+			userUseCase := NewUserUseCase(userRepo, roleRepo, sessionRepo, auditLogger, nil)
 			resp, err := userUseCase.UpdateUser(context.Background(), tt.userID, tt.req)
 
 			if tt.expectedError != nil {
@@ -410,24 +438,25 @@ func TestUserUseCase_DeleteUser(t *testing.T) {
 	tests := []struct {
 		name          string
 		userID        uuid.UUID
-		setupMocks    func(*mocks.MockUserRepository)
+		setupMocks    func(*mocks.MockUserRepository, *mocks.MockRefreshSessionRepository)
 		expectedError error
 	}{
 		{
 			name:   "success - delete user",
 			userID: userID,
-			setupMocks: func(userRepo *mocks.MockUserRepository) {
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository) {
 				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{
 					ID: userID,
 				}, nil)
 				userRepo.On("Delete", mock.Anything, userID).Return(nil)
+				sessionRepo.On("RevokeAllByUser", mock.Anything, userID, entity.SessionRevokeReasonDeactivated).Return(int64(2), nil)
 			},
 			expectedError: nil,
 		},
 		{
 			name:   "failure - user not found",
 			userID: userID,
-			setupMocks: func(userRepo *mocks.MockUserRepository) {
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository) {
 				userRepo.On("GetByID", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
 			},
 			expectedError: domainErrors.ErrUserNotFound,
@@ -438,10 +467,11 @@ func TestUserUseCase_DeleteUser(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			userRepo := new(mocks.MockUserRepository)
 			roleRepo := new(mocks.MockRoleRepository)
-			tt.setupMocks(userRepo)
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
+			tt.setupMocks(userRepo, sessionRepo)
 
 			auditLogger := &noopAuditLogger{}
-			userUseCase := NewUserUseCase(userRepo, roleRepo, auditLogger)
+			userUseCase := NewUserUseCase(userRepo, roleRepo, sessionRepo, auditLogger, nil)
 			err := userUseCase.DeleteUser(context.Background(), tt.userID)
 
 			if tt.expectedError != nil {
@@ -452,6 +482,7 @@ func TestUserUseCase_DeleteUser(t *testing.T) {
 			}
 
 			userRepo.AssertExpectations(t)
+			sessionRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -510,7 +541,8 @@ func TestUserUseCase_ListUsers(t *testing.T) {
 			tt.setupMocks(userRepo)
 
 			auditLogger := &noopAuditLogger{}
-			userUseCase := NewUserUseCase(userRepo, roleRepo, auditLogger)
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
+			userUseCase := NewUserUseCase(userRepo, roleRepo, sessionRepo, auditLogger, nil)
 			resp, err := userUseCase.ListUsers(context.Background(), tt.page, tt.pageSize)
 
 			if tt.expectedError != nil {
@@ -531,3 +563,169 @@ func TestUserUseCase_ListUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestUserUseCase_UpdateUser_DeactivationRevokesSessions(t *testing.T) {
+	userID := uuid.New()
+	inactive := false
+
+	userRepo := new(mocks.MockUserRepository)
+	roleRepo := new(mocks.MockRoleRepository)
+	sessionRepo := new(mocks.MockRefreshSessionRepository)
+
+	userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "staff", IsActive: true}, nil)
+	userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool { return !u.IsActive })).Return(nil)
+	userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "staff"}, nil)
+	sessionRepo.On("RevokeAllByUser", mock.Anything, userID, entity.SessionRevokeReasonDeactivated).Return(int64(3), nil)
+
+	uc := NewUserUseCase(userRepo, roleRepo, sessionRepo, &noopAuditLogger{}, nil)
+	resp, err := uc.UpdateUser(context.Background(), userID, dto.UpdateUserRequest{IsActive: &inactive})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	userRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestUserUseCase_UpdateUser_AuditsRoleChanges(t *testing.T) {
+	userID := uuid.New()
+	oldRole := entity.Role{ID: uuid.New(), Name: "Teacher"}
+	newRole := entity.Role{ID: uuid.New(), Name: "Secretary"}
+
+	userRepo := new(mocks.MockUserRepository)
+	roleRepo := new(mocks.MockRoleRepository)
+	sessionRepo := new(mocks.MockRefreshSessionRepository)
+
+	userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "staff", IsActive: true}, nil)
+	userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "staff", Roles: []entity.Role{oldRole}}, nil).Once()
+	roleRepo.On("GetByID", mock.Anything, newRole.ID).Return(&newRole, nil)
+	userRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "staff", Roles: []entity.Role{newRole}}, nil).Once()
+
+	recorder := &changeRecorder{}
+	uc := NewUserUseCase(userRepo, roleRepo, sessionRepo, recorder, nil)
+	_, err := uc.UpdateUser(context.Background(), userID, dto.UpdateUserRequest{RoleIDs: []string{newRole.ID.String()}})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]appService.FieldChange{
+		"role_ids": {Old: []string{oldRole.ID.String()}, New: []string{newRole.ID.String()}},
+	}, recorder.changes["user:update"])
+	userRepo.AssertExpectations(t)
+}
+
+func TestUserUseCase_RoleAssignmentAuditsRoleIDs(t *testing.T) {
+	userID := uuid.New()
+	kept := entity.Role{ID: uuid.New(), Name: "Teacher"}
+	role := entity.Role{ID: uuid.New(), Name: "Secretary"}
+
+	userRepo := new(mocks.MockUserRepository)
+	roleRepo := new(mocks.MockRoleRepository)
+	userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "staff", Roles: []entity.Role{kept}}, nil).Once()
+	userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "staff", Roles: []entity.Role{kept, role}}, nil).Once()
+	roleRepo.On("GetByID", mock.Anything, role.ID).Return(&role, nil)
+	userRepo.On("AssignRole", mock.Anything, userID, role.ID).Return(nil)
+	userRepo.On("RemoveRole", mock.Anything, userID, role.ID).Return(nil)
+
+	recorder := &changeRecorder{}
+	uc := NewUserUseCase(userRepo, roleRepo, new(mocks.MockRefreshSessionRepository), recorder, nil)
+	require.NoError(t, uc.AssignRoleToUser(context.Background(), userID, role.ID))
+	require.NoError(t, uc.RemoveRoleFromUser(context.Background(), userID, role.ID))
+
+	assigned := recorder.changes["user:assign_role"]
+	require.Len(t, assigned, 1)
+	assert.Equal(t, []string{kept.ID.String()}, assigned["role_ids"].Old)
+	assert.ElementsMatch(t, []string{kept.ID.String(), role.ID.String()}, assigned["role_ids"].New)
+
+	removed := recorder.changes["user:remove_role"]
+	require.Len(t, removed, 1)
+	assert.ElementsMatch(t, []string{kept.ID.String(), role.ID.String()}, removed["role_ids"].Old)
+	assert.Equal(t, []string{kept.ID.String()}, removed["role_ids"].New)
+}
+
+func TestUserUseCase_RevokeUserSessions(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.MockUserRepository, *mocks.MockRefreshSessionRepository)
+		expected      int64
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository) {
+				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Username: "staff"}, nil)
+				sessionRepo.On("RevokeAllByUser", mock.Anything, userID, entity.SessionRevokeReasonAdmin).Return(int64(2), nil)
+			},
+			expected: 2,
+		},
+		{
+			name: "user not found",
+			setupMocks: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockRefreshSessionRepository) {
+				userRepo.On("GetByID", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
+			},
+			expectedError: domainErrors.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(mocks.MockUserRepository)
+			roleRepo := new(mocks.MockRoleRepository)
+			sessionRepo := new(mocks.MockRefreshSessionRepository)
+			tt.setupMocks(userRepo, sessionRepo)
+
+			uc := NewUserUseCase(userRepo, roleRepo, sessionRepo, &noopAuditLogger{}, nil)
+			resp, err := uc.RevokeUserSessions(context.Background(), userID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, resp.RevokedSessions)
+			}
+
+			userRepo.AssertExpectations(t)
+			sessionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUseCase_ResetPassword(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		roleRepo := new(mocks.MockRoleRepository)
+		sessionRepo := new(mocks.MockRefreshSessionRepository)
+
+		user := &entity.User{ID: userID, Username: "staff", IsActive: true}
+		userRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+			return u.MustChangePassword
+		})).Return(nil)
+		sessionRepo.On("RevokeAllByUser", mock.Anything, userID, entity.SessionRevokeReasonPassword).Return(int64(1), nil)
+
+		uc := NewUserUseCase(userRepo, roleRepo, sessionRepo, &noopAuditLogger{}, nil)
+		resp, err := uc.ResetPassword(context.Background(), userID)
+
+		require.NoError(t, err)
+		assert.Len(t, resp.TemporaryPassword, temporaryPasswordLength)
+		assert.True(t, resp.MustChangePassword)
+		assert.Equal(t, int64(1), resp.RevokedSessions)
+		assert.True(t, user.CheckPassword(resp.TemporaryPassword))
+		userRepo.AssertExpectations(t)
+		sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		userRepo.On("GetByID", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
+
+		uc := NewUserUseCase(userRepo, new(mocks.MockRoleRepository), new(mocks.MockRefreshSessionRepository), &noopAuditLogger{}, nil)
+		resp, err := uc.ResetPassword(context.Background(), userID)
+
+		assert.ErrorIs(t, err, domainErrors.ErrUserNotFound)
+		assert.Nil(t, resp)
+	})
+}
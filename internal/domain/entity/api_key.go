@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix starts every API key so keys can be told apart from JWTs and
+// spotted by secret scanners.
+const APIKeyPrefix = "sigap_"
+
+// APIKey authenticates a service account. Prefix identifies the key in lists
+// and logs; only the SHA-256 hash of the full key is stored. Permissions
+// restricts the key to a subset of the service account's permissions.
+type APIKey struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:char(36);not null;index"`
+	Name        string     `json:"name" gorm:"type:varchar(100);not null"`
+	Prefix      string     `json:"prefix" gorm:"type:varchar(32);not null;uniqueIndex"`
+	KeyHash     string     `json:"-" gorm:"type:char(64);not null"`
+	Permissions []string   `json:"permissions" gorm:"type:text;serializer:json"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty" gorm:"type:char(36)"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsUsable reports whether the key is neither revoked nor expired at now.
+func (k *APIKey) IsUsable(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// Allows reports whether the key grants the permission, honouring wildcard
+// scopes such as "student:*".
+func (k *APIKey) Allows(permission string) bool {
+	return permissionCovered(k.Permissions, permission)
+}
+
+// HashAPIKey returns the stored hash of a raw API key.
+func HashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,29 +1,166 @@
 package entity
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-// AuditLog represents an audit log entry for sensitive operations
+// AuditLog represents an audit log entry for sensitive operations. Entries
+// form a hash chain: each stores a hash over its content and the hash of the
+// entry before it, so editing or deleting a row breaks every later link.
 type AuditLog struct {
-	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
-	ActorID       *uuid.UUID `json:"actor_id" gorm:"type:uuid"`
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	// Sequence numbers entries 1, 2, 3... in chain order.
+	Sequence      int64      `json:"sequence" gorm:"uniqueIndex"`
+	PrevHash      string     `json:"prev_hash" gorm:"size:64"`
+	Hash          string     `json:"hash" gorm:"size:64"`
+	ActorID       *uuid.UUID `json:"actor_id" gorm:"type:uuid;index"`
 	ActorUsername string     `json:"actor_username" gorm:"size:255"`
 	ActorRoles    string     `json:"actor_roles" gorm:"type:text"`
-	Action        string     `json:"action" gorm:"size:100;index"`
-	Resource      string     `json:"resource" gorm:"size:100;index"`
-	TargetID      string     `json:"target_id" gorm:"size:255;index"`
-	RequestPath   string     `json:"request_path" gorm:"size:255"`
-	RequestMethod string     `json:"request_method" gorm:"size:10"`
-	StatusCode    int        `json:"status_code"`
-	IPAddress     string     `json:"ip_address" gorm:"size:100"`
-	UserAgent     string     `json:"user_agent" gorm:"size:512"`
-	Metadata      string     `json:"metadata" gorm:"type:text"`
-	CreatedAt     time.Time  `json:"created_at"`
+	// APIKeyID is set when the actor authenticated with a service-account API key.
+	APIKeyID *uuid.UUID `json:"api_key_id,omitempty" gorm:"type:uuid"`
+	// ImpersonatorID and ImpersonatorUsername identify the admin acting as
+	// the actor through an impersonation token.
+	ImpersonatorID       *uuid.UUID `json:"impersonator_id,omitempty" gorm:"type:uuid;index"`
+	ImpersonatorUsername string     `json:"impersonator_username,omitempty" gorm:"size:255"`
+	Action               string     `json:"action" gorm:"size:100;index"`
+	Resource             string     `json:"resource" gorm:"size:100;index"`
+	TargetID             string     `json:"target_id" gorm:"size:255;index"`
+	RequestPath          string     `json:"request_path" gorm:"size:255"`
+	RequestMethod        string     `json:"request_method" gorm:"size:10"`
+	// RequestID correlates the entry with the request's log records.
+	RequestID  string `json:"request_id,omitempty" gorm:"size:64;index"`
+	StatusCode int    `json:"status_code" gorm:"index"`
+	IPAddress  string `json:"ip_address" gorm:"size:100;index"`
+	UserAgent  string `json:"user_agent" gorm:"size:512"`
+	Metadata   string `json:"metadata" gorm:"type:text"`
+	// Changes holds the old and new values of the fields an update or delete
+	// modified, as JSON keyed by field name. Sensitive values are redacted.
+	Changes   string    `json:"changes,omitempty" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
 }
 
 func (AuditLog) TableName() string {
 	return "audit_logs"
 }
+
+// auditLogHashInput lists the hashed fields in a fixed order.
+type auditLogHashInput struct {
+	Sequence             int64  `json:"sequence"`
+	PrevHash             string `json:"prev_hash"`
+	ID                   string `json:"id"`
+	ActorID              string `json:"actor_id"`
+	ActorUsername        string `json:"actor_username"`
+	ActorRoles           string `json:"actor_roles"`
+	APIKeyID             string `json:"api_key_id"`
+	ImpersonatorID       string `json:"impersonator_id"`
+	ImpersonatorUsername string `json:"impersonator_username"`
+	Action               string `json:"action"`
+	Resource             string `json:"resource"`
+	TargetID             string `json:"target_id"`
+	RequestPath          string `json:"request_path"`
+	RequestMethod        string `json:"request_method"`
+	// RequestID is omitted when empty so entries written before it existed
+	// keep their hashes.
+	RequestID  string `json:"request_id,omitempty"`
+	StatusCode int    `json:"status_code"`
+	IPAddress  string `json:"ip_address"`
+	UserAgent  string `json:"user_agent"`
+	Metadata   string `json:"metadata"`
+	Changes    string `json:"changes"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// ComputeHash returns the hex SHA-256 over the entry's content, sequence and
+// previous hash. CreatedAt is hashed at microsecond precision in UTC, which
+// every supported database stores without loss.
+func (l *AuditLog) ComputeHash() string {
+	input := auditLogHashInput{
+		Sequence:             l.Sequence,
+		PrevHash:             l.PrevHash,
+		ID:                   l.ID.String(),
+		ActorID:              optionalUUIDString(l.ActorID),
+		ActorUsername:        l.ActorUsername,
+		ActorRoles:           l.ActorRoles,
+		APIKeyID:             optionalUUIDString(l.APIKeyID),
+		ImpersonatorID:       optionalUUIDString(l.ImpersonatorID),
+		ImpersonatorUsername: l.ImpersonatorUsername,
+		Action:               l.Action,
+		Resource:             l.Resource,
+		TargetID:             l.TargetID,
+		RequestPath:          l.RequestPath,
+		RequestMethod:        l.RequestMethod,
+		RequestID:            l.RequestID,
+		StatusCode:           l.StatusCode,
+		IPAddress:            l.IPAddress,
+		UserAgent:            l.UserAgent,
+		Metadata:             l.Metadata,
+		Changes:              l.Changes,
+		CreatedAt:            l.CreatedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+	}
+	// Marshalling a struct of strings and numbers cannot fail
+	payload, _ := json.Marshal(input)
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditCheckpoint is a signed statement of the chain head at a point in time.
+// An exported checkpoint lets auditors detect a chain that was rewritten from
+// scratch, which the hashes alone cannot reveal.
+type AuditCheckpoint struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Sequence  int64     `json:"sequence" gorm:"index"`
+	Hash      string    `json:"hash" gorm:"size:64"`
+	KeyID     string    `json:"key_id" gorm:"size:100"`
+	Algorithm string    `json:"algorithm" gorm:"size:20"`
+	// PublicKey is the PEM encoded key that verifies Signature.
+	PublicKey string    `json:"public_key" gorm:"type:text"`
+	Signature string    `json:"signature" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (AuditCheckpoint) TableName() string {
+	return "audit_checkpoints"
+}
+
+// SigningPayload is the exact byte string the checkpoint signature covers.
+func (c *AuditCheckpoint) SigningPayload() []byte {
+	return []byte(fmt.Sprintf("audit-checkpoint:v1:%d:%s:%s",
+		c.Sequence, c.Hash, c.CreatedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)))
+}
+
+func optionalUUIDString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// AuditArchive records a prefix of the chain that retention moved out of
+// audit_logs into a compressed NDJSON file. The first entry left in the table
+// links to LastHash, so the chain stays verifiable without the archived rows.
+type AuditArchive struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	FromSequence int64     `json:"from_sequence"`
+	ToSequence   int64     `json:"to_sequence" gorm:"index"`
+	// FirstPrevHash is the PrevHash of the entry at FromSequence.
+	FirstPrevHash string `json:"first_prev_hash" gorm:"size:64"`
+	// LastHash is the Hash of the entry at ToSequence.
+	LastHash string `json:"last_hash" gorm:"size:64"`
+	Entries  int64  `json:"entries"`
+	// File locates the archive in the archive store; FileSHA256 is the hex
+	// SHA-256 of its compressed bytes.
+	File       string    `json:"file" gorm:"size:512"`
+	FileSHA256 string    `json:"file_sha256" gorm:"size:64"`
+	OlderThan  time.Time `json:"older_than"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (AuditArchive) TableName() string {
+	return "audit_archives"
+}
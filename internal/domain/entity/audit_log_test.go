@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLog_ComputeHash_CoversRequestID(t *testing.T) {
+	log := &AuditLog{
+		ID:        uuid.New(),
+		Sequence:  1,
+		Action:    "student:update",
+		Resource:  "student",
+		CreatedAt: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC),
+	}
+	withoutRequestID := log.ComputeHash()
+
+	log.RequestID = "req-1"
+	assert.NotEqual(t, withoutRequestID, log.ComputeHash())
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Holiday marks a date on which no attendance sessions are generated.
+// A nil DormitoryID applies the holiday to every dormitory.
+type Holiday struct {
+	ID          uuid.UUID  `json:"id"`
+	DormitoryID *uuid.UUID `json:"dormitory_id" gorm:"index"`
+	Date        time.Time  `json:"date" gorm:"type:date;not null;index"`
+	Name        string     `json:"name" gorm:"size:150;not null"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the default table name for GORM.
+func (Holiday) TableName() string {
+	return "holidays"
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invitation lets an admin onboard a user without open self-registration.
+// The invitee redeems the single-use token to set their name and password;
+// only the SHA-256 hash of the token is stored.
+type Invitation struct {
+	ID           uuid.UUID   `json:"id" gorm:"type:char(36);primaryKey"`
+	Username     string      `json:"username" gorm:"type:varchar(32);not null;index"`
+	TokenHash    string      `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	RoleID       uuid.UUID   `json:"role_id" gorm:"type:char(36);not null"`
+	DormitoryIDs []uuid.UUID `json:"dormitory_ids" gorm:"type:text;serializer:json"`
+	ExpiresAt    time.Time   `json:"expires_at" gorm:"not null;index"`
+	AcceptedAt   *time.Time  `json:"accepted_at,omitempty"`
+	// AcceptedUserID is the account created when the invitation was redeemed.
+	AcceptedUserID *uuid.UUID `json:"accepted_user_id,omitempty" gorm:"type:char(36)"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy      *uuid.UUID `json:"created_by,omitempty" gorm:"type:char(36)"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	// Relations
+	Role *Role `json:"role,omitempty" gorm:"foreignKey:RoleID"`
+}
+
+// TableName specifies the table name for GORM
+func (Invitation) TableName() string {
+	return "user_invitations"
+}
+
+// IsPending reports whether the invitation can still be redeemed at now.
+func (i *Invitation) IsPending(now time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && now.Before(i.ExpiresAt)
+}
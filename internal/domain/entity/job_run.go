@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobRunStatus captures the outcome of a scheduled job execution.
+type JobRunStatus string
+
+const (
+	JobRunStatusRunning   JobRunStatus = "running"
+	JobRunStatusSucceeded JobRunStatus = "succeeded"
+	JobRunStatusFailed    JobRunStatus = "failed"
+)
+
+// JobRun records a single execution of a scheduled background job.
+type JobRun struct {
+	ID          uuid.UUID    `json:"id" gorm:"type:char(36);primaryKey"`
+	JobName     string       `json:"job_name" gorm:"type:varchar(64);not null;index"`
+	Status      JobRunStatus `json:"status" gorm:"type:varchar(16);not null;index"`
+	Holder      string       `json:"holder" gorm:"type:varchar(128);not null"`
+	ScheduledAt time.Time    `json:"scheduled_at" gorm:"not null;index"`
+	StartedAt   time.Time    `json:"started_at" gorm:"not null"`
+	FinishedAt  *time.Time   `json:"finished_at"`
+	DurationMs  int64        `json:"duration_ms"`
+	Message     string       `json:"message" gorm:"type:text"`
+	Error       string       `json:"error" gorm:"type:text"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// TableName overrides GORM default.
+func (JobRun) TableName() string {
+	return "job_runs"
+}
+
+// JobLease guarantees a scheduled job runs on a single replica at a time.
+type JobLease struct {
+	JobName   string    `json:"job_name" gorm:"type:varchar(64);primaryKey"`
+	Holder    string    `json:"holder" gorm:"type:varchar(128);not null"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName overrides GORM default.
+func (JobLease) TableName() string {
+	return "job_leases"
+}
@@ -22,6 +22,7 @@ const (
 	LeavePermitStatusApproved  LeavePermitStatus = "approved"
 	LeavePermitStatusRejected  LeavePermitStatus = "rejected"
 	LeavePermitStatusCompleted LeavePermitStatus = "completed"
+	LeavePermitStatusExpired   LeavePermitStatus = "expired"
 )
 
 // LeavePermit models the security leave permit lifecycle.
@@ -59,8 +60,8 @@ type HealthStatus struct {
 	ID        uuid.UUID         `json:"id" gorm:"type:char(36);primaryKey"`
 	StudentID uuid.UUID         `json:"student_id" gorm:"type:char(36);not null;index"`
 	Student   *Student          `json:"-" gorm:"foreignKey:StudentID"`
-	Diagnosis string            `json:"diagnosis" gorm:"type:varchar(255);not null"`
-	Notes     string            `json:"notes" gorm:"type:text"`
+	Diagnosis string            `json:"diagnosis" gorm:"type:varchar(255);not null" audit:"redact"`
+	Notes     string            `json:"notes" gorm:"type:text" audit:"redact"`
 	StartDate time.Time         `json:"start_date" gorm:"type:date;not null;index"`
 	EndDate   *time.Time        `json:"end_date" gorm:"type:date"`
 	Status    HealthStatusState `json:"status" gorm:"type:varchar(32);not null;index"`
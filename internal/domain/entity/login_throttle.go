@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Login throttle subjects.
+const (
+	LoginThrottleKindUsername = "username"
+	LoginThrottleKindIP       = "ip"
+)
+
+// LoginThrottle tracks recent failed logins for a single username or client IP.
+// Reaching the failure threshold locks the subject out; Lockouts counts consecutive
+// lockouts so repeated offenders are locked out for progressively longer.
+type LoginThrottle struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey"`
+	Kind           string     `json:"kind" gorm:"type:varchar(16);not null;uniqueIndex:idx_login_throttles_kind_value"`
+	Value          string     `json:"value" gorm:"type:varchar(255);not null;uniqueIndex:idx_login_throttles_kind_value"`
+	FailedAttempts int        `json:"failed_attempts" gorm:"not null;default:0"`
+	Lockouts       int        `json:"lockouts" gorm:"not null;default:0"`
+	LastFailedAt   time.Time  `json:"last_failed_at"`
+	LockedUntil    *time.Time `json:"locked_until" gorm:"index"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName overrides GORM default.
+func (LoginThrottle) TableName() string {
+	return "login_throttles"
+}
+
+// IsLocked reports whether the subject is locked out at the given time.
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && now.Before(*t.LockedUntil)
+}
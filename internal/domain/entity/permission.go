@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,3 +25,39 @@ type Permission struct {
 func (Permission) TableName() string {
 	return "permissions"
 }
+
+// PermissionWildcard matches any single segment of a permission name, or
+// every remaining segment when it is the last one: "reports:*" grants
+// "reports:attendance:read", "*:read" grants "student:read" and "*" grants
+// everything.
+const PermissionWildcard = "*"
+
+// PermissionMatches reports whether grant covers permission. Names are split
+// into ':'-separated segments; a grant without wildcards only matches itself.
+func PermissionMatches(grant, permission string) bool {
+	if grant == permission {
+		return true
+	}
+	grants := strings.Split(grant, ":")
+	perms := strings.Split(permission, ":")
+	for i, segment := range grants {
+		if i >= len(perms) {
+			return false
+		}
+		if segment != PermissionWildcard {
+			if segment != perms[i] {
+				return false
+			}
+			continue
+		}
+		if i == len(grants)-1 {
+			return true
+		}
+	}
+	return len(grants) == len(perms)
+}
+
+// IsWildcard reports whether the permission grants more than its own name.
+func (p Permission) IsWildcard() bool {
+	return strings.Contains(p.Name, PermissionWildcard)
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PrincipalCacheEntry is a cached authenticated user shared by all replicas.
+// An entry with an empty Payload is a tombstone left by an invalidation; the
+// row for uuid.Nil records when every entry was last invalidated.
+type PrincipalCacheEntry struct {
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:char(36);primaryKey"`
+	Payload       string     `json:"-" gorm:"type:text;not null"`
+	LoadedAt      time.Time  `json:"loaded_at"`
+	InvalidatedAt *time.Time `json:"invalidated_at"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"index"`
+}
+
+// TableName overrides GORM default.
+func (PrincipalCacheEntry) TableName() string {
+	return "principal_cache_entries"
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the
+// authenticator device is unavailable. Only the SHA-256 hash is stored.
+type RecoveryCode struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:char(36);not null;index"`
+	CodeHash  string     `json:"-" gorm:"type:char(64);not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
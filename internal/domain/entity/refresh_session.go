@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Refresh session revocation reasons.
+const (
+	SessionRevokeReasonRotated     = "rotated"
+	SessionRevokeReasonLogout      = "logout"
+	SessionRevokeReasonReuse       = "reuse_detected"
+	SessionRevokeReasonAdmin       = "admin_revoked"
+	SessionRevokeReasonDeactivated = "user_deactivated"
+	SessionRevokeReasonPassword    = "password_changed"
+)
+
+// RefreshSession is the server-side record of an issued refresh token.
+// Every refresh rotates the token within the same family; presenting an
+// already rotated token revokes the whole family.
+type RefreshSession struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	FamilyID      uuid.UUID  `json:"family_id" gorm:"type:uuid;not null;index"`
+	ReplacedByID  *uuid.UUID `json:"replaced_by_id" gorm:"type:uuid"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"not null;index"`
+	RevokedAt     *time.Time `json:"revoked_at" gorm:"index"`
+	RevokedReason string     `json:"revoked_reason" gorm:"size:50"`
+	IPAddress     string     `json:"ip_address" gorm:"size:100"`
+	UserAgent     string     `json:"user_agent" gorm:"size:512"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the default table name.
+func (RefreshSession) TableName() string {
+	return "refresh_sessions"
+}
+
+// IsActive reports whether the session can still be used at the given time.
+func (s *RefreshSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -13,8 +13,10 @@ type Role struct {
 	Slug        string    `json:"slug"`
 	IsActive    bool      `json:"is_active"`
 	IsProtected bool      `json:"is_protected"` // Roles that cannot have permissions edited
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	// TwoFactorRequired forces holders of the role to enrol in TOTP two-factor authentication.
+	TwoFactorRequired bool      `json:"two_factor_required" gorm:"not null;default:false"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 
 	// Relations
 	Permissions []Permission `gorm:"many2many:role_permissions;" json:"permissions,omitempty"`
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,14 +11,27 @@ import (
 
 // User represents a user entity in the domain
 type User struct {
-	ID        uuid.UUID  `json:"id"`
-	Username  string     `json:"username" gorm:"uniqueIndex"`
-	Password  string     `json:"-"` // Never expose password in JSON
-	Name      string     `json:"name"`
-	IsActive  bool       `json:"is_active"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username" gorm:"uniqueIndex"`
+	Password string    `json:"-"` // Never expose password in JSON
+	Name     string    `json:"name"`
+	IsActive bool      `json:"is_active"`
+	// IsServiceAccount marks a machine user that authenticates with API keys
+	// only and cannot log in with a password.
+	IsServiceAccount bool `json:"is_service_account" gorm:"not null;default:false"`
+	// MustChangePassword blocks API access (except changing the password) until
+	// the user replaces a temporary password.
+	MustChangePassword bool       `json:"must_change_password" gorm:"not null;default:false"`
+	PasswordChangedAt  *time.Time `json:"password_changed_at,omitempty"`
+	// TwoFactorEnabled turns on TOTP verification at login. TwoFactorSecret holds
+	// the base32 secret, which is stored at enrolment before it is confirmed.
+	TwoFactorEnabled bool   `json:"two_factor_enabled" gorm:"not null;default:false"`
+	TwoFactorSecret  string `json:"-"`
+	// TwoFactorLastStep is the last accepted TOTP time step, so a code cannot be replayed.
+	TwoFactorLastStep int64      `json:"-" gorm:"not null;default:0"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
 
 	// Relations
 	Roles       []Role      `gorm:"many2many:user_roles;" json:"roles,omitempty"`
@@ -44,11 +59,12 @@ func (u *User) CheckPassword(password string) bool {
 	return err == nil
 }
 
-// HasPermission checks if user has a specific permission through their roles
+// HasPermission checks if user has a specific permission through their roles.
+// Wildcard grants such as "reports:*" are honoured, see PermissionMatches.
 func (u *User) HasPermission(permission string) bool {
 	for _, role := range u.Roles {
 		for _, perm := range role.Permissions {
-			if perm.Name == permission {
+			if PermissionMatches(perm.Name, permission) {
 				return true
 			}
 		}
@@ -56,6 +72,109 @@ func (u *User) HasPermission(permission string) bool {
 	return false
 }
 
+// HasAnyPermission reports whether the user has at least one of the permissions.
+func (u *User) HasAnyPermission(permissions ...string) bool {
+	for _, permission := range permissions {
+		if u.HasPermission(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllPermissions reports whether the user has every one of the permissions.
+func (u *User) HasAllPermissions(permissions ...string) bool {
+	for _, permission := range permissions {
+		if !u.HasPermission(permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// RestrictPermissions narrows the role permissions to scope, e.g. the
+// permissions of the API key used for the request. A grant covered by the
+// scope is kept; a wildcard grant broader than the scope is replaced by the
+// scope entries it covers. Roles are copied so the loaded entity's shared
+// slices are left untouched.
+func (u *User) RestrictPermissions(scope []string) {
+	roles := make([]Role, len(u.Roles))
+	for i, role := range u.Roles {
+		permissions := make([]Permission, 0, len(role.Permissions))
+		seen := map[string]bool{}
+		add := func(perm Permission) {
+			if !seen[perm.Name] {
+				seen[perm.Name] = true
+				permissions = append(permissions, perm)
+			}
+		}
+		for _, perm := range role.Permissions {
+			if permissionCovered(scope, perm.Name) {
+				add(perm)
+				continue
+			}
+			for _, allowed := range scope {
+				if PermissionMatches(perm.Name, allowed) {
+					add(Permission{Name: allowed})
+				}
+			}
+		}
+		role.Permissions = permissions
+		roles[i] = role
+	}
+	u.Roles = roles
+}
+
+// EffectivePermissions expands the user's grants against catalog, the names
+// of all known permissions, and returns the sorted set the user holds.
+// Exact grants missing from the catalog are included as they are.
+func (u *User) EffectivePermissions(catalog []string) []string {
+	seen := map[string]bool{}
+	effective := []string{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			effective = append(effective, name)
+		}
+	}
+	for _, name := range catalog {
+		if !strings.Contains(name, PermissionWildcard) && u.HasPermission(name) {
+			add(name)
+		}
+	}
+	for _, name := range u.PermissionNames() {
+		if !strings.Contains(name, PermissionWildcard) {
+			add(name)
+		}
+	}
+	sort.Strings(effective)
+	return effective
+}
+
+func permissionCovered(grants []string, permission string) bool {
+	for _, grant := range grants {
+		if PermissionMatches(grant, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionNames returns the distinct permission names granted by the user's roles.
+func (u *User) PermissionNames() []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, role := range u.Roles {
+		for _, perm := range role.Permissions {
+			if !seen[perm.Name] {
+				seen[perm.Name] = true
+				names = append(names, perm.Name)
+			}
+		}
+	}
+	return names
+}
+
 // HasRole checks if user has a specific role
 func (u *User) HasRole(roleName string) bool {
 	for _, role := range u.Roles {
@@ -66,14 +185,41 @@ func (u *User) HasRole(roleName string) bool {
 	return false
 }
 
+// RequiresTwoFactorSetup reports whether one of the user's roles demands
+// two-factor authentication that the user has not enabled yet.
+func (u *User) RequiresTwoFactorSetup() bool {
+	if u.TwoFactorEnabled {
+		return false
+	}
+	for _, role := range u.Roles {
+		if role.TwoFactorRequired {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllDormitoryAccess reports whether the user bypasses dormitory scoping
+// (admin roles). Roles are matched on slug, or name, case-insensitively: the
+// seeded roles are named "Admin" and "Super Admin" with slugs "admin" and
+// "super_admin".
+func (u *User) HasAllDormitoryAccess() bool {
+	for _, role := range u.Roles {
+		for _, admin := range []string{"admin", "super_admin"} {
+			if strings.EqualFold(role.Slug, admin) || strings.EqualFold(role.Name, admin) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // CanAccessDormitory checks if user can access a specific dormitory
 // Returns true if user has access to all dormitories or specific dormitory
 func (u *User) CanAccessDormitory(dormitoryID uuid.UUID) bool {
 	// Check if user has access to all dormitories (via special role or guard)
-	for _, role := range u.Roles {
-		if role.Name == "admin" || role.Name == "super_admin" {
-			return true
-		}
+	if u.HasAllDormitoryAccess() {
+		return true
 	}
 
 	// Check if user has access to specific dormitory
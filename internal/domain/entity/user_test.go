@@ -228,6 +228,30 @@ func TestUser_CanAccessDormitory(t *testing.T) {
 			dormitoryID:    dormitoryID,
 			expectedResult: true,
 		},
+		{
+			name: "success - seeded admin role (Name Admin, slug admin) can access any dormitory",
+			user: &User{
+				ID:       uuid.New(),
+				Username: "seededadmin",
+				Roles: []Role{
+					{ID: uuid.New(), Name: "Admin", Slug: "admin"},
+				},
+			},
+			dormitoryID:    dormitoryID,
+			expectedResult: true,
+		},
+		{
+			name: "success - seeded super admin role (Name Super Admin, slug super_admin) can access any dormitory",
+			user: &User{
+				ID:       uuid.New(),
+				Username: "seededsuperadmin",
+				Roles: []Role{
+					{ID: uuid.New(), Name: "Super Admin", Slug: "super_admin"},
+				},
+			},
+			dormitoryID:    dormitoryID,
+			expectedResult: true,
+		},
 		{
 			name: "success - user can access assigned dormitory",
 			user: &User{
@@ -276,3 +300,91 @@ func TestUser_CanAccessDormitory(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_RestrictPermissions(t *testing.T) {
+	shared := []Permission{{Name: "student:read"}, {Name: "student:create"}}
+	user := &User{Roles: []Role{{Name: "kiosk", Permissions: shared}}}
+
+	user.RestrictPermissions([]string{"student:read"})
+
+	assert.True(t, user.HasPermission("student:read"))
+	assert.False(t, user.HasPermission("student:create"))
+	assert.Equal(t, []string{"student:read"}, user.PermissionNames())
+	assert.Len(t, shared, 2, "the original role permissions must stay untouched")
+}
+
+func TestUser_RestrictPermissions_Wildcards(t *testing.T) {
+	user := &User{Roles: []Role{
+		{Name: "operator", Permissions: []Permission{{Name: "reports:*"}, {Name: "student:read"}}},
+	}}
+
+	// A wildcard grant broader than the scope is narrowed to the scope
+	user.RestrictPermissions([]string{"reports:attendance:read", "student:*"})
+
+	assert.True(t, user.HasPermission("reports:attendance:read"))
+	assert.False(t, user.HasPermission("reports:health:read"))
+	assert.True(t, user.HasPermission("student:read"))
+	assert.False(t, user.HasPermission("student:create"))
+	assert.ElementsMatch(t, []string{"reports:attendance:read", "student:read"}, user.PermissionNames())
+}
+
+func TestPermissionMatches(t *testing.T) {
+	tests := []struct {
+		grant      string
+		permission string
+		expected   bool
+	}{
+		{"student:read", "student:read", true},
+		{"student:read", "student:create", false},
+		{"reports:*", "reports:attendance:read", true},
+		{"reports:*", "reports:health:read", true},
+		{"reports:*", "reports", false},
+		{"reports:*", "reportsx:attendance:read", false},
+		{"attendance_sessions:*", "attendance_sessions:lock", true},
+		{"reports:*:read", "reports:attendance:read", true},
+		{"reports:*:read", "reports:attendance:export", false},
+		{"*:read", "student:read", true},
+		{"*:read", "reports:attendance:read", false},
+		{"*", "user:delete", true},
+		{"student", "student:read", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.grant+" "+tt.permission, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PermissionMatches(tt.grant, tt.permission))
+		})
+	}
+}
+
+func TestUser_HasAnyAndAllPermissions(t *testing.T) {
+	user := &User{Roles: []Role{
+		{Name: "musyrif", Permissions: []Permission{{Name: "attendance_sessions:*"}, {Name: "student:read"}}},
+	}}
+
+	assert.True(t, user.HasAnyPermission("user:delete", "attendance_sessions:lock"))
+	assert.False(t, user.HasAnyPermission("user:delete", "role:read"))
+	assert.True(t, user.HasAllPermissions("student:read", "attendance_sessions:update"))
+	assert.False(t, user.HasAllPermissions("student:read", "student:create"))
+}
+
+func TestUser_EffectivePermissions(t *testing.T) {
+	user := &User{Roles: []Role{
+		{Name: "staff", Permissions: []Permission{{Name: "reports:*"}, {Name: "student:read"}}},
+		{Name: "legacy", Permissions: []Permission{{Name: "student:read"}, {Name: "legacy:export"}}},
+	}}
+	catalog := []string{
+		"reports:*",
+		"reports:attendance:read",
+		"reports:health:read",
+		"student:create",
+		"student:read",
+		"user:read",
+	}
+
+	assert.Equal(t, []string{
+		"legacy:export",
+		"reports:attendance:read",
+		"reports:health:read",
+		"student:read",
+	}, user.EffectivePermissions(catalog))
+}
@@ -8,12 +8,41 @@ var (
 	ErrTokenExpired       = errors.New("token has expired")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrTokenNotFound      = errors.New("token not found")
+	ErrTokenReused        = errors.New("refresh token reuse detected")
+	ErrLoginLocked        = errors.New("too many failed login attempts")
+
+	// Login lockout errors
+	ErrLoginThrottleNotFound = errors.New("login lockout not found")
+
+	// Two-factor authentication errors
+	ErrTwoFactorCodeInvalid     = errors.New("invalid two-factor code")
+	ErrTwoFactorAlreadyEnabled  = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorNotEnabled      = errors.New("two-factor authentication not enabled")
+	ErrTwoFactorSetupNotStarted = errors.New("two-factor setup not started")
+	ErrTwoFactorEnforced        = errors.New("two-factor authentication is required by a role")
+	ErrTwoFactorSetupRequired   = errors.New("two-factor authentication setup required")
 
 	// User errors
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrUserInactive      = errors.New("user is inactive")
 
+	// Invitation errors
+	ErrRegistrationClosed   = errors.New("self-registration is disabled, ask an admin for an invitation")
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrInvitationInvalid    = errors.New("invitation is invalid, used or expired")
+	ErrInvitationPending    = errors.New("a pending invitation already exists for this username")
+	ErrInvitationNotAllowed = errors.New("cannot invite with a role or dormitory you do not hold")
+
+	// Impersonation errors
+	ErrImpersonationNotAllowed = errors.New("cannot impersonate this user")
+	ErrImpersonationActive     = errors.New("not allowed while impersonating")
+
+	// Password errors
+	ErrCurrentPasswordInvalid = errors.New("current password is incorrect")
+	ErrPasswordUnchanged      = errors.New("new password must differ from the current password")
+	ErrPasswordChangeRequired = errors.New("password change required")
+
 	// Role errors
 	ErrRoleNotFound      = errors.New("role not found")
 	ErrRoleAlreadyExists = errors.New("role already exists")
@@ -32,6 +61,7 @@ var (
 	// Student errors
 	ErrStudentNotFound      = errors.New("student not found")
 	ErrStudentAlreadyExists = errors.New("student already exists")
+	ErrStudentImportInvalid = errors.New("student import contains invalid rows")
 
 	// Fan errors
 	ErrFanNotFound = errors.New("fan not found")
@@ -47,7 +77,8 @@ var (
 	ErrScheduleSlotInactive = errors.New("schedule slot inactive")
 
 	// Subject errors
-	ErrSubjectNotFound = errors.New("subject not found")
+	ErrSubjectNotFound      = errors.New("subject not found")
+	ErrSubjectAlreadyExists = errors.New("subject already exists")
 
 	// Class schedule errors
 	ErrClassScheduleNotFound = errors.New("class schedule not found")
@@ -63,6 +94,7 @@ var (
 	ErrAttendanceSessionNotFound = errors.New("attendance session not found")
 	ErrAttendanceAlreadyLocked   = errors.New("attendance session already locked")
 	ErrAttendanceInvalidStatus   = errors.New("invalid attendance status")
+	ErrHolidayNotFound           = errors.New("holiday not found")
 
 	// Leave/health errors
 	ErrLeavePermitNotFound   = errors.New("leave permit not found")
@@ -72,11 +104,24 @@ var (
 	ErrHealthStatusActive    = errors.New("health status already active")
 	ErrHealthStatusForbidden = errors.New("operation not allowed for current health status")
 
+	// Service account errors
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	ErrAPIKeyNotFound         = errors.New("api key not found")
+	ErrInvalidAPIKey          = errors.New("invalid api key")
+	ErrAPIKeyScopeInvalid     = errors.New("api key permissions must be a subset of the service account's permissions")
+
 	// Class errors
 	ErrClassNotFound          = errors.New("class not found")
 	ErrStudentAlreadyEnrolled = errors.New("student already enrolled in class")
 	ErrClassStaffExists       = errors.New("staff already assigned to class")
 
+	// Audit log errors
+	ErrAuditLogEmpty    = errors.New("audit log is empty")
+	ErrAuditChainBroken = errors.New("audit hash chain is broken")
+	// ErrAuditRetentionDisabled is returned when archiving is requested
+	// without an age and AUDIT_RETENTION_MONTHS is not set.
+	ErrAuditRetentionDisabled = errors.New("audit retention is disabled")
+
 	// General errors
 	ErrInternalServer = errors.New("internal server error")
 	ErrBadRequest     = errors.New("bad request")
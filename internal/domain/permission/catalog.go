@@ -0,0 +1,248 @@
+// Package permission is the catalog of every permission the API checks.
+// Routes and the seed reference these constants instead of string literals,
+// and SyncPermissions keeps the permissions table in line with the catalog.
+package permission
+
+import "strings"
+
+// Permission names, "<resource>:<action>". Names ending in "*" are wildcard
+// grants, see entity.PermissionMatches.
+const (
+	// User management
+	UserRead   = "user:read"
+	UserCreate = "user:create"
+	UserUpdate = "user:update"
+	UserDelete = "user:delete"
+	// UserImpersonate allows acting as another user to reproduce what they see
+	UserImpersonate = "user:impersonate"
+
+	// Dormitories
+	DormRead   = "dorm:read"
+	DormCreate = "dorm:create"
+	DormUpdate = "dorm:update"
+	DormDelete = "dorm:delete"
+
+	// Roles and the permission catalog
+	RoleRead   = "role:read"
+	RoleCreate = "role:create"
+	RoleUpdate = "role:update"
+	RoleDelete = "role:delete"
+
+	// Audit log
+	AuditRead = "audit:read"
+	// AuditVerify allows walking the hash chain and signing checkpoints
+	AuditVerify = "audit:verify"
+	// AuditArchive allows moving old entries out of the audit_logs table
+	AuditArchive = "audit:archive"
+
+	// Students
+	StudentRead   = "student:read"
+	StudentCreate = "student:create"
+	StudentUpdate = "student:update"
+
+	// FANs
+	FansRead   = "fans:read"
+	FansCreate = "fans:create"
+	FansUpdate = "fans:update"
+	FansDelete = "fans:delete"
+
+	// Classes
+	ClassesRead   = "classes:read"
+	ClassesCreate = "classes:create"
+	ClassesUpdate = "classes:update"
+	ClassesDelete = "classes:delete"
+
+	// Teachers
+	TeachersRead   = "teachers:read"
+	TeachersCreate = "teachers:create"
+	TeachersUpdate = "teachers:update"
+	TeachersDelete = "teachers:delete"
+
+	// Schedule slots
+	ScheduleSlotsRead   = "schedule_slots:read"
+	ScheduleSlotsCreate = "schedule_slots:create"
+	ScheduleSlotsUpdate = "schedule_slots:update"
+	ScheduleSlotsDelete = "schedule_slots:delete"
+
+	// Class schedules
+	ClassSchedulesRead   = "class_schedules:read"
+	ClassSchedulesCreate = "class_schedules:create"
+	ClassSchedulesUpdate = "class_schedules:update"
+	ClassSchedulesDelete = "class_schedules:delete"
+
+	// SKS definitions
+	SKSDefinitionsRead   = "sks_definitions:read"
+	SKSDefinitionsCreate = "sks_definitions:create"
+	SKSDefinitionsUpdate = "sks_definitions:update"
+	SKSDefinitionsDelete = "sks_definitions:delete"
+
+	// SKS exam schedules
+	SKSExamsRead   = "sks_exams:read"
+	SKSExamsCreate = "sks_exams:create"
+	SKSExamsUpdate = "sks_exams:update"
+	SKSExamsDelete = "sks_exams:delete"
+
+	// Student SKS results and FAN completion
+	StudentSKSResultsRead   = "student_sks_results:read"
+	StudentSKSResultsCreate = "student_sks_results:create"
+	StudentSKSResultsUpdate = "student_sks_results:update"
+
+	// Attendance sessions
+	AttendanceSessionsRead   = "attendance_sessions:read"
+	AttendanceSessionsCreate = "attendance_sessions:create"
+	AttendanceSessionsUpdate = "attendance_sessions:update"
+	AttendanceSessionsLock   = "attendance_sessions:lock"
+	AttendanceSessionsAll    = "attendance_sessions:*"
+
+	// Leave permits
+	LeavePermitsRead     = "leave_permits:read"
+	LeavePermitsCreate   = "leave_permits:create"
+	LeavePermitsApprove  = "leave_permits:approve"
+	LeavePermitsComplete = "leave_permits:complete"
+
+	// Health statuses
+	HealthStatusesRead   = "health_statuses:read"
+	HealthStatusesCreate = "health_statuses:create"
+	HealthStatusesRevoke = "health_statuses:revoke"
+
+	// Holidays
+	HolidaysRead   = "holidays:read"
+	HolidaysCreate = "holidays:create"
+	HolidaysDelete = "holidays:delete"
+
+	// Subjects
+	SubjectsRead   = "subjects:read"
+	SubjectsCreate = "subjects:create"
+	SubjectsUpdate = "subjects:update"
+	SubjectsDelete = "subjects:delete"
+
+	// Scheduled job runs
+	JobRunsRead = "job_runs:read"
+
+	// Reports
+	ReportsAttendanceRead = "reports:attendance:read"
+	ReportsSecurityRead   = "reports:security:read"
+	ReportsHealthRead     = "reports:health:read"
+	ReportsAcademicRead   = "reports:academic:read"
+	ReportsAll            = "reports:*"
+)
+
+// Definition describes a catalog permission as stored in the permissions table.
+type Definition struct {
+	Name     string
+	Resource string
+	Action   string
+}
+
+// Slug derives the permission slug from its name, e.g. "schedule-slots-read"
+// for "schedule_slots:read" and "reports-all" for "reports:*".
+func (d Definition) Slug() string {
+	return Slug(d.Name)
+}
+
+// Slug derives a permission slug from a permission name.
+func Slug(name string) string {
+	return strings.NewReplacer(":", "-", "_", "-", "*", "all").Replace(name)
+}
+
+var catalog = []Definition{
+	{Name: UserRead, Resource: "user", Action: "read"},
+	{Name: UserCreate, Resource: "user", Action: "create"},
+	{Name: UserUpdate, Resource: "user", Action: "update"},
+	{Name: UserDelete, Resource: "user", Action: "delete"},
+	{Name: UserImpersonate, Resource: "user", Action: "impersonate"},
+	{Name: DormRead, Resource: "dorm", Action: "read"},
+	{Name: DormCreate, Resource: "dorm", Action: "create"},
+	{Name: DormUpdate, Resource: "dorm", Action: "update"},
+	{Name: DormDelete, Resource: "dorm", Action: "delete"},
+	{Name: RoleRead, Resource: "role", Action: "read"},
+	{Name: RoleCreate, Resource: "role", Action: "create"},
+	{Name: RoleUpdate, Resource: "role", Action: "update"},
+	{Name: RoleDelete, Resource: "role", Action: "delete"},
+	{Name: AuditRead, Resource: "audit_log", Action: "read"},
+	{Name: AuditVerify, Resource: "audit_log", Action: "verify"},
+	{Name: AuditArchive, Resource: "audit_log", Action: "archive"},
+	{Name: StudentRead, Resource: "student", Action: "read"},
+	{Name: StudentCreate, Resource: "student", Action: "create"},
+	{Name: StudentUpdate, Resource: "student", Action: "update"},
+	{Name: FansRead, Resource: "fans", Action: "read"},
+	{Name: FansCreate, Resource: "fans", Action: "create"},
+	{Name: FansUpdate, Resource: "fans", Action: "update"},
+	{Name: FansDelete, Resource: "fans", Action: "delete"},
+	{Name: ClassesRead, Resource: "classes", Action: "read"},
+	{Name: ClassesCreate, Resource: "classes", Action: "create"},
+	{Name: ClassesUpdate, Resource: "classes", Action: "update"},
+	{Name: ClassesDelete, Resource: "classes", Action: "delete"},
+	{Name: TeachersRead, Resource: "teachers", Action: "read"},
+	{Name: TeachersCreate, Resource: "teachers", Action: "create"},
+	{Name: TeachersUpdate, Resource: "teachers", Action: "update"},
+	{Name: TeachersDelete, Resource: "teachers", Action: "delete"},
+	{Name: ScheduleSlotsRead, Resource: "schedule_slots", Action: "read"},
+	{Name: ScheduleSlotsCreate, Resource: "schedule_slots", Action: "create"},
+	{Name: ScheduleSlotsUpdate, Resource: "schedule_slots", Action: "update"},
+	{Name: ScheduleSlotsDelete, Resource: "schedule_slots", Action: "delete"},
+	{Name: ClassSchedulesRead, Resource: "class_schedules", Action: "read"},
+	{Name: ClassSchedulesCreate, Resource: "class_schedules", Action: "create"},
+	{Name: ClassSchedulesUpdate, Resource: "class_schedules", Action: "update"},
+	{Name: ClassSchedulesDelete, Resource: "class_schedules", Action: "delete"},
+	{Name: SKSDefinitionsRead, Resource: "sks_definitions", Action: "read"},
+	{Name: SKSDefinitionsCreate, Resource: "sks_definitions", Action: "create"},
+	{Name: SKSDefinitionsUpdate, Resource: "sks_definitions", Action: "update"},
+	{Name: SKSDefinitionsDelete, Resource: "sks_definitions", Action: "delete"},
+	{Name: SKSExamsRead, Resource: "sks_exams", Action: "read"},
+	{Name: SKSExamsCreate, Resource: "sks_exams", Action: "create"},
+	{Name: SKSExamsUpdate, Resource: "sks_exams", Action: "update"},
+	{Name: SKSExamsDelete, Resource: "sks_exams", Action: "delete"},
+	{Name: StudentSKSResultsRead, Resource: "student_sks_results", Action: "read"},
+	{Name: StudentSKSResultsCreate, Resource: "student_sks_results", Action: "create"},
+	{Name: StudentSKSResultsUpdate, Resource: "student_sks_results", Action: "update"},
+	{Name: AttendanceSessionsRead, Resource: "attendance_sessions", Action: "read"},
+	{Name: AttendanceSessionsCreate, Resource: "attendance_sessions", Action: "create"},
+	{Name: AttendanceSessionsUpdate, Resource: "attendance_sessions", Action: "update"},
+	{Name: AttendanceSessionsLock, Resource: "attendance_sessions", Action: "lock"},
+	{Name: AttendanceSessionsAll, Resource: "attendance_sessions", Action: "*"},
+	{Name: LeavePermitsRead, Resource: "leave_permits", Action: "read"},
+	{Name: LeavePermitsCreate, Resource: "leave_permits", Action: "create"},
+	{Name: LeavePermitsApprove, Resource: "leave_permits", Action: "approve"},
+	{Name: LeavePermitsComplete, Resource: "leave_permits", Action: "complete"},
+	{Name: HealthStatusesRead, Resource: "health_statuses", Action: "read"},
+	{Name: HealthStatusesCreate, Resource: "health_statuses", Action: "create"},
+	{Name: HealthStatusesRevoke, Resource: "health_statuses", Action: "revoke"},
+	{Name: HolidaysRead, Resource: "holidays", Action: "read"},
+	{Name: HolidaysCreate, Resource: "holidays", Action: "create"},
+	{Name: HolidaysDelete, Resource: "holidays", Action: "delete"},
+	{Name: SubjectsRead, Resource: "subjects", Action: "read"},
+	{Name: SubjectsCreate, Resource: "subjects", Action: "create"},
+	{Name: SubjectsUpdate, Resource: "subjects", Action: "update"},
+	{Name: SubjectsDelete, Resource: "subjects", Action: "delete"},
+	{Name: JobRunsRead, Resource: "job_runs", Action: "read"},
+	{Name: ReportsAttendanceRead, Resource: "reports:attendance", Action: "read"},
+	{Name: ReportsSecurityRead, Resource: "reports:security", Action: "read"},
+	{Name: ReportsHealthRead, Resource: "reports:health", Action: "read"},
+	{Name: ReportsAcademicRead, Resource: "reports:academic", Action: "read"},
+	{Name: ReportsAll, Resource: "reports", Action: "*"},
+}
+
+// Definitions returns the catalog in declaration order.
+func Definitions() []Definition {
+	return append([]Definition(nil), catalog...)
+}
+
+// Names returns the names of all catalog permissions.
+func Names() []string {
+	names := make([]string, len(catalog))
+	for i, d := range catalog {
+		names[i] = d.Name
+	}
+	return names
+}
+
+// Known reports whether name is in the catalog.
+func Known(name string) bool {
+	for _, d := range catalog {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
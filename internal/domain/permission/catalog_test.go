@@ -0,0 +1,32 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_NamesAndSlugsAreUnique(t *testing.T) {
+	names := map[string]bool{}
+	slugs := map[string]bool{}
+	for _, def := range Definitions() {
+		assert.False(t, names[def.Name], "duplicate permission %s", def.Name)
+		assert.False(t, slugs[def.Slug()], "duplicate slug %s", def.Slug())
+		assert.NotEmpty(t, def.Resource, def.Name)
+		assert.NotEmpty(t, def.Action, def.Name)
+		names[def.Name] = true
+		slugs[def.Slug()] = true
+	}
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "schedule-slots-read", Slug(ScheduleSlotsRead))
+	assert.Equal(t, "reports-attendance-read", Slug(ReportsAttendanceRead))
+	assert.Equal(t, "reports-all", Slug(ReportsAll))
+}
+
+func TestKnown(t *testing.T) {
+	assert.True(t, Known(UserRead))
+	assert.True(t, Known(ReportsAll))
+	assert.False(t, Known("legacy:export"))
+}
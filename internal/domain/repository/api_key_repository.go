@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// APIKeyRepository persists hashed service-account API keys.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.APIKey, error)
+	// GetByPrefix returns the key with the given prefix, or nil when there is none.
+	GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
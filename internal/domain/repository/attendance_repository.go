@@ -12,6 +12,7 @@ import (
 type AttendanceSessionFilter struct {
 	ClassScheduleID *uuid.UUID
 	TeacherID       *uuid.UUID
+	DormitoryIDs    []uuid.UUID // schedules in these dormitories; nil means unrestricted
 	Date            *time.Time
 	Status          *entity.AttendanceSessionStatus
 	Limit           int
@@ -25,7 +26,9 @@ type AttendanceSessionRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.AttendanceSession, error)
 	GetOpenByScheduleAndDate(ctx context.Context, scheduleID uuid.UUID, date time.Time) (*entity.AttendanceSession, error)
 	List(ctx context.Context, filter AttendanceSessionFilter) ([]*entity.AttendanceSession, int64, error)
-	LockSessionsByDate(ctx context.Context, date time.Time) error
+	ListScheduleIDsByDate(ctx context.Context, date time.Time) ([]uuid.UUID, error)
+	// LockSessionsByDate locks the date's sessions, limited to dormitoryIDs unless it is nil.
+	LockSessionsByDate(ctx context.Context, date time.Time, dormitoryIDs []uuid.UUID) error
 }
 
 // StudentAttendanceRepository defines persistence for student attendance rows.
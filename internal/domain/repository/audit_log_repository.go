@@ -2,14 +2,56 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 )
 
 // AuditLogRepository defines the interface for audit log data operations
 type AuditLogRepository interface {
+	// Create appends the entry to the hash chain, setting its Sequence,
+	// PrevHash and Hash.
 	Create(ctx context.Context, log *entity.AuditLog) error
+	// CreateBatch appends the entries to the hash chain in slice order, all
+	// or none of them.
+	CreateBatch(ctx context.Context, logs []*entity.AuditLog) error
 	List(ctx context.Context, filter AuditLogFilter) ([]*entity.AuditLog, int64, error)
+	// Each passes the entries matching filter to fn in chain order, at most
+	// batchSize at a time, ignoring the filter's pagination. It stops at the
+	// first error fn returns.
+	Each(ctx context.Context, filter AuditLogFilter, batchSize int, fn func([]*entity.AuditLog) error) error
+	// ListByTarget returns every entry for one record of a resource, oldest first.
+	ListByTarget(ctx context.Context, resource, targetID string) ([]*entity.AuditLog, error)
+	// ListChain returns up to limit entries with a sequence above afterSequence, in chain order.
+	ListChain(ctx context.Context, afterSequence int64, limit int) ([]*entity.AuditLog, error)
+	// GetLatest returns the chain head, or nil when the log is empty.
+	GetLatest(ctx context.Context) (*entity.AuditLog, error)
+	// GetFirstSince returns the entry with the lowest sequence created at or
+	// after since, or nil when there is none.
+	GetFirstSince(ctx context.Context, since time.Time) (*entity.AuditLog, error)
+	// DeleteThrough removes every entry with a sequence up to and including
+	// sequence and returns how many were removed.
+	DeleteThrough(ctx context.Context, sequence int64) (int64, error)
+}
+
+// AuditCheckpointRepository stores signed audit chain checkpoints
+type AuditCheckpointRepository interface {
+	Create(ctx context.Context, checkpoint *entity.AuditCheckpoint) error
+	// List returns every checkpoint, oldest first.
+	List(ctx context.Context) ([]*entity.AuditCheckpoint, error)
+	// GetLatest returns the newest checkpoint, or nil when none was taken.
+	GetLatest(ctx context.Context) (*entity.AuditCheckpoint, error)
+}
+
+// AuditArchiveRepository records the chain ranges moved out of audit_logs
+type AuditArchiveRepository interface {
+	Create(ctx context.Context, archive *entity.AuditArchive) error
+	// List returns every archive, oldest range first.
+	List(ctx context.Context) ([]*entity.AuditArchive, error)
+	// GetLatest returns the archive with the highest ToSequence, or nil when
+	// nothing was archived.
+	GetLatest(ctx context.Context) (*entity.AuditArchive, error)
 }
 
 // AuditLogFilter represents filtering and pagination options for listing audit logs
@@ -19,4 +61,12 @@ type AuditLogFilter struct {
 	Resource      string
 	Action        string
 	ActorUsername string
+	ActorID       *uuid.UUID
+	TargetID      string
+	IPAddress     string
+	RequestID     string
+	StatusCode    int
+	// From and To bound CreatedAt; From is inclusive, To exclusive.
+	From *time.Time
+	To   *time.Time
 }
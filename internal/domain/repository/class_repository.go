@@ -12,6 +12,8 @@ type ClassRepository interface {
 	Create(ctx context.Context, class *entity.Class) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Class, error)
 	ListByFan(ctx context.Context, fanID uuid.UUID, limit, offset int) ([]*entity.Class, int64, error)
+	// ListByDormitory returns every class whose fan belongs to the dormitory.
+	ListByDormitory(ctx context.Context, dormitoryID uuid.UUID) ([]*entity.Class, error)
 	Update(ctx context.Context, class *entity.Class) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -9,20 +9,35 @@ import (
 
 // ClassScheduleFilter encapsulates query parameters for listing schedules.
 type ClassScheduleFilter struct {
-	ClassID     uuid.UUID
-	TeacherID   uuid.UUID
-	DormitoryID uuid.UUID
-	DayOfWeek   string
-	IsActive    *bool
-	Page        int
-	PageSize    int
+	ClassID      uuid.UUID
+	TeacherID    uuid.UUID
+	DormitoryID  uuid.UUID
+	DormitoryIDs []uuid.UUID // caller's dormitory scope; nil means unrestricted
+	DayOfWeek    string
+	IsActive     *bool
+	Page         int
+	PageSize     int
+}
+
+// ClassScheduleConflictFilter selects active schedules on the same day that share
+// a teacher, class or location with a candidate schedule.
+type ClassScheduleConflictFilter struct {
+	ExcludeID uuid.UUID
+	DayOfWeek string
+	TeacherID uuid.UUID
+	ClassID   uuid.UUID
+	Location  string
 }
 
 // ClassScheduleRepository defines persistence operations for class schedules.
 type ClassScheduleRepository interface {
 	Create(ctx context.Context, schedule *entity.ClassSchedule) error
+	CreateBatch(ctx context.Context, schedules []*entity.ClassSchedule) error
 	Update(ctx context.Context, schedule *entity.ClassSchedule) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.ClassSchedule, error)
 	List(ctx context.Context, filter ClassScheduleFilter) ([]*entity.ClassSchedule, int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	ListConflictCandidates(ctx context.Context, filter ClassScheduleConflictFilter) ([]*entity.ClassSchedule, error)
+	// ListActiveByDay returns every active schedule on the given day; uuid.Nil dormitoryID means all dormitories.
+	ListActiveByDay(ctx context.Context, dayOfWeek string, dormitoryID uuid.UUID) ([]*entity.ClassSchedule, error)
 }
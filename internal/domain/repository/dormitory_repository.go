@@ -11,6 +11,7 @@ import (
 type DormitoryRepository interface {
 	Create(ctx context.Context, dormitory *entity.Dormitory) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Dormitory, error)
+	GetByCode(ctx context.Context, code string) (*entity.Dormitory, error)
 	Update(ctx context.Context, dormitory *entity.Dormitory) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, limit, offset int) ([]*entity.Dormitory, int64, error)
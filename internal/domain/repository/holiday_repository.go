@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// HolidayFilter narrows holidays to an inclusive date range. When DormitoryID is set,
+// global holidays and the holidays of that dormitory are returned.
+type HolidayFilter struct {
+	StartDate   time.Time
+	EndDate     time.Time
+	DormitoryID *uuid.UUID
+}
+
+// HolidayRepository defines persistence operations for holidays.
+type HolidayRepository interface {
+	Create(ctx context.Context, holiday *entity.Holiday) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Holiday, error)
+	List(ctx context.Context, filter HolidayFilter) ([]*entity.Holiday, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
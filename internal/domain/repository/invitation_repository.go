@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// InvitationRepository persists onboarding invitations.
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *entity.Invitation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Invitation, error)
+	// GetByTokenHash returns the invitation with the given token hash, or nil when there is none.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error)
+	// ListPending returns invitations that are neither accepted, revoked nor expired at now.
+	ListPending(ctx context.Context, now time.Time) ([]*entity.Invitation, error)
+	// HasPendingForUsername reports whether a pending invitation exists for username.
+	HasPendingForUsername(ctx context.Context, username string, now time.Time) (bool, error)
+	// Accept redeems a pending invitation, creating user and assigning the dormitories in one
+	// transaction. It reports whether the invitation was still pending, so a token can only be
+	// used once even under concurrent requests; on failure nothing is written.
+	Accept(ctx context.Context, id uuid.UUID, user *entity.User, dormitoryIDs []uuid.UUID, at time.Time) (bool, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+}
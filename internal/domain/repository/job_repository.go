@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// JobRunFilter collects optional filters for listing job runs.
+type JobRunFilter struct {
+	JobName *string
+	Status  *entity.JobRunStatus
+	Limit   int
+	Offset  int
+}
+
+// JobRunRepository persists the history of scheduled job executions.
+type JobRunRepository interface {
+	Create(ctx context.Context, run *entity.JobRun) error
+	Update(ctx context.Context, run *entity.JobRun) error
+	List(ctx context.Context, filter JobRunFilter) ([]*entity.JobRun, int64, error)
+}
+
+// JobLeaseRepository coordinates scheduled jobs across replicas.
+type JobLeaseRepository interface {
+	// Acquire claims the lease for jobName until ttl elapses. It returns false when
+	// another holder owns a lease that has not expired yet.
+	Acquire(ctx context.Context, jobName, holder string, ttl time.Duration) (bool, error)
+}
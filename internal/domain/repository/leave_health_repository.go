@@ -10,21 +10,23 @@ import (
 
 // LeavePermitFilter collects optional filters for listing permits.
 type LeavePermitFilter struct {
-	StudentID *uuid.UUID
-	Status    *entity.LeavePermitStatus
-	Type      *entity.LeavePermitType
-	Date      *time.Time // filter permits overlapping specific date
-	Limit     int
-	Offset    int
+	StudentID    *uuid.UUID
+	DormitoryIDs []uuid.UUID // students currently in these dormitories; nil means unrestricted
+	Status       *entity.LeavePermitStatus
+	Type         *entity.LeavePermitType
+	Date         *time.Time // filter permits overlapping specific date
+	Limit        int
+	Offset       int
 }
 
 // HealthStatusFilter collects filters for health status queries.
 type HealthStatusFilter struct {
-	StudentID *uuid.UUID
-	Status    *entity.HealthStatusState
-	Date      *time.Time // overlapping date
-	Limit     int
-	Offset    int
+	StudentID    *uuid.UUID
+	DormitoryIDs []uuid.UUID // students currently in these dormitories; nil means unrestricted
+	Status       *entity.HealthStatusState
+	Date         *time.Time // overlapping date
+	Limit        int
+	Offset       int
 }
 
 // LeavePermitRepository defines persistence behavior for leave permits.
@@ -35,6 +37,8 @@ type LeavePermitRepository interface {
 	List(ctx context.Context, filter LeavePermitFilter) ([]*entity.LeavePermit, int64, error)
 	HasOverlap(ctx context.Context, studentID uuid.UUID, startDate, endDate time.Time, excludeID *uuid.UUID) (bool, error)
 	ActiveByDate(ctx context.Context, studentID uuid.UUID, date time.Time) (*entity.LeavePermit, error)
+	// ExpireEndedBefore marks pending/approved permits whose end date is before date as expired.
+	ExpireEndedBefore(ctx context.Context, date time.Time) (int64, error)
 }
 
 // HealthStatusRepository defines persistence behavior for health statuses.
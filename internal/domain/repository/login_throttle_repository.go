@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// LoginThrottleRepository persists failed-login counters per username and client IP.
+type LoginThrottleRepository interface {
+	// GetByKey returns the throttle for kind/value, or nil when the subject has no recorded failures.
+	GetByKey(ctx context.Context, kind, value string) (*entity.LoginThrottle, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.LoginThrottle, error)
+	Save(ctx context.Context, throttle *entity.LoginThrottle) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListLocked returns subjects whose lockout has not expired at now.
+	ListLocked(ctx context.Context, now time.Time) ([]*entity.LoginThrottle, error)
+}
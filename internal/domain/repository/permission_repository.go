@@ -12,7 +12,10 @@ type PermissionRepository interface {
 	Create(ctx context.Context, permission *entity.Permission) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Permission, error)
 	GetBySlug(ctx context.Context, slug string) (*entity.Permission, error)
+	GetByName(ctx context.Context, name string) (*entity.Permission, error)
 	Update(ctx context.Context, permission *entity.Permission) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, limit, offset int) ([]*entity.Permission, int64, error)
+	// ListNames returns the names of all permissions, used to expand wildcard grants.
+	ListNames(ctx context.Context) ([]string, error)
 }
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// RecoveryCodeRepository persists two-factor recovery codes.
+type RecoveryCodeRepository interface {
+	// ReplaceForUser deletes the user's existing codes and stores codes in their place.
+	ReplaceForUser(ctx context.Context, userID uuid.UUID, codes []*entity.RecoveryCode) error
+	// Consume marks the unused code with codeHash as used and reports whether one was found.
+	Consume(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	CountUnused(ctx context.Context, userID uuid.UUID) (int64, error)
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// RefreshSessionRepository persists refresh-token sessions.
+type RefreshSessionRepository interface {
+	Create(ctx context.Context, session *entity.RefreshSession) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.RefreshSession, error)
+	// Rotate marks an active session as replaced. It returns false when the
+	// session was already revoked or rotated by a concurrent request.
+	Rotate(ctx context.Context, id, replacedByID uuid.UUID) (bool, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID, reason string) error
+	RevokeAllByUser(ctx context.Context, userID uuid.UUID, reason string) (int64, error)
+}
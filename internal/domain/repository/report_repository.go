@@ -15,10 +15,11 @@ type DateRange struct {
 
 // StudentAttendanceReportFilter defines inputs for aggregating student attendance.
 type StudentAttendanceReportFilter struct {
-	Date        time.Time
-	DormitoryID *uuid.UUID
-	ClassID     *uuid.UUID
-	FanID       *uuid.UUID
+	Date         time.Time
+	DormitoryID  *uuid.UUID
+	DormitoryIDs []uuid.UUID // caller's dormitory scope; nil means unrestricted
+	ClassID      *uuid.UUID
+	FanID        *uuid.UUID
 }
 
 // StudentAttendanceAggregation represents counts grouped by dorm/class/FAN.
@@ -35,9 +36,10 @@ type StudentAttendanceAggregation struct {
 
 // TeacherAttendanceReportFilter defines inputs for aggregating teacher attendance.
 type TeacherAttendanceReportFilter struct {
-	Date      time.Time
-	SlotID    *uuid.UUID
-	TeacherID *uuid.UUID
+	Date         time.Time
+	SlotID       *uuid.UUID
+	TeacherID    *uuid.UUID
+	DormitoryIDs []uuid.UUID // caller's dormitory scope; nil means unrestricted
 }
 
 // TeacherAttendanceAggregation summarizes teacher attendance metrics.
@@ -50,10 +52,11 @@ type TeacherAttendanceAggregation struct {
 
 // LeavePermitReportFilter defines filters for leave permit aggregations.
 type LeavePermitReportFilter struct {
-	Status      *string
-	Type        *string
-	DormitoryID *uuid.UUID
-	DateRange   DateRange
+	Status       *string
+	Type         *string
+	DormitoryID  *uuid.UUID
+	DormitoryIDs []uuid.UUID // caller's dormitory scope; nil means unrestricted
+	DateRange    DateRange
 }
 
 // LeavePermitAggregation represents aggregated leave permit counts.
@@ -66,9 +69,10 @@ type LeavePermitAggregation struct {
 
 // HealthStatusReportFilter defines filters for health status aggregation.
 type HealthStatusReportFilter struct {
-	Status      *string
-	DormitoryID *uuid.UUID
-	DateRange   DateRange
+	Status       *string
+	DormitoryID  *uuid.UUID
+	DormitoryIDs []uuid.UUID // caller's dormitory scope; nil means unrestricted
+	DateRange    DateRange
 }
 
 // HealthStatusAggregation represents active/revoked counts per dormitory.
@@ -81,10 +85,11 @@ type HealthStatusAggregation struct {
 
 // SKSReportFilter defines filters for SKS pass-rate aggregation.
 type SKSReportFilter struct {
-	FanID     *uuid.UUID
-	SKSID     *uuid.UUID
-	IsPassed  *bool
-	DateRange DateRange
+	FanID        *uuid.UUID
+	SKSID        *uuid.UUID
+	IsPassed     *bool
+	DormitoryIDs []uuid.UUID // students currently in these dormitories; nil means unrestricted
+	DateRange    DateRange
 }
 
 // SKSAggregation holds pass/fail totals and optional average score.
@@ -99,10 +104,11 @@ type SKSAggregation struct {
 
 // MutationReportFilter defines filters for student mutation history reporting.
 type MutationReportFilter struct {
-	StudentID   *uuid.UUID
-	FanID       *uuid.UUID
-	DormitoryID *uuid.UUID
-	DateRange   DateRange
+	StudentID    *uuid.UUID
+	FanID        *uuid.UUID
+	DormitoryID  *uuid.UUID
+	DormitoryIDs []uuid.UUID // caller's dormitory scope; nil means unrestricted
+	DateRange    DateRange
 }
 
 // MutationHistoryRow represents a dorm/class transition window.
@@ -116,6 +122,26 @@ type MutationHistoryRow struct {
 	EndDate     *time.Time
 }
 
+// ReportLabelQuery lists the entities whose display names a report needs.
+type ReportLabelQuery struct {
+	DormitoryIDs []uuid.UUID
+	ClassIDs     []uuid.UUID
+	FanIDs       []uuid.UUID
+	TeacherIDs   []uuid.UUID
+	SKSIDs       []uuid.UUID
+	StudentIDs   []uuid.UUID
+}
+
+// ReportLabels maps entity IDs to display names. IDs that no longer exist are absent.
+type ReportLabels struct {
+	Dormitories map[uuid.UUID]string
+	Classes     map[uuid.UUID]string
+	Fans        map[uuid.UUID]string
+	Teachers    map[uuid.UUID]string
+	SKS         map[uuid.UUID]string
+	Students    map[uuid.UUID]string
+}
+
 // ReportRepository declares read-only aggregation queries for Phase 8 reports.
 type ReportRepository interface {
 	AggregateStudentAttendance(ctx context.Context, filter StudentAttendanceReportFilter) ([]StudentAttendanceAggregation, error)
@@ -124,4 +150,5 @@ type ReportRepository interface {
 	AggregateHealthStatuses(ctx context.Context, filter HealthStatusReportFilter) ([]HealthStatusAggregation, error)
 	AggregateSKSResults(ctx context.Context, filter SKSReportFilter) ([]SKSAggregation, error)
 	ListMutationHistory(ctx context.Context, filter MutationReportFilter) ([]MutationHistoryRow, error)
+	ResolveLabels(ctx context.Context, query ReportLabelQuery) (ReportLabels, error)
 }
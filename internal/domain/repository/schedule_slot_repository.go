@@ -19,8 +19,9 @@ type ScheduleSlotRepository interface {
 
 // ScheduleSlotFilter encapsulates query filters for listing schedule slots.
 type ScheduleSlotFilter struct {
-	DormitoryID uuid.UUID
-	IsActive    *bool
-	Page        int
-	PageSize    int
+	DormitoryID  uuid.UUID
+	DormitoryIDs []uuid.UUID // caller's dormitory scope; nil means unrestricted
+	IsActive     *bool
+	Page         int
+	PageSize     int
 }
@@ -8,15 +8,53 @@ import (
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 )
 
+// Student list sort fields accepted by StudentFilter.SortBy.
+const (
+	StudentSortFullName      = "full_name"
+	StudentSortStudentNumber = "student_number"
+	StudentSortBirthDate     = "birth_date"
+	StudentSortCreatedAt     = "created_at"
+)
+
+// StudentFilter collects optional filters and sorting for listing students.
+type StudentFilter struct {
+	Search string // case-insensitive match on full name or student number
+	// DormitoryIDs keeps students whose active dormitory history is in the list.
+	// Nil means no dormitory restriction.
+	DormitoryIDs  []uuid.UUID
+	ClassID       *uuid.UUID // active class enrollment
+	FanID         *uuid.UUID // active enrollment in any class of the fan
+	Status        *string
+	Gender        *string
+	BirthYearFrom *int
+	BirthYearTo   *int
+	SortBy        string // one of the StudentSort* constants; defaults to created_at
+	SortDesc      bool
+	Limit         int
+	Offset        int
+}
+
+// StudentImportRecord bundles a new student with its initial placement.
+// Enrollment is nil when the import row names no class.
+type StudentImportRecord struct {
+	Student    *entity.Student
+	History    *entity.StudentDormitoryHistory
+	Enrollment *entity.StudentClassEnrollment
+}
+
 // StudentRepository defines persistence operations for students.
 type StudentRepository interface {
 	Create(ctx context.Context, student *entity.Student) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Student, error)
 	GetByStudentNumber(ctx context.Context, studentNumber string) (*entity.Student, error)
-	List(ctx context.Context, limit, offset int) ([]*entity.Student, int64, error)
+	List(ctx context.Context, filter StudentFilter) ([]*entity.Student, int64, error)
 	Update(ctx context.Context, student *entity.Student) error
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string, isActive bool) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// ExistingStudentNumbers returns which of the given student numbers are already taken.
+	ExistingStudentNumbers(ctx context.Context, studentNumbers []string) ([]string, error)
+	// CreateImport stores every record in a single transaction.
+	CreateImport(ctx context.Context, records []StudentImportRecord) error
 
 	// Dormitory history helpers
 	CreateHistory(ctx context.Context, history *entity.StudentDormitoryHistory) error
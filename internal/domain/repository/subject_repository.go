@@ -15,4 +15,6 @@ type SubjectRepository interface {
 	GetByName(ctx context.Context, name string) (*entity.Subject, error)
 	List(ctx context.Context, limit, offset int) ([]*entity.Subject, int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	// CountUsage returns how many class schedules and SKS definitions reference the subject.
+	CountUsage(ctx context.Context, id uuid.UUID) (int64, error)
 }
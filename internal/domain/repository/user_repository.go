@@ -15,8 +15,13 @@ type UserRepository interface {
 	Update(ctx context.Context, user *entity.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, limit, offset int) ([]*entity.User, int64, error)
+	// ListServiceAccounts returns all service-account users with roles and permissions.
+	ListServiceAccounts(ctx context.Context) ([]*entity.User, error)
 	GetWithRoles(ctx context.Context, id uuid.UUID) (*entity.User, error)
 	GetWithRolesAndDormitories(ctx context.Context, id uuid.UUID) (*entity.User, error)
 	AssignRole(ctx context.Context, userID, roleID uuid.UUID) error
 	RemoveRole(ctx context.Context, userID, roleID uuid.UUID) error
+	// AdvanceTwoFactorStep records step as the last accepted TOTP time step if it
+	// is later than the stored one, and reports whether it was.
+	AdvanceTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
 }
@@ -0,0 +1,21 @@
+package service
+
+import "io"
+
+// AuditArchiveStore keeps the compressed files that retention moves old
+// audit entries into.
+type AuditArchiveStore interface {
+	// Create starts a new archive. Entries written to it are compressed;
+	// nothing is visible until Commit succeeds.
+	Create(name string) (AuditArchiveWriter, error)
+}
+
+// AuditArchiveWriter receives the content of one archive.
+type AuditArchiveWriter interface {
+	io.Writer
+	// Commit finishes the archive and returns where it is stored and the hex
+	// SHA-256 of its compressed bytes.
+	Commit() (location, sha256 string, err error)
+	// Abort discards an archive that was not committed.
+	Abort() error
+}
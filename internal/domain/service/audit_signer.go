@@ -0,0 +1,16 @@
+package service
+
+// AuditSigner signs audit chain checkpoints so that exported checkpoints can
+// be verified without trusting the database.
+type AuditSigner interface {
+	// KeyID identifies the signing key (its RFC 7638 thumbprint).
+	KeyID() string
+	// Algorithm is the signature algorithm, "EdDSA" or "RS256".
+	Algorithm() string
+	// PublicKeyPEM returns the PEM encoded public key.
+	PublicKeyPEM() string
+	// Sign returns the base64url encoded signature of payload.
+	Sign(payload []byte) (string, error)
+	// Verify checks a signature made by the key in publicKeyPEM.
+	Verify(payload []byte, signature, publicKeyPEM string) error
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// PrincipalCache keeps authenticated users with their roles, permissions and
+// dormitories for a short time, so requests need not reload them from the
+// database. Use cases invalidate entries when those assignments change.
+type PrincipalCache interface {
+	// Get returns the cached user, or nil when it is absent, expired or invalidated.
+	Get(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	// Set caches a user that was read from the database at loadedAt. It has no
+	// effect when the user was invalidated after loadedAt, so a slow request
+	// cannot put back a graph that changed while it was loading.
+	Set(ctx context.Context, user *entity.User, loadedAt time.Time) error
+	// Invalidate drops the given users.
+	Invalidate(ctx context.Context, userIDs ...uuid.UUID) error
+	// InvalidateAll drops every user, e.g. after a role's permissions changed.
+	InvalidateAll(ctx context.Context) error
+}
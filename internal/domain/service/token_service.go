@@ -9,17 +9,67 @@ import (
 // TokenService defines the interface for token operations
 type TokenService interface {
 	GenerateAccessToken(userID uuid.UUID, username string, roles []string) (string, error)
-	GenerateRefreshToken(userID uuid.UUID) (string, error)
+	GenerateRefreshToken(userID, sessionID, familyID uuid.UUID) (string, error)
+	// ValidateToken validates an access token; refresh tokens are rejected.
 	ValidateToken(tokenString string) (*TokenClaims, error)
-	RefreshAccessToken(refreshToken string) (string, error)
+	// ValidateRefreshToken validates a refresh token; access tokens are rejected.
+	ValidateRefreshToken(tokenString string) (*TokenClaims, error)
+	RefreshTokenExpiry() time.Duration
+	// GenerateTwoFactorToken issues a short-lived token proving the password
+	// step of a login, to be exchanged for tokens with a second factor.
+	GenerateTwoFactorToken(userID uuid.UUID) (string, error)
+	// ValidateTwoFactorToken validates a two-factor login token; other token types are rejected.
+	ValidateTwoFactorToken(tokenString string) (*TokenClaims, error)
+	// GenerateImpersonationToken issues a short-lived access token for userID
+	// that also names the impersonating admin. It is accepted by ValidateToken.
+	GenerateImpersonationToken(userID uuid.UUID, username string, impersonatorID uuid.UUID, impersonatorUsername string) (string, error)
+	// VerificationKeys returns the public keys that tokens may be signed with,
+	// for publishing as a JWK set.
+	VerificationKeys() []JSONWebKey
 }
 
+// Token types carried in the "type" claim.
+const (
+	TokenTypeAccess    = "access"
+	TokenTypeRefresh   = "refresh"
+	TokenTypeTwoFactor = "two_factor"
+)
+
+// TwoFactorTokenExpiry is how long a user has to enter the second factor after the password.
+const TwoFactorTokenExpiry = 5 * time.Minute
+
+// ImpersonationTokenExpiry is how long an admin can act as another user
+// before requesting a new impersonation token.
+const ImpersonationTokenExpiry = 15 * time.Minute
+
 // TokenClaims represents the claims in a JWT token
 type TokenClaims struct {
-	UserID   uuid.UUID
-	Username string
-	Roles    []string
-	Exp      int64
+	UserID    uuid.UUID
+	Username  string
+	Roles     []string
+	Exp       int64
+	TokenType string
+	// TokenID and FamilyID are only set on refresh tokens and reference the
+	// server-side refresh session.
+	TokenID  uuid.UUID
+	FamilyID uuid.UUID
+	// ImpersonatorID and ImpersonatorUsername are only set on impersonation
+	// tokens and identify the admin acting as UserID.
+	ImpersonatorID       uuid.UUID
+	ImpersonatorUsername string
+}
+
+// JSONWebKey is the public part of a token signing key (RFC 7517). RSA keys
+// set N and E, Ed25519 keys set Curve and X.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
 }
 
 // TokenPair represents a pair of access and refresh tokens
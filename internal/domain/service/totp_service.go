@@ -0,0 +1,14 @@
+package service
+
+import "time"
+
+// TOTPService generates and verifies RFC 6238 time-based one-time passwords.
+type TOTPService interface {
+	// GenerateSecret returns a new random base32-encoded shared secret.
+	GenerateSecret() (string, error)
+	// ProvisioningURI returns the otpauth:// URI that authenticator apps read from a QR code.
+	ProvisioningURI(accountName, secret string) string
+	// Validate checks code against secret at the given time, allowing one step of
+	// clock drift, and returns the matched time step.
+	Validate(secret, code string, at time.Time) (int64, bool)
+}
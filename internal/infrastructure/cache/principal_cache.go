@@ -0,0 +1,166 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// Principal cache backends selectable with PRINCIPAL_CACHE_BACKEND.
+const (
+	BackendMemory   = "memory"
+	BackendDatabase = "database"
+	BackendOff      = "off"
+)
+
+// DefaultPrincipalTTL bounds how long a change made outside the use cases
+// (e.g. directly in the database) can go unnoticed.
+const DefaultPrincipalTTL = 30 * time.Second
+
+// NewPrincipalCache builds the principal cache selected by
+// PRINCIPAL_CACHE_BACKEND: "memory" (default) keeps entries per process,
+// "database" shares them, and their invalidations, between all replicas.
+// It returns nil when the backend is "off".
+func NewPrincipalCache() (service.PrincipalCache, error) {
+	ttl := DefaultPrincipalTTL
+	if v, err := time.ParseDuration(os.Getenv("PRINCIPAL_CACHE_TTL")); err == nil && v > 0 {
+		ttl = v
+	}
+
+	switch backend := os.Getenv("PRINCIPAL_CACHE_BACKEND"); backend {
+	case "", BackendMemory:
+		return NewMemoryPrincipalCache(ttl), nil
+	case BackendDatabase:
+		return NewDatabasePrincipalCache(ttl), nil
+	case BackendOff:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown PRINCIPAL_CACHE_BACKEND %q, use %s, %s or %s", backend, BackendMemory, BackendDatabase, BackendOff)
+	}
+}
+
+// encodePrincipal serialises a user with its roles, permissions and
+// dormitories. Secrets (password hash, TOTP secret) are tagged json:"-" and
+// never leave the database.
+func encodePrincipal(user *entity.User) ([]byte, error) {
+	return json.Marshal(user)
+}
+
+func decodePrincipal(payload []byte) (*entity.User, error) {
+	var user entity.User
+	if err := json.Unmarshal(payload, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+var _ service.PrincipalCache = (*memoryPrincipalCache)(nil)
+
+// memoryPrincipalCache keeps principals in process memory. Invalidations only
+// reach the replica that made them, so use the database backend when several
+// replicas serve traffic.
+type memoryPrincipalCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[uuid.UUID]memoryPrincipal
+	// invalidated remembers recent invalidations so Set can reject graphs
+	// loaded before them.
+	invalidated    map[uuid.UUID]time.Time
+	invalidatedAll time.Time
+	nextSweep      time.Time
+}
+
+type memoryPrincipal struct {
+	payload   []byte
+	expiresAt time.Time
+}
+
+// NewMemoryPrincipalCache creates a per-process principal cache.
+func NewMemoryPrincipalCache(ttl time.Duration) service.PrincipalCache {
+	return &memoryPrincipalCache{
+		ttl:         ttl,
+		now:         time.Now,
+		entries:     make(map[uuid.UUID]memoryPrincipal),
+		invalidated: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (c *memoryPrincipalCache) Get(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[userID]
+	c.mu.Unlock()
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return nil, nil
+	}
+	// Decoding hands every request its own copy of the graph
+	return decodePrincipal(entry.payload)
+}
+
+func (c *memoryPrincipalCache) Set(ctx context.Context, user *entity.User, loadedAt time.Time) error {
+	payload, err := encodePrincipal(user)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.sweep(now)
+	if !loadedAt.After(c.invalidatedAll) {
+		return nil
+	}
+	if at, ok := c.invalidated[user.ID]; ok && !loadedAt.After(at) {
+		return nil
+	}
+	c.entries[user.ID] = memoryPrincipal{payload: payload, expiresAt: now.Add(c.ttl)}
+	return nil
+}
+
+func (c *memoryPrincipalCache) Invalidate(ctx context.Context, userIDs ...uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for _, id := range userIDs {
+		delete(c.entries, id)
+		c.invalidated[id] = now
+	}
+	return nil
+}
+
+func (c *memoryPrincipalCache) InvalidateAll(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[uuid.UUID]memoryPrincipal)
+	c.invalidated = make(map[uuid.UUID]time.Time)
+	c.invalidatedAll = c.now()
+	return nil
+}
+
+// sweep drops expired entries and invalidation markers at most once per TTL.
+// A marker is only needed while requests that started before it can still
+// finish loading, which takes far less than the TTL.
+func (c *memoryPrincipalCache) sweep(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+	c.nextSweep = now.Add(c.ttl)
+	for id, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, id)
+		}
+	}
+	for id, at := range c.invalidated {
+		if now.Sub(at) >= c.ttl {
+			delete(c.invalidated, id)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ service.PrincipalCache = (*databasePrincipalCache)(nil)
+
+// databasePrincipalCache shares principals between replicas through the
+// principal_cache_entries table. A lookup is a single primary key read instead
+// of the role, permission and dormitory preloads, and an invalidation made by
+// one replica is seen by all of them.
+type databasePrincipalCache struct {
+	db  *gorm.DB
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewDatabasePrincipalCache creates a principal cache shared by all replicas.
+func NewDatabasePrincipalCache(ttl time.Duration) service.PrincipalCache {
+	return &databasePrincipalCache{db: database.DB, ttl: ttl, now: time.Now}
+}
+
+func (c *databasePrincipalCache) Get(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	// The uuid.Nil row records the last InvalidateAll
+	var rows []entity.PrincipalCacheEntry
+	err := c.db.WithContext(ctx).
+		Where("user_id IN ?", []uuid.UUID{userID, uuid.Nil}).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var entry *entity.PrincipalCacheEntry
+	var invalidatedAll *time.Time
+	for i := range rows {
+		if rows[i].UserID == uuid.Nil {
+			invalidatedAll = rows[i].InvalidatedAt
+		} else {
+			entry = &rows[i]
+		}
+	}
+	if entry == nil || entry.Payload == "" || !c.now().Before(entry.ExpiresAt) {
+		return nil, nil
+	}
+	if invalidatedAll != nil && !entry.LoadedAt.After(*invalidatedAll) {
+		return nil, nil
+	}
+	return decodePrincipal([]byte(entry.Payload))
+}
+
+func (c *databasePrincipalCache) Set(ctx context.Context, user *entity.User, loadedAt time.Time) error {
+	payload, err := encodePrincipal(user)
+	if err != nil {
+		return err
+	}
+
+	entry := entity.PrincipalCacheEntry{
+		UserID:    user.ID,
+		Payload:   string(payload),
+		LoadedAt:  loadedAt,
+		ExpiresAt: c.now().Add(c.ttl),
+	}
+	// A tombstone newer than loadedAt wins over the stale graph
+	return c.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"payload", "loaded_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			gorm.Expr("principal_cache_entries.invalidated_at IS NULL OR principal_cache_entries.invalidated_at < ?", loadedAt),
+		}},
+	}).Create(&entry).Error
+}
+
+func (c *databasePrincipalCache) Invalidate(ctx context.Context, userIDs ...uuid.UUID) error {
+	now := c.now()
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	tombstones := make([]entity.PrincipalCacheEntry, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id == uuid.Nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		tombstones = append(tombstones, entity.PrincipalCacheEntry{
+			UserID:        id,
+			LoadedAt:      now,
+			InvalidatedAt: &now,
+			ExpiresAt:     now.Add(c.ttl),
+		})
+	}
+	if len(tombstones) == 0 {
+		return nil
+	}
+
+	return c.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"payload", "invalidated_at", "expires_at"}),
+	}).Create(&tombstones).Error
+}
+
+func (c *databasePrincipalCache) InvalidateAll(ctx context.Context) error {
+	now := c.now()
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id <> ?", uuid.Nil).Delete(&entity.PrincipalCacheEntry{}).Error; err != nil {
+			return err
+		}
+		marker := entity.PrincipalCacheEntry{UserID: uuid.Nil, LoadedAt: now, InvalidatedAt: &now, ExpiresAt: now}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"invalidated_at"}),
+		}).Create(&marker).Error
+	})
+}
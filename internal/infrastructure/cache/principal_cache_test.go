@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+// testClock is a manually advanced clock shared by a cache under test.
+type testClock struct{ at time.Time }
+
+func (c *testClock) now() time.Time { return c.at }
+
+func newTestPrincipal() *entity.User {
+	dormID := uuid.New()
+	return &entity.User{
+		ID:              uuid.New(),
+		Username:        "musyrif",
+		Password:        "$2a$10$hash",
+		TwoFactorSecret: "SECRET",
+		IsActive:        true,
+		Roles: []entity.Role{{
+			ID:          uuid.New(),
+			Name:        "musyrif",
+			Permissions: []entity.Permission{{ID: uuid.New(), Name: "attendance:update"}},
+		}},
+		Dormitories: []entity.Dormitory{{ID: dormID, Name: "Asrama A"}},
+	}
+}
+
+func TestPrincipalCache(t *testing.T) {
+	backends := map[string]func(t *testing.T, clock *testClock) service.PrincipalCache{
+		"memory": func(t *testing.T, clock *testClock) service.PrincipalCache {
+			c := NewMemoryPrincipalCache(time.Minute).(*memoryPrincipalCache)
+			c.now = clock.now
+			return c
+		},
+		"database": func(t *testing.T, clock *testClock) service.PrincipalCache {
+			db := testutil.SetupTestDB(t)
+			t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+			require.NoError(t, db.AutoMigrate(&entity.PrincipalCacheEntry{}))
+			return &databasePrincipalCache{db: db, ttl: time.Minute, now: clock.now}
+		},
+	}
+
+	for name, newCache := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			t.Run("round trip without secrets", func(t *testing.T) {
+				clock := &testClock{at: time.Now()}
+				cache := newCache(t, clock)
+				user := newTestPrincipal()
+
+				require.NoError(t, cache.Set(ctx, user, clock.at))
+				cached, err := cache.Get(ctx, user.ID)
+				require.NoError(t, err)
+				require.NotNil(t, cached)
+				assert.Equal(t, user.Username, cached.Username)
+				assert.True(t, cached.HasPermission("attendance:update"))
+				assert.True(t, cached.CanAccessDormitory(user.Dormitories[0].ID))
+				assert.Empty(t, cached.Password)
+				assert.Empty(t, cached.TwoFactorSecret)
+			})
+
+			t.Run("entries expire", func(t *testing.T) {
+				clock := &testClock{at: time.Now()}
+				cache := newCache(t, clock)
+				user := newTestPrincipal()
+
+				require.NoError(t, cache.Set(ctx, user, clock.at))
+				clock.at = clock.at.Add(time.Minute)
+				cached, err := cache.Get(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Nil(t, cached)
+			})
+
+			t.Run("invalidate rejects graphs loaded before it", func(t *testing.T) {
+				clock := &testClock{at: time.Now()}
+				cache := newCache(t, clock)
+				user := newTestPrincipal()
+				other := newTestPrincipal()
+				loadedAt := clock.at
+
+				require.NoError(t, cache.Set(ctx, user, loadedAt))
+				require.NoError(t, cache.Set(ctx, other, loadedAt))
+				clock.at = clock.at.Add(time.Second)
+				require.NoError(t, cache.Invalidate(ctx, user.ID))
+
+				cached, err := cache.Get(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Nil(t, cached)
+				cached, err = cache.Get(ctx, other.ID)
+				require.NoError(t, err)
+				assert.NotNil(t, cached)
+
+				// A request that started loading before the change must not restore it
+				require.NoError(t, cache.Set(ctx, user, loadedAt))
+				cached, err = cache.Get(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Nil(t, cached)
+
+				clock.at = clock.at.Add(time.Second)
+				require.NoError(t, cache.Set(ctx, user, clock.at))
+				cached, err = cache.Get(ctx, user.ID)
+				require.NoError(t, err)
+				assert.NotNil(t, cached)
+			})
+
+			t.Run("invalidate all", func(t *testing.T) {
+				clock := &testClock{at: time.Now()}
+				cache := newCache(t, clock)
+				user := newTestPrincipal()
+				loadedAt := clock.at
+
+				require.NoError(t, cache.Set(ctx, user, loadedAt))
+				clock.at = clock.at.Add(time.Second)
+				require.NoError(t, cache.InvalidateAll(ctx))
+
+				cached, err := cache.Get(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Nil(t, cached)
+
+				require.NoError(t, cache.Set(ctx, user, loadedAt))
+				cached, err = cache.Get(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Nil(t, cached)
+
+				clock.at = clock.at.Add(time.Second)
+				require.NoError(t, cache.Set(ctx, user, clock.at))
+				cached, err = cache.Get(ctx, user.ID)
+				require.NoError(t, err)
+				assert.NotNil(t, cached)
+			})
+		})
+	}
+}
+
+func TestNewPrincipalCache(t *testing.T) {
+	t.Setenv("PRINCIPAL_CACHE_BACKEND", "")
+	cache, err := NewPrincipalCache()
+	require.NoError(t, err)
+	assert.IsType(t, &memoryPrincipalCache{}, cache)
+
+	t.Setenv("PRINCIPAL_CACHE_BACKEND", BackendOff)
+	cache, err = NewPrincipalCache()
+	require.NoError(t, err)
+	assert.Nil(t, cache)
+
+	t.Setenv("PRINCIPAL_CACHE_BACKEND", "redis")
+	_, err = NewPrincipalCache()
+	assert.Error(t, err)
+}
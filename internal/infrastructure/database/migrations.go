@@ -490,4 +490,348 @@ func init() {
 			return nil
 		},
 	)
+
+	RegisterMigration(
+		"017_create_refresh_sessions",
+		"Create refresh_sessions table for refresh-token rotation",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.RefreshSession{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.RefreshSession{})
+		},
+	)
+
+	RegisterMigration(
+		"018_create_holidays",
+		"Create holidays table used to skip attendance session generation",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.Holiday{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.Holiday{})
+		},
+	)
+
+	RegisterMigration(
+		"019_create_job_runs_and_leases",
+		"Create job_runs history and job_leases used by the in-process scheduler",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.JobRun{}, &entity.JobLease{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.JobLease{}, &entity.JobRun{})
+		},
+	)
+
+	RegisterMigration(
+		"020_add_user_password_change_fields",
+		"Add must_change_password and password_changed_at to users",
+		func(db *gorm.DB) error {
+			for _, column := range []string{"MustChangePassword", "PasswordChangedAt"} {
+				if !db.Migrator().HasColumn(&entity.User{}, column) {
+					if err := db.Migrator().AddColumn(&entity.User{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		func(db *gorm.DB) error {
+			for _, column := range []string{"PasswordChangedAt", "MustChangePassword"} {
+				if db.Migrator().HasColumn(&entity.User{}, column) {
+					if err := db.Migrator().DropColumn(&entity.User{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	)
+
+	RegisterMigration(
+		"021_create_login_throttles",
+		"Create login_throttles used for failed-login lockouts per username and IP",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.LoginThrottle{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.LoginThrottle{})
+		},
+	)
+
+	RegisterMigration(
+		"022_add_two_factor_auth",
+		"Add TOTP fields to users, two_factor_required to roles and the user_recovery_codes table",
+		func(db *gorm.DB) error {
+			for _, column := range []string{"TwoFactorEnabled", "TwoFactorSecret", "TwoFactorLastStep"} {
+				if !db.Migrator().HasColumn(&entity.User{}, column) {
+					if err := db.Migrator().AddColumn(&entity.User{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			if !db.Migrator().HasColumn(&entity.Role{}, "TwoFactorRequired") {
+				if err := db.Migrator().AddColumn(&entity.Role{}, "TwoFactorRequired"); err != nil {
+					return err
+				}
+			}
+			if err := db.AutoMigrate(&entity.RecoveryCode{}); err != nil {
+				return err
+			}
+
+			// Admin-class roles must enrol in 2FA
+			return db.Model(&entity.Role{}).
+				Where("slug IN ?", []string{"admin", "super_admin"}).
+				Update("two_factor_required", true).Error
+		},
+		func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable(&entity.RecoveryCode{}); err != nil {
+				return err
+			}
+			if db.Migrator().HasColumn(&entity.Role{}, "TwoFactorRequired") {
+				if err := db.Migrator().DropColumn(&entity.Role{}, "TwoFactorRequired"); err != nil {
+					return err
+				}
+			}
+			for _, column := range []string{"TwoFactorLastStep", "TwoFactorSecret", "TwoFactorEnabled"} {
+				if db.Migrator().HasColumn(&entity.User{}, column) {
+					if err := db.Migrator().DropColumn(&entity.User{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	)
+
+	RegisterMigration(
+		"023_create_service_accounts_and_api_keys",
+		"Add is_service_account to users, api_key_id to audit_logs and the api_keys table",
+		func(db *gorm.DB) error {
+			if !db.Migrator().HasColumn(&entity.User{}, "IsServiceAccount") {
+				if err := db.Migrator().AddColumn(&entity.User{}, "IsServiceAccount"); err != nil {
+					return err
+				}
+			}
+			if !db.Migrator().HasColumn(&entity.AuditLog{}, "APIKeyID") {
+				if err := db.Migrator().AddColumn(&entity.AuditLog{}, "APIKeyID"); err != nil {
+					return err
+				}
+			}
+			return db.AutoMigrate(&entity.APIKey{})
+		},
+		func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable(&entity.APIKey{}); err != nil {
+				return err
+			}
+			if db.Migrator().HasColumn(&entity.AuditLog{}, "APIKeyID") {
+				if err := db.Migrator().DropColumn(&entity.AuditLog{}, "APIKeyID"); err != nil {
+					return err
+				}
+			}
+			if db.Migrator().HasColumn(&entity.User{}, "IsServiceAccount") {
+				return db.Migrator().DropColumn(&entity.User{}, "IsServiceAccount")
+			}
+			return nil
+		},
+	)
+
+	RegisterMigration(
+		"024_create_principal_cache_entries",
+		"Create the shared principal cache table",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.PrincipalCacheEntry{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.PrincipalCacheEntry{})
+		},
+	)
+
+	RegisterMigration(
+		"025_add_impersonator_to_audit_logs",
+		"Record the impersonating admin on audit logs",
+		func(db *gorm.DB) error {
+			for _, field := range []string{"ImpersonatorID", "ImpersonatorUsername"} {
+				if !db.Migrator().HasColumn(&entity.AuditLog{}, field) {
+					if err := db.Migrator().AddColumn(&entity.AuditLog{}, field); err != nil {
+						return err
+					}
+				}
+			}
+			if !db.Migrator().HasIndex(&entity.AuditLog{}, "ImpersonatorID") {
+				return db.Migrator().CreateIndex(&entity.AuditLog{}, "ImpersonatorID")
+			}
+			return nil
+		},
+		func(db *gorm.DB) error {
+			for _, field := range []string{"ImpersonatorUsername", "ImpersonatorID"} {
+				if db.Migrator().HasColumn(&entity.AuditLog{}, field) {
+					if err := db.Migrator().DropColumn(&entity.AuditLog{}, field); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	)
+
+	RegisterMigration(
+		"026_create_user_invitations",
+		"Create the user_invitations table for invite-based onboarding",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.Invitation{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.Invitation{})
+		},
+	)
+
+	RegisterMigration(
+		"027_add_changes_to_audit_logs",
+		"Record before/after field changes on audit logs",
+		func(db *gorm.DB) error {
+			if !db.Migrator().HasColumn(&entity.AuditLog{}, "Changes") {
+				return db.Migrator().AddColumn(&entity.AuditLog{}, "Changes")
+			}
+			return nil
+		},
+		func(db *gorm.DB) error {
+			if db.Migrator().HasColumn(&entity.AuditLog{}, "Changes") {
+				return db.Migrator().DropColumn(&entity.AuditLog{}, "Changes")
+			}
+			return nil
+		},
+	)
+
+	RegisterMigration(
+		"028_add_audit_hash_chain",
+		"Hash-chain audit logs and create the audit_checkpoints table",
+		func(db *gorm.DB) error {
+			for _, field := range []string{"Sequence", "PrevHash", "Hash"} {
+				if !db.Migrator().HasColumn(&entity.AuditLog{}, field) {
+					if err := db.Migrator().AddColumn(&entity.AuditLog{}, field); err != nil {
+						return err
+					}
+				}
+			}
+			if err := backfillAuditChain(db); err != nil {
+				return err
+			}
+			if !db.Migrator().HasIndex(&entity.AuditLog{}, "Sequence") {
+				if err := db.Migrator().CreateIndex(&entity.AuditLog{}, "Sequence"); err != nil {
+					return err
+				}
+			}
+			return db.AutoMigrate(&entity.AuditCheckpoint{})
+		},
+		func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable(&entity.AuditCheckpoint{}); err != nil {
+				return err
+			}
+			if db.Migrator().HasIndex(&entity.AuditLog{}, "Sequence") {
+				if err := db.Migrator().DropIndex(&entity.AuditLog{}, "Sequence"); err != nil {
+					return err
+				}
+			}
+			for _, field := range []string{"Hash", "PrevHash", "Sequence"} {
+				if db.Migrator().HasColumn(&entity.AuditLog{}, field) {
+					if err := db.Migrator().DropColumn(&entity.AuditLog{}, field); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	)
+
+	RegisterMigration(
+		"029_add_audit_filters_and_archives",
+		"Index audit log filter columns and create the audit_archives table",
+		func(db *gorm.DB) error {
+			for _, field := range auditLogFilterIndexes {
+				if !db.Migrator().HasIndex(&entity.AuditLog{}, field) {
+					if err := db.Migrator().CreateIndex(&entity.AuditLog{}, field); err != nil {
+						return err
+					}
+				}
+			}
+			return db.AutoMigrate(&entity.AuditArchive{})
+		},
+		func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable(&entity.AuditArchive{}); err != nil {
+				return err
+			}
+			for _, field := range auditLogFilterIndexes {
+				if db.Migrator().HasIndex(&entity.AuditLog{}, field) {
+					if err := db.Migrator().DropIndex(&entity.AuditLog{}, field); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	)
+
+	RegisterMigration(
+		"030_add_audit_request_id",
+		"Add request_id to audit logs",
+		func(db *gorm.DB) error {
+			if !db.Migrator().HasColumn(&entity.AuditLog{}, "RequestID") {
+				if err := db.Migrator().AddColumn(&entity.AuditLog{}, "RequestID"); err != nil {
+					return err
+				}
+			}
+			if !db.Migrator().HasIndex(&entity.AuditLog{}, "RequestID") {
+				return db.Migrator().CreateIndex(&entity.AuditLog{}, "RequestID")
+			}
+			return nil
+		},
+		func(db *gorm.DB) error {
+			if db.Migrator().HasIndex(&entity.AuditLog{}, "RequestID") {
+				if err := db.Migrator().DropIndex(&entity.AuditLog{}, "RequestID"); err != nil {
+					return err
+				}
+			}
+			if db.Migrator().HasColumn(&entity.AuditLog{}, "RequestID") {
+				return db.Migrator().DropColumn(&entity.AuditLog{}, "RequestID")
+			}
+			return nil
+		},
+	)
+}
+
+// auditLogFilterIndexes are the audit log columns the list and export
+// filters query besides those indexed from the start.
+var auditLogFilterIndexes = []string{"ActorID", "StatusCode", "IPAddress", "CreatedAt"}
+
+// backfillAuditChain links existing audit logs into the hash chain in the
+// order they were written.
+func backfillAuditChain(db *gorm.DB) error {
+	const batchSize = 500
+	var sequence int64
+	prevHash := ""
+	for offset := 0; ; offset += batchSize {
+		var logs []*entity.AuditLog
+		if err := db.Order("created_at ASC, id ASC").Limit(batchSize).Offset(offset).Find(&logs).Error; err != nil {
+			return err
+		}
+		for _, entry := range logs {
+			sequence++
+			entry.Sequence = sequence
+			entry.PrevHash = prevHash
+			entry.Hash = entry.ComputeHash()
+			if err := db.Model(&entity.AuditLog{}).Where("id = ?", entry.ID).Updates(map[string]interface{}{
+				"sequence":  entry.Sequence,
+				"prev_hash": entry.PrevHash,
+				"hash":      entry.Hash,
+			}).Error; err != nil {
+				return err
+			}
+			prevHash = entry.Hash
+		}
+		if len(logs) < batchSize {
+			return nil
+		}
+	}
 }
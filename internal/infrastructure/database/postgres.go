@@ -2,7 +2,7 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
@@ -35,7 +35,7 @@ func Connect() error {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	log.Println("Database connected successfully")
+	slog.Info("Database connected successfully")
 	return nil
 }
 
@@ -60,7 +60,7 @@ func Migrate() error {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
-	log.Println("Database migrations completed successfully")
+	slog.Info("Database migrations completed successfully")
 	return nil
 }
 
@@ -0,0 +1,264 @@
+// Package export renders tabular data as downloadable CSV, XLSX and PDF files.
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+// Format identifies an output representation of a report.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatPDF  Format = "pdf"
+)
+
+// ErrUnsupportedFormat is returned for format names other than json, csv, xlsx and pdf.
+var ErrUnsupportedFormat = errors.New("unsupported format, use json, csv, xlsx or pdf")
+
+var contentTypes = map[Format]string{
+	FormatJSON: "application/json",
+	FormatCSV:  "text/csv",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatPDF:  "application/pdf",
+}
+
+// ParseFormat parses a format name such as "csv" case-insensitively.
+func ParseFormat(value string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := contentTypes[format]; !ok {
+		return "", ErrUnsupportedFormat
+	}
+	return format, nil
+}
+
+// FormatFromAccept returns the first file format named in an Accept header,
+// falling back to JSON when none is requested.
+func FormatFromAccept(accept string) Format {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		for format, contentType := range contentTypes {
+			if format != FormatJSON && mediaType == contentType {
+				return format
+			}
+		}
+	}
+	return FormatJSON
+}
+
+// ContentType returns the MIME type served for the format.
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// Extension returns the file extension, without dot, for the format.
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Table is a titled grid of values. Numeric cells stay numeric in XLSX output.
+type Table struct {
+	Title       string
+	Columns     []string
+	Rows        [][]interface{}
+	GeneratedAt string
+}
+
+func (t Table) footer() string {
+	return "Generated at " + t.GeneratedAt
+}
+
+// Write renders table to w in the given file format.
+func Write(w io.Writer, format Format, table Table) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, table)
+	case FormatXLSX:
+		return writeXLSX(w, table)
+	case FormatPDF:
+		return writePDF(w, table)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+func cellText(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// CSVSafeCell prefixes text a spreadsheet would evaluate as a formula with a
+// quote, so names, notes and other user-entered values stay plain text. A lone
+// character such as the "-" placeholder for missing values is left alone.
+func CSVSafeCell(value string) string {
+	if len(value) > 1 && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func writeCSV(w io.Writer, table Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Columns); err != nil {
+		return err
+	}
+	for _, row := range table.Rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			// Numbers, negative ones included, stay numeric
+			if text, ok := value.(string); ok {
+				record[i] = CSVSafeCell(text)
+				continue
+			}
+			record[i] = cellText(value)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	if err := writer.Write(nil); err != nil {
+		return err
+	}
+	if err := writer.Write([]string{table.footer()}); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+const xlsxSheetName = "Report"
+
+func writeXLSX(w io.Writer, table Table) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName(file.GetSheetName(0), xlsxSheetName); err != nil {
+		return err
+	}
+
+	header := make([]interface{}, len(table.Columns))
+	for i, column := range table.Columns {
+		header[i] = column
+	}
+	if err := file.SetSheetRow(xlsxSheetName, "A1", &header); err != nil {
+		return err
+	}
+	if len(table.Columns) > 0 {
+		bold, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+		if err != nil {
+			return err
+		}
+		lastHeader, err := excelize.CoordinatesToCellName(len(table.Columns), 1)
+		if err != nil {
+			return err
+		}
+		if err := file.SetCellStyle(xlsxSheetName, "A1", lastHeader, bold); err != nil {
+			return err
+		}
+	}
+
+	for i, row := range table.Rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		if err := file.SetSheetRow(xlsxSheetName, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	footerCell, err := excelize.CoordinatesToCellName(1, len(table.Rows)+3)
+	if err != nil {
+		return err
+	}
+	if err := file.SetCellValue(xlsxSheetName, footerCell, table.footer()); err != nil {
+		return err
+	}
+
+	return file.Write(w)
+}
+
+const (
+	pdfRowHeight    = 7.0
+	pdfBottomMargin = 15.0
+)
+
+func writePDF(w io.Writer, table Table) error {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	// Core fonts are cp1252 encoded; translate UTF-8 names so accents survive.
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetAutoPageBreak(false, pdfBottomMargin)
+	pdf.AliasNbPages("")
+	pageWidth, pageHeight := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	contentWidth := pageWidth - left - right
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfBottomMargin + 3)
+		pdf.SetFont("Helvetica", "I", 8)
+		// A zero width would run to the right margin and push the page number off the line
+		pdf.CellFormat(contentWidth/2, 6, translate(table.footer()), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	columnWidth := contentWidth
+	if len(table.Columns) > 0 {
+		columnWidth /= float64(len(table.Columns))
+	}
+
+	writeHeader := func() {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(230, 230, 230)
+		for _, column := range table.Columns {
+			pdf.CellFormat(columnWidth, pdfRowHeight, fitText(pdf, translate(column), columnWidth), "1", 0, "L", true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 9)
+	}
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 10, translate(table.Title), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+	writeHeader()
+
+	for _, row := range table.Rows {
+		if pdf.GetY()+pdfRowHeight > pageHeight-pdfBottomMargin {
+			pdf.AddPage()
+			writeHeader()
+		}
+		for _, value := range row {
+			pdf.CellFormat(columnWidth, pdfRowHeight, fitText(pdf, translate(cellText(value)), columnWidth), "1", 0, "L", false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	return pdf.Output(w)
+}
+
+// fitText shortens text with an ellipsis until it fits into a cell of the given width.
+// The text is already cp1252 encoded, so trimming byte by byte is safe.
+func fitText(pdf *gofpdf.Fpdf, text string, width float64) string {
+	available := width - 2*pdf.GetCellMargin()
+	if pdf.GetStringWidth(text) <= available {
+		return text
+	}
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > available {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
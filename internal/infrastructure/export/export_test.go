@@ -0,0 +1,108 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+var sampleTable = Table{
+	Title:       "Leave Permit Report",
+	Columns:     []string{"Dormitory", "Status", "Total"},
+	Rows:        [][]interface{}{{"Putra 1", "approved", 3}, {"Asrama Fāṭimah", "pending", 12}},
+	GeneratedAt: "2025-11-21T08:00:00Z",
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat(" XLSX ")
+	require.NoError(t, err)
+	assert.Equal(t, FormatXLSX, format)
+
+	_, err = ParseFormat("docx")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestFormatFromAccept(t *testing.T) {
+	assert.Equal(t, FormatCSV, FormatFromAccept("text/csv; charset=utf-8"))
+	assert.Equal(t, FormatPDF, FormatFromAccept("application/json;q=0.5, application/pdf"))
+	assert.Equal(t, FormatJSON, FormatFromAccept("*/*"))
+	assert.Equal(t, FormatJSON, FormatFromAccept(""))
+}
+
+func TestWrite_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatCSV, sampleTable))
+	assert.Equal(t, "Dormitory,Status,Total\nPutra 1,approved,3\nAsrama Fāṭimah,pending,12\n\nGenerated at 2025-11-21T08:00:00Z\n", buf.String())
+}
+
+func TestWrite_CSVNeutralisesFormulas(t *testing.T) {
+	table := Table{
+		Columns:     []string{"Student", "Note", "Delta"},
+		Rows:        [][]interface{}{{"=HYPERLINK(\"http://evil.example\")", "@SUM(A1)", -2}, {"+62 812", "\tsick", 0}, {"-", "", nil}, {"\r=1+1", "a", 1}},
+		GeneratedAt: "2025-11-21T08:00:00Z",
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatCSV, table))
+	reader := csv.NewReader(&buf)
+	reader.FieldsPerRecord = -1 // the footer is a single cell
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{`'=HYPERLINK("http://evil.example")`, "'@SUM(A1)", "-2"}, records[1])
+	assert.Equal(t, []string{"'+62 812", "'\tsick", "0"}, records[2])
+	assert.Equal(t, []string{"-", "", ""}, records[3])
+	assert.Equal(t, []string{"'\r=1+1", "a", "1"}, records[4])
+}
+
+func TestCSVSafeCell(t *testing.T) {
+	for _, value := range []string{"=1+1", "+1", "-1", "@SUM(A1)", "\tcmd", "\rcmd"} {
+		assert.Equal(t, "'"+value, CSVSafeCell(value), value)
+	}
+	for _, value := range []string{"", "-", "admin", `{"note":"x"}`, "1"} {
+		assert.Equal(t, value, CSVSafeCell(value), value)
+	}
+}
+
+func TestWrite_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatXLSX, sampleTable))
+
+	file, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer file.Close()
+
+	rows, err := file.GetRows(xlsxSheetName)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Dormitory", "Status", "Total"},
+		{"Putra 1", "approved", "3"},
+		{"Asrama Fāṭimah", "pending", "12"},
+		nil,
+		{"Generated at 2025-11-21T08:00:00Z"},
+	}, rows)
+
+	cellType, err := file.GetCellType(xlsxSheetName, "C2")
+	require.NoError(t, err)
+	assert.NotEqual(t, excelize.CellTypeSharedString, cellType)
+}
+
+func TestWrite_PDF(t *testing.T) {
+	rows := make([][]interface{}, 0, 60)
+	for i := 0; i < 60; i++ {
+		rows = append(rows, []interface{}{"A dormitory name long enough to need truncation in a narrow column", "approved", i})
+	}
+	table := sampleTable
+	table.Rows = rows
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatPDF, table))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
+
+func TestWrite_UnsupportedFormat(t *testing.T) {
+	assert.ErrorIs(t, Write(&bytes.Buffer{}, FormatJSON, sampleTable), ErrUnsupportedFormat)
+}
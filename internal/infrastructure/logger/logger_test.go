@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "warn", "json")
+
+	ctx := WithAttrs(context.Background(), slog.String("request_id", "req-1"))
+	ctx = WithAttrs(ctx, slog.String("user_id", "user-1"))
+	l.InfoContext(ctx, "below the level")
+	l.WarnContext(ctx, "slow query", "latency_ms", 1200)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "slow query", record["msg"])
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, "user-1", record["user_id"])
+	assert.Equal(t, float64(1200), record["latency_ms"])
+}
+
+func TestParseLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, ParseLevel("DEBUG"))
+	assert.Equal(t, slog.LevelWarn, ParseLevel("warning"))
+	assert.Equal(t, slog.LevelError, ParseLevel("error"))
+	assert.Equal(t, slog.LevelInfo, ParseLevel(""))
+	assert.Equal(t, slog.LevelInfo, ParseLevel("verbose"))
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+var _ domainRepo.APIKeyRepository = (*apiKeyRepository)(nil)
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository.
+func NewAPIKeyRepository() domainRepo.APIKeyRepository {
+	return &apiKeyRepository{db: database.DB}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+func (r *apiKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.WithContext(ctx).Where("prefix = ?", prefix).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error) {
+	var keys []*entity.APIKey
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
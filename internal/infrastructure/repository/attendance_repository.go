@@ -94,6 +94,9 @@ func (r *attendanceSessionRepository) List(ctx context.Context, filter domainRep
 	if filter.TeacherID != nil {
 		query = query.Where("teacher_id = ?", filter.TeacherID)
 	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("class_schedule_id IN (?)", schedulesInDormitories(r.db.WithContext(ctx), filter.DormitoryIDs))
+	}
 	if filter.Date != nil {
 		query = query.Where("date = ?", filter.Date)
 	}
@@ -126,11 +129,26 @@ func (r *attendanceSessionRepository) List(ctx context.Context, filter domainRep
 	return sessions, total, nil
 }
 
-func (r *attendanceSessionRepository) LockSessionsByDate(ctx context.Context, date time.Time) error {
-	now := time.Now()
-	return r.db.WithContext(ctx).
+func (r *attendanceSessionRepository) ListScheduleIDsByDate(ctx context.Context, date time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
 		Model(&entity.AttendanceSession{}).
-		Where("date = ? AND status <> ?", date, entity.AttendanceSessionStatusLocked).
+		Where("date = ?", date).
+		Pluck("class_schedule_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *attendanceSessionRepository) LockSessionsByDate(ctx context.Context, date time.Time, dormitoryIDs []uuid.UUID) error {
+	now := time.Now()
+	db := r.db.WithContext(ctx)
+	query := db.Model(&entity.AttendanceSession{}).
+		Where("date = ? AND status <> ?", date, entity.AttendanceSessionStatusLocked)
+	if dormitoryIDs != nil {
+		query = query.Where("class_schedule_id IN (?)", schedulesInDormitories(db, dormitoryIDs))
+	}
+	return query.
 		Updates(map[string]interface{}{
 			"status":    entity.AttendanceSessionStatusLocked,
 			"locked_at": now,
@@ -180,3 +198,10 @@ func (r *teacherAttendanceRepository) GetBySession(ctx context.Context, sessionI
 	}
 	return &record, nil
 }
+
+// schedulesInDormitories selects the IDs of class schedules held in one of the dormitories.
+func schedulesInDormitories(db *gorm.DB, dormitoryIDs []uuid.UUID) *gorm.DB {
+	return db.Model(&entity.ClassSchedule{}).
+		Select("id").
+		Where("dormitory_id IN ?", dormitoryIDs)
+}
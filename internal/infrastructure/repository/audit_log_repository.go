@@ -2,24 +2,128 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
 	"gorm.io/gorm"
 )
 
+// chainAppendAttempts bounds retries when another instance appended the same
+// sequence number first.
+const chainAppendAttempts = 5
+
+// auditInsertBatchSize bounds the rows sent in one INSERT statement.
+const auditInsertBatchSize = 100
+
+// auditDeleteBatchSize bounds the rows removed by one DELETE statement so
+// retention does not hold long locks on the table.
+const auditDeleteBatchSize = 5000
+
+// chainMu serialises appends within this process; the unique sequence index
+// catches races with other instances.
+var chainMu sync.Mutex
+
+var _ repository.AuditLogRepository = (*auditLogRepository)(nil)
+var _ repository.AuditCheckpointRepository = (*auditCheckpointRepository)(nil)
+var _ repository.AuditArchiveRepository = (*auditArchiveRepository)(nil)
+
 type auditLogRepository struct {
 	db *gorm.DB
 }
 
+type auditCheckpointRepository struct {
+	db *gorm.DB
+}
+
+type auditArchiveRepository struct {
+	db *gorm.DB
+}
+
 // NewAuditLogRepository creates a new audit log repository
 func NewAuditLogRepository() repository.AuditLogRepository {
 	return &auditLogRepository{db: database.DB}
 }
 
+// NewAuditCheckpointRepository creates a new audit checkpoint repository
+func NewAuditCheckpointRepository() repository.AuditCheckpointRepository {
+	return &auditCheckpointRepository{db: database.DB}
+}
+
+// NewAuditArchiveRepository creates a new audit archive repository
+func NewAuditArchiveRepository() repository.AuditArchiveRepository {
+	return &auditArchiveRepository{db: database.DB}
+}
+
+// Create links the entry to the current chain head and inserts it.
 func (r *auditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
-	return r.db.WithContext(ctx).Create(log).Error
+	return r.CreateBatch(ctx, []*entity.AuditLog{log})
+}
+
+// CreateBatch links the entries to the current chain head in slice order and
+// inserts them in one transaction. When a concurrent writer took the same
+// sequence numbers the insert is retried on top of the new head.
+func (r *auditLogRepository) CreateBatch(ctx context.Context, logs []*entity.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
+	for _, log := range logs {
+		if log.ID == uuid.Nil {
+			log.ID = uuid.New()
+		}
+		if log.CreatedAt.IsZero() {
+			log.CreatedAt = time.Now()
+		}
+		log.CreatedAt = log.CreatedAt.UTC().Truncate(time.Microsecond)
+	}
+
+	var err error
+	for attempt := 0; attempt < chainAppendAttempts; attempt++ {
+		err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			head, err := latestAuditLog(tx)
+			if err != nil {
+				return err
+			}
+			sequence, prevHash := int64(0), ""
+			if head != nil {
+				sequence, prevHash = head.Sequence, head.Hash
+			}
+			for _, log := range logs {
+				sequence++
+				log.Sequence, log.PrevHash = sequence, prevHash
+				log.Hash = log.ComputeHash()
+				prevHash = log.Hash
+			}
+			return tx.CreateInBatches(logs, auditInsertBatchSize).Error
+		})
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func latestAuditLog(db *gorm.DB) (*entity.AuditLog, error) {
+	var head entity.AuditLog
+	err := db.Order("sequence DESC").Limit(1).Take(&head).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &head, nil
 }
 
 func (r *auditLogRepository) List(ctx context.Context, filter repository.AuditLogFilter) ([]*entity.AuditLog, int64, error) {
@@ -35,8 +139,45 @@ func (r *auditLogRepository) List(ctx context.Context, filter repository.AuditLo
 
 	offset := (filter.Page - 1) * filter.PageSize
 
-	query := r.db.WithContext(ctx).Model(&entity.AuditLog{})
+	query := applyAuditLogFilter(r.db.WithContext(ctx).Model(&entity.AuditLog{}), filter)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []*entity.AuditLog
+	if err := query.Order("sequence DESC").Limit(filter.PageSize).Offset(offset).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
 
+// Each pages through the matching entries by sequence rather than offset, so
+// exporting a large range costs the same per batch at any depth.
+func (r *auditLogRepository) Each(ctx context.Context, filter repository.AuditLogFilter, batchSize int, fn func([]*entity.AuditLog) error) error {
+	var after int64
+	for {
+		var logs []*entity.AuditLog
+		query := applyAuditLogFilter(r.db.WithContext(ctx).Model(&entity.AuditLog{}), filter)
+		if err := query.Where("sequence > ?", after).Order("sequence ASC").Limit(batchSize).Find(&logs).Error; err != nil {
+			return err
+		}
+		if len(logs) == 0 {
+			return nil
+		}
+		if err := fn(logs); err != nil {
+			return err
+		}
+		if len(logs) < batchSize {
+			return nil
+		}
+		after = logs[len(logs)-1].Sequence
+	}
+}
+
+func applyAuditLogFilter(query *gorm.DB, filter repository.AuditLogFilter) *gorm.DB {
 	if filter.Resource != "" {
 		query = query.Where("resource = ?", filter.Resource)
 	}
@@ -46,16 +187,126 @@ func (r *auditLogRepository) List(ctx context.Context, filter repository.AuditLo
 	if filter.ActorUsername != "" {
 		query = query.Where("actor_username = ?", filter.ActorUsername)
 	}
-
-	var total int64
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.IPAddress != "" {
+		query = query.Where("ip_address = ?", filter.IPAddress)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.StatusCode != 0 {
+		query = query.Where("status_code = ?", filter.StatusCode)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", filter.From.UTC())
 	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", filter.To.UTC())
+	}
+	return query
+}
 
+func (r *auditLogRepository) ListByTarget(ctx context.Context, resource, targetID string) ([]*entity.AuditLog, error) {
 	var logs []*entity.AuditLog
-	if err := query.Order("created_at DESC").Limit(filter.PageSize).Offset(offset).Find(&logs).Error; err != nil {
-		return nil, 0, err
+	err := r.db.WithContext(ctx).
+		Where("resource = ? AND target_id = ?", resource, targetID).
+		Order("sequence ASC").
+		Find(&logs).Error
+	return logs, err
+}
+
+func (r *auditLogRepository) ListChain(ctx context.Context, afterSequence int64, limit int) ([]*entity.AuditLog, error) {
+	var logs []*entity.AuditLog
+	err := r.db.WithContext(ctx).
+		Where("sequence > ?", afterSequence).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&logs).Error
+	return logs, err
+}
+
+func (r *auditLogRepository) GetLatest(ctx context.Context) (*entity.AuditLog, error) {
+	return latestAuditLog(r.db.WithContext(ctx))
+}
+
+func (r *auditLogRepository) GetFirstSince(ctx context.Context, since time.Time) (*entity.AuditLog, error) {
+	var entry entity.AuditLog
+	err := r.db.WithContext(ctx).Where("created_at >= ?", since.UTC()).Order("sequence ASC").Limit(1).Take(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
 
-	return logs, total, nil
+// DeleteThrough removes the entries oldest first in bounded batches.
+func (r *auditLogRepository) DeleteThrough(ctx context.Context, sequence int64) (int64, error) {
+	var deleted int64
+	for {
+		var first entity.AuditLog
+		err := r.db.WithContext(ctx).Select("sequence").Where("sequence <= ?", sequence).Order("sequence ASC").Limit(1).Take(&first).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return deleted, nil
+		}
+		if err != nil {
+			return deleted, err
+		}
+		upTo := min(first.Sequence+auditDeleteBatchSize-1, sequence)
+		result := r.db.WithContext(ctx).Where("sequence <= ?", upTo).Delete(&entity.AuditLog{})
+		if result.Error != nil {
+			return deleted, result.Error
+		}
+		deleted += result.RowsAffected
+	}
+}
+
+func (r *auditCheckpointRepository) Create(ctx context.Context, checkpoint *entity.AuditCheckpoint) error {
+	return r.db.WithContext(ctx).Create(checkpoint).Error
+}
+
+func (r *auditCheckpointRepository) List(ctx context.Context) ([]*entity.AuditCheckpoint, error) {
+	var checkpoints []*entity.AuditCheckpoint
+	err := r.db.WithContext(ctx).Order("sequence ASC, created_at ASC").Find(&checkpoints).Error
+	return checkpoints, err
+}
+
+func (r *auditCheckpointRepository) GetLatest(ctx context.Context) (*entity.AuditCheckpoint, error) {
+	var checkpoint entity.AuditCheckpoint
+	err := r.db.WithContext(ctx).Order("sequence DESC, created_at DESC").Limit(1).Take(&checkpoint).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func (r *auditArchiveRepository) Create(ctx context.Context, archive *entity.AuditArchive) error {
+	return r.db.WithContext(ctx).Create(archive).Error
+}
+
+func (r *auditArchiveRepository) List(ctx context.Context) ([]*entity.AuditArchive, error) {
+	var archives []*entity.AuditArchive
+	err := r.db.WithContext(ctx).Order("to_sequence ASC").Find(&archives).Error
+	return archives, err
+}
+
+func (r *auditArchiveRepository) GetLatest(ctx context.Context) (*entity.AuditArchive, error) {
+	var archive entity.AuditArchive
+	err := r.db.WithContext(ctx).Order("to_sequence DESC").Limit(1).Take(&archive).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &archive, nil
 }
@@ -50,6 +50,15 @@ func (r *classRepository) ListByFan(ctx context.Context, fanID uuid.UUID, limit,
 	return classes, total, nil
 }
 
+func (r *classRepository) ListByDormitory(ctx context.Context, dormitoryID uuid.UUID) ([]*entity.Class, error) {
+	var classes []*entity.Class
+	err := r.db.WithContext(ctx).
+		Where("fan_id IN (?)", r.db.Model(&entity.Fan{}).Select("id").Where("dormitory_id = ?", dormitoryID)).
+		Order("name ASC").
+		Find(&classes).Error
+	return classes, err
+}
+
 func (r *classRepository) Update(ctx context.Context, class *entity.Class) error {
 	return r.db.WithContext(ctx).Save(class).Error
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
@@ -26,6 +27,15 @@ func (r *classScheduleRepository) Create(ctx context.Context, schedule *entity.C
 	return r.db.WithContext(ctx).Create(schedule).Error
 }
 
+func (r *classScheduleRepository) CreateBatch(ctx context.Context, schedules []*entity.ClassSchedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&schedules).Error
+	})
+}
+
 func (r *classScheduleRepository) Update(ctx context.Context, schedule *entity.ClassSchedule) error {
 	return r.db.WithContext(ctx).Save(schedule).Error
 }
@@ -50,6 +60,9 @@ func (r *classScheduleRepository) List(ctx context.Context, filter domainRepo.Cl
 	if filter.DormitoryID != uuid.Nil {
 		query = query.Where("dormitory_id = ?", filter.DormitoryID)
 	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("dormitory_id IN ?", filter.DormitoryIDs)
+	}
 	if filter.DayOfWeek != "" {
 		query = query.Where("day_of_week = ?", filter.DayOfWeek)
 	}
@@ -88,3 +101,42 @@ func (r *classScheduleRepository) List(ctx context.Context, filter domainRepo.Cl
 func (r *classScheduleRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&entity.ClassSchedule{}, id).Error
 }
+
+func (r *classScheduleRepository) ListConflictCandidates(ctx context.Context, filter domainRepo.ClassScheduleConflictFilter) ([]*entity.ClassSchedule, error) {
+	query := r.db.WithContext(ctx).
+		Model(&entity.ClassSchedule{}).
+		Where("is_active = ? AND day_of_week = ?", true, filter.DayOfWeek)
+
+	if filter.ExcludeID != uuid.Nil {
+		query = query.Where("id <> ?", filter.ExcludeID)
+	}
+
+	location := strings.TrimSpace(filter.Location)
+	if location != "" {
+		query = query.Where("(teacher_id = ? OR class_id = ? OR LOWER(TRIM(location)) = ?)", filter.TeacherID, filter.ClassID, strings.ToLower(location))
+	} else {
+		query = query.Where("(teacher_id = ? OR class_id = ?)", filter.TeacherID, filter.ClassID)
+	}
+
+	var schedules []*entity.ClassSchedule
+	if err := query.Order("start_time ASC").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *classScheduleRepository) ListActiveByDay(ctx context.Context, dayOfWeek string, dormitoryID uuid.UUID) ([]*entity.ClassSchedule, error) {
+	query := r.db.WithContext(ctx).
+		Model(&entity.ClassSchedule{}).
+		Where("is_active = ? AND day_of_week = ?", true, dayOfWeek)
+
+	if dormitoryID != uuid.Nil {
+		query = query.Where("dormitory_id = ?", dormitoryID)
+	}
+
+	var schedules []*entity.ClassSchedule
+	if err := query.Order("start_time ASC").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
@@ -34,6 +34,15 @@ func (r *dormitoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entit
 	return &dormitory, nil
 }
 
+func (r *dormitoryRepository) GetByCode(ctx context.Context, code string) (*entity.Dormitory, error) {
+	var dormitory entity.Dormitory
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&dormitory).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dormitory, nil
+}
+
 func (r *dormitoryRepository) Update(ctx context.Context, dormitory *entity.Dormitory) error {
 	return r.db.WithContext(ctx).Save(dormitory).Error
 }
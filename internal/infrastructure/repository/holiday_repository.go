@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// Ensure holidayRepository implements HolidayRepository.
+var _ domainRepo.HolidayRepository = (*holidayRepository)(nil)
+
+type holidayRepository struct {
+	db *gorm.DB
+}
+
+// NewHolidayRepository creates a new holiday repository.
+func NewHolidayRepository() domainRepo.HolidayRepository {
+	return &holidayRepository{db: database.DB}
+}
+
+func (r *holidayRepository) Create(ctx context.Context, holiday *entity.Holiday) error {
+	return r.db.WithContext(ctx).Create(holiday).Error
+}
+
+func (r *holidayRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Holiday, error) {
+	var holiday entity.Holiday
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&holiday).Error; err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+func (r *holidayRepository) List(ctx context.Context, filter domainRepo.HolidayFilter) ([]*entity.Holiday, error) {
+	query := r.db.WithContext(ctx).
+		Model(&entity.Holiday{}).
+		Where("date >= ? AND date <= ?", filter.StartDate, filter.EndDate)
+
+	if filter.DormitoryID != nil {
+		query = query.Where("(dormitory_id IS NULL OR dormitory_id = ?)", *filter.DormitoryID)
+	}
+
+	var holidays []*entity.Holiday
+	if err := query.Order("date ASC").Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+func (r *holidayRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&entity.Holiday{}, id).Error
+}
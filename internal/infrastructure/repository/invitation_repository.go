@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+var _ domainRepo.InvitationRepository = (*invitationRepository)(nil)
+
+// errInvitationNotPending rolls back Accept when the invitation was redeemed,
+// revoked or expired in the meantime.
+var errInvitationNotPending = errors.New("invitation is no longer pending")
+
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationRepository creates a new invitation repository.
+func NewInvitationRepository() domainRepo.InvitationRepository {
+	return &invitationRepository{db: database.DB}
+}
+
+func (r *invitationRepository) Create(ctx context.Context, invitation *entity.Invitation) error {
+	return r.db.WithContext(ctx).Omit("Role").Create(invitation).Error
+}
+
+func (r *invitationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Invitation, error) {
+	var invitation entity.Invitation
+	if err := r.db.WithContext(ctx).Preload("Role").Where("id = ?", id).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	var invitation entity.Invitation
+	err := r.db.WithContext(ctx).Preload("Role").Where("token_hash = ?", tokenHash).First(&invitation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationRepository) ListPending(ctx context.Context, now time.Time) ([]*entity.Invitation, error) {
+	var invitations []*entity.Invitation
+	err := pendingInvitations(r.db.WithContext(ctx), now).
+		Preload("Role").
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *invitationRepository) HasPendingForUsername(ctx context.Context, username string, now time.Time) (bool, error) {
+	var count int64
+	err := pendingInvitations(r.db.WithContext(ctx), now).
+		Model(&entity.Invitation{}).
+		Where("username = ?", username).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *invitationRepository) Accept(ctx context.Context, id uuid.UUID, user *entity.User, dormitoryIDs []uuid.UUID, at time.Time) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := pendingInvitations(tx, at).
+			Model(&entity.Invitation{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{"accepted_at": at, "accepted_user_id": user.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errInvitationNotPending
+		}
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		for _, dormitoryID := range dormitoryIDs {
+			if err := tx.Create(&entity.UserDormitory{UserID: user.ID, DormitoryID: dormitoryID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errInvitationNotPending) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *invitationRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.Invitation{}).
+		Where("id = ? AND revoked_at IS NULL AND accepted_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+func pendingInvitations(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.
+		Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", now)
+}
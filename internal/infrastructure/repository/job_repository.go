@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ domainRepo.JobRunRepository = (*jobRunRepository)(nil)
+var _ domainRepo.JobLeaseRepository = (*jobLeaseRepository)(nil)
+
+type jobRunRepository struct {
+	db *gorm.DB
+}
+
+type jobLeaseRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRunRepository creates a new job run repository.
+func NewJobRunRepository() domainRepo.JobRunRepository {
+	return &jobRunRepository{db: database.DB}
+}
+
+// NewJobLeaseRepository creates a new job lease repository.
+func NewJobLeaseRepository() domainRepo.JobLeaseRepository {
+	return &jobLeaseRepository{db: database.DB}
+}
+
+func (r *jobRunRepository) Create(ctx context.Context, run *entity.JobRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+func (r *jobRunRepository) Update(ctx context.Context, run *entity.JobRun) error {
+	return r.db.WithContext(ctx).Save(run).Error
+}
+
+func (r *jobRunRepository) List(ctx context.Context, filter domainRepo.JobRunFilter) ([]*entity.JobRun, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.JobRun{})
+
+	if filter.JobName != nil {
+		query = query.Where("job_name = ?", *filter.JobName)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	limit, offset := normalizePaging(filter.Limit, filter.Offset)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []*entity.JobRun
+	if err := query.Order("started_at DESC").Limit(limit).Offset(offset).Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return runs, total, nil
+}
+
+// Acquire inserts the lease row on first use, then takes it over only when it has
+// expired or already belongs to holder. The conditional UPDATE is atomic, so at most
+// one replica wins a contended lease.
+func (r *jobLeaseRepository) Acquire(ctx context.Context, jobName, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+
+	lease := &entity.JobLease{JobName: jobName, Holder: holder, ExpiresAt: now, UpdatedAt: now}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(lease).Error; err != nil {
+		return false, err
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&entity.JobLease{}).
+		Where("job_name = ?", jobName).
+		Where("expires_at <= ? OR holder = ?", now, holder).
+		Updates(map[string]interface{}{
+			"holder":     holder,
+			"expires_at": now.Add(ttl),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+func TestJobLeaseRepository_Acquire(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	require.NoError(t, db.AutoMigrate(&entity.JobLease{}))
+
+	repo := &jobLeaseRepository{db: db}
+	ctx := context.Background()
+
+	acquired, err := repo.Acquire(ctx, "lock_attendance_sessions", "replica-a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Another replica cannot take an unexpired lease, but the holder can renew it.
+	acquired, err = repo.Acquire(ctx, "lock_attendance_sessions", "replica-b", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	acquired, err = repo.Acquire(ctx, "lock_attendance_sessions", "replica-a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Leases are per job.
+	acquired, err = repo.Acquire(ctx, "expire_leave_permits", "replica-b", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Once expired, the lease can be taken over.
+	require.NoError(t, db.Model(&entity.JobLease{}).
+		Where("job_name = ?", "lock_attendance_sessions").
+		Update("expires_at", time.Now().UTC().Add(-time.Second)).Error)
+
+	acquired, err = repo.Acquire(ctx, "lock_attendance_sessions", "replica-b", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	var lease entity.JobLease
+	require.NoError(t, db.Where("job_name = ?", "lock_attendance_sessions").First(&lease).Error)
+	assert.Equal(t, "replica-b", lease.Holder)
+}
+
+func TestJobRunRepository_List(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	require.NoError(t, db.AutoMigrate(&entity.JobRun{}))
+
+	repo := &jobRunRepository{db: db}
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 6, 23, 55, 0, 0, time.UTC)
+	runs := []*entity.JobRun{
+		{ID: uuid.New(), JobName: "lock_attendance_sessions", Status: entity.JobRunStatusSucceeded, Holder: "a", ScheduledAt: base, StartedAt: base},
+		{ID: uuid.New(), JobName: "lock_attendance_sessions", Status: entity.JobRunStatusFailed, Holder: "a", ScheduledAt: base.AddDate(0, 0, 1), StartedAt: base.AddDate(0, 0, 1)},
+		{ID: uuid.New(), JobName: "expire_leave_permits", Status: entity.JobRunStatusSucceeded, Holder: "b", ScheduledAt: base, StartedAt: base},
+	}
+	for _, run := range runs {
+		require.NoError(t, repo.Create(ctx, run))
+	}
+
+	jobName := "lock_attendance_sessions"
+	found, total, err := repo.List(ctx, domainRepo.JobRunFilter{JobName: &jobName})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, found, 2)
+	assert.Equal(t, runs[1].ID, found[0].ID)
+
+	failed := entity.JobRunStatusFailed
+	found, total, err = repo.List(ctx, domainRepo.JobRunFilter{Status: &failed})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, runs[1].ID, found[0].ID)
+}
@@ -47,11 +47,15 @@ func (r *leavePermitRepository) GetByID(ctx context.Context, id uuid.UUID) (*ent
 }
 
 func (r *leavePermitRepository) List(ctx context.Context, filter domainRepo.LeavePermitFilter) ([]*entity.LeavePermit, int64, error) {
-	query := r.db.WithContext(ctx).Model(&entity.LeavePermit{})
+	db := r.db.WithContext(ctx)
+	query := db.Model(&entity.LeavePermit{})
 
 	if filter.StudentID != nil {
 		query = query.Where("student_id = ?", *filter.StudentID)
 	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("student_id IN (?)", studentsInDormitories(db, filter.DormitoryIDs))
+	}
 	if filter.Status != nil {
 		query = query.Where("status = ?", *filter.Status)
 	}
@@ -115,6 +119,21 @@ func (r *leavePermitRepository) ActiveByDate(ctx context.Context, studentID uuid
 	return &permit, nil
 }
 
+func (r *leavePermitRepository) ExpireEndedBefore(ctx context.Context, date time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&entity.LeavePermit{}).
+		Where("status IN ?", []entity.LeavePermitStatus{
+			entity.LeavePermitStatusPending,
+			entity.LeavePermitStatusApproved,
+		}).
+		Where("end_date < ?", date).
+		Updates(map[string]interface{}{
+			"status":     entity.LeavePermitStatusExpired,
+			"updated_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
 func (r *healthStatusRepository) Create(ctx context.Context, status *entity.HealthStatus) error {
 	return r.db.WithContext(ctx).Create(status).Error
 }
@@ -132,11 +151,15 @@ func (r *healthStatusRepository) GetByID(ctx context.Context, id uuid.UUID) (*en
 }
 
 func (r *healthStatusRepository) List(ctx context.Context, filter domainRepo.HealthStatusFilter) ([]*entity.HealthStatus, int64, error) {
-	query := r.db.WithContext(ctx).Model(&entity.HealthStatus{})
+	db := r.db.WithContext(ctx)
+	query := db.Model(&entity.HealthStatus{})
 
 	if filter.StudentID != nil {
 		query = query.Where("student_id = ?", *filter.StudentID)
 	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("student_id IN (?)", studentsInDormitories(db, filter.DormitoryIDs))
+	}
 	if filter.Status != nil {
 		query = query.Where("status = ?", *filter.Status)
 	}
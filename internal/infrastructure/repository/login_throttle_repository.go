@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+var _ domainRepo.LoginThrottleRepository = (*loginThrottleRepository)(nil)
+
+type loginThrottleRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginThrottleRepository creates a new login throttle repository.
+func NewLoginThrottleRepository() domainRepo.LoginThrottleRepository {
+	return &loginThrottleRepository{db: database.DB}
+}
+
+func (r *loginThrottleRepository) GetByKey(ctx context.Context, kind, value string) (*entity.LoginThrottle, error) {
+	var throttle entity.LoginThrottle
+	err := r.db.WithContext(ctx).Where("kind = ? AND value = ?", kind, value).First(&throttle).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+func (r *loginThrottleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.LoginThrottle, error) {
+	var throttle entity.LoginThrottle
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&throttle).Error; err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+func (r *loginThrottleRepository) Save(ctx context.Context, throttle *entity.LoginThrottle) error {
+	return r.db.WithContext(ctx).Save(throttle).Error
+}
+
+func (r *loginThrottleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&entity.LoginThrottle{}, "id = ?", id).Error
+}
+
+func (r *loginThrottleRepository) ListLocked(ctx context.Context, now time.Time) ([]*entity.LoginThrottle, error) {
+	var throttles []*entity.LoginThrottle
+	err := r.db.WithContext(ctx).
+		Where("locked_until > ?", now).
+		Order("locked_until DESC").
+		Find(&throttles).Error
+	return throttles, err
+}
@@ -43,6 +43,15 @@ func (r *permissionRepository) GetBySlug(ctx context.Context, slug string) (*ent
 	return &permission, nil
 }
 
+func (r *permissionRepository) GetByName(ctx context.Context, name string) (*entity.Permission, error) {
+	var permission entity.Permission
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&permission).Error
+	if err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
 func (r *permissionRepository) Update(ctx context.Context, permission *entity.Permission) error {
 	return r.db.WithContext(ctx).Save(permission).Error
 }
@@ -67,3 +76,12 @@ func (r *permissionRepository) List(ctx context.Context, limit, offset int) ([]*
 
 	return permissions, total, err
 }
+
+func (r *permissionRepository) ListNames(ctx context.Context) ([]string, error) {
+	var names []string
+	err := r.db.WithContext(ctx).
+		Model(&entity.Permission{}).
+		Order("name").
+		Pluck("name", &names).Error
+	return names, err
+}
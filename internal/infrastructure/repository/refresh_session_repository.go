@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// Ensure refreshSessionRepository implements RefreshSessionRepository interface.
+var _ domainRepo.RefreshSessionRepository = (*refreshSessionRepository)(nil)
+
+type refreshSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshSessionRepository creates a new refresh session repository.
+func NewRefreshSessionRepository() domainRepo.RefreshSessionRepository {
+	return &refreshSessionRepository{db: database.DB}
+}
+
+func (r *refreshSessionRepository) Create(ctx context.Context, session *entity.RefreshSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *refreshSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.RefreshSession, error) {
+	var session entity.RefreshSession
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *refreshSessionRepository) Rotate(ctx context.Context, id, replacedByID uuid.UUID) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&entity.RefreshSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"replaced_by_id": replacedByID,
+			"revoked_at":     now,
+			"revoked_reason": entity.SessionRevokeReasonRotated,
+			"updated_at":     now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *refreshSessionRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID, reason string) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&entity.RefreshSession{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Updates(map[string]interface{}{
+			"revoked_at":     now,
+			"revoked_reason": reason,
+			"updated_at":     now,
+		}).Error
+}
+
+func (r *refreshSessionRepository) RevokeAllByUser(ctx context.Context, userID uuid.UUID, reason string) (int64, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&entity.RefreshSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at":     now,
+			"revoked_reason": reason,
+			"updated_at":     now,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+func TestRefreshSessionRepository_RotateAndRevoke(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+	require.NoError(t, db.AutoMigrate(&entity.RefreshSession{}))
+
+	repo := &refreshSessionRepository{db: db}
+	ctx := context.Background()
+
+	userID := uuid.New()
+	familyID := uuid.New()
+	session := &entity.RefreshSession{ID: uuid.New(), UserID: userID, FamilyID: familyID, ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, repo.Create(ctx, session))
+
+	// First rotation wins, second one is rejected
+	nextID := uuid.New()
+	rotated, err := repo.Rotate(ctx, session.ID, nextID)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+
+	rotated, err = repo.Rotate(ctx, session.ID, uuid.New())
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	found, err := repo.GetByID(ctx, session.ID)
+	require.NoError(t, err)
+	assert.False(t, found.IsActive(time.Now()))
+	require.NotNil(t, found.ReplacedByID)
+	assert.Equal(t, nextID, *found.ReplacedByID)
+	assert.Equal(t, entity.SessionRevokeReasonRotated, found.RevokedReason)
+
+	// Revoking the family only touches sessions that are still active
+	next := &entity.RefreshSession{ID: nextID, UserID: userID, FamilyID: familyID, ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, repo.Create(ctx, next))
+	require.NoError(t, repo.RevokeFamily(ctx, familyID, entity.SessionRevokeReasonReuse))
+
+	found, err = repo.GetByID(ctx, nextID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.SessionRevokeReasonReuse, found.RevokedReason)
+
+	other := &entity.RefreshSession{ID: uuid.New(), UserID: userID, FamilyID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, repo.Create(ctx, other))
+	revoked, err := repo.RevokeAllByUser(ctx, userID, entity.SessionRevokeReasonAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revoked)
+}
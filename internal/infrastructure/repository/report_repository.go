@@ -2,12 +2,24 @@ package repository
 
 import (
 	"context"
+	"math"
+	"sort"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
 	"gorm.io/gorm"
 )
 
+// Ensure reportRepository implements ReportRepository interface.
+var _ domainRepo.ReportRepository = (*reportRepository)(nil)
+
+// consecutiveSickDays is the span (in days past the start date) after which a
+// health status is flagged as a prolonged sick case.
+const consecutiveSickDays = 3
+
 type reportRepository struct {
 	db *gorm.DB
 }
@@ -18,25 +30,405 @@ func NewReportRepository() domainRepo.ReportRepository {
 }
 
 func (r *reportRepository) AggregateStudentAttendance(ctx context.Context, filter domainRepo.StudentAttendanceReportFilter) ([]domainRepo.StudentAttendanceAggregation, error) {
-	return []domainRepo.StudentAttendanceAggregation{}, nil
+	query := r.db.WithContext(ctx).
+		Table("student_attendances").
+		Select(`class_schedules.dormitory_id AS dormitory_id,
+			class_schedules.class_id AS class_id,
+			classes.fan_id AS fan_id,
+			COUNT(*) AS total,
+			SUM(CASE WHEN student_attendances.status = ? THEN 1 ELSE 0 END) AS present,
+			SUM(CASE WHEN student_attendances.status = ? THEN 1 ELSE 0 END) AS absent,
+			SUM(CASE WHEN student_attendances.status = ? THEN 1 ELSE 0 END) AS permit,
+			SUM(CASE WHEN student_attendances.status = ? THEN 1 ELSE 0 END) AS sick`,
+			entity.StudentAttendancePresent,
+			entity.StudentAttendanceAbsent,
+			entity.StudentAttendancePermit,
+			entity.StudentAttendanceSick,
+		).
+		Joins("JOIN attendance_sessions ON attendance_sessions.id = student_attendances.attendance_session_id").
+		Joins("JOIN class_schedules ON class_schedules.id = attendance_sessions.class_schedule_id").
+		Joins("LEFT JOIN classes ON classes.id = class_schedules.class_id").
+		Where("attendance_sessions.date = ?", filter.Date)
+
+	if filter.DormitoryID != nil {
+		query = query.Where("class_schedules.dormitory_id = ?", *filter.DormitoryID)
+	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("class_schedules.dormitory_id IN ?", filter.DormitoryIDs)
+	}
+	if filter.ClassID != nil {
+		query = query.Where("class_schedules.class_id = ?", *filter.ClassID)
+	}
+	if filter.FanID != nil {
+		query = query.Where("classes.fan_id = ?", *filter.FanID)
+	}
+
+	var rows []domainRepo.StudentAttendanceAggregation
+	if err := query.
+		Group("class_schedules.dormitory_id, class_schedules.class_id, classes.fan_id").
+		Order("class_schedules.dormitory_id, class_schedules.class_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
 }
 
 func (r *reportRepository) AggregateTeacherAttendance(ctx context.Context, filter domainRepo.TeacherAttendanceReportFilter) ([]domainRepo.TeacherAttendanceAggregation, error) {
-	return []domainRepo.TeacherAttendanceAggregation{}, nil
+	query := r.db.WithContext(ctx).
+		Table("teacher_attendances").
+		Select(`teacher_attendances.teacher_id AS teacher_id,
+			COUNT(*) AS total,
+			SUM(CASE WHEN teacher_attendances.status = ? THEN 1 ELSE 0 END) AS present,
+			SUM(CASE WHEN teacher_attendances.status = ? THEN 1 ELSE 0 END) AS absent`,
+			entity.TeacherAttendancePresent,
+			entity.TeacherAttendanceAbsent,
+		).
+		Joins("JOIN attendance_sessions ON attendance_sessions.id = teacher_attendances.attendance_session_id").
+		Joins("JOIN class_schedules ON class_schedules.id = attendance_sessions.class_schedule_id").
+		Where("attendance_sessions.date = ?", filter.Date)
+
+	if filter.SlotID != nil {
+		query = query.Where("class_schedules.slot_id = ?", *filter.SlotID)
+	}
+	if filter.TeacherID != nil {
+		query = query.Where("teacher_attendances.teacher_id = ?", *filter.TeacherID)
+	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("class_schedules.dormitory_id IN ?", filter.DormitoryIDs)
+	}
+
+	var rows []domainRepo.TeacherAttendanceAggregation
+	if err := query.
+		Group("teacher_attendances.teacher_id").
+		Order("teacher_attendances.teacher_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
 }
 
 func (r *reportRepository) AggregateLeavePermits(ctx context.Context, filter domainRepo.LeavePermitReportFilter) ([]domainRepo.LeavePermitAggregation, error) {
-	return []domainRepo.LeavePermitAggregation{}, nil
+	query := r.db.WithContext(ctx).
+		Table("leave_permits").
+		Select(`student_dormitory_history.dormitory_id AS dormitory_id,
+			leave_permits.type AS type,
+			leave_permits.status AS status,
+			COUNT(*) AS total`).
+		Joins(`LEFT JOIN student_dormitory_history ON student_dormitory_history.student_id = leave_permits.student_id
+			AND student_dormitory_history.start_date <= leave_permits.start_date
+			AND (student_dormitory_history.end_date IS NULL OR student_dormitory_history.end_date > leave_permits.start_date)`)
+
+	if filter.Status != nil {
+		query = query.Where("leave_permits.status = ?", *filter.Status)
+	}
+	if filter.Type != nil {
+		query = query.Where("leave_permits.type = ?", *filter.Type)
+	}
+	if filter.DormitoryID != nil {
+		query = query.Where("student_dormitory_history.dormitory_id = ?", *filter.DormitoryID)
+	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("student_dormitory_history.dormitory_id IN ?", filter.DormitoryIDs)
+	}
+	if filter.DateRange.Start != nil {
+		query = query.Where("leave_permits.end_date >= ?", *filter.DateRange.Start)
+	}
+	if filter.DateRange.End != nil {
+		query = query.Where("leave_permits.start_date <= ?", *filter.DateRange.End)
+	}
+
+	var rows []domainRepo.LeavePermitAggregation
+	if err := query.
+		Group("student_dormitory_history.dormitory_id, leave_permits.type, leave_permits.status").
+		Order("student_dormitory_history.dormitory_id, leave_permits.type, leave_permits.status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
 }
 
 func (r *reportRepository) AggregateHealthStatuses(ctx context.Context, filter domainRepo.HealthStatusReportFilter) ([]domainRepo.HealthStatusAggregation, error) {
-	return []domainRepo.HealthStatusAggregation{}, nil
+	query := r.db.WithContext(ctx).
+		Table("health_statuses").
+		Select(`student_dormitory_history.dormitory_id AS dormitory_id,
+			health_statuses.status AS status,
+			COUNT(*) AS total,
+			SUM(CASE WHEN `+r.sickDaysExpr()+` >= ? THEN 1 ELSE 0 END) AS consecutive`,
+			consecutiveSickDays,
+		).
+		Joins(`LEFT JOIN student_dormitory_history ON student_dormitory_history.student_id = health_statuses.student_id
+			AND student_dormitory_history.start_date <= health_statuses.start_date
+			AND (student_dormitory_history.end_date IS NULL OR student_dormitory_history.end_date > health_statuses.start_date)`)
+
+	if filter.Status != nil {
+		query = query.Where("health_statuses.status = ?", *filter.Status)
+	}
+	if filter.DormitoryID != nil {
+		query = query.Where("student_dormitory_history.dormitory_id = ?", *filter.DormitoryID)
+	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("student_dormitory_history.dormitory_id IN ?", filter.DormitoryIDs)
+	}
+	if filter.DateRange.Start != nil {
+		query = query.Where("(health_statuses.end_date IS NULL OR health_statuses.end_date >= ?)", *filter.DateRange.Start)
+	}
+	if filter.DateRange.End != nil {
+		query = query.Where("health_statuses.start_date <= ?", *filter.DateRange.End)
+	}
+
+	var rows []domainRepo.HealthStatusAggregation
+	if err := query.
+		Group("student_dormitory_history.dormitory_id, health_statuses.status").
+		Order("student_dormitory_history.dormitory_id, health_statuses.status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// sickDaysExpr returns a dialect-specific expression measuring how many days a
+// health status has lasted. Open statuses are measured up to today.
+func (r *reportRepository) sickDaysExpr() string {
+	if r.db.Dialector.Name() == "sqlite" {
+		return "CAST(julianday(date(COALESCE(health_statuses.end_date, 'now'))) - julianday(date(health_statuses.start_date)) AS INTEGER)"
+	}
+	return "(COALESCE(health_statuses.end_date, CURRENT_DATE)::date - health_statuses.start_date::date)"
 }
 
 func (r *reportRepository) AggregateSKSResults(ctx context.Context, filter domainRepo.SKSReportFilter) ([]domainRepo.SKSAggregation, error) {
-	return []domainRepo.SKSAggregation{}, nil
+	query := r.db.WithContext(ctx).
+		Table("student_sks_results").
+		Select(`sks_definitions.fan_id AS fan_id,
+			student_sks_results.sks_id AS sks_id,
+			COUNT(*) AS total,
+			SUM(CASE WHEN student_sks_results.is_passed = ? THEN 1 ELSE 0 END) AS passed,
+			SUM(CASE WHEN student_sks_results.is_passed = ? THEN 0 ELSE 1 END) AS failed,
+			AVG(student_sks_results.score) AS average_score`,
+			true, true,
+		).
+		Joins("JOIN sks_definitions ON sks_definitions.id = student_sks_results.sks_id")
+
+	if filter.FanID != nil {
+		query = query.Where("sks_definitions.fan_id = ?", *filter.FanID)
+	}
+	if filter.SKSID != nil {
+		query = query.Where("student_sks_results.sks_id = ?", *filter.SKSID)
+	}
+	if filter.IsPassed != nil {
+		query = query.Where("student_sks_results.is_passed = ?", *filter.IsPassed)
+	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("student_sks_results.student_id IN (?)", studentsInDormitories(r.db.WithContext(ctx), filter.DormitoryIDs))
+	}
+	if filter.DateRange.Start != nil {
+		query = query.Where("student_sks_results.exam_date >= ?", *filter.DateRange.Start)
+	}
+	if filter.DateRange.End != nil {
+		query = query.Where("student_sks_results.exam_date < ?", filter.DateRange.End.AddDate(0, 0, 1))
+	}
+
+	var scanned []struct {
+		FanID        *uuid.UUID
+		SKSID        *uuid.UUID `gorm:"column:sks_id"`
+		Total        int
+		Passed       int
+		Failed       int
+		AverageScore *float64
+	}
+	if err := query.
+		Group("sks_definitions.fan_id, student_sks_results.sks_id").
+		Order("sks_definitions.fan_id, student_sks_results.sks_id").
+		Scan(&scanned).Error; err != nil {
+		return nil, err
+	}
+
+	rows := make([]domainRepo.SKSAggregation, 0, len(scanned))
+	for _, item := range scanned {
+		row := domainRepo.SKSAggregation{
+			FanID:  item.FanID,
+			SKSID:  item.SKSID,
+			Total:  item.Total,
+			Passed: item.Passed,
+			Failed: item.Failed,
+		}
+		if item.AverageScore != nil {
+			avg := int(math.Round(*item.AverageScore))
+			row.AverageScore = &avg
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
 }
 
+// ListMutationHistory returns dormitory and class transitions as separate rows.
+// Each row spans the window a student spent in the destination dorm/class; the
+// "from" side is taken from the student's previous window of the same kind, so
+// the filters apply to windows that carry their predecessor alongside.
 func (r *reportRepository) ListMutationHistory(ctx context.Context, filter domainRepo.MutationReportFilter) ([]domainRepo.MutationHistoryRow, error) {
-	return []domainRepo.MutationHistoryRow{}, nil
+	db := r.db.WithContext(ctx)
+
+	dormWindows := db.Model(&entity.StudentDormitoryHistory{}).
+		Select(`student_id, dormitory_id, start_date, end_date,
+			LAG(dormitory_id) OVER (PARTITION BY student_id ORDER BY start_date) AS from_dormitory_id`)
+	if filter.StudentID != nil {
+		dormWindows = dormWindows.Where("student_id = ?", *filter.StudentID)
+	}
+	if filter.FanID != nil {
+		dormWindows = dormWindows.Where(`student_id IN (
+			SELECT student_class_enrollments.student_id FROM student_class_enrollments
+			JOIN classes ON classes.id = student_class_enrollments.class_id
+			WHERE classes.fan_id = ?)`, *filter.FanID)
+	}
+
+	dormQuery := db.Table("(?) AS dormitory_windows", dormWindows)
+	if filter.DormitoryID != nil {
+		dormQuery = dormQuery.Where("(dormitory_id = ? OR from_dormitory_id = ?)", *filter.DormitoryID, *filter.DormitoryID)
+	}
+	if filter.DormitoryIDs != nil {
+		dormQuery = dormQuery.Where("(dormitory_id IN ? OR from_dormitory_id IN ?)", filter.DormitoryIDs, filter.DormitoryIDs)
+	}
+	dormQuery = whereWindowOverlaps(dormQuery, "start_date", "end_date", filter.DateRange)
+
+	var histories []struct {
+		StudentID       uuid.UUID
+		DormitoryID     uuid.UUID
+		FromDormitoryID *uuid.UUID
+		StartDate       time.Time
+		EndDate         *time.Time
+	}
+	if err := dormQuery.Order("student_id, start_date").Scan(&histories).Error; err != nil {
+		return nil, err
+	}
+
+	const enrollmentOrder = "OVER (PARTITION BY student_class_enrollments.student_id ORDER BY student_class_enrollments.enrolled_at)"
+	classWindows := db.Table("student_class_enrollments").
+		Select(`student_class_enrollments.student_id AS student_id,
+			student_class_enrollments.class_id AS class_id,
+			student_class_enrollments.enrolled_at AS enrolled_at,
+			student_class_enrollments.left_at AS left_at,
+			classes.fan_id AS fan_id,
+			fans.dormitory_id AS dormitory_id,
+			LAG(student_class_enrollments.class_id) ` + enrollmentOrder + ` AS from_class_id,
+			LAG(classes.fan_id) ` + enrollmentOrder + ` AS from_fan_id,
+			LAG(fans.dormitory_id) ` + enrollmentOrder + ` AS from_dormitory_id`).
+		Joins("LEFT JOIN classes ON classes.id = student_class_enrollments.class_id").
+		Joins("LEFT JOIN fans ON fans.id = classes.fan_id")
+	if filter.StudentID != nil {
+		classWindows = classWindows.Where("student_class_enrollments.student_id = ?", *filter.StudentID)
+	}
+
+	classQuery := db.Table("(?) AS class_windows", classWindows)
+	if filter.FanID != nil {
+		classQuery = classQuery.Where("(fan_id = ? OR from_fan_id = ?)", *filter.FanID, *filter.FanID)
+	}
+	if filter.DormitoryID != nil {
+		classQuery = classQuery.Where("(dormitory_id = ? OR from_dormitory_id = ?)", *filter.DormitoryID, *filter.DormitoryID)
+	}
+	if filter.DormitoryIDs != nil {
+		classQuery = classQuery.Where("(dormitory_id IN ? OR from_dormitory_id IN ?)", filter.DormitoryIDs, filter.DormitoryIDs)
+	}
+	classQuery = whereWindowOverlaps(classQuery, "enrolled_at", "left_at", filter.DateRange)
+
+	var enrollments []struct {
+		StudentID   uuid.UUID
+		ClassID     uuid.UUID
+		FromClassID *uuid.UUID
+		EnrolledAt  time.Time
+		LeftAt      *time.Time
+	}
+	if err := classQuery.Order("student_id, enrolled_at").Scan(&enrollments).Error; err != nil {
+		return nil, err
+	}
+
+	rows := make([]domainRepo.MutationHistoryRow, 0, len(histories)+len(enrollments))
+	for _, history := range histories {
+		toDorm := history.DormitoryID
+		rows = append(rows, domainRepo.MutationHistoryRow{
+			StudentID:  history.StudentID,
+			FromDormID: history.FromDormitoryID,
+			ToDormID:   &toDorm,
+			StartDate:  history.StartDate,
+			EndDate:    history.EndDate,
+		})
+	}
+	for _, enrollment := range enrollments {
+		toClass := enrollment.ClassID
+		rows = append(rows, domainRepo.MutationHistoryRow{
+			StudentID:   enrollment.StudentID,
+			FromClassID: enrollment.FromClassID,
+			ToClassID:   &toClass,
+			StartDate:   enrollment.EnrolledAt,
+			EndDate:     enrollment.LeftAt,
+		})
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].StudentID != rows[j].StudentID {
+			return rows[i].StudentID.String() < rows[j].StudentID.String()
+		}
+		return rows[i].StartDate.Before(rows[j].StartDate)
+	})
+
+	return rows, nil
+}
+
+func (r *reportRepository) ResolveLabels(ctx context.Context, query domainRepo.ReportLabelQuery) (domainRepo.ReportLabels, error) {
+	var (
+		labels domainRepo.ReportLabels
+		err    error
+	)
+	db := r.db.WithContext(ctx)
+
+	if labels.Dormitories, err = lookupNames(db, &entity.Dormitory{}, "name", query.DormitoryIDs); err != nil {
+		return labels, err
+	}
+	if labels.Classes, err = lookupNames(db, &entity.Class{}, "name", query.ClassIDs); err != nil {
+		return labels, err
+	}
+	if labels.Fans, err = lookupNames(db, &entity.Fan{}, "name", query.FanIDs); err != nil {
+		return labels, err
+	}
+	if labels.Teachers, err = lookupNames(db, &entity.Teacher{}, "full_name", query.TeacherIDs); err != nil {
+		return labels, err
+	}
+	if labels.SKS, err = lookupNames(db, &entity.SKSDefinition{}, "name", query.SKSIDs); err != nil {
+		return labels, err
+	}
+	if labels.Students, err = lookupNames(db, &entity.Student{}, "full_name", query.StudentIDs); err != nil {
+		return labels, err
+	}
+	return labels, nil
+}
+
+// lookupNames maps IDs of model rows to the given name column. Soft-deleted rows are
+// included so historical reports keep their names.
+func lookupNames(db *gorm.DB, model interface{}, column string, ids []uuid.UUID) (map[uuid.UUID]string, error) {
+	names := make(map[uuid.UUID]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	var rows []struct {
+		ID   uuid.UUID
+		Name string
+	}
+	if err := db.Unscoped().Model(model).Select("id, "+column+" AS name").Where("id IN ?", ids).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		names[row.ID] = row.Name
+	}
+	return names, nil
+}
+
+// whereWindowOverlaps keeps windows from startColumn to endColumn that intersect
+// the requested range. A NULL end means the window is still open.
+func whereWindowOverlaps(query *gorm.DB, startColumn, endColumn string, dateRange domainRepo.DateRange) *gorm.DB {
+	if dateRange.End != nil {
+		query = query.Where(startColumn+" < ?", dateRange.End.AddDate(0, 0, 1))
+	}
+	if dateRange.Start != nil {
+		query = query.Where("("+endColumn+" IS NULL OR "+endColumn+" >= ?)", *dateRange.Start)
+	}
+	return query
 }
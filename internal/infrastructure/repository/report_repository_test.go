@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func setupReportTestDB(t *testing.T) *gorm.DB {
+	db := testutil.SetupTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&entity.AttendanceSession{},
+		&entity.StudentAttendance{},
+		&entity.TeacherAttendance{},
+		&entity.LeavePermit{},
+		&entity.HealthStatus{},
+		&entity.StudentSKSResult{},
+	))
+	return db
+}
+
+func reportDate(value string) time.Time {
+	date, _ := time.Parse("2006-01-02", value)
+	return date
+}
+
+func TestReportRepository_AggregateStudentAttendance(t *testing.T) {
+	db := setupReportTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &reportRepository{db: db}
+	ctx := context.Background()
+
+	dormID := uuid.New()
+	fan := &entity.Fan{ID: uuid.New(), DormitoryID: dormID, Name: "Fan", Level: "1"}
+	require.NoError(t, db.Create(fan).Error)
+	class := &entity.Class{ID: uuid.New(), FanID: fan.ID, Name: "1A", IsActive: true}
+	require.NoError(t, db.Create(class).Error)
+	slotID := uuid.New()
+	schedule := &entity.ClassSchedule{ID: uuid.New(), ClassID: class.ID, DormitoryID: dormID, TeacherID: uuid.New(), SlotID: &slotID, DayOfWeek: "monday", IsActive: true}
+	require.NoError(t, db.Create(schedule).Error)
+
+	date := reportDate("2025-01-06")
+	session := &entity.AttendanceSession{ID: uuid.New(), ClassScheduleID: schedule.ID, Date: date, TeacherID: schedule.TeacherID, Status: entity.AttendanceSessionStatusSubmitted}
+	require.NoError(t, db.Create(session).Error)
+	otherDay := &entity.AttendanceSession{ID: uuid.New(), ClassScheduleID: schedule.ID, Date: date.AddDate(0, 0, 1), TeacherID: schedule.TeacherID, Status: entity.AttendanceSessionStatusOpen}
+	require.NoError(t, db.Create(otherDay).Error)
+
+	statuses := []entity.StudentAttendanceStatus{
+		entity.StudentAttendancePresent,
+		entity.StudentAttendancePresent,
+		entity.StudentAttendanceAbsent,
+		entity.StudentAttendanceSick,
+	}
+	for _, status := range statuses {
+		require.NoError(t, db.Create(&entity.StudentAttendance{ID: uuid.New(), AttendanceSessionID: session.ID, StudentID: uuid.New(), Status: status}).Error)
+	}
+	require.NoError(t, db.Create(&entity.StudentAttendance{ID: uuid.New(), AttendanceSessionID: otherDay.ID, StudentID: uuid.New(), Status: entity.StudentAttendancePermit}).Error)
+	require.NoError(t, db.Create(&entity.TeacherAttendance{ID: uuid.New(), AttendanceSessionID: session.ID, TeacherID: schedule.TeacherID, Status: entity.TeacherAttendancePresent}).Error)
+
+	rows, err := repo.AggregateStudentAttendance(ctx, domainRepo.StudentAttendanceReportFilter{Date: date, FanID: &fan.ID})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, dormID, *rows[0].DormitoryID)
+	assert.Equal(t, class.ID, *rows[0].ClassID)
+	assert.Equal(t, fan.ID, *rows[0].FanID)
+	assert.Equal(t, 4, rows[0].Total)
+	assert.Equal(t, 2, rows[0].Present)
+	assert.Equal(t, 1, rows[0].Absent)
+	assert.Equal(t, 0, rows[0].Permit)
+	assert.Equal(t, 1, rows[0].Sick)
+
+	otherDorm := uuid.New()
+	rows, err = repo.AggregateStudentAttendance(ctx, domainRepo.StudentAttendanceReportFilter{Date: date, DormitoryID: &otherDorm})
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+
+	teacherRows, err := repo.AggregateTeacherAttendance(ctx, domainRepo.TeacherAttendanceReportFilter{Date: date, SlotID: &slotID})
+	require.NoError(t, err)
+	require.Len(t, teacherRows, 1)
+	assert.Equal(t, schedule.TeacherID, teacherRows[0].TeacherID)
+	assert.Equal(t, 1, teacherRows[0].Total)
+	assert.Equal(t, 1, teacherRows[0].Present)
+}
+
+func TestReportRepository_AggregateLeaveAndHealth(t *testing.T) {
+	db := setupReportTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &reportRepository{db: db}
+	ctx := context.Background()
+
+	dormID := uuid.New()
+	studentID := uuid.New()
+	require.NoError(t, db.Create(&entity.StudentDormitoryHistory{ID: uuid.New(), StudentID: studentID, DormitoryID: dormID, StartDate: reportDate("2024-07-01")}).Error)
+
+	permits := []*entity.LeavePermit{
+		{ID: uuid.New(), StudentID: studentID, Type: entity.LeavePermitTypeHomeLeave, StartDate: reportDate("2025-01-02"), EndDate: reportDate("2025-01-04"), Status: entity.LeavePermitStatusApproved, CreatedBy: uuid.New()},
+		{ID: uuid.New(), StudentID: studentID, Type: entity.LeavePermitTypeHomeLeave, StartDate: reportDate("2025-01-10"), EndDate: reportDate("2025-01-11"), Status: entity.LeavePermitStatusApproved, CreatedBy: uuid.New()},
+		{ID: uuid.New(), StudentID: studentID, Type: entity.LeavePermitTypeOfficialDuty, StartDate: reportDate("2025-02-01"), EndDate: reportDate("2025-02-02"), Status: entity.LeavePermitStatusPending, CreatedBy: uuid.New()},
+	}
+	for _, permit := range permits {
+		require.NoError(t, db.Create(permit).Error)
+	}
+
+	end := reportDate("2025-01-31")
+	leaveRows, err := repo.AggregateLeavePermits(ctx, domainRepo.LeavePermitReportFilter{
+		DormitoryID: &dormID,
+		DateRange:   domainRepo.DateRange{End: &end},
+	})
+	require.NoError(t, err)
+	require.Len(t, leaveRows, 1)
+	assert.Equal(t, dormID, *leaveRows[0].DormitoryID)
+	assert.Equal(t, string(entity.LeavePermitTypeHomeLeave), leaveRows[0].Type)
+	assert.Equal(t, string(entity.LeavePermitStatusApproved), leaveRows[0].Status)
+	assert.Equal(t, 2, leaveRows[0].Total)
+
+	shortEnd := reportDate("2025-01-02")
+	longEnd := reportDate("2025-01-08")
+	statuses := []*entity.HealthStatus{
+		{ID: uuid.New(), StudentID: studentID, Diagnosis: "flu", StartDate: reportDate("2025-01-01"), EndDate: &shortEnd, Status: entity.HealthStatusStateRevoked, CreatedBy: uuid.New()},
+		{ID: uuid.New(), StudentID: studentID, Diagnosis: "fever", StartDate: reportDate("2025-01-03"), EndDate: &longEnd, Status: entity.HealthStatusStateRevoked, CreatedBy: uuid.New()},
+	}
+	for _, status := range statuses {
+		require.NoError(t, db.Create(status).Error)
+	}
+
+	revoked := string(entity.HealthStatusStateRevoked)
+	healthRows, err := repo.AggregateHealthStatuses(ctx, domainRepo.HealthStatusReportFilter{Status: &revoked, DormitoryID: &dormID})
+	require.NoError(t, err)
+	require.Len(t, healthRows, 1)
+	assert.Equal(t, 2, healthRows[0].Total)
+	assert.Equal(t, 1, healthRows[0].Consecutive)
+}
+
+func TestReportRepository_AggregateLeaveAndHealth_SameDayTransfer(t *testing.T) {
+	db := setupReportTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &reportRepository{db: db}
+	ctx := context.Background()
+
+	// A transfer closes the old history on the day the new one starts
+	studentID := uuid.New()
+	dormA, dormB := uuid.New(), uuid.New()
+	movedAt := reportDate("2025-01-15")
+	require.NoError(t, db.Create(&entity.StudentDormitoryHistory{ID: uuid.New(), StudentID: studentID, DormitoryID: dormA, StartDate: reportDate("2024-07-01"), EndDate: &movedAt}).Error)
+	require.NoError(t, db.Create(&entity.StudentDormitoryHistory{ID: uuid.New(), StudentID: studentID, DormitoryID: dormB, StartDate: movedAt}).Error)
+
+	require.NoError(t, db.Create(&entity.LeavePermit{ID: uuid.New(), StudentID: studentID, Type: entity.LeavePermitTypeHomeLeave, StartDate: movedAt, EndDate: reportDate("2025-01-16"), Status: entity.LeavePermitStatusApproved, CreatedBy: uuid.New()}).Error)
+	require.NoError(t, db.Create(&entity.HealthStatus{ID: uuid.New(), StudentID: studentID, Diagnosis: "flu", StartDate: movedAt, Status: entity.HealthStatusStateRevoked, CreatedBy: uuid.New()}).Error)
+
+	leaveRows, err := repo.AggregateLeavePermits(ctx, domainRepo.LeavePermitReportFilter{})
+	require.NoError(t, err)
+	require.Len(t, leaveRows, 1)
+	assert.Equal(t, dormB, *leaveRows[0].DormitoryID)
+	assert.Equal(t, 1, leaveRows[0].Total)
+
+	healthRows, err := repo.AggregateHealthStatuses(ctx, domainRepo.HealthStatusReportFilter{})
+	require.NoError(t, err)
+	require.Len(t, healthRows, 1)
+	assert.Equal(t, dormB, *healthRows[0].DormitoryID)
+	assert.Equal(t, 1, healthRows[0].Total)
+}
+
+func TestReportRepository_AggregateSKSResults(t *testing.T) {
+	db := setupReportTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &reportRepository{db: db}
+	ctx := context.Background()
+
+	fanID := uuid.New()
+	definition := &entity.SKSDefinition{ID: uuid.New(), FanID: fanID, Code: "SKS-1", Name: "Nahwu", KKM: 70, IsActive: true}
+	require.NoError(t, db.Create(definition).Error)
+
+	examDate := reportDate("2025-03-01")
+	for _, score := range []float64{80, 65, 91} {
+		require.NoError(t, db.Create(&entity.StudentSKSResult{ID: uuid.New(), StudentID: uuid.New(), SKSID: definition.ID, Score: score, IsPassed: score >= definition.KKM, ExamDate: &examDate}).Error)
+	}
+
+	rows, err := repo.AggregateSKSResults(ctx, domainRepo.SKSReportFilter{FanID: &fanID})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, definition.ID, *rows[0].SKSID)
+	assert.Equal(t, 3, rows[0].Total)
+	assert.Equal(t, 2, rows[0].Passed)
+	assert.Equal(t, 1, rows[0].Failed)
+	require.NotNil(t, rows[0].AverageScore)
+	assert.Equal(t, 79, *rows[0].AverageScore)
+
+	passed := true
+	start := reportDate("2025-03-01")
+	rows, err = repo.AggregateSKSResults(ctx, domainRepo.SKSReportFilter{IsPassed: &passed, DateRange: domainRepo.DateRange{Start: &start, End: &start}})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 2, rows[0].Total)
+	assert.Equal(t, 0, rows[0].Failed)
+}
+
+func TestReportRepository_ListMutationHistory(t *testing.T) {
+	db := setupReportTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &reportRepository{db: db}
+	ctx := context.Background()
+
+	studentID := uuid.New()
+	dormA, dormB := uuid.New(), uuid.New()
+	movedAt := reportDate("2025-01-15")
+	require.NoError(t, db.Create(&entity.StudentDormitoryHistory{ID: uuid.New(), StudentID: studentID, DormitoryID: dormA, StartDate: reportDate("2024-07-01"), EndDate: &movedAt}).Error)
+	require.NoError(t, db.Create(&entity.StudentDormitoryHistory{ID: uuid.New(), StudentID: studentID, DormitoryID: dormB, StartDate: movedAt}).Error)
+
+	fan := &entity.Fan{ID: uuid.New(), DormitoryID: dormB, Name: "Fan", Level: "1"}
+	require.NoError(t, db.Create(fan).Error)
+	class := &entity.Class{ID: uuid.New(), FanID: fan.ID, Name: "1A", IsActive: true}
+	require.NoError(t, db.Create(class).Error)
+	require.NoError(t, db.Create(&entity.StudentClassEnrollment{ID: uuid.New(), StudentID: studentID, ClassID: class.ID, EnrolledAt: reportDate("2025-01-20")}).Error)
+
+	rows, err := repo.ListMutationHistory(ctx, domainRepo.MutationReportFilter{StudentID: &studentID})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Nil(t, rows[0].FromDormID)
+	assert.Equal(t, dormA, *rows[1].FromDormID)
+	assert.Equal(t, dormB, *rows[1].ToDormID)
+	assert.Equal(t, class.ID, *rows[2].ToClassID)
+
+	start := reportDate("2025-01-01")
+	rows, err = repo.ListMutationHistory(ctx, domainRepo.MutationReportFilter{DormitoryID: &dormA, DateRange: domainRepo.DateRange{Start: &start}})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, dormA, *rows[0].ToDormID)
+	assert.Equal(t, dormA, *rows[1].FromDormID)
+
+	rows, err = repo.ListMutationHistory(ctx, domainRepo.MutationReportFilter{FanID: &fan.ID, DateRange: domainRepo.DateRange{Start: &movedAt}})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	// The previous window is still paired when the date range filters it out
+	afterMove := reportDate("2025-01-16")
+	rows, err = repo.ListMutationHistory(ctx, domainRepo.MutationReportFilter{DormitoryID: &dormB, DateRange: domainRepo.DateRange{Start: &afterMove}})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, dormA, *rows[0].FromDormID)
+	assert.Equal(t, dormB, *rows[0].ToDormID)
+	assert.Equal(t, class.ID, *rows[1].ToClassID)
+
+	rows, err = repo.ListMutationHistory(ctx, domainRepo.MutationReportFilter{DormitoryIDs: []uuid.UUID{dormA}})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Nil(t, rows[0].ToClassID)
+	assert.Nil(t, rows[1].ToClassID)
+
+	beforeMove := reportDate("2025-01-14")
+	rows, err = repo.ListMutationHistory(ctx, domainRepo.MutationReportFilter{StudentID: &studentID, DateRange: domainRepo.DateRange{End: &beforeMove}})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, dormA, *rows[0].ToDormID)
+}
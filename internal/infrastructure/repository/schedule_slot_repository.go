@@ -51,6 +51,9 @@ func (r *scheduleSlotRepository) List(ctx context.Context, filter domainRepo.Sch
 	if filter.DormitoryID != uuid.Nil {
 		query = query.Where("dormitory_id = ?", filter.DormitoryID)
 	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("dormitory_id IN ?", filter.DormitoryIDs)
+	}
 	if filter.IsActive != nil {
 		query = query.Where("is_active = ?", *filter.IsActive)
 	}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -43,24 +44,86 @@ func (r *studentRepository) GetByStudentNumber(ctx context.Context, studentNumbe
 	return &student, nil
 }
 
-func (r *studentRepository) List(ctx context.Context, limit, offset int) ([]*entity.Student, int64, error) {
+// studentSortColumns whitelists sortable columns so SortBy never reaches SQL unchecked.
+var studentSortColumns = map[string]string{
+	domainRepo.StudentSortFullName:      "full_name",
+	domainRepo.StudentSortStudentNumber: "student_number",
+	domainRepo.StudentSortBirthDate:     "birth_date",
+	domainRepo.StudentSortCreatedAt:     "created_at",
+}
+
+func (r *studentRepository) List(ctx context.Context, filter domainRepo.StudentFilter) ([]*entity.Student, int64, error) {
 	var (
 		students []*entity.Student
 		total    int64
 	)
 
 	db := r.db.WithContext(ctx)
-	if err := db.Model(&entity.Student{}).Count(&total).Error; err != nil {
+	query := db.Model(&entity.Student{})
+
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		pattern := "%" + escapeLike(strings.ToLower(search)) + "%"
+		query = query.Where("(LOWER(full_name) LIKE ? ESCAPE '\\' OR LOWER(student_number) LIKE ? ESCAPE '\\')", pattern, pattern)
+	}
+	if filter.DormitoryIDs != nil {
+		query = query.Where("id IN (?)", studentsInDormitories(db, filter.DormitoryIDs))
+	}
+	if filter.ClassID != nil {
+		query = query.Where("id IN (?)", db.Model(&entity.StudentClassEnrollment{}).
+			Select("student_id").
+			Where("left_at IS NULL AND class_id = ?", *filter.ClassID))
+	}
+	if filter.FanID != nil {
+		query = query.Where("id IN (?)", db.Model(&entity.StudentClassEnrollment{}).
+			Select("student_class_enrollments.student_id").
+			Joins("JOIN classes ON classes.id = student_class_enrollments.class_id").
+			Where("student_class_enrollments.left_at IS NULL AND classes.fan_id = ?", *filter.FanID))
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.Gender != nil {
+		query = query.Where("gender = ?", *filter.Gender)
+	}
+	if filter.BirthYearFrom != nil {
+		query = query.Where("birth_date >= ?", time.Date(*filter.BirthYearFrom, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	if filter.BirthYearTo != nil {
+		query = query.Where("birth_date < ?", time.Date(*filter.BirthYearTo+1, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := db.Order("created_at DESC").Limit(limit).Offset(offset).Find(&students).Error; err != nil {
+	column, ok := studentSortColumns[filter.SortBy]
+	if !ok {
+		column = "created_at"
+	}
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	if err := query.Order(column + " " + direction).Order("id").Limit(filter.Limit).Offset(filter.Offset).Find(&students).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return students, total, nil
 }
 
+// studentsInDormitories selects the IDs of students currently placed in one of the dormitories.
+func studentsInDormitories(db *gorm.DB, dormitoryIDs []uuid.UUID) *gorm.DB {
+	return db.Model(&entity.StudentDormitoryHistory{}).
+		Select("student_id").
+		Where("end_date IS NULL AND dormitory_id IN ?", dormitoryIDs)
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally.
+func escapeLike(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(value)
+}
+
 func (r *studentRepository) Update(ctx context.Context, student *entity.Student) error {
 	return r.db.WithContext(ctx).Save(student).Error
 }
@@ -80,6 +143,50 @@ func (r *studentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&entity.Student{}, id).Error
 }
 
+// importBatchSize bounds the number of rows per INSERT statement during imports.
+const importBatchSize = 100
+
+func (r *studentRepository) ExistingStudentNumbers(ctx context.Context, studentNumbers []string) ([]string, error) {
+	existing := []string{}
+	if len(studentNumbers) == 0 {
+		return existing, nil
+	}
+	err := r.db.WithContext(ctx).Model(&entity.Student{}).
+		Where("student_number IN ?", studentNumbers).
+		Pluck("student_number", &existing).Error
+	return existing, err
+}
+
+func (r *studentRepository) CreateImport(ctx context.Context, records []domainRepo.StudentImportRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	students := make([]*entity.Student, 0, len(records))
+	histories := make([]*entity.StudentDormitoryHistory, 0, len(records))
+	enrollments := make([]*entity.StudentClassEnrollment, 0, len(records))
+	for _, record := range records {
+		students = append(students, record.Student)
+		histories = append(histories, record.History)
+		if record.Enrollment != nil {
+			enrollments = append(enrollments, record.Enrollment)
+		}
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(students, importBatchSize).Error; err != nil {
+			return err
+		}
+		if err := tx.CreateInBatches(histories, importBatchSize).Error; err != nil {
+			return err
+		}
+		if len(enrollments) > 0 {
+			return tx.CreateInBatches(enrollments, importBatchSize).Error
+		}
+		return nil
+	})
+}
+
 func (r *studentRepository) CreateHistory(ctx context.Context, history *entity.StudentDormitoryHistory) error {
 	return r.db.WithContext(ctx).Create(history).Error
 }
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+func TestStudentRepository_ListFilters(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &studentRepository{db: db}
+	ctx := context.Background()
+
+	dormA, dormB := uuid.New(), uuid.New()
+	fan := &entity.Fan{ID: uuid.New(), DormitoryID: dormA, Name: "Fan", Level: "1"}
+	require.NoError(t, db.Create(fan).Error)
+	class := &entity.Class{ID: uuid.New(), FanID: fan.ID, Name: "1A", IsActive: true}
+	require.NoError(t, db.Create(class).Error)
+
+	newStudent := func(number, name, gender, status string, birthYear int, dormID uuid.UUID) *entity.Student {
+		student := &entity.Student{
+			ID:            uuid.New(),
+			StudentNumber: number,
+			FullName:      name,
+			BirthDate:     time.Date(birthYear, 6, 1, 0, 0, 0, 0, time.UTC),
+			Gender:        gender,
+			Status:        status,
+			IsActive:      status == entity.StudentStatusActive,
+		}
+		require.NoError(t, db.Create(student).Error)
+		require.NoError(t, db.Create(&entity.StudentDormitoryHistory{ID: uuid.New(), StudentID: student.ID, DormitoryID: dormID, StartDate: time.Now()}).Error)
+		return student
+	}
+
+	ali := newStudent("S-001", "Ali Rahman", "male", entity.StudentStatusActive, 2010, dormA)
+	budi := newStudent("S-002", "Budi 100%", "male", entity.StudentStatusGraduated, 2005, dormA)
+	citra := newStudent("S-003", "Citra Ayu", "female", entity.StudentStatusActive, 2011, dormB)
+	require.NoError(t, db.Create(&entity.StudentClassEnrollment{ID: uuid.New(), ClassID: class.ID, StudentID: ali.ID, EnrolledAt: time.Now()}).Error)
+
+	// A closed history must not place Citra in dormitory A.
+	ended := time.Now().AddDate(0, -1, 0)
+	require.NoError(t, db.Create(&entity.StudentDormitoryHistory{ID: uuid.New(), StudentID: citra.ID, DormitoryID: dormA, StartDate: ended.AddDate(-1, 0, 0), EndDate: &ended}).Error)
+
+	female := "female"
+	graduated := entity.StudentStatusGraduated
+	from, to := 2010, 2011
+
+	tests := []struct {
+		name     string
+		filter   domainRepo.StudentFilter
+		expected []uuid.UUID
+	}{
+		{name: "search by name", filter: domainRepo.StudentFilter{Search: "ali"}, expected: []uuid.UUID{ali.ID}},
+		{name: "search by number", filter: domainRepo.StudentFilter{Search: "s-003"}, expected: []uuid.UUID{citra.ID}},
+		{name: "wildcards match literally", filter: domainRepo.StudentFilter{Search: "100%"}, expected: []uuid.UUID{budi.ID}},
+		{name: "dormitory", filter: domainRepo.StudentFilter{DormitoryIDs: []uuid.UUID{dormA}, SortBy: domainRepo.StudentSortStudentNumber}, expected: []uuid.UUID{ali.ID, budi.ID}},
+		{name: "empty dormitory scope", filter: domainRepo.StudentFilter{DormitoryIDs: []uuid.UUID{}}, expected: []uuid.UUID{}},
+		{name: "class", filter: domainRepo.StudentFilter{ClassID: &class.ID}, expected: []uuid.UUID{ali.ID}},
+		{name: "fan", filter: domainRepo.StudentFilter{FanID: &fan.ID}, expected: []uuid.UUID{ali.ID}},
+		{name: "status", filter: domainRepo.StudentFilter{Status: &graduated}, expected: []uuid.UUID{budi.ID}},
+		{name: "gender", filter: domainRepo.StudentFilter{Gender: &female}, expected: []uuid.UUID{citra.ID}},
+		{name: "birth year range sorted desc", filter: domainRepo.StudentFilter{BirthYearFrom: &from, BirthYearTo: &to, SortBy: domainRepo.StudentSortBirthDate, SortDesc: true}, expected: []uuid.UUID{citra.ID, ali.ID}},
+		{name: "sort by name", filter: domainRepo.StudentFilter{SortBy: domainRepo.StudentSortFullName}, expected: []uuid.UUID{ali.ID, budi.ID, citra.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Limit = 10
+			students, total, err := repo.List(ctx, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.expected)), total)
+
+			ids := make([]uuid.UUID, 0, len(students))
+			for _, student := range students {
+				ids = append(ids, student.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	t.Run("paginates after counting", func(t *testing.T) {
+		students, total, err := repo.List(ctx, domainRepo.StudentFilter{SortBy: domainRepo.StudentSortStudentNumber, Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, students, 1)
+		assert.Equal(t, budi.ID, students[0].ID)
+	})
+}
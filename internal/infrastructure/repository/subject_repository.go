@@ -77,3 +77,17 @@ func (r *subjectRepository) List(ctx context.Context, limit, offset int) ([]*ent
 func (r *subjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&entity.Subject{}, id).Error
 }
+
+func (r *subjectRepository) CountUsage(ctx context.Context, id uuid.UUID) (int64, error) {
+	var schedules int64
+	if err := r.db.WithContext(ctx).Model(&entity.ClassSchedule{}).Where("subject_id = ?", id).Count(&schedules).Error; err != nil {
+		return 0, err
+	}
+
+	var definitions int64
+	if err := r.db.WithContext(ctx).Model(&entity.SKSDefinition{}).Where("subject_id = ?", id).Count(&definitions).Error; err != nil {
+		return 0, err
+	}
+
+	return schedules + definitions, nil
+}
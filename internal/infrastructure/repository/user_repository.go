@@ -81,6 +81,17 @@ func (r *userRepository) GetWithRoles(ctx context.Context, id uuid.UUID) (*entit
 	return &user, nil
 }
 
+func (r *userRepository) ListServiceAccounts(ctx context.Context) ([]*entity.User, error) {
+	var users []*entity.User
+	err := r.db.WithContext(ctx).
+		Preload("Roles").
+		Preload("Roles.Permissions").
+		Where("is_service_account = ?", true).
+		Order("username").
+		Find(&users).Error
+	return users, err
+}
+
 func (r *userRepository) GetWithRolesAndDormitories(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	var user entity.User
 	err := r.db.WithContext(ctx).
@@ -108,3 +119,14 @@ func (r *userRepository) RemoveRole(ctx context.Context, userID, roleID uuid.UUI
 		Where("user_id = ? AND role_id = ?", userID, roleID).
 		Delete(&entity.UserRole{}).Error
 }
+
+func (r *userRepository) AdvanceTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	// The step guard makes concurrent verifications of the same code race-safe.
+	result := r.db.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND two_factor_last_step < ?", userID, step).
+		Update("two_factor_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
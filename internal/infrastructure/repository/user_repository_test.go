@@ -233,3 +233,36 @@ func TestUserRepository_GetWithRoles(t *testing.T) {
 	assert.Len(t, userWithRoles.Roles, 1)
 	assert.Equal(t, "admin", userWithRoles.Roles[0].Name)
 }
+
+func TestUserRepository_AdvanceTwoFactorStep(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &userRepository{db: db}
+	ctx := context.Background()
+
+	user := &entity.User{
+		ID:                uuid.New(),
+		Username:          "test",
+		Password:          "hashedpassword",
+		Name:              "Test User",
+		IsActive:          true,
+		TwoFactorLastStep: 100,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+	require.NoError(t, db.Create(user).Error)
+
+	advanced, err := repo.AdvanceTwoFactorStep(ctx, user.ID, 101)
+	require.NoError(t, err)
+	assert.True(t, advanced)
+
+	// The same step, as a concurrent verification would submit, is refused
+	advanced, err = repo.AdvanceTwoFactorStep(ctx, user.ID, 101)
+	require.NoError(t, err)
+	assert.False(t, advanced)
+
+	var foundUser entity.User
+	require.NoError(t, db.Where("id = ?", user.ID).First(&foundUser).Error)
+	assert.Equal(t, int64(101), foundUser.TwoFactorLastStep)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+const defaultAuditArchiveDir = "var/audit-archive"
+
+type fileAuditArchiveStore struct {
+	dir string
+}
+
+var _ service.AuditArchiveStore = (*fileAuditArchiveStore)(nil)
+
+// NewAuditArchiveStore stores gzip compressed archives in AUDIT_ARCHIVE_DIR
+// (default var/audit-archive). Copy the directory to long-term storage; the
+// database only keeps each file's name and checksum.
+func NewAuditArchiveStore() service.AuditArchiveStore {
+	dir := os.Getenv("AUDIT_ARCHIVE_DIR")
+	if dir == "" {
+		dir = defaultAuditArchiveDir
+	}
+	return NewFileAuditArchiveStore(dir)
+}
+
+// NewFileAuditArchiveStore stores archives in dir.
+func NewFileAuditArchiveStore(dir string) service.AuditArchiveStore {
+	return &fileAuditArchiveStore{dir: dir}
+}
+
+// Create writes to a temporary file that Commit renames to name.gz, so a
+// crash never leaves a truncated archive under its final name.
+func (s *fileAuditArchiveStore) Create(name string) (service.AuditArchiveWriter, error) {
+	if name == "" || filepath.Base(name) != name {
+		return nil, fmt.Errorf("invalid archive name %q", name)
+	}
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(s.dir, name+".gz")
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("archive %s already exists", path)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.New()
+	return &fileAuditArchiveWriter{
+		file: tmp,
+		path: path,
+		sum:  sum,
+		gz:   gzip.NewWriter(io.MultiWriter(tmp, sum)),
+	}, nil
+}
+
+type fileAuditArchiveWriter struct {
+	file *os.File
+	path string
+	sum  hash.Hash
+	gz   *gzip.Writer
+}
+
+func (w *fileAuditArchiveWriter) Write(p []byte) (int, error) {
+	return w.gz.Write(p)
+}
+
+func (w *fileAuditArchiveWriter) Commit() (string, string, error) {
+	if err := w.gz.Close(); err != nil {
+		return "", "", errors.Join(err, w.Abort())
+	}
+	if err := w.file.Sync(); err != nil {
+		return "", "", errors.Join(err, w.Abort())
+	}
+	if err := w.file.Close(); err != nil {
+		return "", "", errors.Join(err, os.Remove(w.file.Name()))
+	}
+	if err := os.Rename(w.file.Name(), w.path); err != nil {
+		return "", "", errors.Join(err, os.Remove(w.file.Name()))
+	}
+	return w.path, hex.EncodeToString(w.sum.Sum(nil)), nil
+}
+
+func (w *fileAuditArchiveWriter) Abort() error {
+	w.file.Close()
+	if err := os.Remove(w.file.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileAuditArchiveStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileAuditArchiveStore(dir)
+
+	t.Run("commit writes a gzip file with its checksum", func(t *testing.T) {
+		w, err := store.Create("audit-1-2.ndjson")
+		require.NoError(t, err)
+		_, err = io.WriteString(w, "{\"sequence\":1}\n{\"sequence\":2}\n")
+		require.NoError(t, err)
+
+		location, checksum, err := w.Commit()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "audit-1-2.ndjson.gz"), location)
+
+		raw, err := os.ReadFile(location)
+		require.NoError(t, err)
+		sum := sha256.Sum256(raw)
+		assert.Equal(t, hex.EncodeToString(sum[:]), checksum)
+
+		f, err := os.Open(location)
+		require.NoError(t, err)
+		defer f.Close()
+		gz, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		body, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		assert.Equal(t, "{\"sequence\":1}\n{\"sequence\":2}\n", string(body))
+
+		_, err = store.Create("audit-1-2.ndjson")
+		assert.Error(t, err, "existing archives are never overwritten")
+	})
+
+	t.Run("abort leaves nothing behind", func(t *testing.T) {
+		w, err := store.Create("audit-3-4.ndjson")
+		require.NoError(t, err)
+		_, err = io.WriteString(w, "partial")
+		require.NoError(t, err)
+		require.NoError(t, w.Abort())
+
+		matches, err := filepath.Glob(filepath.Join(dir, "audit-3-4*"))
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+
+	t.Run("rejects names outside the directory", func(t *testing.T) {
+		_, err := store.Create("../escape.ndjson")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+type auditSigner struct {
+	key       *jwtKey
+	publicPEM string
+}
+
+var _ service.AuditSigner = (*auditSigner)(nil)
+
+// NewAuditSigner loads the key that signs audit checkpoints from
+// AUDIT_SIGNING_KEY_FILE, falling back to JWT_SIGNING_KEY_FILE. Outside
+// production an ephemeral key is used when neither is set.
+func NewAuditSigner() (service.AuditSigner, error) {
+	path := os.Getenv("AUDIT_SIGNING_KEY_FILE")
+	if path == "" {
+		path = os.Getenv("JWT_SIGNING_KEY_FILE")
+	}
+
+	var key *jwtKey
+	if path != "" {
+		loaded, err := loadJWTKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if loaded.private == nil {
+			return nil, fmt.Errorf("audit signing key %s must be a private key", path)
+		}
+		key = loaded
+	} else {
+		if os.Getenv("APP_ENV") == "production" {
+			return nil, errors.New("AUDIT_SIGNING_KEY_FILE or JWT_SIGNING_KEY_FILE is required when APP_ENV=production")
+		}
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		key, _ = newJWTKey(private.Public(), private)
+		slog.Warn("AUDIT_SIGNING_KEY_FILE not set, signing audit checkpoints with an ephemeral key")
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(key.public)
+	if err != nil {
+		return nil, err
+	}
+	return &auditSigner{
+		key:       key,
+		publicPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}, nil
+}
+
+func (s *auditSigner) KeyID() string {
+	return s.key.id
+}
+
+func (s *auditSigner) Algorithm() string {
+	return s.key.method.Alg()
+}
+
+func (s *auditSigner) PublicKeyPEM() string {
+	return s.publicPEM
+}
+
+func (s *auditSigner) Sign(payload []byte) (string, error) {
+	signature, err := s.key.method.Sign(string(payload), s.key.private)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (s *auditSigner) Verify(payload []byte, signature, publicKeyPEM string) error {
+	key, err := parseJWTKey([]byte(publicKeyPEM))
+	if err != nil {
+		return fmt.Errorf("parse checkpoint public key: %w", err)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("decode checkpoint signature: %w", err)
+	}
+	return key.method.Verify(string(payload), raw, key.public)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditSigner_SignAndVerify(t *testing.T) {
+	for name, newKey := range map[string]func(*testing.T) crypto.Signer{"Ed25519": newEd25519Key, "RSA": newRSAKey} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("AUDIT_SIGNING_KEY_FILE", writeTestKey(t, newKey(t)))
+			signer, err := NewAuditSigner()
+			require.NoError(t, err)
+
+			payload := []byte("audit-checkpoint:v1:42:abc:2025-11-20T00:00:00Z")
+			signature, err := signer.Sign(payload)
+			require.NoError(t, err)
+
+			assert.NoError(t, signer.Verify(payload, signature, signer.PublicKeyPEM()))
+			assert.Error(t, signer.Verify([]byte("audit-checkpoint:v1:43:abc:2025-11-20T00:00:00Z"), signature, signer.PublicKeyPEM()))
+		})
+	}
+}
+
+func TestAuditSigner_KeySelection(t *testing.T) {
+	jwtKey := newEd25519Key(t)
+	t.Setenv("JWT_SIGNING_KEY_FILE", writeTestKey(t, jwtKey))
+	t.Setenv("AUDIT_SIGNING_KEY_FILE", "")
+
+	signer, err := NewAuditSigner()
+	require.NoError(t, err)
+	expected, err := newJWTKey(jwtKey.Public(), jwtKey)
+	require.NoError(t, err)
+	assert.Equal(t, expected.id, signer.KeyID(), "falls back to the JWT signing key")
+	assert.Equal(t, "EdDSA", signer.Algorithm())
+
+	// Signatures from another key are rejected
+	other, err := NewAuditSigner()
+	require.NoError(t, err)
+	t.Setenv("AUDIT_SIGNING_KEY_FILE", writeTestKey(t, newEd25519Key(t)))
+	rotated, err := NewAuditSigner()
+	require.NoError(t, err)
+	signature, err := rotated.Sign([]byte("payload"))
+	require.NoError(t, err)
+	assert.Error(t, other.Verify([]byte("payload"), signature, other.PublicKeyPEM()))
+	assert.NoError(t, other.Verify([]byte("payload"), signature, rotated.PublicKeyPEM()))
+
+	t.Setenv("AUDIT_SIGNING_KEY_FILE", writeTestPublicKey(t, newEd25519Key(t)))
+	_, err = NewAuditSigner()
+	assert.Error(t, err, "public keys cannot sign")
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing or verification.
+const minRSAKeyBits = 2048
+
+// jwtKey is a key usable for verifying tokens, and for signing them when the
+// private half is known.
+type jwtKey struct {
+	id      string
+	method  jwt.SigningMethod
+	public  crypto.PublicKey
+	private crypto.Signer
+}
+
+// loadJWTKeyFile reads a PEM encoded RSA or Ed25519 key. Private keys
+// (PKCS#1 or PKCS#8) and public keys (PKIX or PKCS#1) are accepted.
+func loadJWTKeyFile(path string) (*jwtKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read JWT key %s: %w", path, err)
+	}
+	key, err := parseJWTKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse JWT key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+func parseJWTKey(data []byte) (*jwtKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		return newJWTKey(&k.PublicKey, k)
+	case *rsa.PublicKey:
+		return newJWTKey(k, nil)
+	case ed25519.PrivateKey:
+		return newJWTKey(k.Public(), k)
+	case ed25519.PublicKey:
+		return newJWTKey(k, nil)
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", parsed)
+	}
+}
+
+func newJWTKey(public crypto.PublicKey, private crypto.Signer) (*jwtKey, error) {
+	key := &jwtKey{public: public, private: private}
+	switch k := public.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", minRSAKeyBits)
+		}
+		key.method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", public)
+	}
+	key.id = key.thumbprint()
+	return key, nil
+}
+
+// jwk returns the public half of the key in JWK form (RFC 7517).
+func (k *jwtKey) jwk() service.JSONWebKey {
+	jwk := service.JSONWebKey{KeyID: k.id, Use: "sig", Algorithm: k.method.Alg()}
+	switch pub := k.public.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+	return jwk
+}
+
+// thumbprint derives the key ID from the RFC 7638 JWK thumbprint, so the same
+// key file always yields the same kid on every instance.
+func (k *jwtKey) thumbprint() string {
+	jwk := k.jwk()
+	// Required members only, in lexicographic order.
+	var members map[string]string
+	if jwk.KeyType == "RSA" {
+		members = map[string]string{"e": jwk.E, "kty": jwk.KeyType, "n": jwk.N}
+	} else {
+		members = map[string]string{"crv": jwk.Curve, "kty": jwk.KeyType, "x": jwk.X}
+	}
+	canonical, _ := json.Marshal(members)
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// splitKeyFiles parses a comma-separated list of key file paths.
+func splitKeyFiles(value string) []string {
+	var paths []string
+	for _, path := range strings.Split(value, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -1,8 +1,13 @@
 package service
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
+	"fmt"
+	"log/slog"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,37 +17,70 @@ import (
 )
 
 type jwtService struct {
-	secretKey          []byte
+	signingKey         *jwtKey
+	verificationKeys   map[string]*jwtKey
 	accessTokenExpiry  time.Duration
 	refreshTokenExpiry time.Duration
 }
 
-// NewJWTService creates a new JWT service
-func NewJWTService() service.TokenService {
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		secretKey = "default-secret-key-change-in-production"
+// NewJWTService creates a new JWT service.
+//
+// Tokens are signed with the RSA (RS256) or Ed25519 (EdDSA) private key in
+// JWT_SIGNING_KEY_FILE and carry its kid. JWT_VERIFICATION_KEY_FILES lists
+// further comma-separated key files that are still accepted, e.g. the previous
+// signing key during a rotation. Without a signing key the service refuses to
+// start when APP_ENV=production and otherwise signs with a throwaway key that
+// does not survive a restart.
+func NewJWTService() (service.TokenService, error) {
+	s := &jwtService{
+		verificationKeys:   map[string]*jwtKey{},
+		accessTokenExpiry:  15 * time.Minute,
+		refreshTokenExpiry: 168 * time.Hour, // 7 days
+	}
+
+	if path := os.Getenv("JWT_SIGNING_KEY_FILE"); path != "" {
+		key, err := loadJWTKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if key.private == nil {
+			return nil, fmt.Errorf("JWT signing key %s must be a private key", path)
+		}
+		s.signingKey = key
+	} else {
+		if os.Getenv("APP_ENV") == "production" {
+			return nil, errors.New("JWT_SIGNING_KEY_FILE is required when APP_ENV=production")
+		}
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		s.signingKey, _ = newJWTKey(private.Public(), private)
+		slog.Warn("JWT_SIGNING_KEY_FILE not set, signing tokens with an ephemeral key")
+	}
+	s.verificationKeys[s.signingKey.id] = s.signingKey
+
+	for _, path := range splitKeyFiles(os.Getenv("JWT_VERIFICATION_KEY_FILES")) {
+		key, err := loadJWTKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		s.verificationKeys[key.id] = key
 	}
 
-	accessExpiry := 15 * time.Minute
 	if expiryStr := os.Getenv("JWT_ACCESS_TOKEN_EXPIRY"); expiryStr != "" {
 		if parsed, err := time.ParseDuration(expiryStr); err == nil {
-			accessExpiry = parsed
+			s.accessTokenExpiry = parsed
 		}
 	}
 
-	refreshExpiry := 168 * time.Hour // 7 days
 	if expiryStr := os.Getenv("JWT_REFRESH_TOKEN_EXPIRY"); expiryStr != "" {
 		if parsed, err := time.ParseDuration(expiryStr); err == nil {
-			refreshExpiry = parsed
+			s.refreshTokenExpiry = parsed
 		}
 	}
 
-	return &jwtService{
-		secretKey:          []byte(secretKey),
-		accessTokenExpiry:  accessExpiry,
-		refreshTokenExpiry: refreshExpiry,
-	}
+	return s, nil
 }
 
 // GenerateAccessToken generates a new access token
@@ -51,38 +89,129 @@ func (s *jwtService) GenerateAccessToken(userID uuid.UUID, username string, role
 		"user_id":  userID.String(),
 		"username": username,
 		"roles":    roles,
-		"type":     "access",
+		"type":     service.TokenTypeAccess,
 		"exp":      time.Now().Add(s.accessTokenExpiry).Unix(),
 		"iat":      time.Now().Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	return s.sign(claims)
+}
+
+// GenerateImpersonationToken generates an access token for userID carrying
+// the impersonating admin in the "act" (actor) claim of RFC 8693
+func (s *jwtService) GenerateImpersonationToken(userID uuid.UUID, username string, impersonatorID uuid.UUID, impersonatorUsername string) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id":  userID.String(),
+		"username": username,
+		"act": map[string]interface{}{
+			"sub":      impersonatorID.String(),
+			"username": impersonatorUsername,
+		},
+		"type": service.TokenTypeAccess,
+		"exp":  time.Now().Add(service.ImpersonationTokenExpiry).Unix(),
+		"iat":  time.Now().Unix(),
+	}
+
+	return s.sign(claims)
 }
 
-// GenerateRefreshToken generates a new refresh token
-func (s *jwtService) GenerateRefreshToken(userID uuid.UUID) (string, error) {
+// GenerateRefreshToken generates a new refresh token bound to a refresh session
+func (s *jwtService) GenerateRefreshToken(userID, sessionID, familyID uuid.UUID) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID.String(),
-		"type":    "refresh",
+		"jti":     sessionID.String(),
+		"fid":     familyID.String(),
+		"type":    service.TokenTypeRefresh,
 		"exp":     time.Now().Add(s.refreshTokenExpiry).Unix(),
 		"iat":     time.Now().Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	return s.sign(claims)
+}
+
+// GenerateTwoFactorToken generates a token for the second step of a login
+func (s *jwtService) GenerateTwoFactorToken(userID uuid.UUID) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id": userID.String(),
+		"type":    service.TokenTypeTwoFactor,
+		"exp":     time.Now().Add(service.TwoFactorTokenExpiry).Unix(),
+		"iat":     time.Now().Unix(),
+	}
+
+	return s.sign(claims)
 }
 
-// ValidateToken validates and parses a JWT token
+// ValidateTwoFactorToken validates and parses a two-factor login token
+func (s *jwtService) ValidateTwoFactorToken(tokenString string) (*service.TokenClaims, error) {
+	return s.parseToken(tokenString, service.TokenTypeTwoFactor)
+}
+
+// VerificationKeys returns the public keys accepted for token verification
+func (s *jwtService) VerificationKeys() []service.JSONWebKey {
+	keys := make([]service.JSONWebKey, 0, len(s.verificationKeys))
+	// The current signing key comes first, the rest follow in kid order.
+	keys = append(keys, s.signingKey.jwk())
+	ids := make([]string, 0, len(s.verificationKeys))
+	for id := range s.verificationKeys {
+		if id != s.signingKey.id {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		keys = append(keys, s.verificationKeys[id].jwk())
+	}
+	return keys
+}
+
+// sign signs claims with the current signing key and sets its kid header
+func (s *jwtService) sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(s.signingKey.method, claims)
+	token.Header["kid"] = s.signingKey.id
+	return token.SignedString(s.signingKey.private)
+}
+
+// RefreshTokenExpiry returns the configured refresh token lifetime
+func (s *jwtService) RefreshTokenExpiry() time.Duration {
+	return s.refreshTokenExpiry
+}
+
+// ValidateToken validates and parses an access token
 func (s *jwtService) ValidateToken(tokenString string) (*service.TokenClaims, error) {
+	return s.parseToken(tokenString, service.TokenTypeAccess)
+}
+
+// ValidateRefreshToken validates and parses a refresh token
+func (s *jwtService) ValidateRefreshToken(tokenString string) (*service.TokenClaims, error) {
+	claims, err := s.parseToken(tokenString, service.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenID == uuid.Nil || claims.FamilyID == uuid.Nil {
+		return nil, domainErrors.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// parseToken verifies the signature, expiry and type claim of a token
+func (s *jwtService) parseToken(tokenString, expectedType string) (*service.TokenClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.verificationKeys[kid]
+		if !ok {
+			return nil, errors.New("unknown key id")
+		}
+		// The algorithm must match the key, so an RSA key is never used with another algorithm.
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, errors.New("unexpected signing method")
 		}
-		return s.secretKey, nil
-	})
+		return key.public, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 
 	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, domainErrors.ErrTokenExpired
+		}
 		return nil, domainErrors.ErrInvalidToken
 	}
 
@@ -95,6 +224,10 @@ func (s *jwtService) ValidateToken(tokenString string) (*service.TokenClaims, er
 		return nil, domainErrors.ErrInvalidToken
 	}
 
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != expectedType {
+		return nil, domainErrors.ErrInvalidToken
+	}
+
 	// Check expiration
 	exp, ok := claims["exp"].(float64)
 	if !ok {
@@ -116,8 +249,9 @@ func (s *jwtService) ValidateToken(tokenString string) (*service.TokenClaims, er
 	}
 
 	tokenClaims := &service.TokenClaims{
-		UserID: userID,
-		Exp:    int64(exp),
+		UserID:    userID,
+		Exp:       int64(exp),
+		TokenType: expectedType,
 	}
 
 	// Extract username and roles for access tokens
@@ -134,29 +268,24 @@ func (s *jwtService) ValidateToken(tokenString string) (*service.TokenClaims, er
 		}
 	}
 
-	return tokenClaims, nil
-}
-
-// RefreshAccessToken generates a new access token from a refresh token
-func (s *jwtService) RefreshAccessToken(refreshToken string) (string, error) {
-	claims, err := s.ValidateToken(refreshToken)
-	if err != nil {
-		return "", err
+	// Extract session references for refresh tokens
+	if jti, ok := claims["jti"].(string); ok {
+		tokenClaims.TokenID, _ = uuid.Parse(jti)
+	}
+	if fid, ok := claims["fid"].(string); ok {
+		tokenClaims.FamilyID, _ = uuid.Parse(fid)
 	}
 
-	// Check if it's a refresh token
-	token, _ := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
-		return s.secretKey, nil
-	})
-
-	if tokenClaims, ok := token.Claims.(jwt.MapClaims); ok {
-		if tokenType, ok := tokenClaims["type"].(string); !ok || tokenType != "refresh" {
-			return "", domainErrors.ErrInvalidToken
+	// Extract the impersonating admin for impersonation tokens
+	if act, ok := claims["act"].(map[string]interface{}); ok {
+		sub, _ := act["sub"].(string)
+		impersonatorID, err := uuid.Parse(sub)
+		if err != nil {
+			return nil, domainErrors.ErrInvalidToken
 		}
+		tokenClaims.ImpersonatorID = impersonatorID
+		tokenClaims.ImpersonatorUsername, _ = act["username"].(string)
 	}
 
-	// Generate new access token
-	// Note: We need username and roles, but refresh token doesn't have them
-	// So we'll need to fetch from database in the use case
-	return s.GenerateAccessToken(claims.UserID, "", []string{})
+	return tokenClaims, nil
 }
@@ -1,21 +1,67 @@
 package service
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
 	"github.com/your-org/go-backend-starter/internal/testutil"
 )
 
+func newTestJWTService(t *testing.T) service.TokenService {
+	t.Helper()
+	tokenService, err := NewJWTService()
+	require.NoError(t, err)
+	return tokenService
+}
+
+func newEd25519Key(t *testing.T) crypto.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func newRSAKey(t *testing.T) crypto.Signer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+// writeTestKey stores key as a PKCS#8 PEM file and returns its path.
+func writeTestKey(t *testing.T, key crypto.Signer) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path
+}
+
+// writeTestPublicKey stores the public half of key as a PKIX PEM file and returns its path.
+func writeTestPublicKey(t *testing.T, key crypto.Signer) string {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pub.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	return path
+}
+
 func TestJWTService_GenerateAccessToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service := newTestJWTService(t)
 	userID := uuid.New()
 	username := "test"
 	roles := []string{"admin", "user"}
@@ -30,20 +76,52 @@ func TestJWTService_GenerateRefreshToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service := newTestJWTService(t)
 	userID := uuid.New()
 
-	token, err := service.GenerateRefreshToken(userID)
+	sessionID := uuid.New()
+	familyID := uuid.New()
+
+	token, err := service.GenerateRefreshToken(userID, sessionID, familyID)
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
+
+	claims, err := service.ValidateRefreshToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.Equal(t, sessionID, claims.TokenID)
+	assert.Equal(t, familyID, claims.FamilyID)
+}
+
+func TestJWTService_TokenTypeIsEnforced(t *testing.T) {
+	testutil.SetTestEnv()
+	defer testutil.UnsetTestEnv()
+
+	service := newTestJWTService(t)
+	userID := uuid.New()
+
+	refreshToken, err := service.GenerateRefreshToken(userID, uuid.New(), uuid.New())
+	require.NoError(t, err)
+	accessToken, err := service.GenerateAccessToken(userID, "test", []string{"admin"})
+	require.NoError(t, err)
+
+	// Refresh token must not be accepted as an access token
+	claims, err := service.ValidateToken(refreshToken)
+	assert.Error(t, err)
+	assert.Nil(t, claims)
+
+	// Access token must not be accepted as a refresh token
+	claims, err = service.ValidateRefreshToken(accessToken)
+	assert.Error(t, err)
+	assert.Nil(t, claims)
 }
 
 func TestJWTService_ValidateToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service := newTestJWTService(t)
 	userID := uuid.New()
 	username := "test"
 	roles := []string{"admin", "user"}
@@ -66,7 +144,7 @@ func TestJWTService_ValidateToken_InvalidToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service := newTestJWTService(t)
 
 	tests := []struct {
 		name  string
@@ -97,12 +175,10 @@ func TestJWTService_ValidateToken_InvalidToken(t *testing.T) {
 
 func TestJWTService_ValidateToken_ExpiredToken(t *testing.T) {
 	// Set a very short expiry time
-	os.Setenv("JWT_SECRET", "test-secret")
 	os.Setenv("JWT_ACCESS_TOKEN_EXPIRY", "1ms")
-	defer os.Unsetenv("JWT_SECRET")
 	defer os.Unsetenv("JWT_ACCESS_TOKEN_EXPIRY")
 
-	service := NewJWTService()
+	service := newTestJWTService(t)
 	userID := uuid.New()
 	username := "test"
 	roles := []string{"admin"}
@@ -120,11 +196,9 @@ func TestJWTService_ValidateToken_ExpiredToken(t *testing.T) {
 	assert.Nil(t, claims)
 }
 
-func TestJWTService_ValidateToken_WrongSecret(t *testing.T) {
-	os.Setenv("JWT_SECRET", "secret1")
-	defer os.Unsetenv("JWT_SECRET")
-
-	service1 := NewJWTService()
+func TestJWTService_ValidateToken_WrongKey(t *testing.T) {
+	t.Setenv("JWT_SIGNING_KEY_FILE", writeTestKey(t, newEd25519Key(t)))
+	service1 := newTestJWTService(t)
 	userID := uuid.New()
 	username := "test"
 	roles := []string{"admin"}
@@ -133,71 +207,180 @@ func TestJWTService_ValidateToken_WrongSecret(t *testing.T) {
 	token, err := service1.GenerateAccessToken(userID, username, roles)
 	require.NoError(t, err)
 
-	// Try to validate with service2 using different secret
-	os.Setenv("JWT_SECRET", "secret2")
-	service2 := NewJWTService()
+	// Try to validate with service2 using a different key
+	t.Setenv("JWT_SIGNING_KEY_FILE", writeTestKey(t, newEd25519Key(t)))
+	service2 := newTestJWTService(t)
 
 	claims, err := service2.ValidateToken(token)
 	assert.Error(t, err)
 	assert.Nil(t, claims)
 }
 
-func TestJWTService_RefreshAccessToken(t *testing.T) {
+func TestJWTService_TwoFactorToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service := newTestJWTService(t)
 	userID := uuid.New()
 
-	// Generate refresh token
-	refreshToken, err := service.GenerateRefreshToken(userID)
+	token, err := service.GenerateTwoFactorToken(userID)
 	require.NoError(t, err)
 
-	// Refresh access token
-	accessToken, err := service.RefreshAccessToken(refreshToken)
+	claims, err := service.ValidateTwoFactorToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
 
+	// A two-factor token is not an access token and vice versa
+	_, err = service.ValidateToken(token)
+	assert.Error(t, err)
+	access, err := service.GenerateAccessToken(userID, "test", nil)
 	require.NoError(t, err)
-	assert.NotEmpty(t, accessToken)
+	_, err = service.ValidateTwoFactorToken(access)
+	assert.Error(t, err)
 }
 
-func TestJWTService_RefreshAccessToken_InvalidToken(t *testing.T) {
+func TestJWTService_ImpersonationToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service := newTestJWTService(t)
+	userID, adminID := uuid.New(), uuid.New()
 
-	// Try to refresh with access token (should fail)
-	userID := uuid.New()
-	username := "test"
-	roles := []string{"admin"}
+	token, err := service.GenerateImpersonationToken(userID, "secretary", adminID, "admin")
+	require.NoError(t, err)
 
-	accessToken, err := service.GenerateAccessToken(userID, username, roles)
+	claims, err := service.ValidateToken(token)
 	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.Equal(t, "secretary", claims.Username)
+	assert.Equal(t, adminID, claims.ImpersonatorID)
+	assert.Equal(t, "admin", claims.ImpersonatorUsername)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), time.Unix(claims.Exp, 0), 5*time.Second)
 
-	// Try to use access token as refresh token
-	newAccessToken, err := service.RefreshAccessToken(accessToken)
-	assert.Error(t, err)
-	assert.Empty(t, newAccessToken)
+	// Regular access tokens carry no impersonator
+	access, err := service.GenerateAccessToken(userID, "secretary", nil)
+	require.NoError(t, err)
+	claims, err = service.ValidateToken(access)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, claims.ImpersonatorID)
+}
+
+func TestJWTService_SigningKeyFile(t *testing.T) {
+	for name, key := range map[string]crypto.Signer{"RS256": newRSAKey(t), "EdDSA": newEd25519Key(t)} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("JWT_SIGNING_KEY_FILE", writeTestKey(t, key))
+			service := newTestJWTService(t)
+
+			token, err := service.GenerateAccessToken(uuid.New(), "test", nil)
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, name, parsed.Method.Alg())
+
+			keys := service.VerificationKeys()
+			require.Len(t, keys, 1)
+			assert.Equal(t, keys[0].KeyID, parsed.Header["kid"])
+			assert.Equal(t, name, keys[0].Algorithm)
+			assert.Equal(t, "sig", keys[0].Use)
+
+			_, err = service.ValidateToken(token)
+			assert.NoError(t, err)
+		})
+	}
 }
 
-func TestJWTService_RefreshAccessToken_ExpiredToken(t *testing.T) {
-	os.Setenv("JWT_SECRET", "test-secret")
-	os.Setenv("JWT_REFRESH_TOKEN_EXPIRY", "1ms")
-	defer os.Unsetenv("JWT_SECRET")
-	defer os.Unsetenv("JWT_REFRESH_TOKEN_EXPIRY")
+func TestJWTService_KeyRotation(t *testing.T) {
+	oldKey, newKey := newEd25519Key(t), newRSAKey(t)
 
-	service := NewJWTService()
+	t.Setenv("JWT_SIGNING_KEY_FILE", writeTestKey(t, oldKey))
+	oldService := newTestJWTService(t)
 	userID := uuid.New()
+	oldToken, err := oldService.GenerateAccessToken(userID, "test", nil)
+	require.NoError(t, err)
+
+	// Promote the new key and keep accepting the old one
+	t.Setenv("JWT_SIGNING_KEY_FILE", writeTestKey(t, newKey))
+	t.Setenv("JWT_VERIFICATION_KEY_FILES", writeTestPublicKey(t, oldKey))
+	rotated := newTestJWTService(t)
 
-	// Generate refresh token
-	refreshToken, err := service.GenerateRefreshToken(userID)
+	claims, err := rotated.ValidateToken(oldToken)
 	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
 
-	// Wait for token to expire
-	time.Sleep(10 * time.Millisecond)
+	newToken, err := rotated.GenerateAccessToken(userID, "test", nil)
+	require.NoError(t, err)
+	_, err = oldService.ValidateToken(newToken)
+	assert.Error(t, err, "instances without the new key must not accept its tokens")
+
+	keys := rotated.VerificationKeys()
+	require.Len(t, keys, 2)
+	assert.Equal(t, "RSA", keys[0].KeyType, "current signing key is listed first")
+	assert.NotEmpty(t, keys[0].N)
+	assert.Equal(t, "OKP", keys[1].KeyType)
+	assert.Equal(t, "Ed25519", keys[1].Curve)
+
+	// Once the old key is dropped its tokens are rejected
+	t.Setenv("JWT_VERIFICATION_KEY_FILES", "")
+	_, err = newTestJWTService(t).ValidateToken(oldToken)
+	assert.Error(t, err)
+}
+
+func TestJWTService_RejectsForeignAlgorithms(t *testing.T) {
+	key := newRSAKey(t)
+	t.Setenv("JWT_SIGNING_KEY_FILE", writeTestKey(t, key))
+	service := newTestJWTService(t)
+	kid := service.VerificationKeys()[0].KeyID
 
-	// Try to refresh should fail
-	accessToken, err := service.RefreshAccessToken(refreshToken)
+	claims := jwt.MapClaims{
+		"user_id": uuid.New().String(),
+		"type":    "access",
+		"exp":     time.Now().Add(time.Minute).Unix(),
+	}
+
+	// HS256 with the public key as secret must not pass as an RSA token
+	publicDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	hmacToken.Header["kid"] = kid
+	signed, err := hmacToken.SignedString(publicDER)
+	require.NoError(t, err)
+	_, err = service.ValidateToken(signed)
 	assert.Error(t, err)
-	assert.Empty(t, accessToken)
+
+	// Tokens without a known kid are rejected
+	unknown := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	signed, err = unknown.SignedString(key)
+	require.NoError(t, err)
+	_, err = service.ValidateToken(signed)
+	assert.Error(t, err)
+}
+
+func TestNewJWTService_Configuration(t *testing.T) {
+	t.Run("production requires a signing key", func(t *testing.T) {
+		t.Setenv("APP_ENV", "production")
+		t.Setenv("JWT_SIGNING_KEY_FILE", "")
+		_, err := NewJWTService()
+		assert.Error(t, err)
+	})
+
+	t.Run("signing key must be private", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_KEY_FILE", writeTestPublicKey(t, newEd25519Key(t)))
+		_, err := NewJWTService()
+		assert.Error(t, err)
+	})
+
+	t.Run("short RSA keys are refused", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		t.Setenv("JWT_SIGNING_KEY_FILE", writeTestKey(t, key))
+		_, err = NewJWTService()
+		assert.Error(t, err)
+	})
+
+	t.Run("missing verification key file", func(t *testing.T) {
+		t.Setenv("JWT_VERIFICATION_KEY_FILES", filepath.Join(t.TempDir(), "missing.pem"))
+		_, err := NewJWTService()
+		assert.Error(t, err)
+	})
 }
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA-1 seed from RFC 6238 appendix B.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	// The RFC lists 8-digit values; a 6-digit code is their last six digits.
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, expected := range vectors {
+		code, err := TOTPCode(rfc6238Secret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestTOTPService_Validate(t *testing.T) {
+	svc := NewTOTPService()
+	secret, err := svc.GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	code, err := TOTPCode(secret, now)
+	require.NoError(t, err)
+
+	step, ok := svc.Validate(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/30, step)
+
+	// One step of drift is tolerated, two are not
+	_, ok = svc.Validate(secret, code, now.Add(30*time.Second))
+	assert.True(t, ok)
+	_, ok = svc.Validate(secret, code, now.Add(90*time.Second))
+	assert.False(t, ok)
+
+	_, ok = svc.Validate(secret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPService_ProvisioningURI(t *testing.T) {
+	t.Setenv("TOTP_ISSUER", "SIGAP Test")
+	uri := NewTOTPService().ProvisioningURI("admin", "ABCDEF")
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.True(t, strings.HasSuffix(parsed.Path, "SIGAP Test:admin"))
+	assert.Equal(t, "ABCDEF", parsed.Query().Get("secret"))
+	assert.Equal(t, "SIGAP Test", parsed.Query().Get("issuer"))
+}
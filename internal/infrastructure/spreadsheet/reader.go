@@ -0,0 +1,78 @@
+// Package spreadsheet reads the tabular file formats accepted by bulk imports.
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither CSV nor XLSX.
+var ErrUnsupportedFormat = errors.New("unsupported file format, use .csv or .xlsx")
+
+// ReadRows returns every row of a CSV file or of the first sheet of an XLSX workbook,
+// choosing the format from the file extension. XLSX cells are read unformatted, so
+// date cells arrive as Excel serial numbers.
+func ReadRows(filename string, r io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return readCSV(r)
+	case ".xlsx":
+		return readXLSX(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	// Spreadsheet applications prepend a BOM to UTF-8 CSV exports.
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv: %w", err)
+	}
+	return rows, nil
+}
+
+// detectDelimiter picks ';' when the header line uses it instead of ',',
+// as CSV exports from locales with a decimal comma do.
+func detectDelimiter(data []byte) rune {
+	header := data
+	if idx := bytes.IndexByte(data, '\n'); idx >= 0 {
+		header = data[:idx]
+	}
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(r, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx: %w", err)
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return [][]string{}, nil
+	}
+	return file.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+}
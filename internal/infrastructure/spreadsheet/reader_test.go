@@ -0,0 +1,44 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestReadRows_CSV(t *testing.T) {
+	t.Run("comma separated with BOM", func(t *testing.T) {
+		rows, err := ReadRows("students.CSV", strings.NewReader("\xef\xbb\xbfstudent_number,full_name\nS001, Ahmad\nS002\n"))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"student_number", "full_name"}, {"S001", "Ahmad"}, {"S002"}}, rows)
+	})
+
+	t.Run("semicolon separated", func(t *testing.T) {
+		rows, err := ReadRows("students.csv", strings.NewReader("student_number;full_name\nS001;Ahmad, Jr\n"))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"student_number", "full_name"}, {"S001", "Ahmad, Jr"}}, rows)
+	})
+}
+
+func TestReadRows_XLSX(t *testing.T) {
+	file := excelize.NewFile()
+	sheet := file.GetSheetName(0)
+	require.NoError(t, file.SetSheetRow(sheet, "A1", &[]interface{}{"student_number", "birth_date"}))
+	require.NoError(t, file.SetSheetRow(sheet, "A2", &[]interface{}{"S001", 40179}))
+
+	var buf bytes.Buffer
+	require.NoError(t, file.Write(&buf))
+
+	rows, err := ReadRows("students.xlsx", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"student_number", "birth_date"}, {"S001", "40179"}}, rows)
+}
+
+func TestReadRows_UnsupportedFormat(t *testing.T) {
+	_, err := ReadRows("students.xls", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
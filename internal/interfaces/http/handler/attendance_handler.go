@@ -15,11 +15,12 @@ import (
 // AttendanceHandler manages attendance endpoints.
 type AttendanceHandler struct {
 	attendanceUseCase *usecase.AttendanceUseCase
+	generatorUseCase  *usecase.AttendanceGeneratorUseCase
 }
 
 // NewAttendanceHandler constructs AttendanceHandler.
-func NewAttendanceHandler(attendanceUseCase *usecase.AttendanceUseCase) *AttendanceHandler {
-	return &AttendanceHandler{attendanceUseCase: attendanceUseCase}
+func NewAttendanceHandler(attendanceUseCase *usecase.AttendanceUseCase, generatorUseCase *usecase.AttendanceGeneratorUseCase) *AttendanceHandler {
+	return &AttendanceHandler{attendanceUseCase: attendanceUseCase, generatorUseCase: generatorUseCase}
 }
 
 // OpenSessions handles POST /api/attendance-sessions/open.
@@ -38,6 +39,23 @@ func (h *AttendanceHandler) OpenSessions(c *gin.Context) {
 	response.SuccessOK(c, gin.H{"status": "opened"}, "Attendance sessions opened")
 }
 
+// GenerateSessions handles POST /api/attendance-sessions/generate.
+func (h *AttendanceHandler) GenerateSessions(c *gin.Context) {
+	var req dto.GenerateAttendanceSessionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.generatorUseCase.GenerateSessions(c.Request.Context(), req)
+	if err != nil {
+		h.handleAttendanceError(c, err, "generate attendance sessions")
+		return
+	}
+
+	response.SuccessOK(c, resp, "Attendance sessions generated")
+}
+
 // SubmitStudentAttendance handles POST /api/attendance-sessions/:id/students.
 func (h *AttendanceHandler) SubmitStudentAttendance(c *gin.Context) {
 	sessionID, ok := parseAttendanceSessionID(c)
@@ -135,6 +153,8 @@ func (h *AttendanceHandler) handleAttendanceError(c *gin.Context, err error, act
 		response.ErrorNotFound(c, "Attendance session not found", err.Error())
 	case domainErrors.ErrAttendanceAlreadyLocked:
 		response.ErrorConflict(c, "Attendance session already locked", err.Error())
+	case domainErrors.ErrDormitoryAccessDenied:
+		response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 	default:
 		response.ErrorInternalServer(c, "Failed to "+action, err.Error())
 	}
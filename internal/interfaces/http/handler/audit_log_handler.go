@@ -1,36 +1,227 @@
 package handler
 
 import (
-	"strconv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
 	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
-// AuditLogHandler handles audit log read-only requests
+// AuditLogHandler handles audit log reads, exports, retention and integrity checks
 type AuditLogHandler struct {
-	useCase *usecase.AuditLogUseCase
+	useCase          *usecase.AuditLogUseCase
+	integrityUseCase *usecase.AuditIntegrityUseCase
+	retentionUseCase *usecase.AuditRetentionUseCase
 }
 
 // NewAuditLogHandler creates a new audit log handler
-func NewAuditLogHandler(useCase *usecase.AuditLogUseCase) *AuditLogHandler {
-	return &AuditLogHandler{useCase: useCase}
+func NewAuditLogHandler(useCase *usecase.AuditLogUseCase, integrityUseCase *usecase.AuditIntegrityUseCase, retentionUseCase *usecase.AuditRetentionUseCase) *AuditLogHandler {
+	return &AuditLogHandler{useCase: useCase, integrityUseCase: integrityUseCase, retentionUseCase: retentionUseCase}
 }
 
-// ListAuditLogs lists audit logs with pagination and simple filters
+// ListAuditLogs lists audit logs with pagination and filters
 func (h *AuditLogHandler) ListAuditLogs(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-	resource := c.Query("resource")
-	action := c.Query("action")
-	actorUsername := c.Query("actor_username")
+	var req dto.ListAuditLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
 
-	resp, err := h.useCase.ListAuditLogs(c.Request.Context(), page, pageSize, resource, action, actorUsername)
+	resp, err := h.useCase.ListAuditLogs(c.Request.Context(), req)
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to list audit logs", err.Error())
+		switch err {
+		case domainErrors.ErrBadRequest:
+			response.ErrorBadRequest(c, "Invalid audit log filters", "from and to must be RFC3339 timestamps or YYYY-MM-DD dates with from before to")
+		default:
+			response.ErrorInternalServer(c, "Failed to list audit logs", err.Error())
+		}
 		return
 	}
 
 	response.SuccessOK(c, resp, "Audit logs retrieved successfully")
 }
+
+// ExportAuditLogs streams every filtered entry, oldest first, as NDJSON or CSV
+func (h *AuditLogHandler) ExportAuditLogs(c *gin.Context) {
+	var req dto.ExportAuditLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+	if req.Format == "" {
+		req.Format = usecase.AuditExportNDJSON
+	}
+
+	w := &streamWriter{c: c, start: func() {
+		contentType := "application/x-ndjson"
+		if req.Format == usecase.AuditExportCSV {
+			contentType = "text/csv"
+		}
+		filename := fmt.Sprintf("audit-logs-%s.%s", time.Now().Format("20060102-150405"), req.Format)
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		c.Status(http.StatusOK)
+	}}
+	err := h.useCase.ExportAuditLogs(c.Request.Context(), req, w)
+	switch {
+	case err == nil:
+		if !w.started {
+			w.Write(nil)
+		}
+	case w.started:
+		// Headers are gone; cut the stream short so the client sees an incomplete download
+		_ = c.Error(err)
+		c.Abort()
+	case err == domainErrors.ErrBadRequest:
+		response.ErrorBadRequest(c, "Invalid audit log filters", "from and to must be RFC3339 timestamps or YYYY-MM-DD dates with from before to")
+	default:
+		response.ErrorInternalServer(c, "Failed to export audit logs", err.Error())
+	}
+}
+
+// ListArchives lists the chain ranges moved out of the audit_logs table
+func (h *AuditLogHandler) ListArchives(c *gin.Context) {
+	resp, err := h.retentionUseCase.ListArchives(c.Request.Context())
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to list audit archives", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "Audit archives retrieved successfully")
+}
+
+// ArchiveAuditLogs moves entries older than the retention period into an archive file
+func (h *AuditLogHandler) ArchiveAuditLogs(c *gin.Context) {
+	var req dto.ArchiveAuditLogsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.retentionUseCase.ArchiveEntries(c.Request.Context(), req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrAuditRetentionDisabled:
+			response.ErrorBadRequest(c, "older_than_months is required when AUDIT_RETENTION_MONTHS is not set")
+		case domainErrors.ErrAuditChainBroken:
+			response.ErrorConflict(c, "Audit chain is broken; run GET /api/audit-logs/verify for details")
+		default:
+			response.ErrorInternalServer(c, "Failed to archive audit logs", err.Error())
+		}
+		return
+	}
+	if resp == nil {
+		response.SuccessOK(c, nil, "No audit entries old enough to archive")
+		return
+	}
+
+	response.SuccessCreated(c, resp, "Audit logs archived successfully")
+}
+
+// GetEntityHistory returns the audit timeline of one record
+func (h *AuditLogHandler) GetEntityHistory(c *gin.Context) {
+	resource := c.Param("resource")
+	targetID := c.Param("id")
+
+	resp, err := h.useCase.GetEntityHistory(c.Request.Context(), resource, targetID)
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to get entity history", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "Entity history retrieved successfully")
+}
+
+// GetWriterStats reports the audit writer counters of this replica
+func (h *AuditLogHandler) GetWriterStats(c *gin.Context) {
+	response.SuccessOK(c, h.useCase.GetWriterStats(c.Request.Context()), "Audit writer stats retrieved successfully")
+}
+
+// VerifyChain walks the audit hash chain and reports the first broken link
+func (h *AuditLogHandler) VerifyChain(c *gin.Context) {
+	resp, err := h.integrityUseCase.VerifyChain(c.Request.Context())
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to verify audit chain", err.Error())
+		return
+	}
+
+	message := "Audit chain is intact"
+	if !resp.Valid {
+		message = "Audit chain is broken"
+	}
+	response.SuccessOK(c, resp, message)
+}
+
+// ListCheckpoints lists signed audit checkpoints
+func (h *AuditLogHandler) ListCheckpoints(c *gin.Context) {
+	resp, err := h.integrityUseCase.ListCheckpoints(c.Request.Context())
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to list audit checkpoints", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "Audit checkpoints retrieved successfully")
+}
+
+// CreateCheckpoint signs the current chain head
+func (h *AuditLogHandler) CreateCheckpoint(c *gin.Context) {
+	resp, err := h.integrityUseCase.CreateCheckpoint(c.Request.Context())
+	if err != nil {
+		switch err {
+		case domainErrors.ErrAuditLogEmpty:
+			response.ErrorBadRequest(c, "Audit log is empty")
+		case domainErrors.ErrAuditChainBroken:
+			response.ErrorConflict(c, "Audit chain is broken; run GET /api/audit-logs/verify for details")
+		default:
+			response.ErrorInternalServer(c, "Failed to create audit checkpoint", err.Error())
+		}
+		return
+	}
+
+	response.SuccessCreated(c, resp, "Audit checkpoint created successfully")
+}
+
+// ExportCheckpoints downloads every checkpoint with its public key as JSON
+func (h *AuditLogHandler) ExportCheckpoints(c *gin.Context) {
+	export, err := h.integrityUseCase.ExportCheckpoints(c.Request.Context())
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to export audit checkpoints", err.Error())
+		return
+	}
+	body, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to export audit checkpoints", err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("audit-checkpoints-%s.json", time.Now().Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/json", body)
+}
+
+// streamWriter writes a download straight to the client. start sets the
+// headers on the first write, so errors found before any output can still be
+// answered with a JSON error.
+type streamWriter struct {
+	c       *gin.Context
+	start   func()
+	started bool
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.start()
+	}
+	n, err := w.c.Writer.Write(p)
+	w.c.Writer.Flush()
+	return n, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
@@ -13,7 +14,10 @@ import (
 type AuthUseCase interface {
 	Register(ctx context.Context, req dto.RegisterRequest) (*dto.AuthResponse, error)
 	Login(ctx context.Context, req dto.LoginRequest) (*dto.AuthResponse, error)
+	VerifyTwoFactor(ctx context.Context, req dto.LoginTwoFactorRequest) (*dto.AuthResponse, error)
 	RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (*dto.AuthResponse, error)
+	Logout(ctx context.Context, req dto.LogoutRequest) error
+	ChangePassword(ctx context.Context, userID uuid.UUID, req dto.ChangePasswordRequest) (*dto.AuthResponse, error)
 }
 
 // AuthHandler handles authentication requests
@@ -37,6 +41,7 @@ func NewAuthHandler(authUseCase AuthUseCase) *AuthHandler {
 // @Param request body dto.RegisterRequest true "Registration request"
 // @Success 201 {object} dto.AuthResponse
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 409 {object} map[string]string
 // @Router /api/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -51,6 +56,8 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrUserAlreadyExists:
 			response.ErrorConflict(c, "User already exists")
+		case domainErrors.ErrRegistrationClosed:
+			response.ErrorForbidden(c, "Self-registration is disabled", err.Error())
 		default:
 			response.ErrorInternalServer(c, "Failed to register user", err.Error())
 		}
@@ -62,7 +69,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login handles user login
 // @Summary Login user
-// @Description Authenticate user and return tokens
+// @Description Authenticate user and return tokens, or a two_factor_token when the user has 2FA enabled
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -70,6 +77,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success 200 {object} dto.AuthResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /api/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
@@ -83,12 +91,57 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrInvalidCredentials, domainErrors.ErrUserInactive:
 			response.ErrorUnauthorized(c, "Invalid credentials")
+		case domainErrors.ErrLoginLocked:
+			response.ErrorTooManyRequests(c, "Too many failed login attempts, try again later")
 		default:
 			response.ErrorInternalServer(c, "Failed to login", err.Error())
 		}
 		return
 	}
 
+	if resp.TwoFactorRequired {
+		response.SuccessOK(c, resp, "Two-factor code required")
+		return
+	}
+	response.SuccessOK(c, resp, "Login successful")
+}
+
+// VerifyTwoFactor completes a two-step login
+// @Summary Complete login with a two-factor code
+// @Description Exchange the two_factor_token from login and a TOTP or recovery code for tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginTwoFactorRequest true "Two-factor login request"
+// @Success 200 {object} dto.AuthResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /api/auth/login/2fa [post]
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req dto.LoginTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.authUseCase.VerifyTwoFactor(c.Request.Context(), req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrInvalidToken, domainErrors.ErrTokenExpired:
+			response.ErrorUnauthorized(c, "Invalid or expired two-factor token")
+		case domainErrors.ErrTwoFactorCodeInvalid:
+			response.ErrorUnauthorized(c, "Invalid two-factor code")
+		case domainErrors.ErrUserInactive, domainErrors.ErrTwoFactorNotEnabled:
+			response.ErrorUnauthorized(c, "Invalid credentials")
+		case domainErrors.ErrLoginLocked:
+			response.ErrorTooManyRequests(c, "Too many failed login attempts, try again later")
+		default:
+			response.ErrorInternalServer(c, "Failed to verify two-factor code", err.Error())
+		}
+		return
+	}
+
 	response.SuccessOK(c, resp, "Login successful")
 }
 
@@ -113,7 +166,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	resp, err := h.authUseCase.RefreshToken(c.Request.Context(), req)
 	if err != nil {
 		switch err {
-		case domainErrors.ErrInvalidToken, domainErrors.ErrTokenExpired:
+		case domainErrors.ErrInvalidToken, domainErrors.ErrTokenExpired, domainErrors.ErrTokenReused, domainErrors.ErrUserInactive:
 			response.ErrorUnauthorized(c, "Invalid or expired token")
 		default:
 			response.ErrorInternalServer(c, "Failed to refresh token", err.Error())
@@ -123,3 +176,77 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	response.SuccessOK(c, resp, "Token refreshed successfully")
 }
+
+// Logout handles refresh token revocation
+// @Summary Logout
+// @Description Revoke the refresh token session (and its rotation family)
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LogoutRequest true "Logout request"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /api/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.authUseCase.Logout(c.Request.Context(), req); err != nil {
+		switch err {
+		case domainErrors.ErrInvalidToken:
+			response.ErrorUnauthorized(c, "Invalid token")
+		default:
+			response.ErrorInternalServer(c, "Failed to logout", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, nil, "Logout successful")
+}
+
+// ChangePassword handles a password change by the current user
+// @Summary Change own password
+// @Description Change the current user's password; all sessions are revoked and a new token pair is returned
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ChangePasswordRequest true "Change password request"
+// @Success 200 {object} dto.AuthResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /api/me/password [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	userID, ok := c.Get("user_id")
+	if !ok {
+		response.ErrorUnauthorized(c, "User not found in context")
+		return
+	}
+
+	resp, err := h.authUseCase.ChangePassword(c.Request.Context(), userID.(uuid.UUID), req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrCurrentPasswordInvalid:
+			response.ErrorBadRequest(c, "Current password is incorrect")
+		case domainErrors.ErrPasswordUnchanged:
+			response.ErrorBadRequest(c, "New password must differ from the current password")
+		case domainErrors.ErrUserNotFound, domainErrors.ErrUserInactive:
+			response.ErrorUnauthorized(c, "User not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to change password", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "Password changed successfully")
+}
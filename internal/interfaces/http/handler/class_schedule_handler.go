@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -39,6 +40,23 @@ func (h *ClassScheduleHandler) CreateClassSchedule(c *gin.Context) {
 	response.SuccessCreated(c, schedule, "Class schedule created successfully")
 }
 
+// BulkCreateClassSchedules handles POST /api/class-schedules/bulk.
+func (h *ClassScheduleHandler) BulkCreateClassSchedules(c *gin.Context) {
+	var req dto.BulkCreateClassSchedulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	schedules, err := h.classScheduleUseCase.BulkCreateClassSchedules(c.Request.Context(), req)
+	if err != nil {
+		h.handleScheduleError(c, err, "create")
+		return
+	}
+
+	response.SuccessCreated(c, schedules, "Class schedules created successfully")
+}
+
 // GetClassSchedule handles GET /api/class-schedules/:id.
 func (h *ClassScheduleHandler) GetClassSchedule(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -86,9 +104,12 @@ func (h *ClassScheduleHandler) ListClassSchedules(c *gin.Context) {
 		isActive,
 	)
 	if err != nil {
-		if err == domainErrors.ErrBadRequest {
+		switch err {
+		case domainErrors.ErrBadRequest:
 			response.ErrorBadRequest(c, "Invalid filters", err.Error())
-		} else {
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
+		default:
 			response.ErrorInternalServer(c, "Failed to list class schedules", err.Error())
 		}
 		return
@@ -141,6 +162,12 @@ func (h *ClassScheduleHandler) DeleteClassSchedule(c *gin.Context) {
 }
 
 func (h *ClassScheduleHandler) handleScheduleError(c *gin.Context, err error, action string) {
+	var conflictErr *usecase.ClassScheduleConflictError
+	if errors.As(err, &conflictErr) {
+		response.ErrorConflictWithData(c, "Class schedule conflict", dto.ClassScheduleConflictResponse{Conflicts: conflictErr.Conflicts})
+		return
+	}
+
 	switch err {
 	case domainErrors.ErrClassNotFound:
 		response.ErrorNotFound(c, "Class not found")
@@ -148,6 +175,8 @@ func (h *ClassScheduleHandler) handleScheduleError(c *gin.Context, err error, ac
 		response.ErrorNotFound(c, "Teacher not found")
 	case domainErrors.ErrDormitoryNotFound:
 		response.ErrorNotFound(c, "Dormitory not found")
+	case domainErrors.ErrDormitoryAccessDenied:
+		response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 	case domainErrors.ErrSubjectNotFound:
 		response.ErrorNotFound(c, "Subject not found")
 	case domainErrors.ErrScheduleSlotNotFound:
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// HolidayHandler exposes the holiday calendar.
+type HolidayHandler struct {
+	holidayUseCase *usecase.HolidayUseCase
+}
+
+// NewHolidayHandler creates a new HolidayHandler instance.
+func NewHolidayHandler(holidayUseCase *usecase.HolidayUseCase) *HolidayHandler {
+	return &HolidayHandler{holidayUseCase: holidayUseCase}
+}
+
+// CreateHoliday handles POST /api/holidays.
+func (h *HolidayHandler) CreateHoliday(c *gin.Context) {
+	var req dto.CreateHolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	result, err := h.holidayUseCase.CreateHoliday(c.Request.Context(), req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrBadRequest:
+			response.ErrorBadRequest(c, "Invalid holiday data", err.Error())
+		case domainErrors.ErrDormitoryNotFound:
+			response.ErrorNotFound(c, "Dormitory not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to create holiday", err.Error())
+		}
+		return
+	}
+
+	response.SuccessCreated(c, result, "Holiday created successfully")
+}
+
+// ListHolidays handles GET /api/holidays?start_date=...&end_date=...
+func (h *HolidayHandler) ListHolidays(c *gin.Context) {
+	result, err := h.holidayUseCase.ListHolidays(
+		c.Request.Context(),
+		c.Query("start_date"),
+		c.Query("end_date"),
+		c.Query("dormitory_id"),
+	)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrBadRequest:
+			response.ErrorBadRequest(c, "Invalid filters", "start_date and end_date (YYYY-MM-DD) are required")
+		default:
+			response.ErrorInternalServer(c, "Failed to list holidays", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, result, "Holidays retrieved successfully")
+}
+
+// DeleteHoliday handles DELETE /api/holidays/:id.
+func (h *HolidayHandler) DeleteHoliday(c *gin.Context) {
+	holidayID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid holiday ID", err.Error())
+		return
+	}
+
+	if err := h.holidayUseCase.DeleteHoliday(c.Request.Context(), holidayID); err != nil {
+		switch err {
+		case domainErrors.ErrHolidayNotFound:
+			response.ErrorNotFound(c, "Holiday not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to delete holiday", err.Error())
+		}
+		return
+	}
+
+	response.SuccessNoContent(c)
+}
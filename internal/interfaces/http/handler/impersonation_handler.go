@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// ImpersonationHandler lets admins act as another user.
+type ImpersonationHandler struct {
+	impersonationUseCase *usecase.ImpersonationUseCase
+}
+
+// NewImpersonationHandler creates a new ImpersonationHandler instance.
+func NewImpersonationHandler(impersonationUseCase *usecase.ImpersonationUseCase) *ImpersonationHandler {
+	return &ImpersonationHandler{impersonationUseCase: impersonationUseCase}
+}
+
+// Impersonate handles POST /api/admin/impersonate/:user_id.
+func (h *ImpersonationHandler) Impersonate(c *gin.Context) {
+	targetID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	userVal, exists := c.Get("user")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not found in context")
+		return
+	}
+	admin, ok := userVal.(*entity.User)
+	if !ok {
+		response.ErrorInternalServer(c, "Invalid user type")
+		return
+	}
+
+	resp, err := h.impersonationUseCase.Impersonate(c.Request.Context(), admin, targetID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrUserNotFound:
+			response.ErrorNotFound(c, "User not found")
+		case domainErrors.ErrImpersonationNotAllowed:
+			response.ErrorForbidden(c, "Cannot impersonate this user", err.Error())
+		default:
+			response.ErrorInternalServer(c, "Failed to impersonate user", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "Impersonation token issued")
+}
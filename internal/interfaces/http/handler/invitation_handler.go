@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// InvitationHandler manages invite-based onboarding.
+type InvitationHandler struct {
+	invitationUseCase *usecase.InvitationUseCase
+}
+
+// NewInvitationHandler creates a new InvitationHandler instance.
+func NewInvitationHandler(invitationUseCase *usecase.InvitationUseCase) *InvitationHandler {
+	return &InvitationHandler{invitationUseCase: invitationUseCase}
+}
+
+// CreateInvitation handles POST /api/invitations.
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	var req dto.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	userVal, exists := c.Get("user")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not found in context")
+		return
+	}
+	inviter, ok := userVal.(*entity.User)
+	if !ok {
+		response.ErrorInternalServer(c, "Invalid user type")
+		return
+	}
+
+	resp, err := h.invitationUseCase.CreateInvitation(c.Request.Context(), inviter, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create invitation")
+		return
+	}
+
+	response.SuccessCreated(c, resp, "Invitation created successfully; share the token now, it will not be shown again")
+}
+
+// ListInvitations handles GET /api/invitations.
+func (h *InvitationHandler) ListInvitations(c *gin.Context) {
+	resp, err := h.invitationUseCase.ListPendingInvitations(c.Request.Context())
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to list invitations", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "Invitations retrieved successfully")
+}
+
+// RevokeInvitation handles DELETE /api/invitations/:id.
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid invitation ID", err.Error())
+		return
+	}
+
+	if err := h.invitationUseCase.RevokeInvitation(c.Request.Context(), id); err != nil {
+		h.handleError(c, err, "Failed to revoke invitation")
+		return
+	}
+
+	response.SuccessOK(c, nil, "Invitation revoked successfully")
+}
+
+// AcceptInvitation handles POST /api/auth/invitations/accept.
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	var req dto.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.invitationUseCase.AcceptInvitation(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to accept invitation")
+		return
+	}
+
+	response.SuccessCreated(c, resp, "Invitation accepted; you can now log in")
+}
+
+func (h *InvitationHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case domainErrors.ErrUserAlreadyExists:
+		response.ErrorConflict(c, "User already exists")
+	case domainErrors.ErrInvitationPending:
+		response.ErrorConflict(c, "A pending invitation already exists for this username")
+	case domainErrors.ErrRoleNotFound:
+		response.ErrorBadRequest(c, "Role not found")
+	case domainErrors.ErrDormitoryNotFound:
+		response.ErrorBadRequest(c, "Dormitory not found")
+	case domainErrors.ErrInvitationNotAllowed:
+		response.ErrorForbidden(c, "Cannot invite with this role or dormitory", err.Error())
+	case domainErrors.ErrInvitationNotFound:
+		response.ErrorNotFound(c, "Invitation not found")
+	case domainErrors.ErrInvitationInvalid:
+		response.ErrorBadRequest(c, "Invitation is invalid, used or expired")
+	default:
+		response.ErrorInternalServer(c, message, err.Error())
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// JobRunHandler exposes the scheduler's run history to administrators.
+type JobRunHandler struct {
+	jobRunUseCase *usecase.JobRunUseCase
+}
+
+// NewJobRunHandler creates a new JobRunHandler instance.
+func NewJobRunHandler(jobRunUseCase *usecase.JobRunUseCase) *JobRunHandler {
+	return &JobRunHandler{jobRunUseCase: jobRunUseCase}
+}
+
+// ListJobRuns handles GET /api/job-runs.
+func (h *JobRunHandler) ListJobRuns(c *gin.Context) {
+	var req dto.ListJobRunsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	result, err := h.jobRunUseCase.ListJobRuns(c.Request.Context(), req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrBadRequest:
+			response.ErrorBadRequest(c, "Invalid filters", "status must be one of running, succeeded, failed")
+		default:
+			response.ErrorInternalServer(c, "Failed to list job runs", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, result, "Job runs retrieved successfully")
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// jwksMaxAge is how long clients may cache the key set. It should stay well
+// below the overlap during which a retired key is still accepted.
+const jwksMaxAge = "public, max-age=300"
+
+// JWKSHandler publishes the token verification keys for other services.
+type JWKSHandler struct {
+	tokenService service.TokenService
+}
+
+// NewJWKSHandler creates a new JWKSHandler instance.
+func NewJWKSHandler(tokenService service.TokenService) *JWKSHandler {
+	return &JWKSHandler{tokenService: tokenService}
+}
+
+// GetJWKS handles GET /.well-known/jwks.json. The body is a bare JWK set
+// (RFC 7517) rather than the usual response envelope so standard JWT
+// libraries can consume it directly.
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", jwksMaxAge)
+	c.JSON(http.StatusOK, gin.H{"keys": h.tokenService.VerificationKeys()})
+}
@@ -99,6 +99,8 @@ func (h *LeavePermitHandler) handleLeavePermitError(c *gin.Context, err error, a
 	switch err {
 	case domainErrors.ErrStudentNotFound:
 		response.ErrorNotFound(c, "Student not found")
+	case domainErrors.ErrDormitoryAccessDenied:
+		response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 	case domainErrors.ErrLeavePermitNotFound:
 		response.ErrorNotFound(c, "Leave permit not found")
 	case domainErrors.ErrLeavePermitConflict:
@@ -175,6 +177,8 @@ func (h *HealthStatusHandler) handleHealthStatusError(c *gin.Context, err error,
 	switch err {
 	case domainErrors.ErrStudentNotFound:
 		response.ErrorNotFound(c, "Student not found")
+	case domainErrors.ErrDormitoryAccessDenied:
+		response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 	case domainErrors.ErrHealthStatusNotFound:
 		response.ErrorNotFound(c, "Health status not found")
 	case domainErrors.ErrHealthStatusActive:
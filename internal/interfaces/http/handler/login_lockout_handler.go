@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// LoginLockoutHandler lets administrators review and lift login lockouts.
+type LoginLockoutHandler struct {
+	lockoutUseCase *usecase.LoginLockoutUseCase
+}
+
+// NewLoginLockoutHandler creates a new LoginLockoutHandler instance.
+func NewLoginLockoutHandler(lockoutUseCase *usecase.LoginLockoutUseCase) *LoginLockoutHandler {
+	return &LoginLockoutHandler{lockoutUseCase: lockoutUseCase}
+}
+
+// ListLoginLockouts handles GET /api/login-lockouts.
+func (h *LoginLockoutHandler) ListLoginLockouts(c *gin.Context) {
+	result, err := h.lockoutUseCase.ListLockouts(c.Request.Context())
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to list login lockouts", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, result, "Login lockouts retrieved successfully")
+}
+
+// UnlockLogin handles DELETE /api/login-lockouts/:id.
+func (h *LoginLockoutHandler) UnlockLogin(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid lockout ID", err.Error())
+		return
+	}
+
+	if err := h.lockoutUseCase.Unlock(c.Request.Context(), id); err != nil {
+		switch err {
+		case domainErrors.ErrLoginThrottleNotFound:
+			response.ErrorNotFound(c, "Login lockout not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to unlock login", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, nil, "Login unlocked successfully")
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
 )
@@ -35,3 +36,24 @@ func (m *MockAuthUseCase) RefreshToken(ctx context.Context, req dto.RefreshToken
 	}
 	return args.Get(0).(*dto.AuthResponse), args.Error(1)
 }
+
+func (m *MockAuthUseCase) Logout(ctx context.Context, req dto.LogoutRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) ChangePassword(ctx context.Context, userID uuid.UUID, req dto.ChangePasswordRequest) (*dto.AuthResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthUseCase) VerifyTwoFactor(ctx context.Context, req dto.LoginTwoFactorRequest) (*dto.AuthResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AuthResponse), args.Error(1)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
@@ -33,3 +34,26 @@ func (h *PermissionHandler) ListPermissions(c *gin.Context) {
 
 	response.SuccessOK(c, resp, "Permissions retrieved successfully")
 }
+
+// MyPermissions returns the effective permissions of the current user
+func (h *PermissionHandler) MyPermissions(c *gin.Context) {
+	userVal, exists := c.Get("user")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not found in context")
+		return
+	}
+
+	userEntity, ok := userVal.(*entity.User)
+	if !ok {
+		response.ErrorInternalServer(c, "Invalid user type")
+		return
+	}
+
+	resp, err := h.permissionUseCase.GetEffectivePermissions(c.Request.Context(), userEntity)
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to get permissions", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "Permissions retrieved successfully")
+}
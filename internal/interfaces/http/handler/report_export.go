@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/export"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// reportFormat resolves the requested output format from the format query parameter,
+// falling back to the Accept header. It writes a 400 response for unknown formats.
+func reportFormat(c *gin.Context) (export.Format, bool) {
+	if value := c.Query("format"); value != "" {
+		format, err := export.ParseFormat(value)
+		if err != nil {
+			response.ErrorBadRequest(c, "Invalid report format", err.Error())
+			return "", false
+		}
+		return format, true
+	}
+	return export.FormatFromAccept(c.GetHeader("Accept")), true
+}
+
+// writeReportFile renders table as a downloadable attachment named after the report.
+func writeReportFile(c *gin.Context, format export.Format, name string, table export.Table) {
+	var buf bytes.Buffer
+	if err := export.Write(&buf, format, table); err != nil {
+		response.ErrorInternalServer(c, "Failed to render report", err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), format.Extension())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, format.ContentType(), buf.Bytes())
+}
+
+// reportLabel prefers the resolved name, then the raw ID, then a dash.
+func reportLabel(name string, id *string) string {
+	if name != "" {
+		return name
+	}
+	if id != nil {
+		return *id
+	}
+	return "-"
+}
+
+func reportOptional(value *string) string {
+	if value == nil {
+		return "-"
+	}
+	return *value
+}
+
+func studentAttendanceTable(report *dto.StudentAttendanceReportResponse) export.Table {
+	rows := make([][]interface{}, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		rows = append(rows, []interface{}{
+			reportLabel(row.DormitoryName, row.DormitoryID),
+			reportLabel(row.ClassName, row.ClassID),
+			reportLabel(row.FanName, row.FanID),
+			row.Total, row.Present, row.Absent, row.Permit, row.Sick,
+		})
+	}
+	return export.Table{
+		Title:       "Student Attendance Report " + report.Filters.Date,
+		Columns:     []string{"Dormitory", "Class", "Fan", "Total", "Present", "Absent", "Permit", "Sick"},
+		Rows:        rows,
+		GeneratedAt: report.GeneratedAt,
+	}
+}
+
+func teacherAttendanceTable(report *dto.TeacherAttendanceReportResponse) export.Table {
+	rows := make([][]interface{}, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		rows = append(rows, []interface{}{
+			reportLabel(row.TeacherName, &row.TeacherID),
+			row.Total, row.Present, row.Absent,
+		})
+	}
+	return export.Table{
+		Title:       "Teacher Attendance Report " + report.Filters.Date,
+		Columns:     []string{"Teacher", "Total", "Present", "Absent"},
+		Rows:        rows,
+		GeneratedAt: report.GeneratedAt,
+	}
+}
+
+func leavePermitTable(report *dto.LeavePermitReportResponse) export.Table {
+	rows := make([][]interface{}, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		rows = append(rows, []interface{}{
+			reportLabel(row.DormitoryName, row.DormitoryID),
+			row.Type, row.Status, row.Total,
+		})
+	}
+	return export.Table{
+		Title:       "Leave Permit Report",
+		Columns:     []string{"Dormitory", "Type", "Status", "Total"},
+		Rows:        rows,
+		GeneratedAt: report.GeneratedAt,
+	}
+}
+
+func healthStatusTable(report *dto.HealthStatusReportResponse) export.Table {
+	rows := make([][]interface{}, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		rows = append(rows, []interface{}{
+			reportLabel(row.DormitoryName, row.DormitoryID),
+			row.Status, row.Total, row.Consecutive,
+		})
+	}
+	return export.Table{
+		Title:       "Health Status Report",
+		Columns:     []string{"Dormitory", "Status", "Total", "Consecutive"},
+		Rows:        rows,
+		GeneratedAt: report.GeneratedAt,
+	}
+}
+
+func sksTable(report *dto.SKSReportResponse) export.Table {
+	rows := make([][]interface{}, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		var average interface{} = "-"
+		if row.Average != nil {
+			average = *row.Average
+		}
+		rows = append(rows, []interface{}{
+			reportLabel(row.FanName, row.FanID),
+			reportLabel(row.SKSName, row.SKSID),
+			row.Total, row.Passed, row.Failed, average,
+		})
+	}
+	return export.Table{
+		Title:       "SKS Report",
+		Columns:     []string{"Fan", "SKS", "Total", "Passed", "Failed", "Average Score"},
+		Rows:        rows,
+		GeneratedAt: report.GeneratedAt,
+	}
+}
+
+func mutationTable(report *dto.MutationReportResponse) export.Table {
+	rows := make([][]interface{}, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		rows = append(rows, []interface{}{
+			reportLabel(row.StudentName, &row.StudentID),
+			reportLabel(row.FromDormName, row.FromDormID),
+			reportLabel(row.ToDormName, row.ToDormID),
+			reportLabel(row.FromClassName, row.FromClassID),
+			reportLabel(row.ToClassName, row.ToClassID),
+			row.StartDate,
+			reportOptional(row.EndDate),
+		})
+	}
+	return export.Table{
+		Title:       "Student Mutation Report",
+		Columns:     []string{"Student", "From Dormitory", "To Dormitory", "From Class", "To Class", "Start Date", "End Date"},
+		Rows:        rows,
+		GeneratedAt: report.GeneratedAt,
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
 	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/export"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
-// ReportHandler handles read-only reporting endpoints.
+// ReportHandler handles read-only reporting endpoints. Every report can also be
+// downloaded as CSV, XLSX or PDF via the format query parameter or the Accept header.
 type ReportHandler struct {
 	reportUseCase *usecase.ReportUseCase
 }
@@ -24,10 +27,19 @@ func (h *ReportHandler) GetStudentAttendanceReport(c *gin.Context) {
 		response.ErrorValidation(c, err)
 		return
 	}
+	format, ok := reportFormat(c)
+	if !ok {
+		return
+	}
 
 	report, err := h.reportUseCase.GetStudentAttendanceReport(c.Request.Context(), req)
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to fetch student attendance report", err.Error())
+		handleReportError(c, err, "Failed to fetch student attendance report")
+		return
+	}
+
+	if format != export.FormatJSON {
+		writeReportFile(c, format, "student-attendance-report", studentAttendanceTable(report))
 		return
 	}
 
@@ -41,10 +53,19 @@ func (h *ReportHandler) GetTeacherAttendanceReport(c *gin.Context) {
 		response.ErrorValidation(c, err)
 		return
 	}
+	format, ok := reportFormat(c)
+	if !ok {
+		return
+	}
 
 	report, err := h.reportUseCase.GetTeacherAttendanceReport(c.Request.Context(), req)
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to fetch teacher attendance report", err.Error())
+		handleReportError(c, err, "Failed to fetch teacher attendance report")
+		return
+	}
+
+	if format != export.FormatJSON {
+		writeReportFile(c, format, "teacher-attendance-report", teacherAttendanceTable(report))
 		return
 	}
 
@@ -58,10 +79,19 @@ func (h *ReportHandler) GetLeavePermitReport(c *gin.Context) {
 		response.ErrorValidation(c, err)
 		return
 	}
+	format, ok := reportFormat(c)
+	if !ok {
+		return
+	}
 
 	report, err := h.reportUseCase.GetLeavePermitReport(c.Request.Context(), req)
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to fetch leave permit report", err.Error())
+		handleReportError(c, err, "Failed to fetch leave permit report")
+		return
+	}
+
+	if format != export.FormatJSON {
+		writeReportFile(c, format, "leave-permit-report", leavePermitTable(report))
 		return
 	}
 
@@ -75,10 +105,19 @@ func (h *ReportHandler) GetHealthStatusReport(c *gin.Context) {
 		response.ErrorValidation(c, err)
 		return
 	}
+	format, ok := reportFormat(c)
+	if !ok {
+		return
+	}
 
 	report, err := h.reportUseCase.GetHealthStatusReport(c.Request.Context(), req)
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to fetch health status report", err.Error())
+		handleReportError(c, err, "Failed to fetch health status report")
+		return
+	}
+
+	if format != export.FormatJSON {
+		writeReportFile(c, format, "health-status-report", healthStatusTable(report))
 		return
 	}
 
@@ -92,10 +131,19 @@ func (h *ReportHandler) GetSKSReport(c *gin.Context) {
 		response.ErrorValidation(c, err)
 		return
 	}
+	format, ok := reportFormat(c)
+	if !ok {
+		return
+	}
 
 	report, err := h.reportUseCase.GetSKSReport(c.Request.Context(), req)
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to fetch SKS report", err.Error())
+		handleReportError(c, err, "Failed to fetch SKS report")
+		return
+	}
+
+	if format != export.FormatJSON {
+		writeReportFile(c, format, "sks-report", sksTable(report))
 		return
 	}
 
@@ -109,12 +157,30 @@ func (h *ReportHandler) GetMutationReport(c *gin.Context) {
 		response.ErrorValidation(c, err)
 		return
 	}
+	format, ok := reportFormat(c)
+	if !ok {
+		return
+	}
 
 	report, err := h.reportUseCase.GetMutationReport(c.Request.Context(), req)
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to fetch mutation report", err.Error())
+		handleReportError(c, err, "Failed to fetch mutation report")
+		return
+	}
+
+	if format != export.FormatJSON {
+		writeReportFile(c, format, "mutation-report", mutationTable(report))
 		return
 	}
 
 	response.SuccessOK(c, report, "Mutation report retrieved successfully")
 }
+
+func handleReportError(c *gin.Context, err error, message string) {
+	switch err {
+	case domainErrors.ErrDormitoryAccessDenied:
+		response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
+	default:
+		response.ErrorInternalServer(c, message, err.Error())
+	}
+}
@@ -35,6 +35,8 @@ func (h *ScheduleSlotHandler) CreateScheduleSlot(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrDormitoryNotFound:
 			response.ErrorNotFound(c, "Dormitory not found")
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 		case domainErrors.ErrScheduleSlotConflict:
 			response.ErrorConflict(c, "Schedule slot conflict", err.Error())
 		case domainErrors.ErrBadRequest:
@@ -62,11 +64,14 @@ func (h *ScheduleSlotHandler) ListScheduleSlots(c *gin.Context) {
 
 	result, err := h.slotUseCase.ListScheduleSlots(c.Request.Context(), dormitoryID, page, pageSize, isActive)
 	if err != nil {
-		if err == domainErrors.ErrBadRequest {
+		switch err {
+		case domainErrors.ErrBadRequest:
 			response.ErrorBadRequest(c, "Invalid filters", err.Error())
-			return
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
+		default:
+			response.ErrorInternalServer(c, "Failed to list schedule slots", err.Error())
 		}
-		response.ErrorInternalServer(c, "Failed to list schedule slots", err.Error())
 		return
 	}
 
@@ -86,6 +91,8 @@ func (h *ScheduleSlotHandler) GetScheduleSlot(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrScheduleSlotNotFound:
 			response.ErrorNotFound(c, "Schedule slot not found")
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 		default:
 			response.ErrorInternalServer(c, "Failed to get schedule slot", err.Error())
 		}
@@ -114,6 +121,8 @@ func (h *ScheduleSlotHandler) UpdateScheduleSlot(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrScheduleSlotNotFound:
 			response.ErrorNotFound(c, "Schedule slot not found")
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 		case domainErrors.ErrScheduleSlotConflict:
 			response.ErrorConflict(c, "Schedule slot conflict", err.Error())
 		case domainErrors.ErrBadRequest:
@@ -139,6 +148,8 @@ func (h *ScheduleSlotHandler) DeleteScheduleSlot(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrScheduleSlotNotFound:
 			response.ErrorNotFound(c, "Schedule slot not found")
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 		default:
 			response.ErrorInternalServer(c, "Failed to delete schedule slot", err.Error())
 		}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// ServiceAccountHandler manages service accounts and their API keys.
+type ServiceAccountHandler struct {
+	serviceAccountUseCase *usecase.ServiceAccountUseCase
+}
+
+// NewServiceAccountHandler creates a new ServiceAccountHandler instance.
+func NewServiceAccountHandler(serviceAccountUseCase *usecase.ServiceAccountUseCase) *ServiceAccountHandler {
+	return &ServiceAccountHandler{serviceAccountUseCase: serviceAccountUseCase}
+}
+
+// CreateServiceAccount handles POST /api/service-accounts.
+func (h *ServiceAccountHandler) CreateServiceAccount(c *gin.Context) {
+	var req dto.CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.serviceAccountUseCase.CreateServiceAccount(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create service account")
+		return
+	}
+
+	response.SuccessCreated(c, resp, "Service account created successfully")
+}
+
+// ListServiceAccounts handles GET /api/service-accounts.
+func (h *ServiceAccountHandler) ListServiceAccounts(c *gin.Context) {
+	resp, err := h.serviceAccountUseCase.ListServiceAccounts(c.Request.Context())
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to list service accounts", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "Service accounts retrieved successfully")
+}
+
+// CreateAPIKey handles POST /api/service-accounts/:id/api-keys.
+func (h *ServiceAccountHandler) CreateAPIKey(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid service account ID", err.Error())
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.serviceAccountUseCase.CreateAPIKey(c.Request.Context(), accountID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create API key")
+		return
+	}
+
+	response.SuccessCreated(c, resp, "API key created successfully; store it now, it will not be shown again")
+}
+
+// ListAPIKeys handles GET /api/service-accounts/:id/api-keys.
+func (h *ServiceAccountHandler) ListAPIKeys(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid service account ID", err.Error())
+		return
+	}
+
+	resp, err := h.serviceAccountUseCase.ListAPIKeys(c.Request.Context(), accountID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list API keys")
+		return
+	}
+
+	response.SuccessOK(c, resp, "API keys retrieved successfully")
+}
+
+// RevokeAPIKey handles DELETE /api/service-accounts/:id/api-keys/:key_id.
+func (h *ServiceAccountHandler) RevokeAPIKey(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid service account ID", err.Error())
+		return
+	}
+	keyID, err := uuid.Parse(c.Param("key_id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid API key ID", err.Error())
+		return
+	}
+
+	if err := h.serviceAccountUseCase.RevokeAPIKey(c.Request.Context(), accountID, keyID); err != nil {
+		h.handleError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	response.SuccessOK(c, nil, "API key revoked successfully")
+}
+
+func (h *ServiceAccountHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case domainErrors.ErrUserAlreadyExists:
+		response.ErrorConflict(c, "User already exists")
+	case domainErrors.ErrRoleNotFound:
+		response.ErrorBadRequest(c, "Role not found")
+	case domainErrors.ErrServiceAccountNotFound:
+		response.ErrorNotFound(c, "Service account not found")
+	case domainErrors.ErrAPIKeyNotFound:
+		response.ErrorNotFound(c, "API key not found")
+	case domainErrors.ErrAPIKeyScopeInvalid:
+		response.ErrorBadRequest(c, "Invalid API key permissions", err.Error())
+	case domainErrors.ErrBadRequest:
+		response.ErrorBadRequest(c, "Invalid API key expiry", "expires_at must be in the future")
+	default:
+		response.ErrorInternalServer(c, message, err.Error())
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/your-org/go-backend-starter/internal/application/dto"
 	"github.com/your-org/go-backend-starter/internal/application/usecase"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/spreadsheet"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
@@ -16,8 +17,12 @@ import (
 type StudentHandler struct {
 	studentUseCase   *usecase.StudentUseCase
 	sksResultUseCase *usecase.StudentSKSResultUseCase
+	importUseCase    *usecase.StudentImportUseCase
 }
 
+// maxStudentImportSize caps the size of uploaded import files.
+const maxStudentImportSize = 5 << 20
+
 // CreateStudentSKSResult handles POST /api/students/:id/sks-results.
 func (h *StudentHandler) CreateStudentSKSResult(c *gin.Context) {
 	studentID := c.Param("id")
@@ -94,6 +99,8 @@ func (h *StudentHandler) handleSKSError(c *gin.Context, err error, action string
 	switch err {
 	case domainErrors.ErrStudentNotFound:
 		response.ErrorNotFound(c, "Student not found")
+	case domainErrors.ErrDormitoryAccessDenied:
+		response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 	case domainErrors.ErrSKSDefinitionNotFound:
 		response.ErrorNotFound(c, "SKS definition not found")
 	case domainErrors.ErrStudentSKSResultNotFound:
@@ -108,11 +115,72 @@ func (h *StudentHandler) handleSKSError(c *gin.Context, err error, action string
 }
 
 // NewStudentHandler constructs StudentHandler.
-func NewStudentHandler(studentUseCase *usecase.StudentUseCase, sksResultUseCase *usecase.StudentSKSResultUseCase) *StudentHandler {
+func NewStudentHandler(
+	studentUseCase *usecase.StudentUseCase,
+	sksResultUseCase *usecase.StudentSKSResultUseCase,
+	importUseCase *usecase.StudentImportUseCase,
+) *StudentHandler {
 	return &StudentHandler{
 		studentUseCase:   studentUseCase,
 		sksResultUseCase: sksResultUseCase,
+		importUseCase:    importUseCase,
+	}
+}
+
+// ImportStudents handles POST /api/students/import.
+// The multipart "file" field holds a CSV or XLSX file; dry_run=true only validates it.
+func (h *StudentHandler) ImportStudents(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.ErrorBadRequest(c, "Import file is required", err.Error())
+		return
+	}
+	if fileHeader.Size > maxStudentImportSize {
+		response.ErrorBadRequest(c, "Import file is too large", "maximum size is 5 MB")
+		return
+	}
+
+	dryRunInput := c.Query("dry_run")
+	if dryRunInput == "" {
+		dryRunInput = c.PostForm("dry_run")
+	}
+	dryRun := false
+	if dryRunInput != "" {
+		if dryRun, err = strconv.ParseBool(dryRunInput); err != nil {
+			response.ErrorBadRequest(c, "Invalid dry_run value", err.Error())
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.ErrorBadRequest(c, "Failed to read import file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	rows, err := spreadsheet.ReadRows(fileHeader.Filename, file)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid import file", err.Error())
+		return
+	}
+
+	result, err := h.importUseCase.ImportStudents(c.Request.Context(), dto.ImportStudentsRequest{Rows: rows, DryRun: dryRun})
+	if err != nil {
+		switch err {
+		case domainErrors.ErrStudentImportInvalid:
+			response.ErrorUnprocessableWithData(c, "Student import contains invalid rows", result)
+		default:
+			response.ErrorInternalServer(c, "Failed to import students", err.Error())
+		}
+		return
 	}
+
+	if dryRun {
+		response.SuccessOK(c, result, "Student import validated successfully")
+		return
+	}
+	response.SuccessCreated(c, result, "Students imported successfully")
 }
 
 // CreateStudent handles POST /api/students
@@ -149,6 +217,8 @@ func (h *StudentHandler) GetStudent(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrStudentNotFound:
 			response.ErrorNotFound(c, "Student not found")
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 		default:
 			response.ErrorInternalServer(c, "Failed to get student", err.Error())
 		}
@@ -160,12 +230,22 @@ func (h *StudentHandler) GetStudent(c *gin.Context) {
 
 // ListStudents handles GET /api/students
 func (h *StudentHandler) ListStudents(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	var req dto.ListStudentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
 
-	resp, err := h.studentUseCase.ListStudents(c.Request.Context(), page, pageSize)
+	resp, err := h.studentUseCase.ListStudents(c.Request.Context(), req)
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to list students", err.Error())
+		switch err {
+		case domainErrors.ErrBadRequest:
+			response.ErrorBadRequest(c, "Invalid student filters", err.Error())
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
+		default:
+			response.ErrorInternalServer(c, "Failed to list students", err.Error())
+		}
 		return
 	}
 
@@ -190,6 +270,8 @@ func (h *StudentHandler) UpdateStudent(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrStudentNotFound:
 			response.ErrorNotFound(c, "Student not found")
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 		default:
 			response.ErrorInternalServer(c, "Failed to update student", err.Error())
 		}
@@ -217,6 +299,8 @@ func (h *StudentHandler) UpdateStudentStatus(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrStudentNotFound:
 			response.ErrorNotFound(c, "Student not found")
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 		default:
 			response.ErrorInternalServer(c, "Failed to update student status", err.Error())
 		}
@@ -255,6 +339,8 @@ func (h *StudentHandler) MutateStudentDormitory(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrStudentNotFound:
 			response.ErrorNotFound(c, "Student not found")
+		case domainErrors.ErrDormitoryAccessDenied:
+			response.ErrorForbidden(c, "Access denied to this dormitory", err.Error())
 		case domainErrors.ErrDormitoryNotFound:
 			response.ErrorNotFound(c, "Dormitory not found")
 		default:
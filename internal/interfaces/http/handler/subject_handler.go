@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// SubjectHandler handles subject related HTTP requests.
+type SubjectHandler struct {
+	subjectUseCase *usecase.SubjectUseCase
+}
+
+// NewSubjectHandler creates a new SubjectHandler instance.
+func NewSubjectHandler(subjectUseCase *usecase.SubjectUseCase) *SubjectHandler {
+	return &SubjectHandler{subjectUseCase: subjectUseCase}
+}
+
+// CreateSubject handles POST /api/subjects.
+func (h *SubjectHandler) CreateSubject(c *gin.Context) {
+	var req dto.CreateSubjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	result, err := h.subjectUseCase.CreateSubject(c.Request.Context(), req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrSubjectAlreadyExists:
+			response.ErrorConflict(c, "Subject already exists")
+		default:
+			response.ErrorInternalServer(c, "Failed to create subject", err.Error())
+		}
+		return
+	}
+
+	response.SuccessCreated(c, result, "Subject created successfully")
+}
+
+// GetSubject handles GET /api/subjects/:id.
+func (h *SubjectHandler) GetSubject(c *gin.Context) {
+	subjectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid subject ID", err.Error())
+		return
+	}
+
+	result, err := h.subjectUseCase.GetSubject(c.Request.Context(), subjectID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrSubjectNotFound:
+			response.ErrorNotFound(c, "Subject not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to get subject", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, result, "Subject retrieved successfully")
+}
+
+// ListSubjects handles GET /api/subjects.
+func (h *SubjectHandler) ListSubjects(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	result, err := h.subjectUseCase.ListSubjects(c.Request.Context(), page, pageSize)
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to list subjects", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, result, "Subjects retrieved successfully")
+}
+
+// UpdateSubject handles PUT /api/subjects/:id.
+func (h *SubjectHandler) UpdateSubject(c *gin.Context) {
+	subjectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid subject ID", err.Error())
+		return
+	}
+
+	var req dto.UpdateSubjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	result, err := h.subjectUseCase.UpdateSubject(c.Request.Context(), subjectID, req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrSubjectNotFound:
+			response.ErrorNotFound(c, "Subject not found")
+		case domainErrors.ErrSubjectAlreadyExists:
+			response.ErrorConflict(c, "Subject name already taken")
+		default:
+			response.ErrorInternalServer(c, "Failed to update subject", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, result, "Subject updated successfully")
+}
+
+// DeleteSubject handles DELETE /api/subjects/:id.
+// Subjects still used by class schedules or SKS definitions are deactivated instead.
+func (h *SubjectHandler) DeleteSubject(c *gin.Context) {
+	subjectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid subject ID", err.Error())
+		return
+	}
+
+	deactivated, err := h.subjectUseCase.DeleteSubject(c.Request.Context(), subjectID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrSubjectNotFound:
+			response.ErrorNotFound(c, "Subject not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to delete subject", err.Error())
+		}
+		return
+	}
+
+	if deactivated != nil {
+		response.SuccessOK(c, deactivated, "Subject is in use and has been deactivated")
+		return
+	}
+
+	response.SuccessNoContent(c)
+}
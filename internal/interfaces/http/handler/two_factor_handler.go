@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// TwoFactorHandler handles TOTP enrolment for the current user and admin resets.
+type TwoFactorHandler struct {
+	twoFactorUseCase *usecase.TwoFactorUseCase
+}
+
+// NewTwoFactorHandler creates a new TwoFactorHandler instance.
+func NewTwoFactorHandler(twoFactorUseCase *usecase.TwoFactorUseCase) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorUseCase: twoFactorUseCase}
+}
+
+// BeginSetup handles POST /api/me/2fa/setup.
+func (h *TwoFactorHandler) BeginSetup(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.twoFactorUseCase.BeginSetup(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to start two-factor setup")
+		return
+	}
+
+	response.SuccessOK(c, resp, "Scan the provisioning URI and confirm with a code")
+}
+
+// ConfirmSetup handles POST /api/me/2fa/confirm.
+func (h *TwoFactorHandler) ConfirmSetup(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.twoFactorUseCase.ConfirmSetup(c.Request.Context(), userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	response.SuccessOK(c, resp, "Two-factor authentication enabled")
+}
+
+// RegenerateRecoveryCodes handles POST /api/me/2fa/recovery-codes.
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.twoFactorUseCase.RegenerateRecoveryCodes(c.Request.Context(), userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	response.SuccessOK(c, resp, "Recovery codes regenerated")
+}
+
+// Disable handles DELETE /api/me/2fa.
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	var req dto.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.twoFactorUseCase.Disable(c.Request.Context(), userID, req); err != nil {
+		h.handleError(c, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	response.SuccessOK(c, nil, "Two-factor authentication disabled")
+}
+
+// ResetUser handles DELETE /api/users/:id/2fa.
+func (h *TwoFactorHandler) ResetUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	if err := h.twoFactorUseCase.Reset(c.Request.Context(), id); err != nil {
+		h.handleError(c, err, "Failed to reset two-factor authentication")
+		return
+	}
+
+	response.SuccessOK(c, nil, "Two-factor authentication reset")
+}
+
+func (h *TwoFactorHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case domainErrors.ErrUserNotFound:
+		response.ErrorNotFound(c, "User not found")
+	case domainErrors.ErrTwoFactorCodeInvalid:
+		response.ErrorBadRequest(c, "Invalid two-factor code")
+	case domainErrors.ErrCurrentPasswordInvalid:
+		response.ErrorBadRequest(c, "Current password is incorrect")
+	case domainErrors.ErrTwoFactorSetupNotStarted:
+		response.ErrorBadRequest(c, "Start two-factor setup first")
+	case domainErrors.ErrTwoFactorAlreadyEnabled:
+		response.ErrorConflict(c, "Two-factor authentication is already enabled")
+	case domainErrors.ErrTwoFactorNotEnabled:
+		response.ErrorConflict(c, "Two-factor authentication is not enabled")
+	case domainErrors.ErrTwoFactorEnforced:
+		response.ErrorForbidden(c, "Two-factor authentication is required by your role")
+	default:
+		response.ErrorInternalServer(c, message, err.Error())
+	}
+}
+
+// currentUserID reads the authenticated user ID, writing a 401 when it is missing.
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, ok := c.Get("user_id")
+	if !ok {
+		response.ErrorUnauthorized(c, "User not found in context")
+		return uuid.Nil, false
+	}
+	return userID.(uuid.UUID), true
+}
@@ -2,7 +2,6 @@ package handler
 
 import (
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -42,14 +41,9 @@ func (h *UserHandler) Me(c *gin.Context) {
 
 	roles := make([]string, 0, len(userEntity.Roles))
 	permissions := make([]string, 0)
-	isAdminAllDorms := false
+	isAdminAllDorms := userEntity.HasAllDormitoryAccess()
 	for _, r := range userEntity.Roles {
 		roles = append(roles, r.Name)
-		// Use slug/name case-insensitively to detect admin and super_admin
-		if strings.EqualFold(r.Slug, "admin") || strings.EqualFold(r.Slug, "super_admin") ||
-			strings.EqualFold(r.Name, "admin") || strings.EqualFold(r.Name, "super_admin") {
-			isAdminAllDorms = true
-		}
 		for _, p := range r.Permissions {
 			permissions = append(permissions, p.Name)
 		}
@@ -70,15 +64,18 @@ func (h *UserHandler) Me(c *gin.Context) {
 	}
 
 	resp := dto.UserResponse{
-		ID:          userEntity.ID.String(),
-		Username:    userEntity.Username,
-		Name:        userEntity.Name,
-		IsActive:    userEntity.IsActive,
-		Roles:       roles,
-		Permissions: permissions,
-		Dormitories: dorms,
-		CreatedAt:   userEntity.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   userEntity.UpdatedAt.Format(time.RFC3339),
+		ID:                     userEntity.ID.String(),
+		Username:               userEntity.Username,
+		Name:                   userEntity.Name,
+		IsActive:               userEntity.IsActive,
+		Roles:                  roles,
+		Permissions:            permissions,
+		Dormitories:            dorms,
+		CreatedAt:              userEntity.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:              userEntity.UpdatedAt.Format(time.RFC3339),
+		MustChangePassword:     userEntity.MustChangePassword,
+		TwoFactorEnabled:       userEntity.TwoFactorEnabled,
+		TwoFactorSetupRequired: userEntity.RequiresTwoFactorSetup(),
 	}
 
 	response.SuccessOK(c, resp, "Current user retrieved successfully")
@@ -322,3 +319,67 @@ func (h *UserHandler) RemoveRoleFromUser(c *gin.Context) {
 
 	response.SuccessOK(c, nil, "Role removed successfully")
 }
+
+// RevokeUserSessions handles revoking all refresh sessions of a user
+// @Summary Revoke user sessions
+// @Description Revoke every active refresh token session of a user
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.RevokeSessionsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/users/{id}/sessions [delete]
+func (h *UserHandler) RevokeUserSessions(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	resp, err := h.userUseCase.RevokeUserSessions(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrUserNotFound:
+			response.ErrorNotFound(c, "User not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to revoke sessions", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "Sessions revoked successfully")
+}
+
+// ResetPassword handles an admin password reset
+// @Summary Reset user password
+// @Description Replace a user's password with a one-time temporary password that must be changed on next login
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.ResetPasswordResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/users/{id}/reset-password [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	resp, err := h.userUseCase.ResetPassword(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrUserNotFound:
+			response.ErrorNotFound(c, "User not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to reset password", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "Password reset successfully")
+}
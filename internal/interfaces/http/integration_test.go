@@ -3,10 +3,14 @@ package http
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -21,11 +25,13 @@ import (
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainRepo "github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/domain/service"
+	infraCache "github.com/your-org/go-backend-starter/internal/infrastructure/cache"
 	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
 	infraRepo "github.com/your-org/go-backend-starter/internal/infrastructure/repository"
 	infraService "github.com/your-org/go-backend-starter/internal/infrastructure/service"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/handler"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/middleware"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/router"
 	"github.com/your-org/go-backend-starter/internal/testutil"
 	"gorm.io/gorm"
@@ -43,6 +49,7 @@ func TestAttendanceSubmitStudentEndpoint_WithHealthOverride(t *testing.T) {
 	dorm := seedDormitory(t, db, "Attendance Health Dorm")
 	student := seedStudent(t, db, "Health Override Student")
 	user, token := createTestUser(t, db, "attendance-health", tokenService, "attendance_sessions:update")
+	assignUserDormitory(t, db, user.ID, dorm.ID)
 	assignPermissionsToUser(t, db, user.ID, []string{"attendance_sessions:update"})
 
 	healthStatus := entity.HealthStatus{
@@ -96,6 +103,7 @@ func TestAttendanceSubmitStudentEndpoint_WithLeaveOverride(t *testing.T) {
 	dorm := seedDormitory(t, db, "Attendance Leave Dorm")
 	student := seedStudent(t, db, "Leave Override Student")
 	user, token := createTestUser(t, db, "attendance-leave", tokenService, "attendance_sessions:update")
+	assignUserDormitory(t, db, user.ID, dorm.ID)
 	assignPermissionsToUser(t, db, user.ID, []string{"attendance_sessions:update"})
 
 	leavePermit := entity.LeavePermit{
@@ -154,6 +162,7 @@ func TestAttendanceOpenSessionsEndpoint(t *testing.T) {
 	schedule := seedClassSchedule(t, db, classEntity, teacher, dorm.ID)
 
 	user, token := createTestUser(t, db, "attendance-open", tokenService, "attendance_sessions:create", "attendance_sessions:read")
+	assignUserDormitory(t, db, user.ID, dorm.ID)
 	assignPermissionsToUser(t, db, user.ID, []string{"attendance_sessions:create", "attendance_sessions:read"})
 
 	payload := map[string]interface{}{
@@ -196,6 +205,7 @@ func TestAttendanceSubmitStudentEndpoint(t *testing.T) {
 	require.NoError(t, db.Create(&session).Error)
 
 	user, token := createTestUser(t, db, "attendance-submit", tokenService, "attendance_sessions:update")
+	assignUserDormitory(t, db, user.ID, dorm.ID)
 	assignPermissionsToUser(t, db, user.ID, []string{"attendance_sessions:update"})
 
 	payload := dto.SubmitStudentAttendanceRequest{
@@ -250,6 +260,7 @@ func TestAttendanceLockEndpoint(t *testing.T) {
 	require.NoError(t, db.Create(&sessions).Error)
 
 	user, token := createTestUser(t, db, "attendance-lock", tokenService, "attendance_sessions:lock", "attendance_sessions:read")
+	assignUserDormitory(t, db, user.ID, dorm.ID)
 	assignPermissionsToUser(t, db, user.ID, []string{"attendance_sessions:lock", "attendance_sessions:read"})
 
 	payload := dto.LockAttendanceRequest{Date: "2025-11-22"}
@@ -268,6 +279,124 @@ func TestAttendanceLockEndpoint(t *testing.T) {
 	}
 }
 
+func TestAttendanceGenerateSessionsEndpoint(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	dorm := seedDormitory(t, db, "Generator Dorm")
+	fan := seedFan(t, db)
+	classEntity := seedClass(t, db, fan.ID)
+	teacher := seedTeacher(t, db)
+	schedule := seedClassSchedule(t, db, classEntity, teacher, dorm.ID)
+
+	permissions := []string{"attendance_sessions:create", "holidays:read", "holidays:create", "holidays:delete"}
+	user, token := createTestUser(t, db, "attendance-generator", tokenService, permissions...)
+	assignUserDormitory(t, db, user.ID, dorm.ID)
+	assignPermissionsToUser(t, db, user.ID, permissions)
+
+	// 2025-11-24 and 2025-12-01 are Mondays; the second one is a dormitory holiday.
+	holidayBody, _ := json.Marshal(map[string]interface{}{
+		"date":         "2025-12-01",
+		"name":         "Haflah Akhirussanah",
+		"dormitory_id": dorm.ID.String(),
+	})
+	holidayReq := httptest.NewRequest(http.MethodPost, "/api/holidays", bytes.NewBuffer(holidayBody))
+	holidayReq.Header.Set("Authorization", "Bearer "+token)
+	holidayReq.Header.Set("Content-Type", "application/json")
+	holidayRes := httptest.NewRecorder()
+	router.ServeHTTP(holidayRes, holidayReq)
+	require.Equal(t, http.StatusCreated, holidayRes.Code)
+
+	listReq := httptest.NewRequest(http.MethodGet, "/api/holidays?start_date=2025-11-01&end_date=2025-12-31", nil)
+	listReq.Header.Set("Authorization", "Bearer "+token)
+	listRes := httptest.NewRecorder()
+	router.ServeHTTP(listRes, listReq)
+	assert.Equal(t, http.StatusOK, listRes.Code)
+
+	var listResp struct {
+		Data dto.ListHolidaysResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(listRes.Body.Bytes(), &listResp))
+	require.Len(t, listResp.Data.Holidays, 1)
+
+	generate := func() dto.GenerateAttendanceSessionsResponse {
+		body, _ := json.Marshal(dto.GenerateAttendanceSessionsRequest{StartDate: "2025-11-24", EndDate: "2025-12-01"})
+		req := httptest.NewRequest(http.MethodPost, "/api/attendance-sessions/generate", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+
+		var resp struct {
+			Data dto.GenerateAttendanceSessionsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+		return resp.Data
+	}
+
+	first := generate()
+	assert.Equal(t, 1, first.Created)
+	assert.Equal(t, 1, first.SkippedHolidays)
+	assert.Equal(t, []string{"2025-12-01"}, first.HolidayDates)
+
+	second := generate()
+	assert.Equal(t, 0, second.Created)
+	assert.Equal(t, 1, second.SkippedExisting)
+
+	var count int64
+	require.NoError(t, db.Model(&entity.AttendanceSession{}).Where("class_schedule_id = ?", schedule.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	deleteReq := httptest.NewRequest(http.MethodDelete, "/api/holidays/"+listResp.Data.Holidays[0].ID, nil)
+	deleteReq.Header.Set("Authorization", "Bearer "+token)
+	deleteRes := httptest.NewRecorder()
+	router.ServeHTTP(deleteRes, deleteReq)
+	assert.Equal(t, http.StatusNoContent, deleteRes.Code)
+}
+
+func TestJobRunsEndpoint(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	started := time.Date(2025, 1, 6, 23, 55, 0, 0, time.UTC)
+	runs := []entity.JobRun{
+		{ID: uuid.New(), JobName: "lock_attendance_sessions", Status: entity.JobRunStatusSucceeded, Holder: "api-1", ScheduledAt: started, StartedAt: started},
+		{ID: uuid.New(), JobName: "expire_leave_permits", Status: entity.JobRunStatusFailed, Holder: "api-2", ScheduledAt: started, StartedAt: started, Error: "boom"},
+	}
+	require.NoError(t, db.Create(&runs).Error)
+
+	_, noPermToken := createTestUser(t, db, "job-runs-denied", tokenService)
+	deniedReq := httptest.NewRequest(http.MethodGet, "/api/job-runs", nil)
+	deniedReq.Header.Set("Authorization", "Bearer "+noPermToken)
+	deniedRes := httptest.NewRecorder()
+	router.ServeHTTP(deniedRes, deniedReq)
+	assert.Equal(t, http.StatusForbidden, deniedRes.Code)
+
+	user, token := createTestUser(t, db, "job-runs-admin", tokenService, "job_runs:read")
+	assignPermissionsToUser(t, db, user.ID, []string{"job_runs:read"})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/job-runs?status=failed", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	require.Equal(t, http.StatusOK, res.Code)
+
+	var resp struct {
+		Data dto.ListJobRunsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Runs, 1)
+	assert.Equal(t, "expire_leave_permits", resp.Data.Runs[0].JobName)
+	assert.Equal(t, "boom", resp.Data.Runs[0].Error)
+
+	badReq := httptest.NewRequest(http.MethodGet, "/api/job-runs?status=unknown", nil)
+	badReq.Header.Set("Authorization", "Bearer "+token)
+	badRes := httptest.NewRecorder()
+	router.ServeHTTP(badRes, badReq)
+	assert.Equal(t, http.StatusBadRequest, badRes.Code)
+}
+
 func seedClass(t *testing.T, db *gorm.DB, fanID uuid.UUID) entity.Class {
 	classEntity := entity.Class{
 		ID:        uuid.New(),
@@ -379,6 +508,7 @@ func TestScheduleSlotEndpoints(t *testing.T) {
 
 	dorm := seedDormitory(t, db, "Slot Dorm")
 	user, token := createTestUser(t, db, "slot-admin", tokenService, "schedule_slots:read", "schedule_slots:create", "schedule_slots:update", "schedule_slots:delete")
+	assignUserDormitory(t, db, user.ID, dorm.ID)
 	assignPermissionsToUser(t, db, user.ID, []string{"schedule_slots:read", "schedule_slots:create", "schedule_slots:update", "schedule_slots:delete"})
 
 	start := time.Now().UTC().Add(time.Hour)
@@ -457,6 +587,7 @@ func TestClassScheduleEndpoints(t *testing.T) {
 		"class_schedules:update",
 		"class_schedules:delete",
 	)
+	assignUserDormitory(t, db, user.ID, dorm.ID)
 	assignPermissionsToUser(t, db, user.ID, []string{"class_schedules:read", "class_schedules:create", "class_schedules:update", "class_schedules:delete"})
 
 	createPayload := map[string]interface{}{
@@ -484,6 +615,79 @@ func TestClassScheduleEndpoints(t *testing.T) {
 	scheduleID := createResp.Data.ID
 	require.NotEmpty(t, scheduleID)
 
+	// Same slot and room with another class/teacher must be rejected with conflict details
+	otherClass := seedClass(t, db, fan.ID)
+	otherTeacher := seedTeacher(t, db)
+	conflictPayload := map[string]interface{}{
+		"class_id":     otherClass.ID.String(),
+		"dormitory_id": dorm.ID.String(),
+		"teacher_id":   otherTeacher.ID.String(),
+		"slot_id":      slot.ID.String(),
+		"day_of_week":  "mon",
+		"location":     "room 101",
+	}
+	conflictBody, _ := json.Marshal(conflictPayload)
+	conflictReq := httptest.NewRequest(http.MethodPost, "/api/class-schedules", bytes.NewBuffer(conflictBody))
+	conflictReq.Header.Set("Authorization", "Bearer "+token)
+	conflictReq.Header.Set("Content-Type", "application/json")
+	conflictRes := httptest.NewRecorder()
+	router.ServeHTTP(conflictRes, conflictReq)
+	assert.Equal(t, http.StatusConflict, conflictRes.Code)
+
+	var conflictResp struct {
+		Data dto.ClassScheduleConflictResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(conflictRes.Body.Bytes(), &conflictResp))
+	require.Len(t, conflictResp.Data.Conflicts, 1)
+	assert.Equal(t, scheduleID, conflictResp.Data.Conflicts[0].Schedule.ID)
+	assert.Equal(t, []string{"location"}, conflictResp.Data.Conflicts[0].Reasons)
+
+	// Bulk creation reports clashes with stored schedules and within the batch together
+	bulkPayload := map[string]interface{}{
+		"schedules": []map[string]interface{}{
+			conflictPayload,
+			{
+				"class_id":     otherClass.ID.String(),
+				"dormitory_id": dorm.ID.String(),
+				"teacher_id":   otherTeacher.ID.String(),
+				"slot_id":      slot.ID.String(),
+				"day_of_week":  "mon",
+				"location":     "Room 102",
+			},
+		},
+	}
+	bulkBody, _ := json.Marshal(bulkPayload)
+	bulkReq := httptest.NewRequest(http.MethodPost, "/api/class-schedules/bulk", bytes.NewBuffer(bulkBody))
+	bulkReq.Header.Set("Authorization", "Bearer "+token)
+	bulkReq.Header.Set("Content-Type", "application/json")
+	bulkRes := httptest.NewRecorder()
+	router.ServeHTTP(bulkRes, bulkReq)
+	assert.Equal(t, http.StatusConflict, bulkRes.Code)
+
+	var bulkConflictResp struct {
+		Data dto.ClassScheduleConflictResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(bulkRes.Body.Bytes(), &bulkConflictResp))
+	assert.Len(t, bulkConflictResp.Data.Conflicts, 2)
+
+	bulkPayload["schedules"] = []map[string]interface{}{
+		{
+			"class_id":     otherClass.ID.String(),
+			"dormitory_id": dorm.ID.String(),
+			"teacher_id":   otherTeacher.ID.String(),
+			"slot_id":      slot.ID.String(),
+			"day_of_week":  "tue",
+			"location":     "Room 102",
+		},
+	}
+	bulkBody, _ = json.Marshal(bulkPayload)
+	bulkReq = httptest.NewRequest(http.MethodPost, "/api/class-schedules/bulk", bytes.NewBuffer(bulkBody))
+	bulkReq.Header.Set("Authorization", "Bearer "+token)
+	bulkReq.Header.Set("Content-Type", "application/json")
+	bulkRes = httptest.NewRecorder()
+	router.ServeHTTP(bulkRes, bulkReq)
+	assert.Equal(t, http.StatusCreated, bulkRes.Code)
+
 	listReq := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/class-schedules?class_id=%s", classEntity.ID.String()), nil)
 	listReq.Header.Set("Authorization", "Bearer "+token)
 	listRes := httptest.NewRecorder()
@@ -514,6 +718,83 @@ func TestClassScheduleEndpoints(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, deleteRes.Code)
 }
 
+func TestSubjectEndpoints(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	user, token := createTestUser(t, db, "subject-admin", tokenService, "subjects:read", "subjects:create", "subjects:update", "subjects:delete")
+	assignPermissionsToUser(t, db, user.ID, []string{"subjects:read", "subjects:create", "subjects:update", "subjects:delete"})
+
+	createPayload := map[string]interface{}{
+		"name":        "Integration Fiqh",
+		"description": "Initial subject",
+	}
+	createBody, _ := json.Marshal(createPayload)
+	createReq := httptest.NewRequest(http.MethodPost, "/api/subjects", bytes.NewBuffer(createBody))
+	createReq.Header.Set("Authorization", "Bearer "+token)
+	createReq.Header.Set("Content-Type", "application/json")
+	createRes := httptest.NewRecorder()
+	router.ServeHTTP(createRes, createReq)
+	assert.Equal(t, http.StatusCreated, createRes.Code)
+
+	var createResp struct {
+		Data dto.SubjectResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(createRes.Body.Bytes(), &createResp))
+	subjectID := createResp.Data.ID
+	require.NotEmpty(t, subjectID)
+
+	dupReq := httptest.NewRequest(http.MethodPost, "/api/subjects", bytes.NewBuffer(createBody))
+	dupReq.Header.Set("Authorization", "Bearer "+token)
+	dupReq.Header.Set("Content-Type", "application/json")
+	dupRes := httptest.NewRecorder()
+	router.ServeHTTP(dupRes, dupReq)
+	assert.Equal(t, http.StatusConflict, dupRes.Code)
+
+	listReq := httptest.NewRequest(http.MethodGet, "/api/subjects?page=1&page_size=10", nil)
+	listReq.Header.Set("Authorization", "Bearer "+token)
+	listRes := httptest.NewRecorder()
+	router.ServeHTTP(listRes, listReq)
+	assert.Equal(t, http.StatusOK, listRes.Code)
+
+	getReq := httptest.NewRequest(http.MethodGet, "/api/subjects/"+subjectID, nil)
+	getReq.Header.Set("Authorization", "Bearer "+token)
+	getRes := httptest.NewRecorder()
+	router.ServeHTTP(getRes, getReq)
+	assert.Equal(t, http.StatusOK, getRes.Code)
+
+	updateBody, _ := json.Marshal(map[string]interface{}{"description": "Updated subject"})
+	updateReq := httptest.NewRequest(http.MethodPut, "/api/subjects/"+subjectID, bytes.NewBuffer(updateBody))
+	updateReq.Header.Set("Authorization", "Bearer "+token)
+	updateReq.Header.Set("Content-Type", "application/json")
+	updateRes := httptest.NewRecorder()
+	router.ServeHTTP(updateRes, updateReq)
+	assert.Equal(t, http.StatusOK, updateRes.Code)
+
+	deleteReq := httptest.NewRequest(http.MethodDelete, "/api/subjects/"+subjectID, nil)
+	deleteReq.Header.Set("Authorization", "Bearer "+token)
+	deleteRes := httptest.NewRecorder()
+	router.ServeHTTP(deleteRes, deleteReq)
+	assert.Equal(t, http.StatusNoContent, deleteRes.Code)
+
+	// A subject referenced by a class schedule is deactivated rather than deleted
+	used := seedSubject(t, db, "Used Subject")
+	dorm := seedDormitory(t, db, "Subject Dorm")
+	fan := seedFan(t, db)
+	schedule := seedClassSchedule(t, db, seedClass(t, db, fan.ID), seedTeacher(t, db), dorm.ID)
+	require.NoError(t, db.Model(&entity.ClassSchedule{}).Where("id = ?", schedule.ID).Update("subject_id", used.ID).Error)
+
+	deleteUsedReq := httptest.NewRequest(http.MethodDelete, "/api/subjects/"+used.ID.String(), nil)
+	deleteUsedReq.Header.Set("Authorization", "Bearer "+token)
+	deleteUsedRes := httptest.NewRecorder()
+	router.ServeHTTP(deleteUsedRes, deleteUsedReq)
+	assert.Equal(t, http.StatusOK, deleteUsedRes.Code)
+
+	var stored entity.Subject
+	require.NoError(t, db.Where("id = ?", used.ID).First(&stored).Error)
+	assert.False(t, stored.IsActive)
+}
+
 func TestSKSDefinitionEndpoints(t *testing.T) {
 	router, db, tokenService, cleanup := setupTestRouter(t)
 	defer cleanup()
@@ -918,6 +1199,12 @@ func (r *testUserRepository) List(ctx context.Context, limit, offset int) ([]*en
 	return users, total, err
 }
 
+func (r *testUserRepository) ListServiceAccounts(ctx context.Context) ([]*entity.User, error) {
+	var users []*entity.User
+	err := r.db.WithContext(ctx).Preload("Roles").Preload("Roles.Permissions").Where("is_service_account = ?", true).Order("username").Find(&users).Error
+	return users, err
+}
+
 func (r *testUserRepository) GetWithRoles(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	var user entity.User
 	err := r.db.WithContext(ctx).Preload("Roles").Preload("Roles.Permissions").Where("id = ?", id).First(&user).Error
@@ -949,6 +1236,13 @@ func (r *testUserRepository) RemoveRole(ctx context.Context, userID, roleID uuid
 		Delete(&entity.UserRole{}).Error
 }
 
+func (r *testUserRepository) AdvanceTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND two_factor_last_step < ?", userID, step).
+		Update("two_factor_last_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
 func setupTestRouter(t *testing.T) (*gin.Engine, *gorm.DB, service.TokenService, func()) {
 	gin.SetMode(gin.TestMode)
 
@@ -979,6 +1273,7 @@ func setupTestRouter(t *testing.T) (*gin.Engine, *gorm.DB, service.TokenService,
 	classStaffRepo := infraRepo.NewClassStaffRepository()
 	teacherRepo := infraRepo.NewTeacherRepository()
 	subjectRepo := infraRepo.NewSubjectRepository()
+	holidayRepo := infraRepo.NewHolidayRepository()
 	classScheduleRepo := infraRepo.NewClassScheduleRepository()
 	leavePermitRepo := infraRepo.NewLeavePermitRepository()
 	healthStatusRepo := infraRepo.NewHealthStatusRepository()
@@ -988,25 +1283,45 @@ func setupTestRouter(t *testing.T) (*gin.Engine, *gorm.DB, service.TokenService,
 	scheduleSlotRepo := infraRepo.NewScheduleSlotRepository()
 	permissionRepo := infraRepo.NewPermissionRepository()
 	auditLogRepo := infraRepo.NewAuditLogRepository()
+	auditCheckpointRepo := infraRepo.NewAuditCheckpointRepository()
+	auditArchiveRepo := infraRepo.NewAuditArchiveRepository()
 	provinceRepo := infraRepo.NewProvinceRepository()
 	regencyRepo := infraRepo.NewRegencyRepository()
 	districtRepo := infraRepo.NewDistrictRepository()
 	villageRepo := infraRepo.NewVillageRepository()
+	refreshSessionRepo := infraRepo.NewRefreshSessionRepository()
+	jobRunRepo := infraRepo.NewJobRunRepository()
+	loginThrottleRepo := infraRepo.NewLoginThrottleRepository()
+	recoveryCodeRepo := infraRepo.NewRecoveryCodeRepository()
+	apiKeyRepo := infraRepo.NewAPIKeyRepository()
+	invitationRepo := infraRepo.NewInvitationRepository()
 
 	// Initialize services
-	tokenService := infraService.NewJWTService()
-	auditLogger := appService.NewAuditLogger(auditLogRepo)
+	tokenService, err := infraService.NewJWTService()
+	require.NoError(t, err)
+	totpService := infraService.NewTOTPService()
+	auditSigner, err := infraService.NewAuditSigner()
+	require.NoError(t, err)
+	// Synchronous so assertions see audit entries as soon as a request returns
+	auditLogger, err := appService.NewAuditSink(auditLogRepo, appService.AuditWriterConfig{Mode: appService.AuditWriterModeSync})
+	require.NoError(t, err)
+	principalCache := infraCache.NewMemoryPrincipalCache(infraCache.DefaultPrincipalTTL)
 	ensureRoleExists(t, roleRepo, "teacher")
 
 	// Initialize use cases
-	authUseCase := usecase.NewAuthUseCase(userRepo, tokenService)
-	userUseCase := usecase.NewUserUseCase(userRepo, roleRepo, auditLogger)
-	dormitoryUseCase := usecase.NewDormitoryUseCase(dormitoryRepo, userRepo, auditLogger)
+	registrationPolicy := usecase.LoadRegistrationPolicy()
+	twoFactorUseCase := usecase.NewTwoFactorUseCase(userRepo, recoveryCodeRepo, totpService, auditLogger, principalCache)
+	authUseCase := usecase.NewAuthUseCase(userRepo, refreshSessionRepo, tokenService, loginThrottleRepo, auditLogger, usecase.LoadLoginLockoutPolicy(), registrationPolicy, twoFactorUseCase, principalCache)
+	userUseCase := usecase.NewUserUseCase(userRepo, roleRepo, refreshSessionRepo, auditLogger, principalCache)
+	serviceAccountUseCase := usecase.NewServiceAccountUseCase(userRepo, roleRepo, apiKeyRepo, auditLogger)
+	dormitoryUseCase := usecase.NewDormitoryUseCase(dormitoryRepo, userRepo, auditLogger, principalCache)
 	studentUseCase := usecase.NewStudentUseCase(studentRepo, dormitoryRepo, auditLogger)
+	studentImportUseCase := usecase.NewStudentImportUseCase(studentRepo, dormitoryRepo, classRepo, auditLogger)
 	fanUseCase := usecase.NewFanUseCase(fanRepo, dormitoryRepo, auditLogger)
 	classUseCase := usecase.NewClassUseCase(classRepo, fanRepo, studentRepo, enrollmentRepo, classStaffRepo, auditLogger)
-	teacherUseCase := usecase.NewTeacherUseCase(teacherRepo, userRepo, roleRepo, auditLogger)
+	teacherUseCase := usecase.NewTeacherUseCase(teacherRepo, userRepo, roleRepo, auditLogger, principalCache)
 	scheduleSlotUseCase := usecase.NewScheduleSlotUseCase(scheduleSlotRepo, dormitoryRepo, auditLogger)
+	subjectUseCase := usecase.NewSubjectUseCase(subjectRepo, auditLogger)
 	classScheduleUseCase := usecase.NewClassScheduleUseCase(classScheduleRepo, classRepo, teacherRepo, subjectRepo, scheduleSlotRepo, dormitoryRepo, auditLogger)
 	sksDefinitionUseCase := usecase.NewSKSDefinitionUseCase(sksDefinitionRepo, fanRepo, subjectRepo, auditLogger)
 	sksExamUseCase := usecase.NewSKSExamScheduleUseCase(sksExamRepo, sksDefinitionRepo, teacherRepo, auditLogger)
@@ -1017,35 +1332,52 @@ func setupTestRouter(t *testing.T) (*gin.Engine, *gorm.DB, service.TokenService,
 	leavePermitUseCase := usecase.NewLeavePermitUseCase(leavePermitRepo, studentRepo, auditLogger)
 	healthStatusUseCase := usecase.NewHealthStatusUseCase(healthStatusRepo, studentRepo, auditLogger)
 	attendanceUseCase := usecase.NewAttendanceUseCase(attendanceSessionRepo, studentAttendanceRepo, teacherAttendanceRepo, classScheduleRepo, leavePermitUseCase, healthStatusUseCase, auditLogger)
-	roleUseCase := usecase.NewRoleUseCase(roleRepo, permissionRepo, auditLogger)
+	attendanceGeneratorUseCase := usecase.NewAttendanceGeneratorUseCase(attendanceSessionRepo, classScheduleRepo, holidayRepo, auditLogger)
+	holidayUseCase := usecase.NewHolidayUseCase(holidayRepo, dormitoryRepo, auditLogger)
+	roleUseCase := usecase.NewRoleUseCase(roleRepo, permissionRepo, auditLogger, principalCache)
 	locationUseCase := usecase.NewLocationUseCase(provinceRepo, regencyRepo, districtRepo, villageRepo)
 	permissionUseCase := usecase.NewPermissionUseCase(permissionRepo)
-	auditLogUseCase := usecase.NewAuditLogUseCase(auditLogRepo)
+	auditLogUseCase := usecase.NewAuditLogUseCase(auditLogRepo, auditLogger)
+	auditIntegrityUseCase := usecase.NewAuditIntegrityUseCase(auditLogRepo, auditCheckpointRepo, auditArchiveRepo, auditSigner)
+	auditRetentionUseCase := usecase.NewAuditRetentionUseCase(auditLogRepo, auditArchiveRepo, infraService.NewFileAuditArchiveStore(t.TempDir()), auditIntegrityUseCase, auditLogger, usecase.AuditRetentionPolicy{})
 	reportUseCase := usecase.NewReportUseCase(reportRepo)
+	jobRunUseCase := usecase.NewJobRunUseCase(jobRunRepo)
+	loginLockoutUseCase := usecase.NewLoginLockoutUseCase(loginThrottleRepo, auditLogger)
+	impersonationUseCase := usecase.NewImpersonationUseCase(userRepo, tokenService, auditLogger)
+	invitationUseCase := usecase.NewInvitationUseCase(invitationRepo, userRepo, roleRepo, dormitoryRepo, auditLogger, registrationPolicy)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authUseCase)
 	userHandler := handler.NewUserHandler(userUseCase)
 	dormitoryHandler := handler.NewDormitoryHandler(dormitoryUseCase)
-	studentHandler := handler.NewStudentHandler(studentUseCase, studentSKSResultUseCase)
+	studentHandler := handler.NewStudentHandler(studentUseCase, studentSKSResultUseCase, studentImportUseCase)
 	fanHandler := handler.NewFanHandler(fanUseCase)
 	classHandler := handler.NewClassHandler(classUseCase)
 	teacherHandler := handler.NewTeacherHandler(teacherUseCase)
 	scheduleSlotHandler := handler.NewScheduleSlotHandler(scheduleSlotUseCase)
 	classScheduleHandler := handler.NewClassScheduleHandler(classScheduleUseCase)
+	subjectHandler := handler.NewSubjectHandler(subjectUseCase)
 	sksDefinitionHandler := handler.NewSKSDefinitionHandler(sksDefinitionUseCase)
 	sksExamHandler := handler.NewSKSExamScheduleHandler(sksExamUseCase)
-	attendanceHandler := handler.NewAttendanceHandler(attendanceUseCase)
+	attendanceHandler := handler.NewAttendanceHandler(attendanceUseCase, attendanceGeneratorUseCase)
+	holidayHandler := handler.NewHolidayHandler(holidayUseCase)
 	leavePermitHandler := handler.NewLeavePermitHandler(leavePermitUseCase)
 	healthStatusHandler := handler.NewHealthStatusHandler(healthStatusUseCase)
 	roleHandler := handler.NewRoleHandler(roleUseCase)
 	locationHandler := handler.NewLocationHandler(locationUseCase)
 	permissionHandler := handler.NewPermissionHandler(permissionUseCase)
-	auditLogHandler := handler.NewAuditLogHandler(auditLogUseCase)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogUseCase, auditIntegrityUseCase, auditRetentionUseCase)
 	reportHandler := handler.NewReportHandler(reportUseCase)
+	jobRunHandler := handler.NewJobRunHandler(jobRunUseCase)
+	loginLockoutHandler := handler.NewLoginLockoutHandler(loginLockoutUseCase)
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorUseCase)
+	jwksHandler := handler.NewJWKSHandler(tokenService)
+	serviceAccountHandler := handler.NewServiceAccountHandler(serviceAccountUseCase)
+	impersonationHandler := handler.NewImpersonationHandler(impersonationUseCase)
+	invitationHandler := handler.NewInvitationHandler(invitationUseCase)
 
 	// Initialize middleware
-	authMiddleware := middleware.NewAuthMiddleware(tokenService, userRepo)
+	authMiddleware := middleware.NewAuthMiddleware(tokenService, userRepo, serviceAccountUseCase, principalCache)
 
 	// Setup router
 	r := router.SetupRouter(
@@ -1061,13 +1393,22 @@ func setupTestRouter(t *testing.T) (*gin.Engine, *gorm.DB, service.TokenService,
 		classHandler,
 		teacherHandler,
 		classScheduleHandler,
+		subjectHandler,
 		sksDefinitionHandler,
 		sksExamHandler,
 		attendanceHandler,
+		holidayHandler,
 		scheduleSlotHandler,
 		leavePermitHandler,
 		healthStatusHandler,
 		reportHandler,
+		jobRunHandler,
+		loginLockoutHandler,
+		twoFactorHandler,
+		jwksHandler,
+		serviceAccountHandler,
+		impersonationHandler,
+		invitationHandler,
 		authMiddleware,
 	)
 
@@ -1188,6 +1529,10 @@ func assignStudentAdminRole(t *testing.T, db *gorm.DB, userID uuid.UUID) {
 	assignRoleWithPermissions(t, db, userID, "student-admin", []string{"student:read", "student:create", "student:update"})
 }
 
+func assignUserDormitory(t *testing.T, db *gorm.DB, userID, dormitoryID uuid.UUID) {
+	require.NoError(t, db.Create(&entity.UserDormitory{UserID: userID, DormitoryID: dormitoryID}).Error)
+}
+
 func ensureRoleExists(t *testing.T, roleRepo domainRepo.RoleRepository, slug string) {
 	ctx := context.Background()
 	if role, _ := roleRepo.GetBySlug(ctx, slug); role != nil {
@@ -1208,6 +1553,7 @@ func ensureRoleExists(t *testing.T, roleRepo domainRepo.RoleRepository, slug str
 }
 
 func TestAuthIntegration_RegisterAndLogin(t *testing.T) {
+	t.Setenv("REGISTRATION_MODE", "open")
 	router, _, _, cleanup := setupTestRouter(t)
 	defer cleanup()
 
@@ -1270,6 +1616,7 @@ func TestAuthIntegration_RegisterAndLogin(t *testing.T) {
 }
 
 func TestAuthIntegration_InvalidCredentials(t *testing.T) {
+	t.Setenv("REGISTRATION_MODE", "open")
 	router, _, _, cleanup := setupTestRouter(t)
 	defer cleanup()
 
@@ -1338,6 +1685,8 @@ func TestStudentIntegration_CreateStatusMutate(t *testing.T) {
 	studentNumber := fmt.Sprintf("STD%d", time.Now().UnixNano())
 	user, token := createTestUser(t, db, "student-admin", tokenService, "student:read", "student:create", "student:update")
 	assignStudentAdminRole(t, db, user.ID)
+	// Newly created students have no dormitory yet, so only unrestricted users can manage them.
+	assignRoleWithPermissions(t, db, user.ID, "admin", nil)
 
 	birthDate := time.Now().AddDate(-15, 0, 0).UTC().Format(time.RFC3339)
 	createPayload := map[string]interface{}{
@@ -1400,3 +1749,1215 @@ func TestStudentIntegration_CreateStatusMutate(t *testing.T) {
 	require.Greater(t, len(mutateResp.Data.DormitoryHistory), 0)
 	assert.Equal(t, dorm.ID.String(), mutateResp.Data.DormitoryHistory[0].DormitoryID)
 }
+
+func TestStudentIntegration_ListSearchAndScope(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	dormA := seedDormitory(t, db, "Scope Dorm A")
+	dormB := seedDormitory(t, db, "Scope Dorm B")
+	placeStudent := func(name string, dormID uuid.UUID) entity.Student {
+		student := seedStudent(t, db, name)
+		history := entity.StudentDormitoryHistory{ID: uuid.New(), StudentID: student.ID, DormitoryID: dormID, StartDate: time.Now()}
+		require.NoError(t, db.Create(&history).Error)
+		return student
+	}
+	ahmad := placeStudent("Ahmad Fauzi", dormA.ID)
+	placeStudent("Zaki Hamdan", dormA.ID)
+	placeStudent("Ahmad Bakri", dormB.ID)
+
+	listStudents := func(token, query string) (int, dto.ListStudentsResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/api/students"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+
+		var resp struct {
+			Data dto.ListStudentsResponse `json:"data"`
+		}
+		if res.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+		}
+		return res.Code, resp.Data
+	}
+
+	admin, adminToken := createTestUser(t, db, "student-list-admin", tokenService, "student:read")
+	assignRoleWithPermissions(t, db, admin.ID, "admin", []string{"student:read"})
+
+	code, all := listStudents(adminToken, "?q=ahmad&sort_by=full_name&sort_order=asc")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, int64(2), all.Total)
+	assert.Equal(t, "Ahmad Bakri", all.Students[0].FullName)
+	assert.Equal(t, "Ahmad Fauzi", all.Students[1].FullName)
+
+	code, _ = listStudents(adminToken, "?sort_by=age")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	staff, staffToken := createTestUser(t, db, "student-list-staff", tokenService, "student:read")
+	assignPermissionsToUser(t, db, staff.ID, []string{"student:read"})
+	require.NoError(t, db.Create(&entity.UserDormitory{UserID: staff.ID, DormitoryID: dormA.ID}).Error)
+
+	code, scoped := listStudents(staffToken, "?q=ahmad")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, int64(1), scoped.Total)
+	assert.Equal(t, ahmad.ID.String(), scoped.Students[0].ID)
+
+	code, scoped = listStudents(staffToken, "?dormitory_id="+dormA.ID.String())
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(2), scoped.Total)
+
+	code, _ = listStudents(staffToken, "?dormitory_id="+dormB.ID.String())
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestDormitoryScopeIntegration_StudentRecords(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	dormA := seedDormitory(t, db, "Scoped Records Dorm A")
+	dormB := seedDormitory(t, db, "Scoped Records Dorm B")
+	placeStudent := func(name string, dormID uuid.UUID) entity.Student {
+		student := seedStudent(t, db, name)
+		history := entity.StudentDormitoryHistory{ID: uuid.New(), StudentID: student.ID, DormitoryID: dormID, StartDate: time.Now()}
+		require.NoError(t, db.Create(&history).Error)
+		permit := entity.LeavePermit{
+			ID:        uuid.New(),
+			StudentID: student.ID,
+			Type:      entity.LeavePermitTypeHomeLeave,
+			StartDate: time.Now(),
+			EndDate:   time.Now().Add(24 * time.Hour),
+			Status:    entity.LeavePermitStatusPending,
+			CreatedBy: uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		require.NoError(t, db.Create(&permit).Error)
+		return student
+	}
+	own := placeStudent("Own Dorm Student", dormA.ID)
+	other := placeStudent("Other Dorm Student", dormB.ID)
+
+	staff, token := createTestUser(t, db, "scoped-records-staff", tokenService, "student:read", "student:update", "leave_permits:read")
+	assignPermissionsToUser(t, db, staff.ID, []string{"student:read", "student:update", "leave_permits:read"})
+	assignUserDormitory(t, db, staff.ID, dormA.ID)
+
+	do := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/students/"+own.ID.String(), nil).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/students/"+other.ID.String(), nil).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPut, "/api/students/"+other.ID.String(), map[string]string{"full_name": "Renamed Student"}).Code)
+
+	listRes := do(http.MethodGet, "/api/leave-permits?page=1&page_size=10", nil)
+	require.Equal(t, http.StatusOK, listRes.Code)
+	var listResp struct {
+		Data dto.ListLeavePermitsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(listRes.Body.Bytes(), &listResp))
+	require.Equal(t, int64(1), listResp.Data.Total)
+	assert.Equal(t, own.ID.String(), listResp.Data.Permits[0].StudentID)
+
+	// The seeded admin role (Name "Admin", slug "admin") bypasses the scope
+	seededAdmin, adminToken := createTestUser(t, db, "scoped-records-admin", tokenService, "student:read", "leave_permits:read")
+	require.NoError(t, db.Model(&seededAdmin).Update("two_factor_enabled", true).Error)
+	var adminRole entity.Role
+	require.NoError(t, db.Where("slug = ?", "admin").First(&adminRole).Error)
+	require.Equal(t, "Admin", adminRole.Name)
+	require.NoError(t, db.Create(&entity.UserRole{UserID: seededAdmin.ID, RoleID: adminRole.ID}).Error)
+	assignPermissionsToUser(t, db, seededAdmin.ID, []string{"student:read", "leave_permits:read"})
+	token = adminToken
+
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/students/"+other.ID.String(), nil).Code)
+	listRes = do(http.MethodGet, "/api/leave-permits?page=1&page_size=10", nil)
+	require.Equal(t, http.StatusOK, listRes.Code)
+	require.NoError(t, json.Unmarshal(listRes.Body.Bytes(), &listResp))
+	assert.Equal(t, int64(2), listResp.Data.Total)
+}
+
+func TestStudentIntegration_Import(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	fan := seedFan(t, db)
+	var dorm entity.Dormitory
+	require.NoError(t, db.First(&dorm, "id = ?", fan.DormitoryID).Error)
+	classEntity := seedClass(t, db, fan.ID)
+
+	user, token := createTestUser(t, db, "student-importer", tokenService, "student:create")
+	assignPermissionsToUser(t, db, user.ID, []string{"student:create"})
+	assignUserDormitory(t, db, user.ID, dorm.ID)
+
+	upload := func(filename, csv, query string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile("file", filename)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(csv))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/students/import"+query, &body)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	header := "student_number,full_name,birth_date,gender,parent_name,dormitory_code,class\n"
+	valid := header +
+		fmt.Sprintf("IMP001,Ahmad Fauzi,2010-05-01,male,Bapak Fauzi,%s,%s\n", dorm.Code, classEntity.Name) +
+		fmt.Sprintf("IMP002,Zaki Hamdan,2011-06-02,male,Bapak Hamdan,%s,\n", dorm.Code)
+
+	type importResp struct {
+		Data dto.ImportStudentsResponse `json:"data"`
+	}
+
+	dryRun := upload("students.csv", valid, "?dry_run=true")
+	require.Equal(t, http.StatusOK, dryRun.Code)
+	var dryResp importResp
+	require.NoError(t, json.Unmarshal(dryRun.Body.Bytes(), &dryResp))
+	assert.Equal(t, 2, dryResp.Data.ValidRows)
+	assert.Zero(t, dryResp.Data.Imported)
+
+	var count int64
+	require.NoError(t, db.Model(&entity.Student{}).Where("student_number LIKE ?", "IMP%").Count(&count).Error)
+	assert.Zero(t, count)
+
+	invalid := upload("students.csv", valid+fmt.Sprintf("IMP001,Duplicate Row,2011-06-02,male,Bapak Hamdan,%s,\n", dorm.Code), "")
+	require.Equal(t, http.StatusUnprocessableEntity, invalid.Code)
+	var invalidResp importResp
+	require.NoError(t, json.Unmarshal(invalid.Body.Bytes(), &invalidResp))
+	require.Len(t, invalidResp.Data.Errors, 1)
+	assert.Equal(t, 4, invalidResp.Data.Errors[0].Row)
+	require.NoError(t, db.Model(&entity.Student{}).Where("student_number LIKE ?", "IMP%").Count(&count).Error)
+	assert.Zero(t, count)
+
+	created := upload("students.csv", valid, "")
+	require.Equal(t, http.StatusCreated, created.Code)
+	var createdResp importResp
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &createdResp))
+	assert.Equal(t, 2, createdResp.Data.Imported)
+
+	var student entity.Student
+	require.NoError(t, db.First(&student, "student_number = ?", "IMP001").Error)
+	var history entity.StudentDormitoryHistory
+	require.NoError(t, db.First(&history, "student_id = ? AND end_date IS NULL", student.ID).Error)
+	assert.Equal(t, dorm.ID, history.DormitoryID)
+	var enrollment entity.StudentClassEnrollment
+	require.NoError(t, db.First(&enrollment, "student_id = ?", student.ID).Error)
+	assert.Equal(t, classEntity.ID, enrollment.ClassID)
+
+	assert.Equal(t, http.StatusBadRequest, upload("students.txt", valid, "").Code)
+}
+
+func TestReportIntegration_Export(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	dorm := seedDormitory(t, db, "Asrama Putra")
+	student := seedStudent(t, db, "Ahmad Fauzi")
+	require.NoError(t, db.Create(&entity.StudentDormitoryHistory{
+		ID:          uuid.New(),
+		StudentID:   student.ID,
+		DormitoryID: dorm.ID,
+		StartDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	}).Error)
+
+	user, token := createTestUser(t, db, "report-exporter", tokenService, "reports:academic:read")
+	assignRoleWithPermissions(t, db, user.ID, "admin", []string{"reports:academic:read"})
+
+	get := func(query, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/reports/mutations"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	jsonRes := get("", "")
+	require.Equal(t, http.StatusOK, jsonRes.Code)
+	assert.Contains(t, jsonRes.Body.String(), `"student_name":"Ahmad Fauzi"`)
+
+	csvRes := get("?format=csv", "")
+	require.Equal(t, http.StatusOK, csvRes.Code)
+	assert.Equal(t, "text/csv", csvRes.Header().Get("Content-Type"))
+	assert.Regexp(t, `^attachment; filename="mutation-report-\d{8}\.csv"$`, csvRes.Header().Get("Content-Disposition"))
+	lines := strings.Split(strings.TrimSpace(csvRes.Body.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "Student,From Dormitory,To Dormitory,From Class,To Class,Start Date,End Date", lines[0])
+	assert.Equal(t, "Ahmad Fauzi,-,Asrama Putra,-,-,2025-07-01,-", lines[1])
+	assert.True(t, strings.HasPrefix(lines[3], "Generated at "))
+
+	xlsxRes := get("", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	require.Equal(t, http.StatusOK, xlsxRes.Code)
+	assert.Contains(t, xlsxRes.Header().Get("Content-Disposition"), ".xlsx")
+	assert.True(t, bytes.HasPrefix(xlsxRes.Body.Bytes(), []byte("PK")))
+
+	pdfRes := get("?format=pdf", "")
+	require.Equal(t, http.StatusOK, pdfRes.Code)
+	assert.True(t, bytes.HasPrefix(pdfRes.Body.Bytes(), []byte("%PDF-")))
+
+	assert.Equal(t, http.StatusBadRequest, get("?format=docx", "").Code)
+}
+
+func TestAuthIntegration_PasswordResetAndForcedChange(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	admin, adminToken := createTestUser(t, db, "pwadmin", tokenService, "user:update")
+	assignPermissionsToUser(t, db, admin.ID, []string{"user:update"})
+	staff, _ := createTestUser(t, db, "pwstaff", tokenService, "student:read")
+	assignRoleWithPermissions(t, db, staff.ID, "admin", []string{"student:read"})
+
+	do := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+	type authResp struct {
+		Data dto.AuthResponse `json:"data"`
+	}
+
+	resetRes := do(http.MethodPost, "/api/users/"+staff.ID.String()+"/reset-password", adminToken, nil)
+	require.Equal(t, http.StatusOK, resetRes.Code)
+	var reset struct {
+		Data dto.ResetPasswordResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(resetRes.Body.Bytes(), &reset))
+	require.NotEmpty(t, reset.Data.TemporaryPassword)
+
+	loginRes := do(http.MethodPost, "/api/auth/login", "", dto.LoginRequest{Username: "pwstaff", Password: reset.Data.TemporaryPassword})
+	require.Equal(t, http.StatusOK, loginRes.Code)
+	var login authResp
+	require.NoError(t, json.Unmarshal(loginRes.Body.Bytes(), &login))
+	assert.True(t, login.Data.User.MustChangePassword)
+	tempToken := login.Data.AccessToken
+
+	// Everything but /me and the password change is blocked until the password is replaced
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/students", tempToken, nil).Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/me", tempToken, nil).Code)
+
+	wrong := do(http.MethodPost, "/api/me/password", tempToken, dto.ChangePasswordRequest{CurrentPassword: "nope", NewPassword: "brand-new-pass"})
+	assert.Equal(t, http.StatusBadRequest, wrong.Code)
+
+	changeRes := do(http.MethodPost, "/api/me/password", tempToken, dto.ChangePasswordRequest{CurrentPassword: reset.Data.TemporaryPassword, NewPassword: "brand-new-pass"})
+	require.Equal(t, http.StatusOK, changeRes.Code)
+	var changed authResp
+	require.NoError(t, json.Unmarshal(changeRes.Body.Bytes(), &changed))
+	assert.False(t, changed.Data.User.MustChangePassword)
+
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/students", changed.Data.AccessToken, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/api/auth/login", "", dto.LoginRequest{Username: "pwstaff", Password: reset.Data.TemporaryPassword}).Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/api/auth/login", "", dto.LoginRequest{Username: "pwstaff", Password: "brand-new-pass"}).Code)
+}
+
+func TestAuthIntegration_LoginLockoutAndEvents(t *testing.T) {
+	t.Setenv("LOGIN_MAX_ATTEMPTS", "3")
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	admin, adminToken := createTestUser(t, db, "lockadmin", tokenService, "user:update", "audit:read")
+	assignPermissionsToUser(t, db, admin.ID, []string{"user:update", "audit:read"})
+	staff, _ := createTestUser(t, db, "lockstaff", tokenService)
+	staff.Password = "correct-pass"
+	require.NoError(t, staff.HashPassword())
+	require.NoError(t, db.Model(&entity.User{}).Where("id = ?", staff.ID).Update("password", staff.Password).Error)
+
+	do := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	for i := 0; i < 3; i++ {
+		res := do(http.MethodPost, "/api/auth/login", "", dto.LoginRequest{Username: "lockstaff", Password: "wrong-pass"})
+		require.Equal(t, http.StatusUnauthorized, res.Code)
+	}
+	// Locked out even with the right password
+	assert.Equal(t, http.StatusTooManyRequests, do(http.MethodPost, "/api/auth/login", "", dto.LoginRequest{Username: "LockStaff", Password: "correct-pass"}).Code)
+
+	listRes := do(http.MethodGet, "/api/login-lockouts", adminToken, nil)
+	require.Equal(t, http.StatusOK, listRes.Code)
+	var lockouts struct {
+		Data dto.ListLoginLockoutsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(listRes.Body.Bytes(), &lockouts))
+	require.Len(t, lockouts.Data.Lockouts, 1)
+	assert.Equal(t, "username", lockouts.Data.Lockouts[0].Kind)
+	assert.Equal(t, "lockstaff", lockouts.Data.Lockouts[0].Value)
+
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, "/api/login-lockouts/"+lockouts.Data.Lockouts[0].ID, adminToken, nil).Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/api/login-lockouts/"+lockouts.Data.Lockouts[0].ID, adminToken, nil).Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/api/auth/login", "", dto.LoginRequest{Username: "lockstaff", Password: "correct-pass"}).Code)
+
+	auditRes := do(http.MethodGet, "/api/audit-logs?resource=auth&page_size=50", adminToken, nil)
+	require.Equal(t, http.StatusOK, auditRes.Code)
+	var audit struct {
+		Data dto.ListAuditLogsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(auditRes.Body.Bytes(), &audit))
+	counts := map[string]int{}
+	for _, entry := range audit.Data.Logs {
+		counts[entry.Action]++
+		assert.NotEmpty(t, entry.IPAddress)
+	}
+	assert.Equal(t, 3, counts["auth:login_failed"])
+	assert.Equal(t, 1, counts["auth:lockout"])
+	assert.Equal(t, 1, counts["auth:login_blocked"])
+	assert.Equal(t, 1, counts["auth:unlock"])
+	assert.Equal(t, 1, counts["auth:login_success"])
+}
+
+func TestAuthIntegration_IPLockoutIgnoresSpoofedForwardedFor(t *testing.T) {
+	t.Setenv("LOGIN_IP_MAX_ATTEMPTS", "2")
+	router, _, _, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	login := func(username, forwardedFor string) int {
+		var payload bytes.Buffer
+		require.NoError(t, json.NewEncoder(&payload).Encode(dto.LoginRequest{Username: username, Password: "wrong-pass"}))
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/login", &payload)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	// Without TRUSTED_PROXIES the header is ignored, so rotating it does not
+	// reset the per-IP counter of the connection's address
+	assert.Equal(t, http.StatusUnauthorized, login("spray1", "198.51.100.1"))
+	assert.Equal(t, http.StatusUnauthorized, login("spray2", "198.51.100.2"))
+	assert.Equal(t, http.StatusTooManyRequests, login("spray3", "198.51.100.3"))
+}
+
+func TestAuthIntegration_TwoFactor(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	admin, adminToken := createTestUser(t, db, "tfaadmin", tokenService, "user:update")
+	assignPermissionsToUser(t, db, admin.ID, []string{"user:update"})
+	user, token := createTestUser(t, db, "tfastaff", tokenService)
+	user.Password = "correct-pass"
+	require.NoError(t, user.HashPassword())
+	require.NoError(t, db.Model(&entity.User{}).Where("id = ?", user.ID).Update("password", user.Password).Error)
+	assignRoleWithPermissions(t, db, user.ID, "privileged", []string{"dorm:update", "user:read"})
+	require.NoError(t, db.Model(&entity.Role{}).Where("name = ?", "privileged").Update("two_factor_required", true).Error)
+
+	do := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+	type authEnvelope struct {
+		Data dto.AuthResponse `json:"data"`
+	}
+
+	// Everything but enrolment is blocked until 2FA is set up
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/users", token, nil).Code)
+	meRes := do(http.MethodGet, "/api/me", token, nil)
+	require.Equal(t, http.StatusOK, meRes.Code)
+	assert.Contains(t, meRes.Body.String(), `"two_factor_setup_required":true`)
+
+	setupRes := do(http.MethodPost, "/api/me/2fa/setup", token, nil)
+	require.Equal(t, http.StatusOK, setupRes.Code)
+	var setup struct {
+		Data dto.TwoFactorSetupResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(setupRes.Body.Bytes(), &setup))
+	assert.Contains(t, setup.Data.ProvisioningURI, "otpauth://totp/")
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/me/2fa/confirm", token, dto.TwoFactorCodeRequest{Code: "000000"}).Code)
+	code, err := infraService.TOTPCode(setup.Data.Secret, time.Now())
+	require.NoError(t, err)
+	confirmRes := do(http.MethodPost, "/api/me/2fa/confirm", token, dto.TwoFactorCodeRequest{Code: code})
+	require.Equal(t, http.StatusOK, confirmRes.Code)
+	var recovery struct {
+		Data dto.RecoveryCodesResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(confirmRes.Body.Bytes(), &recovery))
+	require.Len(t, recovery.Data.RecoveryCodes, 10)
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/users", token, nil).Code)
+
+	// The password alone no longer yields tokens
+	loginRes := do(http.MethodPost, "/api/auth/login", "", dto.LoginRequest{Username: "tfastaff", Password: "correct-pass"})
+	require.Equal(t, http.StatusOK, loginRes.Code)
+	var challenge authEnvelope
+	require.NoError(t, json.Unmarshal(loginRes.Body.Bytes(), &challenge))
+	require.True(t, challenge.Data.TwoFactorRequired)
+	assert.Empty(t, challenge.Data.AccessToken)
+
+	// The confirmation code cannot be replayed
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{TwoFactorToken: challenge.Data.TwoFactorToken, Code: code}).Code)
+	verifyRes := do(http.MethodPost, "/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{TwoFactorToken: challenge.Data.TwoFactorToken, Code: recovery.Data.RecoveryCodes[0]})
+	require.Equal(t, http.StatusOK, verifyRes.Code)
+	var tokens authEnvelope
+	require.NoError(t, json.Unmarshal(verifyRes.Body.Bytes(), &tokens))
+	assert.NotEmpty(t, tokens.Data.AccessToken)
+	assert.True(t, tokens.Data.User.TwoFactorEnabled)
+
+	// Recovery codes are single use
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{TwoFactorToken: challenge.Data.TwoFactorToken, Code: recovery.Data.RecoveryCodes[0]}).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/api/auth/login/2fa", "", dto.LoginTwoFactorRequest{TwoFactorToken: tokens.Data.AccessToken, Code: recovery.Data.RecoveryCodes[1]}).Code)
+
+	// The role flag prevents switching 2FA off
+	disableRes := do(http.MethodDelete, "/api/me/2fa", token, dto.DisableTwoFactorRequest{Password: "correct-pass", Code: recovery.Data.RecoveryCodes[1]})
+	assert.Equal(t, http.StatusForbidden, disableRes.Code)
+
+	// An administrator reset sends the user back to enrolment
+	require.Equal(t, http.StatusOK, do(http.MethodDelete, "/api/users/"+user.ID.String()+"/2fa", adminToken, nil).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/users", token, nil).Code)
+	var remaining int64
+	require.NoError(t, db.Model(&entity.RecoveryCode{}).Where("user_id = ?", user.ID).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+}
+
+func TestJWKSEndpoint(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Header().Get("Cache-Control"), "max-age=")
+
+	var jwks struct {
+		Keys []service.JSONWebKey `json:"keys"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &jwks))
+	require.NotEmpty(t, jwks.Keys)
+
+	// Issued tokens reference a published key
+	_, token := createTestUser(t, db, "jwks-user", tokenService)
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	var jose struct {
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+	}
+	require.NoError(t, json.Unmarshal(header, &jose))
+	assert.Equal(t, jwks.Keys[0].KeyID, jose.Kid)
+	assert.Equal(t, jwks.Keys[0].Algorithm, jose.Alg)
+}
+
+func TestServiceAccountIntegration_APIKeys(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	admin, adminToken := createTestUser(t, db, "svcadmin", tokenService)
+	assignPermissionsToUser(t, db, admin.ID, []string{"user:read", "user:create", "user:update", "audit:read"})
+
+	do := func(method, path string, headers map[string]string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+	bearer := func(token string) map[string]string { return map[string]string{"Authorization": "Bearer " + token} }
+
+	createRes := do(http.MethodPost, "/api/service-accounts", bearer(adminToken), dto.CreateServiceAccountRequest{Username: "kiosk", Name: "Gate Kiosk"})
+	require.Equal(t, http.StatusCreated, createRes.Code, createRes.Body.String())
+	var account struct {
+		Data dto.ServiceAccountResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(createRes.Body.Bytes(), &account))
+	accountID := uuid.MustParse(account.Data.ID)
+	// The "admin" role grants access to students outside any dormitory
+	assignRoleWithPermissions(t, db, accountID, "admin", []string{"student:read", "student:create", "student:update"})
+
+	// Keys cannot exceed the account's permissions
+	tooBroad := do(http.MethodPost, "/api/service-accounts/"+account.Data.ID+"/api-keys", bearer(adminToken), dto.CreateAPIKeyRequest{Name: "broad", Permissions: []string{"user:delete"}})
+	assert.Equal(t, http.StatusBadRequest, tooBroad.Code)
+
+	keyRes := do(http.MethodPost, "/api/service-accounts/"+account.Data.ID+"/api-keys", bearer(adminToken), dto.CreateAPIKeyRequest{Name: "gate", Permissions: []string{"student:read", "student:create"}})
+	require.Equal(t, http.StatusCreated, keyRes.Code, keyRes.Body.String())
+	var key struct {
+		Data dto.CreateAPIKeyResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(keyRes.Body.Bytes(), &key))
+	require.True(t, strings.HasPrefix(key.Data.Key, key.Data.Prefix))
+
+	// Accepted as a Bearer credential and in X-API-Key, limited to the key's scope
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/students", bearer(key.Data.Key), nil).Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/students", map[string]string{"X-API-Key": key.Data.Key}, nil).Code)
+	studentRes := do(http.MethodPost, "/api/students", bearer(key.Data.Key), map[string]interface{}{
+		"student_number": "SVC001",
+		"full_name":      "Kiosk Student",
+		"birth_date":     time.Now().AddDate(-15, 0, 0).UTC().Format(time.RFC3339),
+		"gender":         "male",
+		"parent_name":    "Kiosk Parent",
+	})
+	require.Equal(t, http.StatusCreated, studentRes.Code, studentRes.Body.String())
+	var student struct {
+		Data dto.StudentResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(studentRes.Body.Bytes(), &student))
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPatch, "/api/students/"+student.Data.ID+"/status", bearer(key.Data.Key), map[string]string{"status": entity.StudentStatusLeave}).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/students", bearer(key.Data.Key+"x"), nil).Code)
+
+	// Service accounts cannot log in with a password
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/api/auth/login", nil, dto.LoginRequest{Username: "kiosk", Password: "anything"}).Code)
+
+	// The audit trail names the service account and the key
+	auditRes := do(http.MethodGet, "/api/audit-logs?actor_username=kiosk&action=student:create", bearer(adminToken), nil)
+	require.Equal(t, http.StatusOK, auditRes.Code)
+	var audit struct {
+		Data dto.ListAuditLogsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(auditRes.Body.Bytes(), &audit))
+	require.NotEmpty(t, audit.Data.Logs)
+	assert.Equal(t, account.Data.ID, audit.Data.Logs[0].ActorID)
+	assert.Equal(t, key.Data.ID, audit.Data.Logs[0].APIKeyID)
+
+	listRes := do(http.MethodGet, "/api/service-accounts/"+account.Data.ID+"/api-keys", bearer(adminToken), nil)
+	require.Equal(t, http.StatusOK, listRes.Code)
+	var keys struct {
+		Data dto.ListAPIKeysResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(listRes.Body.Bytes(), &keys))
+	require.Len(t, keys.Data.APIKeys, 1)
+	assert.NotNil(t, keys.Data.APIKeys[0].LastUsedAt)
+	assert.NotContains(t, listRes.Body.String(), key.Data.Key)
+
+	// Revoked keys stop working immediately
+	require.Equal(t, http.StatusOK, do(http.MethodDelete, "/api/service-accounts/"+account.Data.ID+"/api-keys/"+key.Data.ID, bearer(adminToken), nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/students", bearer(key.Data.Key), nil).Code)
+}
+
+func TestPrincipalCacheIntegration_Invalidation(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	dorm := seedDormitory(t, db, "Cache Dorm")
+	admin, adminToken := createTestUser(t, db, "cacheadmin", tokenService)
+	assignRoleWithPermissions(t, db, admin.ID, "admin", []string{"dorm:update", "user:update"})
+	staff, staffToken := createTestUser(t, db, "cachestaff", tokenService)
+	assignPermissionsToUser(t, db, staff.ID, []string{"dorm:read"})
+
+	do := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+	dormPath := "/api/dormitories/" + dorm.ID.String()
+
+	// The first request caches the staff principal without the dormitory
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, dormPath, staffToken, nil).Code)
+
+	// Assigning the dormitory takes effect on the next request
+	require.Equal(t, http.StatusNoContent, do(http.MethodPost, dormPath+"/users", adminToken, map[string]string{"user_id": staff.ID.String()}).Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, dormPath, staffToken, nil).Code)
+
+	// So does deactivating the user
+	inactive := false
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/api/users/"+staff.ID.String(), adminToken, dto.UpdateUserRequest{IsActive: &inactive}).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, dormPath, staffToken, nil).Code)
+}
+
+func TestPermissionIntegration_WildcardGrants(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	// Make the concrete report permissions known so the wildcard can expand
+	for _, name := range []string{"reports:attendance:read", "reports:health:read"} {
+		require.NoError(t, db.Create(&entity.Permission{ID: uuid.New(), Name: name, Slug: strings.ReplaceAll(name, ":", "-")}).Error)
+	}
+	user, token := createTestUser(t, db, "reportviewer", tokenService)
+	assignRoleWithPermissions(t, db, user.ID, "admin", []string{"reports:*", "student:read"})
+
+	do := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	// The wildcard grant passes guards on concrete report permissions only
+	assert.Equal(t, http.StatusOK, do("/api/reports/health-statuses").Code)
+	assert.Equal(t, http.StatusForbidden, do("/api/roles").Code)
+
+	res := do("/api/me/permissions")
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	var body struct {
+		Data dto.EffectivePermissionsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+	assert.Equal(t, []string{"reports:attendance:read", "reports:health:read", "student:read"}, body.Data.Permissions)
+	assert.Equal(t, []string{"reports:*", "student:read"}, body.Data.Grants)
+}
+
+func TestInvitationIntegration(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	dorm := seedDormitory(t, db, "Invite Dorm")
+	otherDorm := seedDormitory(t, db, "Other Dorm")
+	admin, adminToken := createTestUser(t, db, "inviteadmin", tokenService)
+	assignRoleWithPermissions(t, db, admin.ID, "admin", []string{"user:read", "user:create", "student:read"})
+	var studentRead entity.Permission
+	require.NoError(t, db.Where("name = ?", "student:read").First(&studentRead).Error)
+	secretaryRole := entity.Role{ID: uuid.New(), Name: "Secretary", Slug: "secretary", IsActive: true, Permissions: []entity.Permission{studentRead}}
+	require.NoError(t, db.Create(&secretaryRole).Error)
+	staff, staffToken := createTestUser(t, db, "invitestaff", tokenService)
+	assignPermissionsToUser(t, db, staff.ID, []string{"user:create", "student:read"})
+	assignUserDormitory(t, db, staff.ID, dorm.ID)
+
+	do := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	// Open self-registration is off by default
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/auth/register", "", dto.RegisterRequest{Username: "stranger", Password: "password123", Name: "Stranger"}).Code)
+
+	// Invitations cannot reach dormitories the inviter cannot access
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/invitations", staffToken, dto.CreateInvitationRequest{
+		Username: "sneaky", RoleID: secretaryRole.ID.String(), DormitoryIDs: []string{otherDorm.ID.String()},
+	}).Code)
+
+	createRes := do(http.MethodPost, "/api/invitations", adminToken, dto.CreateInvitationRequest{
+		Username: "newsecretary", RoleID: secretaryRole.ID.String(), DormitoryIDs: []string{dorm.ID.String()},
+	})
+	require.Equal(t, http.StatusCreated, createRes.Code, createRes.Body.String())
+	var created struct {
+		Data dto.CreateInvitationResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(createRes.Body.Bytes(), &created))
+	require.NotEmpty(t, created.Data.Token)
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/api/invitations", adminToken, dto.CreateInvitationRequest{
+		Username: "newsecretary", RoleID: secretaryRole.ID.String(),
+	}).Code)
+
+	listRes := do(http.MethodGet, "/api/invitations", adminToken, nil)
+	require.Equal(t, http.StatusOK, listRes.Code)
+	var pending struct {
+		Data dto.ListInvitationsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(listRes.Body.Bytes(), &pending))
+	require.Len(t, pending.Data.Invitations, 1)
+	assert.Equal(t, "Secretary", pending.Data.Invitations[0].RoleName)
+	assert.NotContains(t, listRes.Body.String(), created.Data.Token)
+
+	// The invitee sets their name and password, then logs in with the invited role and dormitory
+	accept := dto.AcceptInvitationRequest{Token: created.Data.Token, Name: "New Secretary", Password: "password123"}
+	acceptRes := do(http.MethodPost, "/api/auth/invitations/accept", "", accept)
+	require.Equal(t, http.StatusCreated, acceptRes.Code, acceptRes.Body.String())
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/auth/invitations/accept", "", accept).Code)
+
+	loginRes := do(http.MethodPost, "/api/auth/login", "", dto.LoginRequest{Username: "newsecretary", Password: "password123"})
+	require.Equal(t, http.StatusOK, loginRes.Code, loginRes.Body.String())
+	var login struct {
+		Data dto.AuthResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(loginRes.Body.Bytes(), &login))
+	meRes := do(http.MethodGet, "/api/me", login.Data.AccessToken, nil)
+	require.Equal(t, http.StatusOK, meRes.Code)
+	assert.Contains(t, meRes.Body.String(), "Secretary")
+	assert.Contains(t, meRes.Body.String(), dorm.ID.String())
+
+	// A failed acceptance rolls back the claim, so the invitee can retry
+	retryRes := do(http.MethodPost, "/api/invitations", adminToken, dto.CreateInvitationRequest{
+		Username: "retrysecretary", RoleID: secretaryRole.ID.String(), DormitoryIDs: []string{dorm.ID.String()},
+	})
+	require.Equal(t, http.StatusCreated, retryRes.Code)
+	var retry struct {
+		Data dto.CreateInvitationResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(retryRes.Body.Bytes(), &retry))
+	require.NoError(t, db.Exec(`CREATE TRIGGER fail_user_insert BEFORE INSERT ON users WHEN NEW.username = 'retrysecretary' BEGIN SELECT RAISE(ABORT, 'insert failed'); END`).Error)
+	retryAccept := dto.AcceptInvitationRequest{Token: retry.Data.Token, Name: "Retry Secretary", Password: "password123"}
+	assert.Equal(t, http.StatusInternalServerError, do(http.MethodPost, "/api/auth/invitations/accept", "", retryAccept).Code)
+	var retryInvitation entity.Invitation
+	require.NoError(t, db.Where("id = ?", retry.Data.ID).First(&retryInvitation).Error)
+	assert.Nil(t, retryInvitation.AcceptedAt)
+	require.NoError(t, db.Exec("DROP TRIGGER fail_user_insert").Error)
+	retryAcceptRes := do(http.MethodPost, "/api/auth/invitations/accept", "", retryAccept)
+	require.Equal(t, http.StatusCreated, retryAcceptRes.Code, retryAcceptRes.Body.String())
+	assert.Contains(t, retryAcceptRes.Body.String(), dorm.ID.String())
+
+	// Revoked invitations can no longer be redeemed
+	revokeRes := do(http.MethodPost, "/api/invitations", adminToken, dto.CreateInvitationRequest{Username: "revoked", RoleID: secretaryRole.ID.String()})
+	require.Equal(t, http.StatusCreated, revokeRes.Code)
+	var revoked struct {
+		Data dto.CreateInvitationResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(revokeRes.Body.Bytes(), &revoked))
+	require.Equal(t, http.StatusOK, do(http.MethodDelete, "/api/invitations/"+revoked.Data.ID, adminToken, nil).Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/api/invitations/"+revoked.Data.ID, adminToken, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/auth/invitations/accept", "", dto.AcceptInvitationRequest{
+		Token: revoked.Data.Token, Name: "Revoked", Password: "password123",
+	}).Code)
+}
+
+func TestImpersonationIntegration(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	admin, adminToken := createTestUser(t, db, "impadmin", tokenService)
+	assignRoleWithPermissions(t, db, admin.ID, "admin", []string{"*"})
+	secretary, _ := createTestUser(t, db, "impsecretary", tokenService)
+	assignRoleWithPermissions(t, db, secretary.ID, "secretary", []string{"user:read", "user:create", "user:update", "role:create", "dorm:create", "dorm:update", "user:impersonate"})
+	support, supportToken := createTestUser(t, db, "impsupport", tokenService)
+	assignPermissionsToUser(t, db, support.ID, []string{"user:impersonate", "user:read"})
+	dorm := seedDormitory(t, db, "Impersonation Dorm")
+	assignUserDormitory(t, db, secretary.ID, dorm.ID)
+
+	do := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+	impersonatePath := "/api/admin/impersonate/" + secretary.ID.String()
+
+	// Impersonation cannot grant permissions the caller does not hold
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, impersonatePath, supportToken, nil).Code)
+
+	res := do(http.MethodPost, impersonatePath, adminToken, nil)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	var issued struct {
+		Data dto.ImpersonationResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &issued))
+	assert.Equal(t, admin.ID.String(), issued.Data.ImpersonatorID)
+	impToken := issued.Data.AccessToken
+
+	// Requests run as the secretary
+	meRes := do(http.MethodGet, "/api/me", impToken, nil)
+	require.Equal(t, http.StatusOK, meRes.Code)
+	assert.Contains(t, meRes.Body.String(), `"username":"impsecretary"`)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/roles", impToken, nil).Code)
+
+	// Audit entries record both identities
+	createRes := do(http.MethodPost, "/api/dormitories", impToken, dto.CreateDormitoryRequest{Name: "Impersonated Dorm", Gender: "male", Level: "SMP", Code: "IMP1"})
+	require.Equal(t, http.StatusCreated, createRes.Code, createRes.Body.String())
+	auditRes := do(http.MethodGet, "/api/audit-logs?action=dorm:create&actor_username=impsecretary", adminToken, nil)
+	require.Equal(t, http.StatusOK, auditRes.Code)
+	var audit struct {
+		Data dto.ListAuditLogsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(auditRes.Body.Bytes(), &audit))
+	require.NotEmpty(t, audit.Data.Logs)
+	assert.Equal(t, secretary.ID.String(), audit.Data.Logs[0].ActorID)
+	assert.Equal(t, admin.ID.String(), audit.Data.Logs[0].ImpersonatorID)
+	assert.Equal(t, "impadmin", audit.Data.Logs[0].ImpersonatorUsername)
+
+	// Permission management, credential changes and nested impersonation are
+	// blocked even though the secretary holds the permissions
+	for _, path := range []string{"/api/roles", "/api/admin/impersonate/" + support.ID.String(), "/api/me/password", "/api/users", "/api/users/" + support.ID.String() + "/reset-password"} {
+		blocked := do(http.MethodPost, path, impToken, map[string]string{"name": "blocked"})
+		assert.Equal(t, http.StatusForbidden, blocked.Code, path)
+		assert.Contains(t, blocked.Body.String(), "Not allowed while impersonating", path)
+	}
+	dormUsersPath := "/api/dormitories/" + dorm.ID.String() + "/users"
+	blocked := do(http.MethodPost, dormUsersPath, impToken, dto.AssignDormitoryUserRequest{UserID: support.ID.String()})
+	assert.Equal(t, http.StatusForbidden, blocked.Code)
+	assert.Contains(t, blocked.Body.String(), "Not allowed while impersonating")
+	for _, path := range []string{
+		"/api/users/" + support.ID.String() + "/2fa",
+		"/api/users/" + support.ID.String() + "/sessions",
+		"/api/login-lockouts/" + uuid.New().String(),
+		dormUsersPath + "/" + support.ID.String(),
+	} {
+		blocked = do(http.MethodDelete, path, impToken, nil)
+		assert.Equal(t, http.StatusForbidden, blocked.Code, path)
+		assert.Contains(t, blocked.Body.String(), "Not allowed while impersonating", path)
+	}
+
+	// Role assignments cannot be changed through a user update either
+	var adminRole entity.Role
+	require.NoError(t, db.Where("slug = ?", "admin").First(&adminRole).Error)
+	blocked = do(http.MethodPut, "/api/users/"+support.ID.String(), impToken, dto.UpdateUserRequest{RoleIDs: []string{adminRole.ID.String()}})
+	assert.Equal(t, http.StatusForbidden, blocked.Code)
+	assert.Contains(t, blocked.Body.String(), "Not allowed while impersonating")
+	var supportRoles int64
+	require.NoError(t, db.Model(&entity.UserRole{}).Where("user_id = ? AND role_id = ?", support.ID, adminRole.ID).Count(&supportRoles).Error)
+	assert.Zero(t, supportRoles)
+
+	// Tokens stop working once the admin is deactivated
+	inactive := false
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/api/users/"+admin.ID.String(), adminToken, dto.UpdateUserRequest{IsActive: &inactive}).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/me", impToken, nil).Code)
+}
+
+func TestAuditEntityHistoryIntegration(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	admin, adminToken := createTestUser(t, db, "historyadmin", tokenService)
+	assignRoleWithPermissions(t, db, admin.ID, "admin", []string{"*"})
+	staff, _ := createTestUser(t, db, "historystaff", tokenService)
+
+	do := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/api/users/"+staff.ID.String(), dto.UpdateUserRequest{Name: "History Staff Renamed"}).Code)
+	require.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/api/users/"+staff.ID.String(), nil).Code)
+
+	res := do(http.MethodGet, "/api/audit-logs/entity/user/"+staff.ID.String(), nil)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	var history struct {
+		Data dto.EntityHistoryResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &history))
+	require.Len(t, history.Data.Entries, 2)
+
+	update := history.Data.Entries[0]
+	assert.Equal(t, "user:update", update.Action)
+	assert.Equal(t, dto.AuditFieldChange{Old: staff.Name, New: "History Staff Renamed"}, update.Changes["name"])
+	assert.NotContains(t, update.Changes, "username")
+
+	deletion := history.Data.Entries[1]
+	assert.Equal(t, "user:delete", deletion.Action)
+	assert.Equal(t, dto.AuditFieldChange{Old: "historystaff", New: nil}, deletion.Changes["username"])
+	assert.Equal(t, dto.AuditFieldChange{Old: "[REDACTED]", New: nil}, deletion.Changes["password"])
+	assert.NotContains(t, res.Body.String(), staff.Password)
+}
+
+func TestAuditChainIntegration(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	admin, adminToken := createTestUser(t, db, "chainadmin", tokenService)
+	assignRoleWithPermissions(t, db, admin.ID, "admin", []string{"*"})
+	staff, _ := createTestUser(t, db, "chainstaff", tokenService)
+
+	do := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+	verify := func() dto.AuditChainVerificationResponse {
+		res := do(http.MethodGet, "/api/audit-logs/verify", nil)
+		require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+		var body struct {
+			Data dto.AuditChainVerificationResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		return body.Data
+	}
+
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/api/users/"+staff.ID.String(), dto.UpdateUserRequest{Name: "Chain One"}).Code)
+	result := verify()
+	assert.True(t, result.Valid)
+	require.Positive(t, result.Entries)
+
+	res := do(http.MethodPost, "/api/audit-logs/checkpoints", nil)
+	require.Equal(t, http.StatusCreated, res.Code, res.Body.String())
+	var created struct {
+		Data dto.AuditCheckpointResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &created))
+	assert.Equal(t, result.HeadSequence, created.Data.Sequence)
+	assert.Equal(t, result.HeadHash, created.Data.Hash)
+	assert.Equal(t, 1, verify().Checkpoints)
+
+	// Silently editing a row breaks the chain at that entry
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/api/users/"+staff.ID.String(), dto.UpdateUserRequest{Name: "Chain Two"}).Code)
+	var edited entity.AuditLog
+	require.NoError(t, db.Where("action = ?", "user:update").Order("sequence DESC").First(&edited).Error)
+	require.NoError(t, db.Model(&entity.AuditLog{}).Where("id = ?", edited.ID).Update("metadata", `{"name":"Someone Else"}`).Error)
+
+	result = verify()
+	assert.False(t, result.Valid)
+	require.NotNil(t, result.FirstBroken)
+	assert.Equal(t, edited.Sequence, result.FirstBroken.Sequence)
+	assert.Equal(t, edited.ID.String(), result.FirstBroken.ID)
+
+	// A tampered chain is never signed
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/api/audit-logs/checkpoints", nil).Code)
+
+	exportRes := do(http.MethodGet, "/api/audit-logs/checkpoints/export", nil)
+	require.Equal(t, http.StatusOK, exportRes.Code)
+	assert.Regexp(t, `^attachment; filename="audit-checkpoints-\d{8}\.json"$`, exportRes.Header().Get("Content-Disposition"))
+	var export dto.AuditCheckpointExport
+	require.NoError(t, json.Unmarshal(exportRes.Body.Bytes(), &export))
+	assert.Equal(t, usecase.AuditCheckpointFormat, export.Format)
+	require.Len(t, export.Checkpoints, 1)
+	assert.Contains(t, export.Checkpoints[0].PublicKey, "BEGIN PUBLIC KEY")
+	assert.NotEmpty(t, export.Checkpoints[0].Signature)
+}
+
+func TestAuditExportAndArchiveIntegration(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	admin, adminToken := createTestUser(t, db, "archiveadmin", tokenService)
+	assignRoleWithPermissions(t, db, admin.ID, "admin", []string{"*"})
+	staff, _ := createTestUser(t, db, "archivestaff", tokenService)
+
+	do := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	for _, name := range []string{"Archive One", "Archive Two", "Archive Three"} {
+		require.Equal(t, http.StatusOK, do(http.MethodPut, "/api/users/"+staff.ID.String(), dto.UpdateUserRequest{Name: name}).Code)
+	}
+	// Backdate the first two updates beyond the retention period
+	var updates []entity.AuditLog
+	require.NoError(t, db.Where("action = ?", "user:update").Order("sequence ASC").Find(&updates).Error)
+	require.Len(t, updates, 3)
+	var all []entity.AuditLog
+	require.NoError(t, db.Order("sequence ASC").Find(&all).Error)
+	old := time.Now().AddDate(-1, 0, 0)
+	for i := range all {
+		if all[i].Sequence > updates[1].Sequence {
+			break
+		}
+		all[i].CreatedAt = old
+	}
+	// Rewrite the backdated prefix so the chain stays intact
+	prevHash := ""
+	for i := range all {
+		all[i].PrevHash = prevHash
+		all[i].Hash = all[i].ComputeHash()
+		prevHash = all[i].Hash
+		require.NoError(t, db.Model(&entity.AuditLog{}).Where("id = ?", all[i].ID).Updates(map[string]interface{}{
+			"created_at": all[i].CreatedAt, "prev_hash": all[i].PrevHash, "hash": all[i].Hash,
+		}).Error)
+	}
+
+	res := do(http.MethodGet, "/api/audit-logs?action=user:update&actor_id="+admin.ID.String()+"&status_code=200&from="+time.Now().AddDate(0, 0, -1).Format("2006-01-02"), nil)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	var listed struct {
+		Data dto.ListAuditLogsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &listed))
+	assert.Equal(t, int64(1), listed.Data.Total)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/api/audit-logs?from=yesterday", nil).Code)
+
+	res = do(http.MethodGet, "/api/audit-logs/export?format=csv&action=user:update", nil)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, "text/csv", res.Header().Get("Content-Type"))
+	assert.Regexp(t, `^attachment; filename="audit-logs-\d{8}-\d{6}\.csv"$`, res.Header().Get("Content-Disposition"))
+	records, err := csv.NewReader(res.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, 4)
+
+	res = do(http.MethodGet, "/api/audit-logs/export?action=user:update", nil)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "application/x-ndjson", res.Header().Get("Content-Type"))
+	assert.Len(t, strings.Split(strings.TrimSpace(res.Body.String()), "\n"), 3)
+
+	// Without AUDIT_RETENTION_MONTHS the age must be given
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/audit-logs/archives", nil).Code)
+
+	res = do(http.MethodPost, "/api/audit-logs/archives", dto.ArchiveAuditLogsRequest{OlderThanMonths: 6})
+	require.Equal(t, http.StatusCreated, res.Code, res.Body.String())
+	var archived struct {
+		Data dto.AuditArchiveResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &archived))
+	assert.Equal(t, int64(1), archived.Data.FromSequence)
+	assert.Equal(t, updates[1].Sequence, archived.Data.ToSequence)
+	assert.FileExists(t, archived.Data.File)
+
+	var remaining int64
+	require.NoError(t, db.Model(&entity.AuditLog{}).Where("sequence <= ?", archived.Data.ToSequence).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+
+	res = do(http.MethodGet, "/api/audit-logs/verify", nil)
+	require.Equal(t, http.StatusOK, res.Code)
+	var verified struct {
+		Data dto.AuditChainVerificationResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &verified))
+	assert.True(t, verified.Data.Valid, verified.Data.FirstBroken)
+	assert.Equal(t, archived.Data.ToSequence, verified.Data.ArchivedThrough)
+
+	res = do(http.MethodGet, "/api/audit-logs/archives", nil)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), archived.Data.FileSHA256)
+}
+
+func TestRequestIDIntegration(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	admin, adminToken := createTestUser(t, db, "requestidadmin", tokenService)
+	assignRoleWithPermissions(t, db, admin.ID, "admin", []string{"*"})
+	staff, _ := createTestUser(t, db, "requestidstaff", tokenService)
+
+	do := func(method, path, requestID string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		if requestID != "" {
+			req.Header.Set("X-Request-ID", requestID)
+		}
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	res := do(http.MethodPut, "/api/users/"+staff.ID.String(), "support-ticket-42", dto.UpdateUserRequest{Name: "Renamed"})
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, "support-ticket-42", res.Header().Get("X-Request-ID"))
+
+	res = do(http.MethodGet, "/api/audit-logs?request_id=support-ticket-42", "", nil)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	var listed struct {
+		Data dto.ListAuditLogsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &listed))
+	require.Equal(t, int64(1), listed.Data.Total)
+	assert.Equal(t, "user:update", listed.Data.Logs[0].Action)
+	assert.Equal(t, "support-ticket-42", listed.Data.Logs[0].RequestID)
+
+	// Error bodies echo the request ID, generated when the caller sent none
+	res = do(http.MethodGet, "/api/users/"+uuid.NewString(), "", nil)
+	require.Equal(t, http.StatusNotFound, res.Code, res.Body.String())
+	var errBody response.ErrorResponse
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &errBody))
+	assert.NotEmpty(t, errBody.RequestID)
+	assert.Equal(t, res.Header().Get("X-Request-ID"), errBody.RequestID)
+}
+
+// TestRouter_ProtectedRoutesRequirePermission fails when a route behind
+// RequireAuth is registered without a permission guard. Self-service routes
+// for the current user are the only exception.
+func TestRouter_ProtectedRoutesRequirePermission(t *testing.T) {
+	router, db, tokenService, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	selfServiceRoutes := map[string]bool{
+		"GET /api/me":                     true,
+		"GET /api/me/permissions":         true,
+		"POST /api/me/password":           true,
+		"POST /api/me/2fa/setup":          true,
+		"POST /api/me/2fa/confirm":        true,
+		"POST /api/me/2fa/recovery-codes": true,
+		"DELETE /api/me/2fa":              true,
+	}
+	// An authenticated user without any role or permission
+	_, token := createTestUser(t, db, "nopermissions", tokenService)
+	paramPattern := regexp.MustCompile(`:[a-z_]+`)
+
+	for _, route := range router.Routes() {
+		key := route.Method + " " + route.Path
+		if selfServiceRoutes[key] {
+			continue
+		}
+		path := paramPattern.ReplaceAllString(route.Path, uuid.NewString())
+
+		req := httptest.NewRequest(route.Method, path, nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		if res.Code != http.StatusUnauthorized {
+			continue // public route
+		}
+
+		req = httptest.NewRequest(route.Method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		res = httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusForbidden, res.Code, "%s has no permission guard", key)
+		assert.Contains(t, res.Body.String(), "Permission denied", "%s has no permission guard", key)
+	}
+}
@@ -1,43 +1,90 @@
 package middleware
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/permission"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/domain/service"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/logger"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
-// AuthMiddleware handles JWT authentication
+// passwordChangeRoutes are the only routes reachable while a user must change
+// their password.
+var passwordChangeRoutes = map[string]bool{
+	"GET /api/me":           true,
+	"POST /api/me/password": true,
+}
+
+// twoFactorSetupRoutes are the only routes reachable while a role requires
+// two-factor authentication the user has not enabled yet.
+var twoFactorSetupRoutes = map[string]bool{
+	"GET /api/me":              true,
+	"POST /api/me/password":    true,
+	"POST /api/me/2fa/setup":   true,
+	"POST /api/me/2fa/confirm": true,
+}
+
+// APIKeyAuthenticator resolves service-account API keys to their user, whose
+// permissions are already restricted to the key's scope.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, rawKey string) (*entity.User, *entity.APIKey, error)
+}
+
+// AuthMiddleware handles JWT and API key authentication
 type AuthMiddleware struct {
 	tokenService service.TokenService
 	userRepo     repository.UserRepository
+	apiKeys      APIKeyAuthenticator
+	principals   service.PrincipalCache
 }
 
-// NewAuthMiddleware creates a new auth middleware
+// NewAuthMiddleware creates a new auth middleware. principals may be nil to
+// load the user from the database on every request.
 func NewAuthMiddleware(
 	tokenService service.TokenService,
 	userRepo repository.UserRepository,
+	apiKeys APIKeyAuthenticator,
+	principals service.PrincipalCache,
 ) *AuthMiddleware {
 	return &AuthMiddleware{
 		tokenService: tokenService,
 		userRepo:     userRepo,
+		apiKeys:      apiKeys,
+		principals:   principals,
 	}
 }
 
-// RequireAuth is a middleware that requires valid JWT token
+// RequireAuth is a middleware that requires a valid JWT access token or, for
+// service accounts, an API key sent as "Authorization: Bearer sigap_..." or
+// in the X-API-Key header.
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if m.authenticate(c) {
+			c.Next()
+		}
+	}
+}
+
+// authenticate resolves the request's credential and stores the principal in
+// the context. It writes the error response and aborts when it returns false.
+func (m *AuthMiddleware) authenticate(c *gin.Context) bool {
+	credential := c.GetHeader("X-API-Key")
+	if credential == "" {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			response.ErrorUnauthorized(c, "Authorization header required")
 			c.Abort()
-			return
+			return false
 		}
 
 		// Extract token from "Bearer <token>"
@@ -45,50 +92,193 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		if len(parts) != 2 || parts[0] != "Bearer" {
 			response.ErrorUnauthorized(c, "Invalid authorization header format")
 			c.Abort()
-			return
+			return false
 		}
+		credential = parts[1]
+	}
 
-		tokenString := parts[1]
+	if strings.HasPrefix(credential, entity.APIKeyPrefix) {
+		return m.authenticateAPIKey(c, credential)
+	}
+	return m.authenticateToken(c, credential)
+}
 
-		// Validate token
-		claims, err := m.tokenService.ValidateToken(tokenString)
-		if err != nil {
-			if err == domainErrors.ErrTokenExpired {
-				response.ErrorUnauthorized(c, "Token expired")
-			} else {
-				response.ErrorUnauthorized(c, "Invalid token")
-			}
-			c.Abort()
-			return
+// loadPrincipal returns the user with roles, permissions and dormitories,
+// from the principal cache when possible. Cache errors fall back to the
+// database.
+func (m *AuthMiddleware) loadPrincipal(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	if m.principals != nil {
+		if user, err := m.principals.Get(ctx, userID); err == nil && user != nil {
+			return user, nil
 		}
+	}
 
-		// Get user with roles and dormitories
-		user, err := m.userRepo.GetWithRolesAndDormitories(c.Request.Context(), claims.UserID)
-		if err != nil {
-			response.ErrorUnauthorized(c, "User not found")
-			c.Abort()
-			return
+	loadedAt := time.Now()
+	user, err := m.userRepo.GetWithRolesAndDormitories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if m.principals != nil {
+		if err := m.principals.Set(ctx, user, loadedAt); err != nil {
+			slog.WarnContext(ctx, "principal cache: failed to store user", "cached_user_id", userID.String(), "error", err)
+		}
+	}
+	return user, nil
+}
+
+// authenticateToken handles interactive users holding a JWT access token
+func (m *AuthMiddleware) authenticateToken(c *gin.Context, tokenString string) bool {
+	// Validate token
+	claims, err := m.tokenService.ValidateToken(tokenString)
+	if err != nil {
+		if err == domainErrors.ErrTokenExpired {
+			response.ErrorUnauthorized(c, "Token expired")
+		} else {
+			response.ErrorUnauthorized(c, "Invalid token")
 		}
+		c.Abort()
+		return false
+	}
+
+	// Get user with roles and dormitories
+	user, err := m.loadPrincipal(c.Request.Context(), claims.UserID)
+	if err != nil {
+		response.ErrorUnauthorized(c, "User not found")
+		c.Abort()
+		return false
+	}
+
+	// Check if user is active
+	if !user.IsActive {
+		response.ErrorForbidden(c, "User is inactive")
+		c.Abort()
+		return false
+	}
 
-		// Check if user is active
-		if !user.IsActive {
-			response.ErrorForbidden(c, "User is inactive")
+	// Users holding a temporary password must replace it first
+	if user.MustChangePassword && !passwordChangeRoutes[c.Request.Method+" "+c.FullPath()] {
+		response.ErrorForbidden(c, "Password change required", domainErrors.ErrPasswordChangeRequired.Error())
+		c.Abort()
+		return false
+	}
+
+	// Privileged roles must enrol in two-factor authentication first
+	if user.RequiresTwoFactorSetup() && !twoFactorSetupRoutes[c.Request.Method+" "+c.FullPath()] {
+		response.ErrorForbidden(c, "Two-factor authentication setup required", domainErrors.ErrTwoFactorSetupRequired.Error())
+		c.Abort()
+		return false
+	}
+
+	var impersonator *entity.User
+	if claims.ImpersonatorID != uuid.Nil {
+		// The admin must still be allowed to impersonate for every request
+		impersonator, err = m.loadPrincipal(c.Request.Context(), claims.ImpersonatorID)
+		if err != nil || !impersonator.IsActive || !impersonator.HasPermission(permission.UserImpersonate) {
+			response.ErrorUnauthorized(c, "Impersonation is no longer allowed")
 			c.Abort()
-			return
+			return false
+		}
+	}
+
+	m.setPrincipal(c, user, claims.Roles, nil, impersonator)
+	return true
+}
+
+// authenticateAPIKey handles service accounts. Password and two-factor
+// requirements do not apply, as these accounts never log in interactively.
+func (m *AuthMiddleware) authenticateAPIKey(c *gin.Context, rawKey string) bool {
+	user, key, err := m.apiKeys.Authenticate(c.Request.Context(), rawKey)
+	if err != nil {
+		if err == domainErrors.ErrInvalidAPIKey {
+			response.ErrorUnauthorized(c, "Invalid API key")
+		} else {
+			response.ErrorInternalServer(c, "Failed to verify API key", err.Error())
 		}
+		c.Abort()
+		return false
+	}
+
+	if !user.IsActive {
+		response.ErrorForbidden(c, "User is inactive")
+		c.Abort()
+		return false
+	}
+
+	roles := make([]string, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		roles = append(roles, role.Name)
+	}
+	m.setPrincipal(c, user, roles, key, nil)
+	return true
+}
+
+// setPrincipal stores the authenticated user in the gin context for handlers
+// and in the request context for use cases and the audit logger. impersonator
+// is the admin behind an impersonation token, if any.
+func (m *AuthMiddleware) setPrincipal(c *gin.Context, user *entity.User, roles []string, key *entity.APIKey, impersonator *entity.User) {
+	c.Set("user_id", user.ID)
+	c.Set("user_username", user.Username)
+	c.Set("user_roles", roles)
+	c.Set("user", user)
 
-		// Store user info in context
-		c.Set("user_id", claims.UserID)
-		c.Set("user_username", claims.Username)
-		c.Set("user_roles", claims.Roles)
-		c.Set("user", user)
+	ctx := c.Request.Context()
+	ctx = context.WithValue(ctx, appService.CtxKeyActorID, user.ID)
+	ctx = context.WithValue(ctx, appService.CtxKeyActorUsername, user.Username)
+	ctx = context.WithValue(ctx, appService.CtxKeyActorRoles, roles)
+	logAttrs := []slog.Attr{slog.String("user_id", user.ID.String())}
+	if key != nil {
+		c.Set("api_key", key)
+		ctx = context.WithValue(ctx, appService.CtxKeyAPIKeyID, key.ID)
+	}
+	if impersonator != nil {
+		c.Set("impersonator", impersonator)
+		ctx = context.WithValue(ctx, appService.CtxKeyImpersonatorID, impersonator.ID)
+		ctx = context.WithValue(ctx, appService.CtxKeyImpersonatorUsername, impersonator.Username)
+		logAttrs = append(logAttrs, slog.String("impersonator_id", impersonator.ID.String()))
+	}
+	ctx = logger.WithAttrs(ctx, logAttrs...)
+
+	// Expose dormitory scope to use cases via the request context
+	scope := appService.DormitoryScope{All: user.HasAllDormitoryAccess()}
+	for _, dorm := range user.Dormitories {
+		scope.DormitoryIDs = append(scope.DormitoryIDs, dorm.ID)
+	}
+	c.Request = c.Request.WithContext(appService.WithDormitoryScope(ctx, scope))
+}
 
+// BlockImpersonation rejects requests made with an impersonation token, for
+// routes that change permissions or credentials.
+func (m *AuthMiddleware) BlockImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, impersonating := c.Get("impersonator"); impersonating {
+			response.ErrorForbidden(c, "Not allowed while impersonating", domainErrors.ErrImpersonationActive.Error())
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
 
 // RequirePermission is a middleware that requires specific permission
 func (m *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
+	return m.requirePermissions("RequirePermission", []string{permission}, (*entity.User).HasAllPermissions)
+}
+
+// RequireAnyPermission lets the request through when the user has at least
+// one of the permissions, e.g. a page readable by several roles.
+func (m *AuthMiddleware) RequireAnyPermission(permissions ...string) gin.HandlerFunc {
+	return m.requirePermissions("RequireAnyPermission", permissions, (*entity.User).HasAnyPermission)
+}
+
+// RequireAllPermissions requires the user to have every one of the permissions.
+func (m *AuthMiddleware) RequireAllPermissions(permissions ...string) gin.HandlerFunc {
+	return m.requirePermissions("RequireAllPermissions", permissions, (*entity.User).HasAllPermissions)
+}
+
+func (m *AuthMiddleware) requirePermissions(name string, permissions []string, check func(*entity.User, ...string) bool) gin.HandlerFunc {
+	if len(permissions) == 0 {
+		panic(name + ": at least one permission is required")
+	}
 	return func(c *gin.Context) {
 		// RequireAuth should already have run for this route (protected group).
 		// We only rely on the user that was set in the context by RequireAuth.
@@ -107,13 +297,13 @@ func (m *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
 		}
 
 		// Check permission
-		if !userEntity.HasPermission(permission) {
+		if !check(userEntity, permissions...) {
 			response.ErrorForbidden(c, "Permission denied")
 			c.Abort()
 			return
 		}
 
-		log.Printf("RequirePermission: user=%s checking=%s", userEntity.Username, permission)
+		slog.DebugContext(c.Request.Context(), "permission granted", "check", name, "permissions", permissions)
 
 		c.Next()
 	}
@@ -122,9 +312,9 @@ func (m *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
 // RequireDormitoryAccess is a middleware that checks if user can access a dormitory
 func (m *AuthMiddleware) RequireDormitoryAccess() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// First check auth
-		m.RequireAuth()(c)
-		if c.IsAborted() {
+		// Routes in the protected group are already authenticated; only
+		// authenticate here when used on its own
+		if _, exists := c.Get("user"); !exists && !m.authenticate(c) {
 			return
 		}
 
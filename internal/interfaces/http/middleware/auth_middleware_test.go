@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+func TestAuthMiddleware_PermissionGuards(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user := &entity.User{Username: "musyrif", Roles: []entity.Role{{
+		Name:        "musyrif",
+		Permissions: []entity.Permission{{Name: "attendance_sessions:*"}, {Name: "student:read"}},
+	}}}
+	m := &AuthMiddleware{}
+
+	tests := []struct {
+		name     string
+		guard    gin.HandlerFunc
+		expected int
+	}{
+		{"single exact", m.RequirePermission("student:read"), http.StatusOK},
+		{"single wildcard", m.RequirePermission("attendance_sessions:lock"), http.StatusOK},
+		{"single denied", m.RequirePermission("student:create"), http.StatusForbidden},
+		{"any with one match", m.RequireAnyPermission("user:delete", "attendance_sessions:update"), http.StatusOK},
+		{"any without match", m.RequireAnyPermission("user:delete", "role:read"), http.StatusForbidden},
+		{"all granted", m.RequireAllPermissions("student:read", "attendance_sessions:read"), http.StatusOK},
+		{"all with one missing", m.RequireAllPermissions("student:read", "student:update"), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) { c.Set("user", user) }, tt.guard, func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, tt.expected, res.Code)
+		})
+	}
+
+	assert.Panics(t, func() { m.RequireAnyPermission() })
+}
@@ -1,7 +1,7 @@
 package middleware
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
@@ -52,7 +52,7 @@ func NewCORSMiddlewareFromEnv() gin.HandlerFunc {
 
 		// If we have a match (or allowAll), set CORS headers
 		if allowedOrigin != "" {
-			log.Printf("CORS middleware: origin=%q allowedOrigin=%q", origin, allowedOrigin)
+			slog.DebugContext(c.Request.Context(), "cors: origin allowed", "origin", origin, "allowed_origin", allowedOrigin)
 			c.Header("Access-Control-Allow-Origin", allowedOrigin)
 			c.Header("Vary", "Origin")
 			c.Header("Access-Control-Allow-Credentials", "true")
@@ -69,3 +69,18 @@ func NewCORSMiddlewareFromEnv() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// TrustedProxiesFromEnv returns the proxies listed in TRUSTED_PROXIES
+// (comma-separated IPs or CIDRs). Only requests from these addresses may set
+// the client IP through X-Forwarded-For; with none configured the client IP
+// is the connection's remote address, so callers cannot spoof it to dodge
+// per-IP login throttling.
+func TrustedProxiesFromEnv() []string {
+	var proxies []string
+	for _, p := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if trimmed := strings.TrimSpace(p); trimmed != "" {
+			proxies = append(proxies, trimmed)
+		}
+	}
+	return proxies
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/logger"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// RequestIDHeader carries the request ID in both directions.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength matches the audit_logs.request_id column.
+const maxRequestIDLength = 64
+
+// RequestIDMiddleware gives every request an ID: the caller's X-Request-ID
+// when it is a sane token, a new UUID otherwise. The ID is echoed in the
+// response header and error bodies, stored on audit entries and added to
+// every log record of the request.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Set(response.CtxKeyRequestID, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := context.WithValue(c.Request.Context(), appService.CtxKeyRequestID, requestID)
+		ctx = logger.WithAttrs(ctx, slog.String("request_id", requestID))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// validRequestID accepts IDs of letters, digits and - _ . : only, so a
+// caller cannot inject arbitrary text into logs and audit entries.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RequestLogger replaces gin's access log with one structured record per
+// request. Server errors are logged at error level and client errors at warn.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Int64("latency_ms", time.Since(start).Milliseconds()),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if errs := c.Errors.ByType(gin.ErrorTypeAny).String(); errs != "" {
+			attrs = append(attrs, slog.String("errors", errs))
+		}
+		// The auth middleware adds the user to the request context
+		slog.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// Recovery turns a panicking handler into a 500 response and logs the panic
+// with the request's attributes.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, recovered interface{}) {
+		slog.ErrorContext(c.Request.Context(), "panic recovered", "panic", recovered, "stack", string(debug.Stack()))
+		response.ErrorInternalServer(c, "")
+		c.Abort()
+	})
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/logger"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var seen string
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		seen, _ = c.Request.Context().Value(appService.CtxKeyRequestID).(string)
+		response.ErrorNotFound(c, "")
+	})
+
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"honours incoming ID", "req-2026.03:abc_1", true},
+		{"generates when missing", "", false},
+		{"replaces unsafe ID", "bad id\ninjected", false},
+		{"replaces overlong ID", strings.Repeat("a", maxRequestIDLength+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+
+			requestID := res.Header().Get(RequestIDHeader)
+			if tt.keep {
+				assert.Equal(t, tt.incoming, requestID)
+			} else {
+				_, err := uuid.Parse(requestID)
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, requestID, seen)
+
+			var body response.ErrorResponse
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			assert.Equal(t, requestID, body.RequestID)
+		})
+	}
+}
+
+func TestRequestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logger.New(&buf, "info", "json"))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), RequestLogger(), Recovery())
+	router.GET("/students/:id", func(c *gin.Context) {
+		// Stands in for the auth middleware adding the user
+		c.Request = c.Request.WithContext(logger.WithAttrs(c.Request.Context(), slog.String("user_id", "user-1")))
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/students/42", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 2)
+	assert.Equal(t, "panic recovered", records[0]["msg"])
+
+	access := records[1]
+	assert.Equal(t, "ERROR", access["level"])
+	assert.Equal(t, "request", access["msg"])
+	assert.Equal(t, "req-1", access["request_id"])
+	assert.Equal(t, "user-1", access["user_id"])
+	assert.Equal(t, "/students/:id", access["route"])
+	assert.Equal(t, float64(http.StatusInternalServerError), access["status"])
+	assert.Contains(t, access, "latency_ms")
+}
@@ -10,6 +10,10 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+// CtxKeyRequestID is the gin context key holding the request ID that error
+// responses echo.
+const CtxKeyRequestID = "request_id"
+
 // SuccessResponse represents a standardized success response
 type SuccessResponse struct {
 	Success bool        `json:"success"`
@@ -19,9 +23,11 @@ type SuccessResponse struct {
 
 // ErrorResponse represents a standardized error response
 type ErrorResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Error   string `json:"error,omitempty"`
+	Success   bool        `json:"success"`
+	Message   string      `json:"message"`
+	Error     string      `json:"error,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
 }
 
 // FieldError represents a single field validation error
@@ -33,9 +39,10 @@ type FieldError struct {
 
 // ValidationErrorResponse represents a standardized validation error response
 type ValidationErrorResponse struct {
-	Success bool         `json:"success"`
-	Message string       `json:"message"`
-	Errors  []FieldError `json:"errors"`
+	Success   bool         `json:"success"`
+	Message   string       `json:"message"`
+	Errors    []FieldError `json:"errors"`
+	RequestID string       `json:"request_id,omitempty"`
 }
 
 // Success sends a standardized success response
@@ -60,9 +67,10 @@ func Error(c *gin.Context, statusCode int, message string, errorDetail ...string
 	}
 
 	c.JSON(statusCode, ErrorResponse{
-		Success: false,
-		Message: message,
-		Error:   errorDetailStr,
+		Success:   false,
+		Message:   message,
+		Error:     errorDetailStr,
+		RequestID: c.GetString(CtxKeyRequestID),
 	})
 }
 
@@ -119,9 +127,10 @@ func ErrorValidation(c *gin.Context, err error) {
 		}
 
 		c.JSON(http.StatusBadRequest, ValidationErrorResponse{
-			Success: false,
-			Message: "Validation failed",
-			Errors:  fieldErrors,
+			Success:   false,
+			Message:   "Validation failed",
+			Errors:    fieldErrors,
+			RequestID: c.GetString(CtxKeyRequestID),
 		})
 		return
 	}
@@ -162,6 +171,40 @@ func ErrorConflict(c *gin.Context, message string, errorDetail ...string) {
 	Error(c, http.StatusConflict, message, errorDetail...)
 }
 
+// ErrorConflictWithData sends a 409 Conflict error response carrying details about the conflict
+func ErrorConflictWithData(c *gin.Context, message string, data interface{}) {
+	if message == "" {
+		message = "Conflict"
+	}
+	c.JSON(http.StatusConflict, ErrorResponse{
+		Success:   false,
+		Message:   message,
+		Data:      data,
+		RequestID: c.GetString(CtxKeyRequestID),
+	})
+}
+
+// ErrorUnprocessableWithData sends a 422 Unprocessable Entity error response carrying validation details
+func ErrorUnprocessableWithData(c *gin.Context, message string, data interface{}) {
+	if message == "" {
+		message = "Unprocessable entity"
+	}
+	c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+		Success:   false,
+		Message:   message,
+		Data:      data,
+		RequestID: c.GetString(CtxKeyRequestID),
+	})
+}
+
+// ErrorTooManyRequests sends a 429 Too Many Requests error response
+func ErrorTooManyRequests(c *gin.Context, message string, errorDetail ...string) {
+	if message == "" {
+		message = "Too many requests"
+	}
+	Error(c, http.StatusTooManyRequests, message, errorDetail...)
+}
+
 // ErrorInternalServer sends a 500 Internal Server Error response
 func ErrorInternalServer(c *gin.Context, message string, errorDetail ...string) {
 	if message == "" {
@@ -1,10 +1,12 @@
 package router
 
 import (
+	"log/slog"
 	"net/http"
 	"os"
 
 	"github.com/gin-gonic/gin"
+	"github.com/your-org/go-backend-starter/internal/domain/permission"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/handler"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/middleware"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
@@ -25,16 +27,36 @@ func SetupRouter(
 	classHandler *handler.ClassHandler,
 	teacherHandler *handler.TeacherHandler,
 	classScheduleHandler *handler.ClassScheduleHandler,
+	subjectHandler *handler.SubjectHandler,
 	sksDefinitionHandler *handler.SKSDefinitionHandler,
 	sksExamHandler *handler.SKSExamScheduleHandler,
 	attendanceHandler *handler.AttendanceHandler,
+	holidayHandler *handler.HolidayHandler,
 	scheduleSlotHandler *handler.ScheduleSlotHandler,
 	leavePermitHandler *handler.LeavePermitHandler,
 	healthStatusHandler *handler.HealthStatusHandler,
 	reportHandler *handler.ReportHandler,
+	jobRunHandler *handler.JobRunHandler,
+	loginLockoutHandler *handler.LoginLockoutHandler,
+	twoFactorHandler *handler.TwoFactorHandler,
+	jwksHandler *handler.JWKSHandler,
+	serviceAccountHandler *handler.ServiceAccountHandler,
+	impersonationHandler *handler.ImpersonationHandler,
+	invitationHandler *handler.InvitationHandler,
 	authMiddleware *middleware.AuthMiddleware,
 ) *gin.Engine {
-	router := gin.Default()
+	// gin's default logger and recovery are replaced by structured ones
+	gin.DebugPrintRouteFunc = func(method, path, handlerName string, _ int) {
+		slog.Debug("route registered", "method", method, "path", path, "handler", handlerName)
+	}
+	router := gin.New()
+	if err := router.SetTrustedProxies(middleware.TrustedProxiesFromEnv()); err != nil {
+		slog.Error("invalid TRUSTED_PROXIES, trusting no proxy", "error", err)
+		_ = router.SetTrustedProxies(nil)
+	}
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.RequestLogger())
+	router.Use(middleware.Recovery())
 
 	specPath := os.Getenv("OPENAPI_SPEC_PATH")
 	if specPath == "" {
@@ -77,6 +99,9 @@ func SetupRouter(
 		response.SuccessOK(c, gin.H{"status": "ok"}, "Service is healthy")
 	})
 
+	// Public keys for verifying access tokens in other services
+	router.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	// API routes
 	api := router.Group("/api")
 	{
@@ -84,8 +109,11 @@ func SetupRouter(
 		auth := api.Group("/auth")
 		{
 			auth.POST("/register", authHandler.Register)
+			auth.POST("/invitations/accept", invitationHandler.AcceptInvitation)
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/login/2fa", authHandler.VerifyTwoFactor)
 			auth.POST("/refresh", authHandler.RefreshToken)
+			auth.POST("/logout", authHandler.Logout)
 		}
 
 		// Public location routes (no auth)
@@ -104,128 +132,191 @@ func SetupRouter(
 		{
 			// Current user
 			protected.GET("/me", userHandler.Me)
+			protected.GET("/me/permissions", permissionHandler.MyPermissions)
+			protected.POST("/me/password", authMiddleware.BlockImpersonation(), authHandler.ChangePassword)
+
+			// Two-factor authentication for the current user
+			twoFactor := protected.Group("/me/2fa")
+			twoFactor.Use(authMiddleware.BlockImpersonation())
+			{
+				twoFactor.POST("/setup", twoFactorHandler.BeginSetup)
+				twoFactor.POST("/confirm", twoFactorHandler.ConfirmSetup)
+				twoFactor.POST("/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+				twoFactor.DELETE("", twoFactorHandler.Disable)
+			}
+
+			// Admin tools; an impersonation token cannot start another impersonation
+			admin := protected.Group("/admin")
+			admin.Use(authMiddleware.BlockImpersonation())
+			{
+				admin.POST("/impersonate/:user_id", authMiddleware.RequirePermission(permission.UserImpersonate), impersonationHandler.Impersonate)
+			}
 
 			// Audit log routes (read-only)
 			auditLogs := protected.Group("/audit-logs")
 			{
-				auditLogs.GET("", authMiddleware.RequirePermission("audit:read"), auditLogHandler.ListAuditLogs)
+				auditLogs.GET("", authMiddleware.RequirePermission(permission.AuditRead), auditLogHandler.ListAuditLogs)
+				auditLogs.GET("/entity/:resource/:id", authMiddleware.RequirePermission(permission.AuditRead), auditLogHandler.GetEntityHistory)
+				auditLogs.GET("/export", authMiddleware.RequirePermission(permission.AuditRead), auditLogHandler.ExportAuditLogs)
+				auditLogs.GET("/archives", authMiddleware.RequirePermission(permission.AuditRead), auditLogHandler.ListArchives)
+				auditLogs.POST("/archives", authMiddleware.RequirePermission(permission.AuditArchive), auditLogHandler.ArchiveAuditLogs)
+				auditLogs.GET("/writer-stats", authMiddleware.RequirePermission(permission.AuditRead), auditLogHandler.GetWriterStats)
+				auditLogs.GET("/verify", authMiddleware.RequirePermission(permission.AuditVerify), auditLogHandler.VerifyChain)
+				auditLogs.GET("/checkpoints", authMiddleware.RequirePermission(permission.AuditRead), auditLogHandler.ListCheckpoints)
+				auditLogs.GET("/checkpoints/export", authMiddleware.RequirePermission(permission.AuditRead), auditLogHandler.ExportCheckpoints)
+				auditLogs.POST("/checkpoints", authMiddleware.RequirePermission(permission.AuditVerify), auditLogHandler.CreateCheckpoint)
+			}
+
+			// Scheduled job run history (read-only)
+			jobRuns := protected.Group("/job-runs")
+			{
+				jobRuns.GET("", authMiddleware.RequirePermission(permission.JobRunsRead), jobRunHandler.ListJobRuns)
+			}
+
+			// Failed-login lockouts (admin unlock)
+			loginLockouts := protected.Group("/login-lockouts")
+			{
+				loginLockouts.GET("", authMiddleware.RequirePermission(permission.UserUpdate), loginLockoutHandler.ListLoginLockouts)
+				loginLockouts.DELETE("/:id", authMiddleware.RequirePermission(permission.UserUpdate), authMiddleware.BlockImpersonation(), loginLockoutHandler.UnlockLogin)
 			}
 
 			// Student routes
 			students := protected.Group("/students")
 			{
-				students.GET("", authMiddleware.RequirePermission("student:read"), studentHandler.ListStudents)
-				students.GET(":id", authMiddleware.RequirePermission("student:read"), studentHandler.GetStudent)
-				students.POST("", authMiddleware.RequirePermission("student:create"), studentHandler.CreateStudent)
-				students.PUT(":id", authMiddleware.RequirePermission("student:update"), studentHandler.UpdateStudent)
-				students.PATCH(":id/status", authMiddleware.RequirePermission("student:update"), studentHandler.UpdateStudentStatus)
-				students.POST(":id/mutate-dormitory", authMiddleware.RequirePermission("student:update"), studentHandler.MutateStudentDormitory)
-				students.POST(":id/sks-results", authMiddleware.RequirePermission("student_sks_results:create"), studentHandler.CreateStudentSKSResult)
-				students.PUT(":id/sks-results/:result_id", authMiddleware.RequirePermission("student_sks_results:update"), studentHandler.UpdateStudentSKSResult)
-				students.GET(":id/sks-results", authMiddleware.RequirePermission("student_sks_results:read"), studentHandler.ListStudentSKSResults)
-				students.GET(":id/fans", authMiddleware.RequirePermission("student_sks_results:read"), studentHandler.ListFanCompletionStatuses)
+				students.GET("", authMiddleware.RequirePermission(permission.StudentRead), studentHandler.ListStudents)
+				students.GET(":id", authMiddleware.RequirePermission(permission.StudentRead), studentHandler.GetStudent)
+				students.POST("", authMiddleware.RequirePermission(permission.StudentCreate), studentHandler.CreateStudent)
+				students.POST("import", authMiddleware.RequirePermission(permission.StudentCreate), studentHandler.ImportStudents)
+				students.PUT(":id", authMiddleware.RequirePermission(permission.StudentUpdate), studentHandler.UpdateStudent)
+				students.PATCH(":id/status", authMiddleware.RequirePermission(permission.StudentUpdate), studentHandler.UpdateStudentStatus)
+				students.POST(":id/mutate-dormitory", authMiddleware.RequirePermission(permission.StudentUpdate), studentHandler.MutateStudentDormitory)
+				students.POST(":id/sks-results", authMiddleware.RequirePermission(permission.StudentSKSResultsCreate), studentHandler.CreateStudentSKSResult)
+				students.PUT(":id/sks-results/:result_id", authMiddleware.RequirePermission(permission.StudentSKSResultsUpdate), studentHandler.UpdateStudentSKSResult)
+				students.GET(":id/sks-results", authMiddleware.RequirePermission(permission.StudentSKSResultsRead), studentHandler.ListStudentSKSResults)
+				students.GET(":id/fans", authMiddleware.RequirePermission(permission.StudentSKSResultsRead), studentHandler.ListFanCompletionStatuses)
 			}
 
 			// User routes
 			users := protected.Group("/users")
 			{
-				users.GET("", userHandler.ListUsers)
-				users.GET("/:id", userHandler.GetUser)
-				users.POST("", authMiddleware.RequirePermission("user:create"), userHandler.CreateUser)
-				users.PUT("/:id", authMiddleware.RequirePermission("user:update"), userHandler.UpdateUser)
-				users.DELETE("/:id", authMiddleware.RequirePermission("user:delete"), userHandler.DeleteUser)
-				users.POST("/:id/roles", authMiddleware.RequirePermission("user:update"), userHandler.AssignRoleToUser)
-				users.DELETE("/:id/roles/:role_id", authMiddleware.RequirePermission("user:update"), userHandler.RemoveRoleFromUser)
+				users.GET("", authMiddleware.RequirePermission(permission.UserRead), userHandler.ListUsers)
+				users.GET("/:id", authMiddleware.RequirePermission(permission.UserRead), userHandler.GetUser)
+				users.POST("", authMiddleware.RequirePermission(permission.UserCreate), authMiddleware.BlockImpersonation(), userHandler.CreateUser)
+				users.PUT("/:id", authMiddleware.RequirePermission(permission.UserUpdate), authMiddleware.BlockImpersonation(), userHandler.UpdateUser)
+				users.DELETE("/:id", authMiddleware.RequirePermission(permission.UserDelete), userHandler.DeleteUser)
+				users.POST("/:id/roles", authMiddleware.RequirePermission(permission.UserUpdate), authMiddleware.BlockImpersonation(), userHandler.AssignRoleToUser)
+				users.DELETE("/:id/roles/:role_id", authMiddleware.RequirePermission(permission.UserUpdate), authMiddleware.BlockImpersonation(), userHandler.RemoveRoleFromUser)
+				users.DELETE("/:id/sessions", authMiddleware.RequirePermission(permission.UserUpdate), authMiddleware.BlockImpersonation(), userHandler.RevokeUserSessions)
+				users.POST("/:id/reset-password", authMiddleware.RequirePermission(permission.UserUpdate), authMiddleware.BlockImpersonation(), userHandler.ResetPassword)
+				users.DELETE("/:id/2fa", authMiddleware.RequirePermission(permission.UserUpdate), authMiddleware.BlockImpersonation(), twoFactorHandler.ResetUser)
+			}
+
+			// Invitation routes (invite-based onboarding)
+			invitations := protected.Group("/invitations")
+			{
+				invitations.GET("", authMiddleware.RequirePermission(permission.UserRead), invitationHandler.ListInvitations)
+				invitations.POST("", authMiddleware.RequirePermission(permission.UserCreate), authMiddleware.BlockImpersonation(), invitationHandler.CreateInvitation)
+				invitations.DELETE("/:id", authMiddleware.RequirePermission(permission.UserCreate), invitationHandler.RevokeInvitation)
+			}
+
+			// Service account routes (machine users authenticating with API keys)
+			serviceAccounts := protected.Group("/service-accounts")
+			{
+				serviceAccounts.GET("", authMiddleware.RequirePermission(permission.UserRead), serviceAccountHandler.ListServiceAccounts)
+				serviceAccounts.POST("", authMiddleware.RequirePermission(permission.UserCreate), authMiddleware.BlockImpersonation(), serviceAccountHandler.CreateServiceAccount)
+				serviceAccounts.GET("/:id/api-keys", authMiddleware.RequirePermission(permission.UserRead), serviceAccountHandler.ListAPIKeys)
+				serviceAccounts.POST("/:id/api-keys", authMiddleware.RequirePermission(permission.UserUpdate), authMiddleware.BlockImpersonation(), serviceAccountHandler.CreateAPIKey)
+				serviceAccounts.DELETE("/:id/api-keys/:key_id", authMiddleware.RequirePermission(permission.UserUpdate), authMiddleware.BlockImpersonation(), serviceAccountHandler.RevokeAPIKey)
 			}
 
 			// Dormitory routes
 			dormitories := protected.Group("/dormitories")
 			{
-				dormitories.GET("", dormitoryHandler.ListDormitories)
-				dormitories.GET("/:id", authMiddleware.RequireDormitoryAccess(), dormitoryHandler.GetDormitory)
-				dormitories.POST("", authMiddleware.RequirePermission("dorm:create"), dormitoryHandler.CreateDormitory)
-				dormitories.PUT("/:id", authMiddleware.RequireDormitoryAccess(), authMiddleware.RequirePermission("dorm:update"), dormitoryHandler.UpdateDormitory)
-				dormitories.DELETE("/:id", authMiddleware.RequireDormitoryAccess(), authMiddleware.RequirePermission("dorm:delete"), dormitoryHandler.DeleteDormitory)
-				dormitories.POST("/:id/users", authMiddleware.RequireDormitoryAccess(), authMiddleware.RequirePermission("dorm:update"), dormitoryHandler.AssignDormitoryUser)
-				dormitories.DELETE("/:id/users/:user_id", authMiddleware.RequireDormitoryAccess(), authMiddleware.RequirePermission("dorm:update"), dormitoryHandler.RemoveDormitoryUser)
+				dormitories.GET("", authMiddleware.RequirePermission(permission.DormRead), dormitoryHandler.ListDormitories)
+				dormitories.GET("/:id", authMiddleware.RequirePermission(permission.DormRead), authMiddleware.RequireDormitoryAccess(), dormitoryHandler.GetDormitory)
+				dormitories.POST("", authMiddleware.RequirePermission(permission.DormCreate), dormitoryHandler.CreateDormitory)
+				dormitories.PUT("/:id", authMiddleware.RequirePermission(permission.DormUpdate), authMiddleware.RequireDormitoryAccess(), dormitoryHandler.UpdateDormitory)
+				dormitories.DELETE("/:id", authMiddleware.RequirePermission(permission.DormDelete), authMiddleware.RequireDormitoryAccess(), dormitoryHandler.DeleteDormitory)
+				dormitories.POST("/:id/users", authMiddleware.RequirePermission(permission.DormUpdate), authMiddleware.RequireDormitoryAccess(), authMiddleware.BlockImpersonation(), dormitoryHandler.AssignDormitoryUser)
+				dormitories.DELETE("/:id/users/:user_id", authMiddleware.RequirePermission(permission.DormUpdate), authMiddleware.RequireDormitoryAccess(), authMiddleware.BlockImpersonation(), dormitoryHandler.RemoveDormitoryUser)
 			}
 
-			// Role routes
+			// Role routes; changes are blocked while impersonating
 			roles := protected.Group("/roles")
 			{
-				roles.GET("", authMiddleware.RequirePermission("role:read"), roleHandler.ListRoles)
-				roles.GET("/:id", authMiddleware.RequirePermission("role:read"), roleHandler.GetRole)
-				roles.POST("", authMiddleware.RequirePermission("role:create"), roleHandler.CreateRole)
-				roles.PUT("/:id", authMiddleware.RequirePermission("role:update"), roleHandler.UpdateRole)
-				roles.DELETE("/:id", authMiddleware.RequirePermission("role:delete"), roleHandler.DeleteRole)
-				roles.POST("/:id/permissions", authMiddleware.RequirePermission("role:update"), roleHandler.AssignPermission)
-				roles.DELETE("/:id/permissions", authMiddleware.RequirePermission("role:update"), roleHandler.RemovePermission)
+				roles.GET("", authMiddleware.RequirePermission(permission.RoleRead), roleHandler.ListRoles)
+				roles.GET("/:id", authMiddleware.RequirePermission(permission.RoleRead), roleHandler.GetRole)
+				roles.POST("", authMiddleware.RequirePermission(permission.RoleCreate), authMiddleware.BlockImpersonation(), roleHandler.CreateRole)
+				roles.PUT("/:id", authMiddleware.RequirePermission(permission.RoleUpdate), authMiddleware.BlockImpersonation(), roleHandler.UpdateRole)
+				roles.DELETE("/:id", authMiddleware.RequirePermission(permission.RoleDelete), authMiddleware.BlockImpersonation(), roleHandler.DeleteRole)
+				roles.POST("/:id/permissions", authMiddleware.RequirePermission(permission.RoleUpdate), authMiddleware.BlockImpersonation(), roleHandler.AssignPermission)
+				roles.DELETE("/:id/permissions", authMiddleware.RequirePermission(permission.RoleUpdate), authMiddleware.BlockImpersonation(), roleHandler.RemovePermission)
 			}
 
 			// Permission routes (read-only)
 			permissions := protected.Group("/permissions")
 			{
-				permissions.GET("", authMiddleware.RequirePermission("role:read"), permissionHandler.ListPermissions)
+				permissions.GET("", authMiddleware.RequirePermission(permission.RoleRead), permissionHandler.ListPermissions)
 			}
 
 			// Fan routes
 			fans := protected.Group("/fans")
 			{
-				fans.GET("", authMiddleware.RequirePermission("fans:read"), fanHandler.ListFans)
-				fans.GET(":id", authMiddleware.RequirePermission("fans:read"), fanHandler.GetFan)
-				fans.POST("", authMiddleware.RequirePermission("fans:create"), fanHandler.CreateFan)
-				fans.PUT(":id", authMiddleware.RequirePermission("fans:update"), fanHandler.UpdateFan)
-				fans.DELETE(":id", authMiddleware.RequirePermission("fans:delete"), fanHandler.DeleteFan)
+				fans.GET("", authMiddleware.RequirePermission(permission.FansRead), fanHandler.ListFans)
+				fans.GET(":id", authMiddleware.RequirePermission(permission.FansRead), fanHandler.GetFan)
+				fans.POST("", authMiddleware.RequirePermission(permission.FansCreate), fanHandler.CreateFan)
+				fans.PUT(":id", authMiddleware.RequirePermission(permission.FansUpdate), fanHandler.UpdateFan)
+				fans.DELETE(":id", authMiddleware.RequirePermission(permission.FansDelete), fanHandler.DeleteFan)
 			}
 
 			// Class routes
 			classes := protected.Group("/classes")
 			{
-				classes.GET("", authMiddleware.RequirePermission("classes:read"), classHandler.ListClasses)
-				classes.GET(":id", authMiddleware.RequirePermission("classes:read"), classHandler.GetClass)
-				classes.POST("", authMiddleware.RequirePermission("classes:create"), classHandler.CreateClass)
-				classes.PUT(":id", authMiddleware.RequirePermission("classes:update"), classHandler.UpdateClass)
-				classes.DELETE(":id", authMiddleware.RequirePermission("classes:delete"), classHandler.DeleteClass)
-				classes.POST(":id/students", authMiddleware.RequirePermission("classes:update"), classHandler.EnrollStudent)
-				classes.POST(":id/staff", authMiddleware.RequirePermission("classes:update"), classHandler.AssignStaff)
+				classes.GET("", authMiddleware.RequirePermission(permission.ClassesRead), classHandler.ListClasses)
+				classes.GET(":id", authMiddleware.RequirePermission(permission.ClassesRead), classHandler.GetClass)
+				classes.POST("", authMiddleware.RequirePermission(permission.ClassesCreate), classHandler.CreateClass)
+				classes.PUT(":id", authMiddleware.RequirePermission(permission.ClassesUpdate), classHandler.UpdateClass)
+				classes.DELETE(":id", authMiddleware.RequirePermission(permission.ClassesDelete), classHandler.DeleteClass)
+				classes.POST(":id/students", authMiddleware.RequirePermission(permission.ClassesUpdate), classHandler.EnrollStudent)
+				classes.POST(":id/staff", authMiddleware.RequirePermission(permission.ClassesUpdate), classHandler.AssignStaff)
 			}
 
 			// Teacher routes
 			teachers := protected.Group("/teachers")
 			{
-				teachers.GET("", authMiddleware.RequirePermission("teachers:read"), teacherHandler.ListTeachers)
-				teachers.GET(":id", authMiddleware.RequirePermission("teachers:read"), teacherHandler.GetTeacher)
-				teachers.POST("", authMiddleware.RequirePermission("teachers:create"), teacherHandler.CreateTeacher)
-				teachers.PUT(":id", authMiddleware.RequirePermission("teachers:update"), teacherHandler.UpdateTeacher)
-				teachers.DELETE(":id", authMiddleware.RequirePermission("teachers:delete"), teacherHandler.DeactivateTeacher)
+				teachers.GET("", authMiddleware.RequirePermission(permission.TeachersRead), teacherHandler.ListTeachers)
+				teachers.GET(":id", authMiddleware.RequirePermission(permission.TeachersRead), teacherHandler.GetTeacher)
+				teachers.POST("", authMiddleware.RequirePermission(permission.TeachersCreate), teacherHandler.CreateTeacher)
+				teachers.PUT(":id", authMiddleware.RequirePermission(permission.TeachersUpdate), teacherHandler.UpdateTeacher)
+				teachers.DELETE(":id", authMiddleware.RequirePermission(permission.TeachersDelete), teacherHandler.DeactivateTeacher)
 			}
 
 			// Leave permit routes
 			leavePermits := protected.Group("/leave-permits")
 			{
-				leavePermits.GET("", authMiddleware.RequirePermission("leave_permits:read"), leavePermitHandler.ListLeavePermits)
-				leavePermits.POST("", authMiddleware.RequirePermission("leave_permits:create"), leavePermitHandler.CreateLeavePermit)
-				leavePermits.PUT(":id/approve", authMiddleware.RequirePermission("leave_permits:approve"), leavePermitHandler.ApproveLeavePermit)
-				leavePermits.PUT(":id/reject", authMiddleware.RequirePermission("leave_permits:approve"), leavePermitHandler.RejectLeavePermit)
-				leavePermits.PUT(":id/complete", authMiddleware.RequirePermission("leave_permits:complete"), leavePermitHandler.CompleteLeavePermit)
+				leavePermits.GET("", authMiddleware.RequirePermission(permission.LeavePermitsRead), leavePermitHandler.ListLeavePermits)
+				leavePermits.POST("", authMiddleware.RequirePermission(permission.LeavePermitsCreate), leavePermitHandler.CreateLeavePermit)
+				leavePermits.PUT(":id/approve", authMiddleware.RequirePermission(permission.LeavePermitsApprove), leavePermitHandler.ApproveLeavePermit)
+				leavePermits.PUT(":id/reject", authMiddleware.RequirePermission(permission.LeavePermitsApprove), leavePermitHandler.RejectLeavePermit)
+				leavePermits.PUT(":id/complete", authMiddleware.RequirePermission(permission.LeavePermitsComplete), leavePermitHandler.CompleteLeavePermit)
 			}
 
 			// Health status routes
 			healthStatuses := protected.Group("/health-statuses")
 			{
-				healthStatuses.GET("", authMiddleware.RequirePermission("health_statuses:read"), healthStatusHandler.ListHealthStatuses)
-				healthStatuses.POST("", authMiddleware.RequirePermission("health_statuses:create"), healthStatusHandler.CreateHealthStatus)
-				healthStatuses.PUT(":id/revoke", authMiddleware.RequirePermission("health_statuses:revoke"), healthStatusHandler.RevokeHealthStatus)
+				healthStatuses.GET("", authMiddleware.RequirePermission(permission.HealthStatusesRead), healthStatusHandler.ListHealthStatuses)
+				healthStatuses.POST("", authMiddleware.RequirePermission(permission.HealthStatusesCreate), healthStatusHandler.CreateHealthStatus)
+				healthStatuses.PUT(":id/revoke", authMiddleware.RequirePermission(permission.HealthStatusesRevoke), healthStatusHandler.RevokeHealthStatus)
 			}
 
 			// Schedule slot routes
 			scheduleSlots := protected.Group("/schedule-slots")
 			{
-				scheduleSlots.GET("", authMiddleware.RequirePermission("schedule_slots:read"), scheduleSlotHandler.ListScheduleSlots)
-				scheduleSlots.GET(":id", authMiddleware.RequirePermission("schedule_slots:read"), scheduleSlotHandler.GetScheduleSlot)
-				scheduleSlots.POST("", authMiddleware.RequirePermission("schedule_slots:create"), scheduleSlotHandler.CreateScheduleSlot)
-				scheduleSlots.PUT(":id", authMiddleware.RequirePermission("schedule_slots:update"), scheduleSlotHandler.UpdateScheduleSlot)
-				scheduleSlots.DELETE(":id", authMiddleware.RequirePermission("schedule_slots:delete"), scheduleSlotHandler.DeleteScheduleSlot)
+				scheduleSlots.GET("", authMiddleware.RequirePermission(permission.ScheduleSlotsRead), scheduleSlotHandler.ListScheduleSlots)
+				scheduleSlots.GET(":id", authMiddleware.RequirePermission(permission.ScheduleSlotsRead), scheduleSlotHandler.GetScheduleSlot)
+				scheduleSlots.POST("", authMiddleware.RequirePermission(permission.ScheduleSlotsCreate), scheduleSlotHandler.CreateScheduleSlot)
+				scheduleSlots.PUT(":id", authMiddleware.RequirePermission(permission.ScheduleSlotsUpdate), scheduleSlotHandler.UpdateScheduleSlot)
+				scheduleSlots.DELETE(":id", authMiddleware.RequirePermission(permission.ScheduleSlotsDelete), scheduleSlotHandler.DeleteScheduleSlot)
 			}
 
 			// Reports routes
@@ -233,53 +324,73 @@ func SetupRouter(
 			{
 				attendanceReports := reports.Group("/attendance")
 				{
-					attendanceReports.GET("/students", authMiddleware.RequirePermission("reports:attendance:read"), reportHandler.GetStudentAttendanceReport)
-					attendanceReports.GET("/teachers", authMiddleware.RequirePermission("reports:attendance:read"), reportHandler.GetTeacherAttendanceReport)
+					attendanceReports.GET("/students", authMiddleware.RequirePermission(permission.ReportsAttendanceRead), reportHandler.GetStudentAttendanceReport)
+					attendanceReports.GET("/teachers", authMiddleware.RequirePermission(permission.ReportsAttendanceRead), reportHandler.GetTeacherAttendanceReport)
 				}
-				reports.GET("/leave-permits", authMiddleware.RequirePermission("reports:security:read"), reportHandler.GetLeavePermitReport)
-				reports.GET("/health-statuses", authMiddleware.RequirePermission("reports:health:read"), reportHandler.GetHealthStatusReport)
-				reports.GET("/sks", authMiddleware.RequirePermission("reports:academic:read"), reportHandler.GetSKSReport)
-				reports.GET("/mutations", authMiddleware.RequirePermission("reports:academic:read"), reportHandler.GetMutationReport)
+				reports.GET("/leave-permits", authMiddleware.RequirePermission(permission.ReportsSecurityRead), reportHandler.GetLeavePermitReport)
+				reports.GET("/health-statuses", authMiddleware.RequirePermission(permission.ReportsHealthRead), reportHandler.GetHealthStatusReport)
+				reports.GET("/sks", authMiddleware.RequirePermission(permission.ReportsAcademicRead), reportHandler.GetSKSReport)
+				reports.GET("/mutations", authMiddleware.RequirePermission(permission.ReportsAcademicRead), reportHandler.GetMutationReport)
+			}
+
+			// Subject routes
+			subjects := protected.Group("/subjects")
+			{
+				subjects.GET("", authMiddleware.RequirePermission(permission.SubjectsRead), subjectHandler.ListSubjects)
+				subjects.GET(":id", authMiddleware.RequirePermission(permission.SubjectsRead), subjectHandler.GetSubject)
+				subjects.POST("", authMiddleware.RequirePermission(permission.SubjectsCreate), subjectHandler.CreateSubject)
+				subjects.PUT(":id", authMiddleware.RequirePermission(permission.SubjectsUpdate), subjectHandler.UpdateSubject)
+				subjects.DELETE(":id", authMiddleware.RequirePermission(permission.SubjectsDelete), subjectHandler.DeleteSubject)
 			}
 
 			// Class schedule routes
 			classSchedules := protected.Group("/class-schedules")
 			{
-				classSchedules.GET("", authMiddleware.RequirePermission("class_schedules:read"), classScheduleHandler.ListClassSchedules)
-				classSchedules.GET(":id", authMiddleware.RequirePermission("class_schedules:read"), classScheduleHandler.GetClassSchedule)
-				classSchedules.POST("", authMiddleware.RequirePermission("class_schedules:create"), classScheduleHandler.CreateClassSchedule)
-				classSchedules.PUT(":id", authMiddleware.RequirePermission("class_schedules:update"), classScheduleHandler.UpdateClassSchedule)
-				classSchedules.DELETE(":id", authMiddleware.RequirePermission("class_schedules:delete"), classScheduleHandler.DeleteClassSchedule)
+				classSchedules.GET("", authMiddleware.RequirePermission(permission.ClassSchedulesRead), classScheduleHandler.ListClassSchedules)
+				classSchedules.GET(":id", authMiddleware.RequirePermission(permission.ClassSchedulesRead), classScheduleHandler.GetClassSchedule)
+				classSchedules.POST("", authMiddleware.RequirePermission(permission.ClassSchedulesCreate), classScheduleHandler.CreateClassSchedule)
+				classSchedules.POST("bulk", authMiddleware.RequirePermission(permission.ClassSchedulesCreate), classScheduleHandler.BulkCreateClassSchedules)
+				classSchedules.PUT(":id", authMiddleware.RequirePermission(permission.ClassSchedulesUpdate), classScheduleHandler.UpdateClassSchedule)
+				classSchedules.DELETE(":id", authMiddleware.RequirePermission(permission.ClassSchedulesDelete), classScheduleHandler.DeleteClassSchedule)
 			}
 
 			// SKS definition routes
 			sksDefinitions := protected.Group("/sks")
 			{
-				sksDefinitions.GET("", authMiddleware.RequirePermission("sks_definitions:read"), sksDefinitionHandler.ListSKSDefinitions)
-				sksDefinitions.GET(":id", authMiddleware.RequirePermission("sks_definitions:read"), sksDefinitionHandler.GetSKSDefinition)
-				sksDefinitions.POST("", authMiddleware.RequirePermission("sks_definitions:create"), sksDefinitionHandler.CreateSKSDefinition)
-				sksDefinitions.PUT(":id", authMiddleware.RequirePermission("sks_definitions:update"), sksDefinitionHandler.UpdateSKSDefinition)
-				sksDefinitions.DELETE(":id", authMiddleware.RequirePermission("sks_definitions:delete"), sksDefinitionHandler.DeleteSKSDefinition)
+				sksDefinitions.GET("", authMiddleware.RequirePermission(permission.SKSDefinitionsRead), sksDefinitionHandler.ListSKSDefinitions)
+				sksDefinitions.GET(":id", authMiddleware.RequirePermission(permission.SKSDefinitionsRead), sksDefinitionHandler.GetSKSDefinition)
+				sksDefinitions.POST("", authMiddleware.RequirePermission(permission.SKSDefinitionsCreate), sksDefinitionHandler.CreateSKSDefinition)
+				sksDefinitions.PUT(":id", authMiddleware.RequirePermission(permission.SKSDefinitionsUpdate), sksDefinitionHandler.UpdateSKSDefinition)
+				sksDefinitions.DELETE(":id", authMiddleware.RequirePermission(permission.SKSDefinitionsDelete), sksDefinitionHandler.DeleteSKSDefinition)
 			}
 
 			// SKS exam schedule routes
 			sksExams := protected.Group("/sks-exams")
 			{
-				sksExams.GET("", authMiddleware.RequirePermission("sks_exams:read"), sksExamHandler.ListSKSExamSchedules)
-				sksExams.GET(":id", authMiddleware.RequirePermission("sks_exams:read"), sksExamHandler.GetSKSExamSchedule)
-				sksExams.POST("", authMiddleware.RequirePermission("sks_exams:create"), sksExamHandler.CreateSKSExamSchedule)
-				sksExams.PUT(":id", authMiddleware.RequirePermission("sks_exams:update"), sksExamHandler.UpdateSKSExamSchedule)
-				sksExams.DELETE(":id", authMiddleware.RequirePermission("sks_exams:delete"), sksExamHandler.DeleteSKSExamSchedule)
+				sksExams.GET("", authMiddleware.RequirePermission(permission.SKSExamsRead), sksExamHandler.ListSKSExamSchedules)
+				sksExams.GET(":id", authMiddleware.RequirePermission(permission.SKSExamsRead), sksExamHandler.GetSKSExamSchedule)
+				sksExams.POST("", authMiddleware.RequirePermission(permission.SKSExamsCreate), sksExamHandler.CreateSKSExamSchedule)
+				sksExams.PUT(":id", authMiddleware.RequirePermission(permission.SKSExamsUpdate), sksExamHandler.UpdateSKSExamSchedule)
+				sksExams.DELETE(":id", authMiddleware.RequirePermission(permission.SKSExamsDelete), sksExamHandler.DeleteSKSExamSchedule)
+			}
+
+			// Holiday routes
+			holidays := protected.Group("/holidays")
+			{
+				holidays.GET("", authMiddleware.RequirePermission(permission.HolidaysRead), holidayHandler.ListHolidays)
+				holidays.POST("", authMiddleware.RequirePermission(permission.HolidaysCreate), holidayHandler.CreateHoliday)
+				holidays.DELETE(":id", authMiddleware.RequirePermission(permission.HolidaysDelete), holidayHandler.DeleteHoliday)
 			}
 
 			// Attendance session routes
 			attendanceSessions := protected.Group("/attendance-sessions")
 			{
-				attendanceSessions.GET("", authMiddleware.RequirePermission("attendance_sessions:read"), attendanceHandler.ListAttendanceSessions)
-				attendanceSessions.POST("/open", authMiddleware.RequirePermission("attendance_sessions:create"), attendanceHandler.OpenSessions)
-				attendanceSessions.POST(":"+"id/students", authMiddleware.RequirePermission("attendance_sessions:update"), attendanceHandler.SubmitStudentAttendance)
-				attendanceSessions.POST(":"+"id/teacher", authMiddleware.RequirePermission("attendance_sessions:update"), attendanceHandler.SubmitTeacherAttendance)
-				attendanceSessions.POST("/lock-day", authMiddleware.RequirePermission("attendance_sessions:lock"), attendanceHandler.LockSessions)
+				attendanceSessions.GET("", authMiddleware.RequirePermission(permission.AttendanceSessionsRead), attendanceHandler.ListAttendanceSessions)
+				attendanceSessions.POST("/open", authMiddleware.RequirePermission(permission.AttendanceSessionsCreate), attendanceHandler.OpenSessions)
+				attendanceSessions.POST("/generate", authMiddleware.RequirePermission(permission.AttendanceSessionsCreate), attendanceHandler.GenerateSessions)
+				attendanceSessions.POST(":"+"id/students", authMiddleware.RequirePermission(permission.AttendanceSessionsUpdate), attendanceHandler.SubmitStudentAttendance)
+				attendanceSessions.POST(":"+"id/teacher", authMiddleware.RequirePermission(permission.AttendanceSessionsUpdate), attendanceHandler.SubmitTeacherAttendance)
+				attendanceSessions.POST("/lock-day", authMiddleware.RequirePermission(permission.AttendanceSessionsLock), attendanceHandler.LockSessions)
 			}
 		}
 	}
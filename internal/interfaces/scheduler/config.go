@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultLeaseTTL = 10 * time.Minute
+
+// Config controls the in-process scheduler.
+type Config struct {
+	// Enabled turns the scheduler on for this process (SCHEDULER_ENABLED, default true).
+	Enabled bool
+	// Holder identifies this replica in job leases and runs (SCHEDULER_INSTANCE_ID, default hostname-pid).
+	Holder string
+	// LeaseTTL bounds how long a replica owns a job activation (SCHEDULER_LEASE_TTL, default 10m).
+	LeaseTTL time.Duration
+	// Location is the timezone cron expressions are evaluated in (SCHEDULER_TIMEZONE, default server local).
+	Location *time.Location
+	// Specs maps job names to cron expressions (JOB_<NAME>_CRON, "off" disables a job).
+	Specs map[string]string
+}
+
+// LoadConfig reads scheduler settings from the environment, falling back to DefaultSpecs.
+func LoadConfig() Config {
+	cfg := Config{
+		Enabled:  true,
+		LeaseTTL: defaultLeaseTTL,
+		Location: time.Local,
+		Specs:    make(map[string]string, len(DefaultSpecs)),
+	}
+
+	if enabled := os.Getenv("SCHEDULER_ENABLED"); enabled != "" {
+		cfg.Enabled = enabled == "true" || enabled == "1"
+	}
+
+	cfg.Holder = os.Getenv("SCHEDULER_INSTANCE_ID")
+	if cfg.Holder == "" {
+		hostname, _ := os.Hostname()
+		cfg.Holder = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	if ttl := os.Getenv("SCHEDULER_LEASE_TTL"); ttl != "" {
+		if parsed, err := time.ParseDuration(ttl); err == nil && parsed > 0 {
+			cfg.LeaseTTL = parsed
+		}
+	}
+
+	if tz := os.Getenv("SCHEDULER_TIMEZONE"); tz != "" {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			cfg.Location = loc
+		} else {
+			slog.Warn("scheduler: invalid SCHEDULER_TIMEZONE, using server timezone", "timezone", tz)
+		}
+	}
+
+	for name, spec := range DefaultSpecs {
+		if override, ok := os.LookupEnv(specEnvKey(name)); ok {
+			spec = strings.TrimSpace(override)
+		}
+		cfg.Specs[name] = spec
+	}
+
+	return cfg
+}
+
+// specEnvKey maps a job name to its cron variable, e.g. lock_attendance_sessions -> JOB_LOCK_ATTENDANCE_SESSIONS_CRON.
+func specEnvKey(name string) string {
+	return "JOB_" + strings.ToUpper(name) + "_CRON"
+}
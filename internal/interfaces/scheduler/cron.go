@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron expression (minute hour day-of-month month day-of-week).
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar/dowStar follow cron semantics: when both day fields are restricted,
+	// a day matches if either field matches.
+	domStar, dowStar bool
+}
+
+type cronBounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = cronBounds{0, 59}
+	hourBounds   = cronBounds{0, 23}
+	domBounds    = cronBounds{1, 31}
+	monthBounds  = cronBounds{1, 12}
+	dowBounds    = cronBounds{0, 7}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a standard cron expression such as "55 23 * * *" or "*/15 6-18 * * mon-fri".
+// The @hourly, @daily, @weekly, @monthly and @yearly descriptors are also accepted.
+func ParseCron(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expanded, ok := cronDescriptors[strings.ToLower(spec)]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields", spec)
+	}
+
+	var (
+		s   Schedule
+		err error
+	)
+	if s.minute, err = parseCronField(fields[0], minuteBounds, nil); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseCronField(fields[1], hourBounds, nil); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseCronField(fields[2], domBounds, nil); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseCronField(fields[3], monthBounds, monthNames); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseCronField(fields[4], dowBounds, dayNames); err != nil {
+		return nil, err
+	}
+	// 7 is an alias for Sunday.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*") || fields[2] == "?"
+	s.dowStar = strings.HasPrefix(fields[4], "*") || fields[4] == "?"
+
+	return &s, nil
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+func parseCronField(field string, bounds cronBounds, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			parsed, err := strconv.Atoi(part[idx+1:])
+			if err != nil || parsed < 1 {
+				return 0, fmt.Errorf("invalid step in cron field %q", field)
+			}
+			step = parsed
+			part = part[:idx]
+		}
+
+		lo, hi := bounds.min, bounds.max
+		switch {
+		case part == "*" || part == "?":
+		case strings.Contains(part, "-"):
+			rangeParts := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = parseCronValue(rangeParts[0], names); err != nil {
+				return 0, fmt.Errorf("invalid cron field %q", field)
+			}
+			if hi, err = parseCronValue(rangeParts[1], names); err != nil {
+				return 0, fmt.Errorf("invalid cron field %q", field)
+			}
+		default:
+			value, err := parseCronValue(part, names)
+			if err != nil {
+				return 0, fmt.Errorf("invalid cron field %q", field)
+			}
+			lo = value
+			if step == 1 {
+				hi = value
+			}
+		}
+
+		if lo < bounds.min || hi > bounds.max || lo > hi {
+			return 0, fmt.Errorf("cron field %q out of range %d-%d", field, bounds.min, bounds.max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// Next returns the first activation strictly after t, in t's location.
+// A zero time is returned when the expression never matches (e.g. "0 0 31 2 *").
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		var next time.Time
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			next = t.Add(time.Minute)
+		default:
+			return t
+		}
+		// Daylight-saving transitions can normalize back onto the same instant.
+		if !next.After(t) {
+			next = t.Add(time.Minute)
+		}
+		t = next
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*3600)
+	from := time.Date(2025, 1, 6, 10, 30, 15, 0, loc) // Monday
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"55 23 * * *", time.Date(2025, 1, 6, 23, 55, 0, 0, loc)},
+		{"30 10 * * *", time.Date(2025, 1, 7, 10, 30, 0, 0, loc)},
+		{"*/15 * * * *", time.Date(2025, 1, 6, 10, 45, 0, 0, loc)},
+		{"0 6-8 * * sat,sun", time.Date(2025, 1, 11, 6, 0, 0, 0, loc)},
+		{"0 0 1 * *", time.Date(2025, 2, 1, 0, 0, 0, 0, loc)},
+		{"0 0 * * 7", time.Date(2025, 1, 12, 0, 0, 0, 0, loc)},
+		{"0 12 15 * mon", time.Date(2025, 1, 6, 12, 0, 0, 0, loc)},
+		{"@daily", time.Date(2025, 1, 7, 0, 0, 0, 0, loc)},
+		{"0 0 29 feb *", time.Date(2028, 2, 29, 0, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := ParseCron(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "*/0 * * * *", "5-1 * * * *", "0 0 * * funday"} {
+		_, err := ParseCron(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestSchedule_NextNeverMatches(t *testing.T) {
+	schedule, err := ParseCron("0 0 31 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+}
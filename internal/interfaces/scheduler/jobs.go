@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+// Built-in job names. They double as lease keys and job_runs.job_name values.
+const (
+	JobLockAttendanceSessions = "lock_attendance_sessions"
+	JobOpenAttendanceSessions = "open_attendance_sessions"
+	JobExpireLeavePermits     = "expire_leave_permits"
+	JobAuditCheckpoint        = "audit_checkpoint"
+	JobAuditRetention         = "audit_retention"
+)
+
+// DefaultSpecs are used when no JOB_<NAME>_CRON override is configured.
+var DefaultSpecs = map[string]string{
+	JobLockAttendanceSessions: "55 23 * * *",
+	JobOpenAttendanceSessions: "0 18 * * *",
+	JobExpireLeavePermits:     "10 0 * * *",
+	JobAuditCheckpoint:        "0 * * * *",
+	JobAuditRetention:         "30 2 * * *",
+}
+
+// LockAttendanceSessionsJob locks every session of the activation day.
+func LockAttendanceSessionsJob(uc *usecase.AttendanceUseCase) JobFunc {
+	return func(ctx context.Context, scheduledAt time.Time) (string, error) {
+		date := scheduledAt.Format("2006-01-02")
+		if err := uc.LockSessions(ctx, dto.LockAttendanceRequest{Date: date}); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("locked sessions for %s", date), nil
+	}
+}
+
+// OpenAttendanceSessionsJob opens the next day's sessions from the weekly class schedules.
+func OpenAttendanceSessionsJob(uc *usecase.AttendanceGeneratorUseCase) JobFunc {
+	return func(ctx context.Context, scheduledAt time.Time) (string, error) {
+		date := scheduledAt.AddDate(0, 0, 1).Format("2006-01-02")
+		result, err := uc.GenerateSessions(ctx, dto.GenerateAttendanceSessionsRequest{StartDate: date, EndDate: date})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("opened sessions for %s: created=%d skipped_existing=%d skipped_holidays=%d",
+			date, result.Created, result.SkippedExisting, result.SkippedHolidays), nil
+	}
+}
+
+// ExpireLeavePermitsJob expires pending and approved permits whose end date has passed.
+func ExpireLeavePermitsJob(uc *usecase.LeavePermitUseCase) JobFunc {
+	return func(ctx context.Context, scheduledAt time.Time) (string, error) {
+		expired, err := uc.ExpireLeavePermits(ctx, scheduledAt)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("expired %d leave permit(s) ending before %s", expired, scheduledAt.Format("2006-01-02")), nil
+	}
+}
+
+// AuditCheckpointJob signs the audit chain head, after verifying the entries
+// written since the previous checkpoint.
+func AuditCheckpointJob(uc *usecase.AuditIntegrityUseCase) JobFunc {
+	return func(ctx context.Context, scheduledAt time.Time) (string, error) {
+		checkpoint, err := uc.CreateCheckpoint(ctx)
+		if err == domainErrors.ErrAuditLogEmpty {
+			return "audit log is empty, nothing to sign", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("checkpoint %s at sequence %d", checkpoint.ID, checkpoint.Sequence), nil
+	}
+}
+
+// AuditRetentionJob archives audit entries older than AUDIT_RETENTION_MONTHS.
+func AuditRetentionJob(uc *usecase.AuditRetentionUseCase) JobFunc {
+	return func(ctx context.Context, scheduledAt time.Time) (string, error) {
+		archive, err := uc.ArchiveEntries(ctx, dto.ArchiveAuditLogsRequest{})
+		if err == domainErrors.ErrAuditRetentionDisabled {
+			return "AUDIT_RETENTION_MONTHS not set, nothing archived", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if archive == nil {
+			return "no audit entries old enough to archive", nil
+		}
+		return fmt.Sprintf("archived %d entries (sequence %d to %d) to %s", archive.Entries, archive.FromSequence, archive.ToSequence, archive.File), nil
+	}
+}
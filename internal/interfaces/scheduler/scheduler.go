@@ -0,0 +1,178 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/logger"
+)
+
+// JobFunc executes a scheduled job. scheduledAt is the cron activation in the scheduler's
+// timezone; the returned message is stored on the job run.
+type JobFunc func(ctx context.Context, scheduledAt time.Time) (string, error)
+
+type job struct {
+	name     string
+	schedule *Schedule
+	run      JobFunc
+}
+
+// Scheduler runs registered jobs on their cron schedules inside the API process.
+// Each activation first claims a lease so only one replica executes it, and every
+// execution is recorded in job_runs.
+type Scheduler struct {
+	runRepo   repository.JobRunRepository
+	leaseRepo repository.JobLeaseRepository
+	cfg       Config
+	now       func() time.Time
+	jobs      []*job
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a scheduler; jobs are added with Register before Start.
+func New(runRepo repository.JobRunRepository, leaseRepo repository.JobLeaseRepository, cfg Config) *Scheduler {
+	if cfg.Location == nil {
+		cfg.Location = time.Local
+	}
+	if cfg.LeaseTTL <= 0 {
+		cfg.LeaseTTL = defaultLeaseTTL
+	}
+	return &Scheduler{
+		runRepo:   runRepo,
+		leaseRepo: leaseRepo,
+		cfg:       cfg,
+		now:       time.Now,
+	}
+}
+
+// Register adds a job using the cron expression configured for name.
+// Jobs whose expression is empty or "off" are skipped.
+func (s *Scheduler) Register(name string, run JobFunc) error {
+	spec := s.cfg.Specs[name]
+	if spec == "" || spec == "off" {
+		slog.Info("scheduler: job disabled", "job", name)
+		return nil
+	}
+
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+	s.jobs = append(s.jobs, &job{name: name, schedule: schedule, run: run})
+	return nil
+}
+
+// Start launches one goroutine per registered job. It returns immediately.
+func (s *Scheduler) Start(ctx context.Context) {
+	if !s.cfg.Enabled {
+		slog.Info("scheduler: disabled via SCHEDULER_ENABLED")
+		return
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j)
+	}
+	slog.Info("scheduler: started", "jobs", len(s.jobs), "holder", s.cfg.Holder)
+}
+
+// Stop cancels pending activations and waits for running jobs to finish.
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	defer s.wg.Done()
+
+	for {
+		next := j.schedule.Next(s.now().In(s.cfg.Location))
+		if next.IsZero() {
+			slog.Warn("scheduler: job has no upcoming activation", "job", j.name)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.runJob(ctx, j, next)
+		}
+	}
+}
+
+// runJob executes a single activation. It returns nil when another replica holds the lease.
+func (s *Scheduler) runJob(ctx context.Context, j *job, scheduledAt time.Time) *entity.JobRun {
+	acquired, err := s.leaseRepo.Acquire(ctx, j.name, s.cfg.Holder, s.cfg.LeaseTTL)
+	if err != nil {
+		slog.Error("scheduler: failed to acquire lease", "job", j.name, "error", err)
+		return nil
+	}
+	if !acquired {
+		return nil
+	}
+
+	started := s.now()
+	run := &entity.JobRun{
+		ID:          uuid.New(),
+		JobName:     j.name,
+		Status:      entity.JobRunStatusRunning,
+		Holder:      s.cfg.Holder,
+		ScheduledAt: scheduledAt,
+		StartedAt:   started,
+		CreatedAt:   started,
+	}
+	ctx = logger.WithAttrs(ctx, slog.String("job", j.name), slog.String("job_run_id", run.ID.String()))
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		slog.ErrorContext(ctx, "scheduler: failed to record run", "error", err)
+	}
+
+	// The run is bounded by the lease so a slow job cannot overlap with another replica.
+	runCtx, cancel := context.WithTimeout(ctx, s.cfg.LeaseTTL)
+	runCtx = context.WithValue(runCtx, appService.CtxKeyActorUsername, "scheduler:"+j.name)
+	message, runErr := invoke(runCtx, j, scheduledAt)
+	cancel()
+
+	finished := s.now()
+	run.FinishedAt = &finished
+	run.DurationMs = finished.Sub(started).Milliseconds()
+	run.Message = message
+	run.Status = entity.JobRunStatusSucceeded
+	if runErr != nil {
+		run.Status = entity.JobRunStatusFailed
+		run.Error = runErr.Error()
+	}
+
+	if err := s.runRepo.Update(context.WithoutCancel(ctx), run); err != nil {
+		slog.ErrorContext(ctx, "scheduler: failed to record outcome", "error", err)
+	}
+	if runErr != nil {
+		slog.ErrorContext(ctx, "scheduler: job finished", "status", run.Status, "latency_ms", run.DurationMs, "error", runErr)
+	} else {
+		slog.InfoContext(ctx, "scheduler: job finished", "status", run.Status, "latency_ms", run.DurationMs)
+	}
+	return run
+}
+
+func invoke(ctx context.Context, j *job, scheduledAt time.Time) (message string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return j.run(ctx, scheduledAt)
+}
@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+func newTestScheduler(runRepo *mocks.JobRunRepositoryMock, leaseRepo *mocks.JobLeaseRepositoryMock, run JobFunc) (*Scheduler, *job) {
+	s := New(runRepo, leaseRepo, Config{
+		Enabled:  true,
+		Holder:   "replica-a",
+		LeaseTTL: time.Minute,
+		Specs:    map[string]string{"test_job": "* * * * *"},
+	})
+	if err := s.Register("test_job", run); err != nil {
+		panic(err)
+	}
+	return s, s.jobs[0]
+}
+
+func TestScheduler_RunJob_RecordsSuccess(t *testing.T) {
+	runRepo := new(mocks.JobRunRepositoryMock)
+	leaseRepo := new(mocks.JobLeaseRepositoryMock)
+	scheduledAt := time.Date(2025, 1, 6, 23, 55, 0, 0, time.UTC)
+
+	var gotActor string
+	s, j := newTestScheduler(runRepo, leaseRepo, func(ctx context.Context, at time.Time) (string, error) {
+		gotActor, _ = ctx.Value(appService.CtxKeyActorUsername).(string)
+		assert.Equal(t, scheduledAt, at)
+		return "done", nil
+	})
+
+	leaseRepo.On("Acquire", mock.Anything, "test_job", "replica-a", time.Minute).Return(true, nil)
+	runRepo.On("Create", mock.Anything, mock.MatchedBy(func(run *entity.JobRun) bool {
+		return run.Status == entity.JobRunStatusRunning && run.JobName == "test_job"
+	})).Return(nil)
+	runRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.JobRun")).Return(nil)
+
+	run := s.runJob(context.Background(), j, scheduledAt)
+
+	require.NotNil(t, run)
+	assert.Equal(t, entity.JobRunStatusSucceeded, run.Status)
+	assert.Equal(t, "done", run.Message)
+	assert.Equal(t, "replica-a", run.Holder)
+	assert.NotNil(t, run.FinishedAt)
+	assert.Equal(t, "scheduler:test_job", gotActor)
+	runRepo.AssertExpectations(t)
+}
+
+func TestScheduler_RunJob_RecordsFailureAndPanic(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     JobFunc
+		wantErr string
+	}{
+		{"error", func(ctx context.Context, at time.Time) (string, error) { return "", errors.New("boom") }, "boom"},
+		{"panic", func(ctx context.Context, at time.Time) (string, error) { panic("nil schedule") }, "panic: nil schedule"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runRepo := new(mocks.JobRunRepositoryMock)
+			leaseRepo := new(mocks.JobLeaseRepositoryMock)
+			s, j := newTestScheduler(runRepo, leaseRepo, tt.run)
+
+			leaseRepo.On("Acquire", mock.Anything, "test_job", "replica-a", time.Minute).Return(true, nil)
+			runRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			runRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+			run := s.runJob(context.Background(), j, time.Now())
+
+			require.NotNil(t, run)
+			assert.Equal(t, entity.JobRunStatusFailed, run.Status)
+			assert.Equal(t, tt.wantErr, run.Error)
+		})
+	}
+}
+
+func TestScheduler_RunJob_SkipsWhenLeaseHeldElsewhere(t *testing.T) {
+	runRepo := new(mocks.JobRunRepositoryMock)
+	leaseRepo := new(mocks.JobLeaseRepositoryMock)
+	called := false
+	s, j := newTestScheduler(runRepo, leaseRepo, func(ctx context.Context, at time.Time) (string, error) {
+		called = true
+		return "", nil
+	})
+
+	leaseRepo.On("Acquire", mock.Anything, "test_job", "replica-a", time.Minute).Return(false, nil)
+
+	assert.Nil(t, s.runJob(context.Background(), j, time.Now()))
+	assert.False(t, called)
+	runRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestScheduler_Register(t *testing.T) {
+	s := New(nil, nil, Config{Specs: map[string]string{
+		"valid":   "0 0 * * *",
+		"off":     "off",
+		"invalid": "every day",
+	}})
+
+	require.NoError(t, s.Register("valid", nil))
+	require.NoError(t, s.Register("off", nil))
+	require.NoError(t, s.Register("missing", nil))
+	assert.Error(t, s.Register("invalid", nil))
+	assert.Len(t, s.jobs, 1)
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("SCHEDULER_ENABLED", "false")
+	t.Setenv("SCHEDULER_INSTANCE_ID", "api-1")
+	t.Setenv("SCHEDULER_LEASE_TTL", "2m")
+	t.Setenv("SCHEDULER_TIMEZONE", "UTC")
+	t.Setenv("JOB_LOCK_ATTENDANCE_SESSIONS_CRON", "0 22 * * *")
+	t.Setenv("JOB_EXPIRE_LEAVE_PERMITS_CRON", "off")
+
+	cfg := LoadConfig()
+
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, "api-1", cfg.Holder)
+	assert.Equal(t, 2*time.Minute, cfg.LeaseTTL)
+	assert.Equal(t, time.UTC, cfg.Location)
+	assert.Equal(t, "0 22 * * *", cfg.Specs[JobLockAttendanceSessions])
+	assert.Equal(t, DefaultSpecs[JobOpenAttendanceSessions], cfg.Specs[JobOpenAttendanceSessions])
+	assert.Equal(t, "off", cfg.Specs[JobExpireLeavePermits])
+}
@@ -58,14 +58,12 @@ func CleanupTestDB(t *testing.T, db *gorm.DB) {
 
 // SetTestEnv sets test environment variables
 func SetTestEnv() {
-	os.Setenv("JWT_SECRET", "test-secret-key-for-testing-only")
 	os.Setenv("JWT_ACCESS_TOKEN_EXPIRY", "15m")
 	os.Setenv("JWT_REFRESH_TOKEN_EXPIRY", "168h")
 }
 
 // UnsetTestEnv unsets test environment variables
 func UnsetTestEnv() {
-	os.Unsetenv("JWT_SECRET")
 	os.Unsetenv("JWT_ACCESS_TOKEN_EXPIRY")
 	os.Unsetenv("JWT_REFRESH_TOKEN_EXPIRY")
 }